@@ -3,6 +3,8 @@ package main
 import (
 	"fmt"
 	"os"
+	// embed time zone database so that --server-tz names resolve on systems without one
+	_ "time/tzdata"
 
 	"github.com/spf13/cobra"
 
@@ -10,6 +10,8 @@ require (
 	github.com/stretchr/testify v1.8.0
 	github.com/vbauerster/mpb/v8 v8.0.2
 	go.uber.org/zap v1.23.0
+	golang.org/x/term v0.0.0-20220526004731-065cf7ba2467
+	gopkg.in/yaml.v3 v3.0.1
 )
 
 require (
@@ -25,5 +27,4 @@ require (
 	go.uber.org/atomic v1.7.0 // indirect
 	go.uber.org/multierr v1.6.0 // indirect
 	golang.org/x/sys v0.0.0-20220829200755-d48e67d00261 // indirect
-	gopkg.in/yaml.v3 v3.0.1 // indirect
 )
@@ -1,11 +1,13 @@
 package filestore
 
 import (
+	"fmt"
 	"os"
 	"path/filepath"
 
 	"github.com/hashicorp/go-multierror"
 
+	"github.com/alexZaicev/go-ftp-client/internal/domain/entities"
 	ftperrors "github.com/alexZaicev/go-ftp-client/internal/domain/errors"
 )
 
@@ -44,6 +46,30 @@ func (s *FileStore) SaveFile(path string, data []byte) error {
 
 	return nil
 }
+
+func (s *FileStore) Stat(path string) (*entities.Entry, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, ftperrors.NewNotFoundError(fmt.Sprintf("entry not found under %q path", path), nil)
+		}
+		return nil, ftperrors.NewInternalError("failed to stat file", err)
+	}
+
+	entryType := entities.EntryTypeFile
+	if info.IsDir() {
+		entryType = entities.EntryTypeDir
+	}
+
+	return &entities.Entry{
+		Type:                 entryType,
+		Mode:                 info.Mode(),
+		Name:                 info.Name(),
+		SizeInBytes:          uint64(info.Size()),
+		LastModificationDate: info.ModTime(),
+	}, nil
+}
+
 func (s *FileStore) CreateDir(path string) error {
 	if _, err := os.Stat(path); err != nil {
 		if !os.IsNotExist(err) {
@@ -57,3 +83,30 @@ func (s *FileStore) CreateDir(path string) error {
 
 	return nil
 }
+
+func (s *FileStore) CreateSymlink(path, target string) error {
+	parentDir, _ := filepath.Split(path)
+	if err := s.CreateDir(parentDir); err != nil {
+		return err
+	}
+
+	// existing link may be dangling, in which case only Lstat finds it
+	if info, err := os.Lstat(path); err == nil && !info.IsDir() {
+		if removeErr := os.Remove(path); removeErr != nil {
+			return ftperrors.NewInternalError("failed to remove existing file", removeErr)
+		}
+	}
+
+	if err := os.Symlink(target, path); err != nil {
+		return ftperrors.NewInternalError("failed to create symbolic link", err)
+	}
+
+	return nil
+}
+
+func (s *FileStore) Remove(path string) error {
+	if err := os.RemoveAll(path); err != nil {
+		return ftperrors.NewInternalError("failed to remove file", err)
+	}
+	return nil
+}
@@ -1,12 +1,16 @@
 package filestore_test
 
 import (
+	"os"
 	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/alexZaicev/go-ftp-client/internal/adapters/filestore"
+	"github.com/alexZaicev/go-ftp-client/internal/domain/entities"
+	ftperrors "github.com/alexZaicev/go-ftp-client/internal/domain/errors"
 )
 
 const (
@@ -38,3 +42,64 @@ func Test_FileStore_CreateDir_Success(t *testing.T) {
 	// assert
 	assert.NoError(t, err)
 }
+
+func Test_FileStore_Remove_Success(t *testing.T) {
+	// arrange
+	store := filestore.FileStore{}
+	filePath := filepath.Join(tmpDir, "tmp2", "file-1")
+	require.NoError(t, store.SaveFile(filePath, content))
+
+	// act
+	err := store.Remove(filepath.Join(tmpDir, "tmp2"))
+
+	// assert
+	assert.NoError(t, err)
+	assert.NoFileExists(t, filePath)
+}
+
+func Test_FileStore_CreateSymlink_Success(t *testing.T) {
+	// arrange
+	store := filestore.FileStore{}
+	linkPath := filepath.Join(tmpDir, "tmp3", "latest")
+	require.NoError(t, store.SaveFile(filepath.Join(tmpDir, "tmp3", "v1.2.3"), content))
+	require.NoError(t, store.CreateSymlink(linkPath, "missing"))
+
+	// act
+	err := store.CreateSymlink(linkPath, "v1.2.3")
+
+	// assert
+	require.NoError(t, err)
+	target, readErr := os.Readlink(linkPath)
+	require.NoError(t, readErr)
+	assert.Equal(t, "v1.2.3", target)
+}
+
+func Test_FileStore_Stat_Success(t *testing.T) {
+	// arrange
+	store := filestore.FileStore{}
+	filePath := filepath.Join(tmpDir, "tmp3", "file-1")
+	require.NoError(t, store.SaveFile(filePath, content))
+
+	// act
+	entry, err := store.Stat(filePath)
+
+	// assert
+	assert.NoError(t, err)
+	require.NotNil(t, entry)
+	assert.Equal(t, entities.EntryTypeFile, entry.Type)
+	assert.Equal(t, "file-1", entry.Name)
+	assert.Equal(t, uint64(len(content)), entry.SizeInBytes)
+}
+
+func Test_FileStore_Stat_NotFoundError(t *testing.T) {
+	// arrange
+	store := filestore.FileStore{}
+
+	// act
+	entry, err := store.Stat(filepath.Join(tmpDir, "missing"))
+
+	// assert
+	assert.Nil(t, entry)
+	require.EqualError(t, err, `not found error occurred: entry not found under "tmp/missing" path`)
+	assert.IsType(t, ftperrors.NotFoundErrorType, err)
+}
@@ -9,6 +9,7 @@ import (
 	"time"
 
 	"github.com/alexZaicev/go-ftp-client/internal/adapters/ftpconnection"
+	"github.com/alexZaicev/go-ftp-client/internal/adapters/parsers"
 	"github.com/alexZaicev/go-ftp-client/internal/domain/connection"
 	ftperrors "github.com/alexZaicev/go-ftp-client/internal/domain/errors"
 )
@@ -22,6 +23,12 @@ type ConnectorConfig struct {
 	TLSCertFilePath string
 	TLSKeyFilePath  string
 	TLSInsecure     bool
+	ListFormat      parsers.ListFormat
+	LenientListing  bool
+	ListLanguage    string
+	// Location of the server that listed dates are in, UTC if nil.
+	Location       *time.Location
+	DetectLocation bool
 }
 
 func (c *ConnectorConfig) ServerName() string {
@@ -50,6 +57,21 @@ func (c *connector) Connect(ctx context.Context, config ConnectorConfig) (conn c
 	if config.Verbose {
 		opts = append(opts, ftpconnection.WithVerboseWriter(os.Stdout))
 	}
+	if config.LenientListing {
+		opts = append(opts, ftpconnection.WithLenientListing())
+	}
+	if config.ListFormat != "" {
+		opts = append(opts, ftpconnection.WithListParser(config.ListFormat))
+	}
+	if config.ListLanguage != "" {
+		opts = append(opts, ftpconnection.WithListLanguage(config.ListLanguage))
+	}
+	if config.Location != nil {
+		opts = append(opts, ftpconnection.WithLocation(config.Location))
+	}
+	if config.DetectLocation {
+		opts = append(opts, ftpconnection.WithLocationDetection())
+	}
 
 	// if both TLS certificate and key are provided, dial FTP server with TLS configuration.
 	if config.TLSCertFilePath != "" && config.TLSKeyFilePath != "" {
@@ -6,7 +6,9 @@ import (
 
 	"github.com/alexZaicev/go-ftp-client/internal/adapters/ftpclient"
 	"github.com/alexZaicev/go-ftp-client/internal/domain/connection"
+	"github.com/alexZaicev/go-ftp-client/internal/domain/entities"
 	"github.com/alexZaicev/go-ftp-client/internal/domain/repositories"
+	"github.com/alexZaicev/go-ftp-client/internal/drivers/cli/models"
 	"github.com/alexZaicev/go-ftp-client/internal/drivers/logging"
 	"github.com/alexZaicev/go-ftp-client/internal/usecases/ftp"
 )
@@ -15,6 +17,24 @@ type CmdDownloadInput struct {
 	Config     ftpclient.ConnectorConfig
 	RemotePath string
 	Path       string
+	// Literal takes RemotePath as is, without expanding glob patterns or unescaping backslashes.
+	Literal bool
+	// Filter selects entries of downloaded directories, if provided.
+	Filter *entities.FilterOptions
+	// IfExists decides what happens to files that already exist locally.
+	IfExists entities.OverwritePolicy
+	// UnsafeNames decides what happens to entries whose names are illegal or reserved locally.
+	UnsafeNames entities.UnsafeNamePolicy
+	// Links decides what happens to symbolic links of downloaded directories.
+	Links entities.LinkPolicy
+	// ContinueOnError records entries that fail to be downloaded and carries on, printing a summary of
+	// skipped and failed entries at the end.
+	ContinueOnError bool
+	// ReportPath is the local path of the JSON report listing the outcome of every entry, if provided.
+	ReportPath string
+	// Output is the format the outcome of every entry is written in, only skipped and failed entries are
+	// summarized if it is not set.
+	Output models.OutputFormat
 }
 
 type Dependencies struct {
@@ -22,6 +42,8 @@ type Dependencies struct {
 	FileStore repositories.FileStore
 	UseCase   ftp.DownloadUseCase
 	OutWriter io.Writer
+	// ProgressWriter is where progress bars are drawn, they are not drawn if it is nil.
+	ProgressWriter io.Writer
 }
 
 func PerformDownload(ctx context.Context, logger logging.Logger, deps *Dependencies, input *CmdDownloadInput) (err error) {
@@ -44,12 +66,55 @@ func PerformDownload(ctx context.Context, logger logging.Logger, deps *Dependenc
 	}
 
 	downloadUseCaseInput := &ftp.DownloadInput{
-		RemotePath: input.RemotePath,
-		Path:       input.Path,
+		RemotePath:  input.RemotePath,
+		Path:        input.Path,
+		Literal:     input.Literal,
+		Filter:      input.Filter,
+		IfExists:    input.IfExists,
+		UnsafeNames: input.UnsafeNames,
+		Links:       input.Links,
+
+		ContinueOnError: input.ContinueOnError,
+	}
+
+	observers := ftp.TransferObservers{&ftpclient.TransferLogger{Logger: logger}}
+	var progressBars *ftpclient.ProgressBars
+	if deps.ProgressWriter != nil {
+		progressBars = ftpclient.NewProgressBars(deps.ProgressWriter, "Downloading")
+		observers = append(observers, progressBars)
+	}
+	downloadUseCaseRepos.Observer = observers
+
+	output, err := deps.UseCase.Execute(ctx, downloadUseCaseRepos, downloadUseCaseInput)
+	if progressBars != nil {
+		progressBars.Wait()
+	}
+	if err != nil {
+		return err
+	}
+
+	if input.ReportPath != "" {
+		if reportErr := ftpclient.SaveTransferReport(
+			logger, deps.FileStore, input.ReportPath, output.Results,
+		); reportErr != nil {
+			return reportErr
+		}
+	}
+
+	if input.Output.IsMachineReadable() {
+		if writeErr := ftpclient.WriteTransferResults(deps.OutWriter, input.Output, output.Results); writeErr != nil {
+			return writeErr
+		}
+	} else if input.ContinueOnError {
+		if writeErr := ftpclient.WriteTransferSummary(deps.OutWriter, output.Results); writeErr != nil {
+			return writeErr
+		}
+	} else if writeErr := ftpclient.WriteSkippedSummary(deps.OutWriter, output.Skipped); writeErr != nil {
+		return writeErr
 	}
 
-	if downloadErr := deps.UseCase.Execute(ctx, downloadUseCaseRepos, downloadUseCaseInput); downloadErr != nil {
-		return downloadErr
+	if transferErr := ftpclient.TransferError(output.Results); transferErr != nil {
+		return transferErr
 	}
 
 	logger.Info("OK!")
@@ -4,18 +4,23 @@ import (
 	"bytes"
 	"context"
 	"errors"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 
 	"github.com/alexZaicev/go-ftp-client/internal/adapters/ftpclient"
 	"github.com/alexZaicev/go-ftp-client/internal/adapters/ftpclient/download"
+	"github.com/alexZaicev/go-ftp-client/internal/domain/entities"
+	ftperrors "github.com/alexZaicev/go-ftp-client/internal/domain/errors"
 	"github.com/alexZaicev/go-ftp-client/internal/drivers/logging/assertlogging"
 	"github.com/alexZaicev/go-ftp-client/internal/usecases/ftp"
 	ftpclientMocks "github.com/alexZaicev/go-ftp-client/mocks/adapters/ftpclient"
 	connectionMocks "github.com/alexZaicev/go-ftp-client/mocks/domain/connection"
+	repositoryMocks "github.com/alexZaicev/go-ftp-client/mocks/domain/repositories"
 	useCaseMocks "github.com/alexZaicev/go-ftp-client/mocks/usecases/ftp"
 )
 
@@ -54,6 +59,7 @@ func Test_PerformDownload_Success(t *testing.T) {
 	useCaseRepos := &ftp.DownloadRepos{
 		Logger:     logger,
 		Connection: ftpConnMock,
+		Observer:   ftp.TransferObservers{&ftpclient.TransferLogger{Logger: logger}},
 	}
 
 	useCaseInput := &ftp.DownloadInput{
@@ -64,7 +70,7 @@ func Test_PerformDownload_Success(t *testing.T) {
 	useCaseMock := useCaseMocks.NewDownloadUseCase(t)
 	useCaseMock.
 		On("Execute", ctx, useCaseRepos, useCaseInput).
-		Return(nil).
+		Return(&ftp.DownloadOutput{}, nil).
 		Once()
 
 	buffer := bytes.NewBufferString("")
@@ -93,6 +99,147 @@ func Test_PerformDownload_Success(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func Test_PerformDownload_Progress(t *testing.T) {
+	// arrange
+	ctx := context.Background()
+
+	logger := assertlogging.NewLogger(t)
+	logger.ExpectInfo("OK!")
+
+	ftpConnMock := connectionMocks.NewConnection(t)
+	ftpConnMock.On("Stop").Return(nil).Once()
+
+	config := ftpclient.ConnectorConfig{
+		Address:  address,
+		User:     user,
+		Password: password,
+		Verbose:  true,
+		Timeout:  timeout,
+	}
+	connMock := ftpclientMocks.NewConnector(t)
+	connMock.
+		On("Connect", ctx, config).
+		Return(ftpConnMock, nil).
+		Once()
+
+	useCaseInput := &ftp.DownloadInput{
+		RemotePath: remotePath,
+		Path:       path,
+	}
+
+	useCaseMock := useCaseMocks.NewDownloadUseCase(t)
+	useCaseMock.
+		On("Execute", ctx, mock.MatchedBy(func(repos *ftp.DownloadRepos) bool {
+			return repos.Observer != nil
+		}), useCaseInput).
+		Run(func(args mock.Arguments) {
+			observer := args.Get(1).(*ftp.DownloadRepos).Observer
+			observer.DirListed(remotePath)
+			event := ftp.TransferEvent{Path: remotePath + "/file-1", SizeInBytes: 4}
+			observer.FileStarted(event)
+			observer.BytesTransferred(event, 4)
+			observer.FileCompleted(event)
+		}).
+		Return(&ftp.DownloadOutput{}, nil).
+		Once()
+
+	buffer := bytes.NewBufferString("")
+	progressBuffer := bytes.NewBufferString("")
+
+	deps := &download.Dependencies{
+		Connector:      connMock,
+		UseCase:        useCaseMock,
+		OutWriter:      buffer,
+		ProgressWriter: progressBuffer,
+	}
+	input := &download.CmdDownloadInput{
+		Config:     config,
+		Path:       path,
+		RemotePath: remotePath,
+	}
+
+	// act
+	err := download.PerformDownload(ctx, logger, deps, input)
+
+	// assert
+	require.NoError(t, err)
+	assert.Empty(t, buffer.String())
+	assert.Contains(t, progressBuffer.String(), "Downloading "+remotePath+"/file-1")
+	assert.Contains(t, progressBuffer.String(), "Listed directories: 1")
+}
+
+func Test_PerformDownload_Skipped_Success(t *testing.T) {
+	// arrange
+	ctx := context.Background()
+
+	logger := assertlogging.NewLogger(t)
+	logger.ExpectInfo("OK!")
+
+	ftpConnMock := connectionMocks.NewConnection(t)
+	ftpConnMock.On("Stop").Return(nil).Once()
+
+	config := ftpclient.ConnectorConfig{
+		Address:  address,
+		User:     user,
+		Password: password,
+		Verbose:  true,
+		Timeout:  timeout,
+	}
+	connMock := ftpclientMocks.NewConnector(t)
+	connMock.
+		On("Connect", ctx, config).
+		Return(ftpConnMock, nil).
+		Once()
+
+	useCaseRepos := &ftp.DownloadRepos{
+		Logger:     logger,
+		Connection: ftpConnMock,
+		Observer:   ftp.TransferObservers{&ftpclient.TransferLogger{Logger: logger}},
+	}
+
+	useCaseInput := &ftp.DownloadInput{
+		RemotePath: remotePath,
+		Path:       path,
+		IfExists:   entities.OverwritePolicySkip,
+	}
+
+	useCaseMock := useCaseMocks.NewDownloadUseCase(t)
+	useCaseMock.
+		On("Execute", ctx, useCaseRepos, useCaseInput).
+		Return(&ftp.DownloadOutput{
+			Skipped: []*entities.SkippedTransfer{
+				{Path: path + "/file-1.txt", Reason: "already exists"},
+				{Path: path + "/file-2.txt", Reason: "already exists"},
+			},
+		}, nil).
+		Once()
+
+	buffer := bytes.NewBufferString("")
+
+	deps := &download.Dependencies{
+		Connector: connMock,
+		UseCase:   useCaseMock,
+		OutWriter: buffer,
+	}
+	input := &download.CmdDownloadInput{
+		Config:     config,
+		Path:       path,
+		RemotePath: remotePath,
+		IfExists:   entities.OverwritePolicySkip,
+	}
+
+	// act
+	err := download.PerformDownload(ctx, logger, deps, input)
+
+	// assert
+	assert.NoError(t, err)
+	assert.Equal(
+		t,
+		"Skipped 2 item(s):\n  /foo/bar/baz/file-1.txt (already exists)\n  /foo/bar/baz/file-2.txt (already exists)\n",
+		buffer.String(),
+	)
+}
+
 func Test_PerformDownload_ConnectError(t *testing.T) {
 	// arrange
 	ctx := context.Background()
@@ -173,6 +320,7 @@ func Test_PerformDownload_ConnectStopError(t *testing.T) {
 	useCaseRepos := &ftp.DownloadRepos{
 		Logger:     logger,
 		Connection: ftpConnMock,
+		Observer:   ftp.TransferObservers{&ftpclient.TransferLogger{Logger: logger}},
 	}
 	useCaseInput := &ftp.DownloadInput{
 		Path:       path,
@@ -182,7 +330,7 @@ func Test_PerformDownload_ConnectStopError(t *testing.T) {
 	useCaseMock := useCaseMocks.NewDownloadUseCase(t)
 	useCaseMock.
 		On("Execute", ctx, useCaseRepos, useCaseInput).
-		Return(nil).
+		Return(&ftp.DownloadOutput{}, nil).
 		Once()
 
 	buffer := bytes.NewBufferString("")
@@ -237,6 +385,7 @@ func Test_PerformDownload_UseCaseError(t *testing.T) {
 	useCaseRepos := &ftp.DownloadRepos{
 		Logger:     logger,
 		Connection: ftpConnMock,
+		Observer:   ftp.TransferObservers{&ftpclient.TransferLogger{Logger: logger}},
 	}
 	useCaseInput := &ftp.DownloadInput{
 		RemotePath: remotePath,
@@ -246,7 +395,7 @@ func Test_PerformDownload_UseCaseError(t *testing.T) {
 	useCaseMock := useCaseMocks.NewDownloadUseCase(t)
 	useCaseMock.
 		On("Execute", ctx, useCaseRepos, useCaseInput).
-		Return(errors.New("mock error")).
+		Return(nil, errors.New("mock error")).
 		Once()
 
 	buffer := bytes.NewBufferString("")
@@ -275,3 +424,104 @@ func Test_PerformDownload_UseCaseError(t *testing.T) {
 	require.EqualError(t, err, "mock error")
 	assert.NoError(t, errors.Unwrap(err))
 }
+
+//nolint:funlen // test case can get a bit large
+func Test_PerformDownload_ContinueOnError(t *testing.T) {
+	// arrange
+	ctx := context.Background()
+
+	logger := assertlogging.NewLogger(t)
+
+	ftpConnMock := connectionMocks.NewConnection(t)
+	ftpConnMock.On("Stop").Return(nil).Once()
+
+	config := ftpclient.ConnectorConfig{
+		Address:  address,
+		User:     user,
+		Password: password,
+		Verbose:  true,
+		Timeout:  timeout,
+	}
+	connMock := ftpclientMocks.NewConnector(t)
+	connMock.
+		On("Connect", ctx, config).
+		Return(ftpConnMock, nil).
+		Once()
+
+	fileStoreMock := repositoryMocks.NewFileStore(t)
+
+	useCaseRepos := &ftp.DownloadRepos{
+		Logger:     logger,
+		Connection: ftpConnMock,
+		FileStore:  fileStoreMock,
+		Observer:   ftp.TransferObservers{&ftpclient.TransferLogger{Logger: logger}},
+	}
+
+	useCaseInput := &ftp.DownloadInput{
+		RemotePath:      remotePath,
+		Path:            path,
+		ContinueOnError: true,
+	}
+
+	useCaseMock := useCaseMocks.NewDownloadUseCase(t)
+	useCaseMock.
+		On("Execute", ctx, useCaseRepos, useCaseInput).
+		Return(&ftp.DownloadOutput{
+			Skipped: []*entities.SkippedTransfer{
+				{Path: remotePath + "/link-1", Reason: "symbolic link"},
+			},
+			Results: []*entities.TransferResult{
+				entities.NewTransferOK(remotePath + "/file-1"),
+				entities.NewTransferSkipped(&entities.SkippedTransfer{
+					Path:   remotePath + "/link-1",
+					Reason: "symbolic link",
+				}),
+				entities.NewTransferFailed(
+					remotePath+"/file-2",
+					ftperrors.NewInternalError("failed to download file", errors.New("mock error")),
+				),
+			},
+		}, nil).
+		Once()
+
+	fileStoreMock.
+		On("SaveFile", "/tmp/report.json", mock.MatchedBy(func(data []byte) bool {
+			return strings.Contains(string(data), `"failed": 1`)
+		})).
+		Return(nil).
+		Once()
+
+	buffer := bytes.NewBufferString("")
+
+	deps := &download.Dependencies{
+		Connector: connMock,
+		UseCase:   useCaseMock,
+		FileStore: fileStoreMock,
+		OutWriter: buffer,
+	}
+	input := &download.CmdDownloadInput{
+		Config:          config,
+		Path:            path,
+		RemotePath:      remotePath,
+		ContinueOnError: true,
+		ReportPath:      "/tmp/report.json",
+	}
+
+	// act
+	err := download.PerformDownload(ctx, logger, deps, input)
+
+	// assert
+	require.EqualError(t, err, "an internal error occurred: 1 item(s) failed")
+	assert.IsType(t, ftperrors.InternalErrorType, err)
+	assert.Equal(
+		t,
+		"+---------+---------------------+-----------------------------------------------------------------+\n"+
+			"| STATUS  |        PATH         |                             REASON                              |\n"+
+			"+---------+---------------------+-----------------------------------------------------------------+\n"+
+			"| skipped | /baz/bar/foo/link-1 | symbolic link                                                   |\n"+
+			"| failed  | /baz/bar/foo/file-2 | an internal error occurred: failed to download file: mock error |\n"+
+			"+---------+---------------------+-----------------------------------------------------------------+\n"+
+			"1 ok, 1 skipped, 1 failed\n",
+		buffer.String(),
+	)
+}
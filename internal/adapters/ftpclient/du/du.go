@@ -0,0 +1,88 @@
+package du
+
+import (
+	"context"
+	"io"
+	"strconv"
+
+	"github.com/olekukonko/tablewriter"
+
+	"github.com/alexZaicev/go-ftp-client/internal/adapters/ftpclient"
+	"github.com/alexZaicev/go-ftp-client/internal/domain/connection"
+	"github.com/alexZaicev/go-ftp-client/internal/drivers/cli/models"
+	"github.com/alexZaicev/go-ftp-client/internal/drivers/logging"
+	useCase "github.com/alexZaicev/go-ftp-client/internal/usecases/ftp"
+)
+
+type CmdDiskUsageInput struct {
+	Config ftpclient.ConnectorConfig
+	Path   string
+	// Literal takes Path as is, without unescaping backslashes.
+	Literal bool
+	// MaxDepth limits which directories are written, where 0 only writes the total of the directory at
+	// Path. All directories are written if it is negative.
+	MaxDepth int
+	// Bytes writes sizes of the table in bytes instead of human readable units.
+	Bytes bool
+	// Output is the format usages are written in, they are rendered as a table if it is not set.
+	Output models.OutputFormat
+}
+
+type Dependencies struct {
+	Connector ftpclient.Connector
+	UseCase   useCase.DiskUsageUseCase
+	OutWriter io.Writer
+}
+
+func PerformDiskUsage(ctx context.Context, logger logging.Logger, deps *Dependencies, input *CmdDiskUsageInput) (err error) {
+	conn, err := deps.Connector.Connect(ctx, input.Config)
+	if err != nil {
+		logger.WithError(err).Error("failed to connect to server")
+		return err
+	}
+	defer func(conn connection.Connection) {
+		if stopErr := conn.Stop(); stopErr != nil {
+			logger.WithError(stopErr).Error("failed to stop server connection")
+			err = stopErr
+		}
+	}(conn)
+
+	useCaseRepos := &useCase.DiskUsageRepos{
+		Logger:     logger,
+		Connection: conn,
+	}
+	useCaseInput := &useCase.DiskUsageInput{
+		Path:     input.Path,
+		Literal:  input.Literal,
+		MaxDepth: input.MaxDepth,
+	}
+
+	usages, err := deps.UseCase.Execute(ctx, useCaseRepos, useCaseInput)
+	if err != nil {
+		return err
+	}
+
+	if input.Output.IsMachineReadable() {
+		return ftpclient.WriteDirUsageRecords(deps.OutWriter, input.Output, usages)
+	}
+
+	table := tablewriter.NewWriter(deps.OutWriter)
+	table.SetHeader([]string{"size", "files", "dirs", "path"})
+	// wrapped paths are hard to copy from the table
+	table.SetAutoWrapText(false)
+	for _, usage := range usages {
+		size := ftpclient.FormatSizeInBytes(usage.SizeInBytes)
+		if input.Bytes {
+			size = strconv.FormatUint(usage.SizeInBytes, 10)
+		}
+		table.Append([]string{
+			size,
+			strconv.Itoa(usage.Files),
+			strconv.Itoa(usage.Dirs),
+			usage.Path,
+		})
+	}
+	table.Render()
+
+	return nil
+}
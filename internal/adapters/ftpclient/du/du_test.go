@@ -0,0 +1,217 @@
+package du_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/alexZaicev/go-ftp-client/internal/adapters/ftpclient"
+	"github.com/alexZaicev/go-ftp-client/internal/adapters/ftpclient/du"
+	"github.com/alexZaicev/go-ftp-client/internal/domain/entities"
+	ftperrors "github.com/alexZaicev/go-ftp-client/internal/domain/errors"
+	"github.com/alexZaicev/go-ftp-client/internal/drivers/cli/models"
+	"github.com/alexZaicev/go-ftp-client/internal/drivers/logging/assertlogging"
+	"github.com/alexZaicev/go-ftp-client/internal/usecases/ftp"
+	ftpclientMocks "github.com/alexZaicev/go-ftp-client/mocks/adapters/ftpclient"
+	connectionMocks "github.com/alexZaicev/go-ftp-client/mocks/domain/connection"
+	useCaseMocks "github.com/alexZaicev/go-ftp-client/mocks/usecases/ftp"
+)
+
+const (
+	address  = "10.0.0.1:21"
+	user     = "user01"
+	password = "pwd01"
+	timeout  = 5 * time.Second
+	path     = "/foo/bar/baz"
+)
+
+func Test_PerformDiskUsage_Success(t *testing.T) {
+	testCases := []struct {
+		name           string
+		bytes          bool
+		output         models.OutputFormat
+		expectedOutput string
+	}{
+		{
+			name: "usage with human readable sizes",
+			expectedOutput: `+----------+-------+------+-------------------+
+|   SIZE   | FILES | DIRS |       PATH        |
++----------+-------+------+-------------------+
+| 39.09 KB |     2 |    0 | /foo/bar/baz/dir1 |
+| 39.19 KB |     3 |    1 | /foo/bar/baz      |
++----------+-------+------+-------------------+
+`,
+		},
+		{
+			name:  "usage with sizes in bytes",
+			bytes: true,
+			expectedOutput: `+-------+-------+------+-------------------+
+| SIZE  | FILES | DIRS |       PATH        |
++-------+-------+------+-------------------+
+| 40032 |     2 |    0 | /foo/bar/baz/dir1 |
+| 40132 |     3 |    1 | /foo/bar/baz      |
++-------+-------+------+-------------------+
+`,
+		},
+		{
+			name:   "usage as JSON lines",
+			output: models.OutputFormatJSONL,
+			expectedOutput: `{"path":"/foo/bar/baz/dir1","depth":1,"size_in_bytes":40032,"files":2,"dirs":0}
+{"path":"/foo/bar/baz","depth":0,"size_in_bytes":40132,"files":3,"dirs":1}
+`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			logger := assertlogging.NewLogger(t)
+
+			ftpConnMock := connectionMocks.NewConnection(t)
+			ftpConnMock.On("Stop").Return(nil).Once()
+
+			config := ftpclient.ConnectorConfig{
+				Address:  address,
+				User:     user,
+				Password: password,
+				Timeout:  timeout,
+			}
+			connMock := ftpclientMocks.NewConnector(t)
+			connMock.
+				On("Connect", ctx, config).
+				Return(ftpConnMock, nil).
+				Once()
+
+			useCaseRepos := &ftp.DiskUsageRepos{
+				Logger:     logger,
+				Connection: ftpConnMock,
+			}
+			useCaseInput := &ftp.DiskUsageInput{
+				Path:     path,
+				MaxDepth: -1,
+			}
+
+			useCaseMock := useCaseMocks.NewDiskUsageUseCase(t)
+			useCaseMock.
+				On("Execute", ctx, useCaseRepos, useCaseInput).
+				Return([]*entities.DirUsage{
+					{Path: path + "/dir1", Depth: 1, SizeInBytes: 40032, Files: 2},
+					{Path: path, SizeInBytes: 40132, Files: 3, Dirs: 1},
+				}, nil).
+				Once()
+
+			buffer := bytes.NewBufferString("")
+
+			deps := &du.Dependencies{
+				Connector: connMock,
+				UseCase:   useCaseMock,
+				OutWriter: buffer,
+			}
+			input := &du.CmdDiskUsageInput{
+				Config:   config,
+				Path:     path,
+				MaxDepth: -1,
+				Bytes:    tc.bytes,
+				Output:   tc.output,
+			}
+
+			err := du.PerformDiskUsage(ctx, logger, deps, input)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedOutput, buffer.String())
+		})
+	}
+}
+
+func Test_PerformDiskUsage_ConnectError(t *testing.T) {
+	ctx := context.Background()
+
+	logger := assertlogging.NewLogger(t)
+	logger.
+		ExpectError("failed to connect to server").
+		WithError(assertlogging.EqualError("mock error"))
+
+	config := ftpclient.ConnectorConfig{
+		Address:  address,
+		User:     user,
+		Password: password,
+		Timeout:  timeout,
+	}
+	connMock := ftpclientMocks.NewConnector(t)
+	connMock.
+		On("Connect", ctx, config).
+		Return(nil, errors.New("mock error")).
+		Once()
+
+	deps := &du.Dependencies{
+		Connector: connMock,
+		UseCase:   useCaseMocks.NewDiskUsageUseCase(t),
+		OutWriter: bytes.NewBufferString(""),
+	}
+	input := &du.CmdDiskUsageInput{
+		Config: config,
+		Path:   path,
+	}
+
+	err := du.PerformDiskUsage(ctx, logger, deps, input)
+	require.EqualError(t, err, "mock error")
+	assert.NoError(t, errors.Unwrap(err))
+}
+
+func Test_PerformDiskUsage_UseCaseError(t *testing.T) {
+	ctx := context.Background()
+
+	logger := assertlogging.NewLogger(t)
+
+	ftpConnMock := connectionMocks.NewConnection(t)
+	ftpConnMock.On("Stop").Return(nil).Once()
+
+	config := ftpclient.ConnectorConfig{
+		Address:  address,
+		User:     user,
+		Password: password,
+		Timeout:  timeout,
+	}
+	connMock := ftpclientMocks.NewConnector(t)
+	connMock.
+		On("Connect", ctx, config).
+		Return(ftpConnMock, nil).
+		Once()
+
+	useCaseRepos := &ftp.DiskUsageRepos{
+		Logger:     logger,
+		Connection: ftpConnMock,
+	}
+	useCaseInput := &ftp.DiskUsageInput{
+		Path: path,
+	}
+
+	useCaseMock := useCaseMocks.NewDiskUsageUseCase(t)
+	useCaseMock.
+		On("Execute", ctx, useCaseRepos, useCaseInput).
+		Return(nil, ftperrors.NewInternalError("failed to list directory", nil)).
+		Once()
+
+	buffer := bytes.NewBufferString("")
+
+	deps := &du.Dependencies{
+		Connector: connMock,
+		UseCase:   useCaseMock,
+		OutWriter: buffer,
+	}
+	input := &du.CmdDiskUsageInput{
+		Config: config,
+		Path:   path,
+	}
+
+	err := du.PerformDiskUsage(ctx, logger, deps, input)
+	require.EqualError(t, err, "an internal error occurred: failed to list directory")
+	assert.IsType(t, ftperrors.InternalErrorType, err)
+	assert.NoError(t, errors.Unwrap(err))
+	assert.Empty(t, buffer.String())
+}
@@ -5,6 +5,8 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"strconv"
+	"time"
 
 	"github.com/olekukonko/tablewriter"
 
@@ -18,16 +20,26 @@ import (
 )
 
 type CmdListInput struct {
-	Config   ftpclient.ConnectorConfig
-	ShowAll  bool
-	Path     string
+	Config  ftpclient.ConnectorConfig
+	ShowAll bool
+	Path    string
+	// Literal takes Path as is, without expanding glob patterns or unescaping backslashes.
+	Literal  bool
 	SortType models.SortType
+	// Columns to display, models.DefaultColumns are used if empty. They only apply to tables.
+	Columns []models.Column
+	// Output is the format entries are written in, they are rendered as a table if it is not set.
+	Output models.OutputFormat
+	// Recursive lists subdirectories as well, writing every directory as soon as it is listed.
+	Recursive bool
 }
 
 type Dependencies struct {
 	Connector ftpclient.Connector
 	UseCase   useCase.ListFilesUseCase
-	OutWriter io.Writer
+	// WalkUseCase lists directories recursively.
+	WalkUseCase useCase.WalkUseCase
+	OutWriter   io.Writer
 }
 
 func PerformListFiles(ctx context.Context, logger logging.Logger, deps *Dependencies, input *CmdListInput) (err error) {
@@ -53,10 +65,15 @@ func PerformListFiles(ctx context.Context, logger logging.Logger, deps *Dependen
 		return err
 	}
 
+	if input.Recursive {
+		return listRecursively(ctx, logger, conn, deps, input, sortType)
+	}
+
 	useCaseInput := &useCase.ListFilesInput{
 		Path:     input.Path,
 		ShowAll:  input.ShowAll,
 		SortType: sortType,
+		Literal:  input.Literal,
 	}
 
 	entries, err := deps.UseCase.Execute(ctx, useCaseRepos, useCaseInput)
@@ -64,35 +81,185 @@ func PerformListFiles(ctx context.Context, logger logging.Logger, deps *Dependen
 		var notFoundErr *ftpErrors.NotFoundError
 		if errors.As(err, &notFoundErr) {
 			logger.Info("no entries found under specified path")
+			if input.Output.IsMachineReadable() {
+				return ftpclient.WriteEntryRecords(deps.OutWriter, input.Output, nil)
+			}
 			return nil
 		}
 
 		return err
 	}
 
-	table := tablewriter.NewWriter(deps.OutWriter)
-	table.SetHeader([]string{"type", "permissions", "owners", "name", "last modified", "size"})
+	if input.Output.IsMachineReadable() {
+		return ftpclient.WriteEntryRecords(deps.OutWriter, input.Output, entries)
+	}
+	return WriteEntries(deps.OutWriter, entries, input.Columns)
+}
+
+// listRecursively function walks the directory and writes entries of every directory once it is listed,
+// either as a table per directory or as records named after their paths. Records of JSON lines are
+// written as they arrive too, while other machine readable formats are written once the walk is done.
+func listRecursively(
+	ctx context.Context,
+	logger logging.Logger,
+	conn connection.Connection,
+	deps *Dependencies,
+	input *CmdListInput,
+	sortType entities.SortType,
+) error {
+	var records []*entities.Entry
+	walkUseCaseRepos := &useCase.WalkRepos{
+		Logger:     logger,
+		Connection: conn,
+	}
+	walkUseCaseInput := &useCase.WalkInput{
+		Path:     input.Path,
+		Literal:  input.Literal,
+		ShowAll:  input.ShowAll,
+		SortType: sortType,
+		Visit: func(dir *useCase.WalkedDir) error {
+			if !input.Output.IsMachineReadable() {
+				return writeDirTable(deps.OutWriter, dir, input.Columns)
+			}
+
+			dirRecords := make([]*entities.Entry, 0, len(dir.Entries))
+			for _, entry := range dir.Entries {
+				record := *entry
+				record.Name = entities.RemotePath(dir.Path).Join(entry.Name).String()
+				dirRecords = append(dirRecords, &record)
+			}
+			if input.Output == models.OutputFormatJSONL {
+				return ftpclient.WriteEntryRecords(deps.OutWriter, input.Output, dirRecords)
+			}
+			records = append(records, dirRecords...)
+			return nil
+		},
+	}
+
+	if err := deps.WalkUseCase.Execute(ctx, walkUseCaseRepos, walkUseCaseInput); err != nil {
+		return err
+	}
+
+	if input.Output.IsMachineReadable() && input.Output != models.OutputFormatJSONL {
+		return ftpclient.WriteEntryRecords(deps.OutWriter, input.Output, records)
+	}
+	return nil
+}
+
+// writeDirTable function writes the path of the directory followed by the table of its entries, separated
+// from the previous directory by a blank line.
+func writeDirTable(writer io.Writer, dir *useCase.WalkedDir, columns []models.Column) error {
+	separator := ""
+	if dir.Depth > 0 {
+		separator = "\n"
+	}
+	if _, err := fmt.Fprintf(writer, "%s%s:\n", separator, dir.Path); err != nil {
+		return ftpErrors.NewInternalError("failed to write directory", err)
+	}
+	return WriteEntries(writer, dir.Entries, columns)
+}
+
+// WriteEntries function renders entries as a table with the columns, models.DefaultColumns are used if
+// none are provided.
+func WriteEntries(writer io.Writer, entries []*entities.Entry, columns []models.Column) error {
+	if len(columns) == 0 {
+		columns = models.DefaultColumns
+	}
+
+	header := make([]string, 0, len(columns))
+	for _, column := range columns {
+		header = append(header, columnHeaders[column])
+	}
+
+	table := tablewriter.NewWriter(writer)
+	table.SetHeader(header)
 	for _, entry := range entries {
-		entryType, cnvErr := ftpclient.EntryTypeToStr(entry.Type)
-		if cnvErr != nil {
-			return cnvErr
+		row := make([]string, 0, len(columns))
+		for _, column := range columns {
+			value, cnvErr := columnValue(column, entry)
+			if cnvErr != nil {
+				return cnvErr
+			}
+			row = append(row, value)
 		}
+		table.Append(row)
+	}
+	table.Render()
 
-		entryName := entry.Name
+	return nil
+}
+
+var columnHeaders = map[models.Column]string{
+	models.ColumnType:         "type",
+	models.ColumnPermissions:  "permissions",
+	models.ColumnMode:         "mode",
+	models.ColumnCapabilities: "capabilities",
+	models.ColumnOwners:       "owners",
+	models.ColumnUID:          "uid",
+	models.ColumnGID:          "gid",
+	models.ColumnLinks:        "links",
+	models.ColumnName:         "name",
+	models.ColumnModified:     "last modified",
+	models.ColumnCreated:      "created",
+	models.ColumnSize:         "size",
+	models.ColumnUniqueID:     "unique id",
+	models.ColumnRaw:          "raw",
+	models.ColumnFacts:        "facts",
+}
+
+func columnValue(column models.Column, entry *entities.Entry) (string, error) {
+	switch column {
+	case models.ColumnType:
+		return ftpclient.EntryTypeToStr(entry.Type)
+	case models.ColumnPermissions:
+		return entry.Permissions, nil
+	case models.ColumnMode:
+		return entry.Mode.String(), nil
+	case models.ColumnCapabilities:
+		return entry.Capabilities.String(), nil
+	case models.ColumnOwners:
+		return fmt.Sprintf("%s:%s", entry.OwnerUser, entry.OwnerGroup), nil
+	case models.ColumnUID:
+		return formatID(entry.UID), nil
+	case models.ColumnGID:
+		return formatID(entry.GID), nil
+	case models.ColumnLinks:
+		return strconv.Itoa(entry.NumHardLinks), nil
+	case models.ColumnName:
 		if entry.Type == entities.EntryTypeLink && entry.LinkName != "" {
-			entryName = fmt.Sprintf("%s -> %s", entryName, entry.LinkName)
+			return fmt.Sprintf("%s -> %s", entry.Name, entry.LinkName), nil
 		}
+		return entry.Name, nil
+	case models.ColumnModified:
+		return formatDate(entry.LastModificationDate), nil
+	case models.ColumnCreated:
+		return formatDate(entry.CreationDate), nil
+	case models.ColumnSize:
+		return ftpclient.FormatSizeInBytes(entry.SizeInBytes), nil
+	case models.ColumnUniqueID:
+		return entry.UniqueID, nil
+	case models.ColumnRaw:
+		return entry.Raw, nil
+	case models.ColumnFacts:
+		return ftpclient.FormatFacts(entry.Facts), nil
+	default:
+		return "", ftpErrors.NewUnknownError(
+			fmt.Sprintf("unexpected column: %s", column),
+			nil,
+		)
+	}
+}
 
-		table.Append([]string{
-			entryType,
-			entry.Permissions,
-			fmt.Sprintf("%s:%s", entry.OwnerUser, entry.OwnerGroup),
-			entryName,
-			entry.LastModificationDate.Format(ftpclient.DateFormat),
-			ftpclient.FormatSizeInBytes(entry.SizeInBytes),
-		})
+func formatID(id *uint32) string {
+	if id == nil {
+		return ""
 	}
-	table.Render()
+	return fmt.Sprintf("%d", *id)
+}
 
-	return nil
+func formatDate(date time.Time) string {
+	if date.IsZero() {
+		return ""
+	}
+	return date.Format(ftpclient.DateFormat)
 }
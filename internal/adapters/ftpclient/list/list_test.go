@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 
 	"github.com/alexZaicev/go-ftp-client/internal/adapters/ftpclient"
@@ -109,6 +110,163 @@ func Test_PerformListFiles_Success(t *testing.T) {
 	assert.Equal(t, expectedStatusStr, buffer.String())
 }
 
+func Test_PerformListFiles_Output_Success(t *testing.T) {
+	ctx := context.Background()
+
+	logger := assertlogging.NewLogger(t)
+
+	expectedEntries := getEntries(t)[:2]
+
+	ftpConnMock := connectionMocks.NewConnection(t)
+	ftpConnMock.On("Stop").Return(nil).Once()
+
+	config := ftpclient.ConnectorConfig{
+		Address:  address,
+		User:     user,
+		Password: password,
+		Verbose:  true,
+		Timeout:  timeout,
+	}
+	connMock := ftpclientMocks.NewConnector(t)
+	connMock.
+		On("Connect", ctx, config).
+		Return(ftpConnMock, nil).
+		Once()
+
+	useCaseRepos := &ftp.ListFilesRepos{
+		Logger:     logger,
+		Connection: ftpConnMock,
+	}
+	useCaseInput := &ftp.ListFilesInput{
+		Path:     path,
+		ShowAll:  true,
+		SortType: entities.SortTypeName,
+	}
+
+	useCaseMock := useCaseMocks.NewListFilesUseCase(t)
+	useCaseMock.
+		On("Execute", ctx, useCaseRepos, useCaseInput).
+		Return(expectedEntries, nil).
+		Once()
+
+	buffer := bytes.NewBufferString("")
+
+	deps := &list.Dependencies{
+		Connector: connMock,
+		UseCase:   useCaseMock,
+		OutWriter: buffer,
+	}
+	input := &list.CmdListInput{
+		Config: ftpclient.ConnectorConfig{
+			Address:  address,
+			User:     user,
+			Password: password,
+			Verbose:  true,
+			Timeout:  timeout,
+		},
+		Path:     path,
+		ShowAll:  true,
+		SortType: models.SortTypeName,
+		Output:   models.OutputFormatCSV,
+	}
+
+	expectedOutput := "type,name,link_name,permissions,mode,capabilities,owner_user,owner_group,uid,gid," +
+		"size_in_bytes,num_hard_links,last_modification_date,creation_date,unique_id,raw,facts\n" +
+		"file,file5,,rwxrwxrwx,,,user01,group01,,,167,2,2022-01-12T16:23:00Z,,,,\n" +
+		"dir,dir1,,rwxrwxrwx,,,user01,group01,,,40032,2,2022-01-24T16:23:00Z,,,,\n"
+
+	err := list.PerformListFiles(ctx, logger, deps, input)
+	assert.NoError(t, err)
+	assert.Equal(t, expectedOutput, buffer.String())
+}
+
+func Test_PerformListFiles_Columns_Success(t *testing.T) {
+	ctx := context.Background()
+
+	logger := assertlogging.NewLogger(t)
+
+	uid := uint32(0)
+	entry := newEntry(t, entities.EntryTypeFile, "welcome.msg", 951, "2015-08-13 17:52")
+	entry.Mode = 0o644
+	entry.Capabilities = entities.CapabilityAppend | entities.CapabilityRead
+	entry.UID = &uid
+	entry.UniqueID = "119FBB87UE"
+	entry.Facts = map[string]string{"media-type": "text/plain", "charset": "utf-8"}
+
+	ftpConnMock := connectionMocks.NewConnection(t)
+	ftpConnMock.On("Stop").Return(nil).Once()
+
+	config := ftpclient.ConnectorConfig{
+		Address:  address,
+		User:     user,
+		Password: password,
+		Verbose:  true,
+		Timeout:  timeout,
+	}
+	connMock := ftpclientMocks.NewConnector(t)
+	connMock.
+		On("Connect", ctx, config).
+		Return(ftpConnMock, nil).
+		Once()
+
+	useCaseRepos := &ftp.ListFilesRepos{
+		Logger:     logger,
+		Connection: ftpConnMock,
+	}
+	useCaseInput := &ftp.ListFilesInput{
+		Path:     path,
+		ShowAll:  true,
+		SortType: entities.SortTypeName,
+	}
+
+	useCaseMock := useCaseMocks.NewListFilesUseCase(t)
+	useCaseMock.
+		On("Execute", ctx, useCaseRepos, useCaseInput).
+		Return([]*entities.Entry{entry}, nil).
+		Once()
+
+	buffer := bytes.NewBufferString("")
+
+	deps := &list.Dependencies{
+		Connector: connMock,
+		UseCase:   useCaseMock,
+		OutWriter: buffer,
+	}
+	input := &list.CmdListInput{
+		Config: ftpclient.ConnectorConfig{
+			Address:  address,
+			User:     user,
+			Password: password,
+			Verbose:  true,
+			Timeout:  timeout,
+		},
+		Path:     path,
+		ShowAll:  true,
+		SortType: models.SortTypeName,
+		Columns: []models.Column{
+			models.ColumnName,
+			models.ColumnMode,
+			models.ColumnCapabilities,
+			models.ColumnUID,
+			models.ColumnGID,
+			models.ColumnCreated,
+			models.ColumnUniqueID,
+			models.ColumnFacts,
+		},
+	}
+
+	expectedStatusStr := `+-------------+------------+--------------+-----+-----+---------+------------+--------------------------------------+
+|    NAME     |    MODE    | CAPABILITIES | UID | GID | CREATED | UNIQUE ID  |                FACTS                 |
++-------------+------------+--------------+-----+-----+---------+------------+--------------------------------------+
+| welcome.msg | -rw-r--r-- | ar           |   0 |     |         | 119FBB87UE | charset=utf-8;media-type=text/plain; |
++-------------+------------+--------------+-----+-----+---------+------------+--------------------------------------+
+`
+
+	err := list.PerformListFiles(ctx, logger, deps, input)
+	assert.NoError(t, err)
+	assert.Equal(t, expectedStatusStr, buffer.String())
+}
+
 func Test_PerformListFiles_NotFoundError(t *testing.T) {
 	ctx := context.Background()
 
@@ -172,6 +330,70 @@ func Test_PerformListFiles_NotFoundError(t *testing.T) {
 	assert.Equal(t, "", buffer.String())
 }
 
+func Test_PerformListFiles_Output_NotFoundError(t *testing.T) {
+	ctx := context.Background()
+
+	logger := assertlogging.NewLogger(t)
+	logger.ExpectInfo("no entries found under specified path")
+
+	ftpConnMock := connectionMocks.NewConnection(t)
+	ftpConnMock.On("Stop").Return(nil).Once()
+
+	config := ftpclient.ConnectorConfig{
+		Address:  address,
+		User:     user,
+		Password: password,
+		Verbose:  true,
+		Timeout:  timeout,
+	}
+	connMock := ftpclientMocks.NewConnector(t)
+	connMock.
+		On("Connect", ctx, config).
+		Return(ftpConnMock, nil).
+		Once()
+
+	useCaseRepos := &ftp.ListFilesRepos{
+		Logger:     logger,
+		Connection: ftpConnMock,
+	}
+	useCaseInput := &ftp.ListFilesInput{
+		Path:     path,
+		ShowAll:  true,
+		SortType: entities.SortTypeName,
+	}
+
+	useCaseMock := useCaseMocks.NewListFilesUseCase(t)
+	useCaseMock.
+		On("Execute", ctx, useCaseRepos, useCaseInput).
+		Return(nil, ftperrors.NewNotFoundError("mock error", nil)).
+		Once()
+
+	buffer := bytes.NewBufferString("")
+
+	deps := &list.Dependencies{
+		Connector: connMock,
+		UseCase:   useCaseMock,
+		OutWriter: buffer,
+	}
+	input := &list.CmdListInput{
+		Config: ftpclient.ConnectorConfig{
+			Address:  address,
+			User:     user,
+			Password: password,
+			Verbose:  true,
+			Timeout:  timeout,
+		},
+		Path:     path,
+		ShowAll:  true,
+		SortType: models.SortTypeName,
+		Output:   models.OutputFormatJSON,
+	}
+
+	err := list.PerformListFiles(ctx, logger, deps, input)
+	assert.NoError(t, err)
+	assert.Equal(t, "[]\n", buffer.String())
+}
+
 func Test_PerformListFiles_ConnectionError(t *testing.T) {
 	ctx := context.Background()
 
@@ -497,3 +719,162 @@ func newEntry(t *testing.T, entryType entities.EntryType, name string, sizeInByt
 		LastModificationDate: date,
 	}
 }
+
+func Test_PerformListFiles_Recursive_Success(t *testing.T) {
+	testCases := []struct {
+		name           string
+		output         models.OutputFormat
+		expectedOutput string
+	}{
+		{
+			name:   "recursive listing as tables",
+			output: models.OutputFormatTable,
+			expectedOutput: `/foo/bar/baz:
++------+-------------+----------------+-------+-------------------+----------+
+| TYPE | PERMISSIONS |     OWNERS     | NAME  |   LAST MODIFIED   |   SIZE   |
++------+-------------+----------------+-------+-------------------+----------+
+| D    | rwxrwxrwx   | user01:group01 | dir1  | Mon, 24 Jan 16:23 | 39.09 KB |
+| F    | rwxrwxrwx   | user01:group01 | file5 | Wed, 12 Jan 16:23 | 167 B    |
++------+-------------+----------------+-------+-------------------+----------+
+
+/foo/bar/baz/dir1:
++------+-------------+----------------+-------+-------------------+---------+
+| TYPE | PERMISSIONS |     OWNERS     | NAME  |   LAST MODIFIED   |  SIZE   |
++------+-------------+----------------+-------+-------------------+---------+
+| F    | rwxrwxrwx   | user01:group01 | file7 | Thu, 02 Apr 14:23 | 4.25 KB |
++------+-------------+----------------+-------+-------------------+---------+
+`,
+		},
+		{
+			name:   "recursive listing as JSON lines",
+			output: models.OutputFormatJSONL,
+			expectedOutput: `{"type":"dir","name":"/foo/bar/baz/dir1","permissions":"rwxrwxrwx","owner_user":"user01","owner_group":"group01","size_in_bytes":40032,"num_hard_links":2,"last_modification_date":"2022-01-24T16:23:00Z"}
+{"type":"file","name":"/foo/bar/baz/file5","permissions":"rwxrwxrwx","owner_user":"user01","owner_group":"group01","size_in_bytes":167,"num_hard_links":2,"last_modification_date":"2022-01-12T16:23:00Z"}
+{"type":"file","name":"/foo/bar/baz/dir1/file7","permissions":"rwxrwxrwx","owner_user":"user01","owner_group":"group01","size_in_bytes":4352,"num_hard_links":2,"last_modification_date":"2020-04-02T14:23:00Z"}
+`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			logger := assertlogging.NewLogger(t)
+
+			ftpConnMock := connectionMocks.NewConnection(t)
+			ftpConnMock.On("Stop").Return(nil).Once()
+
+			config := ftpclient.ConnectorConfig{
+				Address:  address,
+				User:     user,
+				Password: password,
+				Timeout:  timeout,
+			}
+			connMock := ftpclientMocks.NewConnector(t)
+			connMock.
+				On("Connect", ctx, config).
+				Return(ftpConnMock, nil).
+				Once()
+
+			useCaseRepos := &ftp.WalkRepos{
+				Logger:     logger,
+				Connection: ftpConnMock,
+			}
+			walkUseCaseMock := useCaseMocks.NewWalkUseCase(t)
+			walkUseCaseMock.
+				On(
+					"Execute",
+					ctx,
+					useCaseRepos,
+					mock.MatchedBy(func(input *ftp.WalkInput) bool {
+						return input.Path == path && input.SortType == entities.SortTypeName && input.MaxDepth == 0
+					}),
+				).
+				Run(func(args mock.Arguments) {
+					input := args.Get(2).(*ftp.WalkInput)
+					require.NoError(t, input.Visit(&ftp.WalkedDir{
+						Path:  path,
+						Depth: 0,
+						Entries: []*entities.Entry{
+							newEntry(t, entities.EntryTypeDir, "dir1", 40032, "2022-01-24 16:23"),
+							newEntry(t, entities.EntryTypeFile, "file5", 167, "2022-01-12 16:23"),
+						},
+					}))
+					require.NoError(t, input.Visit(&ftp.WalkedDir{
+						Path:  path + "/dir1",
+						Depth: 1,
+						Entries: []*entities.Entry{
+							newEntry(t, entities.EntryTypeFile, "file7", 4352, "2020-04-02 14:23"),
+						},
+					}))
+				}).
+				Return(nil).
+				Once()
+
+			buffer := bytes.NewBufferString("")
+
+			deps := &list.Dependencies{
+				Connector:   connMock,
+				UseCase:     useCaseMocks.NewListFilesUseCase(t),
+				WalkUseCase: walkUseCaseMock,
+				OutWriter:   buffer,
+			}
+			input := &list.CmdListInput{
+				Config:    config,
+				Path:      path,
+				Recursive: true,
+				SortType:  models.SortTypeName,
+				Output:    tc.output,
+			}
+
+			err := list.PerformListFiles(ctx, logger, deps, input)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedOutput, buffer.String())
+		})
+	}
+}
+
+func Test_PerformListFiles_Recursive_UseCaseError(t *testing.T) {
+	ctx := context.Background()
+
+	logger := assertlogging.NewLogger(t)
+
+	ftpConnMock := connectionMocks.NewConnection(t)
+	ftpConnMock.On("Stop").Return(nil).Once()
+
+	config := ftpclient.ConnectorConfig{
+		Address:  address,
+		User:     user,
+		Password: password,
+		Timeout:  timeout,
+	}
+	connMock := ftpclientMocks.NewConnector(t)
+	connMock.
+		On("Connect", ctx, config).
+		Return(ftpConnMock, nil).
+		Once()
+
+	walkUseCaseMock := useCaseMocks.NewWalkUseCase(t)
+	walkUseCaseMock.
+		On("Execute", ctx, mock.Anything, mock.Anything).
+		Return(ftperrors.NewInternalError("failed to list directory", nil)).
+		Once()
+
+	deps := &list.Dependencies{
+		Connector:   connMock,
+		UseCase:     useCaseMocks.NewListFilesUseCase(t),
+		WalkUseCase: walkUseCaseMock,
+		OutWriter:   bytes.NewBufferString(""),
+	}
+	input := &list.CmdListInput{
+		Config:    config,
+		Path:      path,
+		Recursive: true,
+		SortType:  models.SortTypeName,
+	}
+
+	err := list.PerformListFiles(ctx, logger, deps, input)
+	require.EqualError(t, err, "an internal error occurred: failed to list directory")
+	assert.IsType(t, ftperrors.InternalErrorType, err)
+	assert.NoError(t, errors.Unwrap(err))
+}
@@ -0,0 +1,63 @@
+package ftpclient
+
+import (
+	"github.com/alexZaicev/go-ftp-client/internal/drivers/logging"
+	"github.com/alexZaicev/go-ftp-client/internal/usecases/ftp"
+)
+
+// TransferLogger is the transfer observer that logs transferred files at debug level, so that they are
+// only visible in verbose mode. Bytes transferred are not logged.
+type TransferLogger struct {
+	Logger logging.Logger
+}
+
+var _ ftp.TransferObserver = (*TransferLogger)(nil)
+
+func (l *TransferLogger) FileStarted(event ftp.TransferEvent) {
+	l.Logger.
+		WithFields(logging.Fields{
+			"remote-path":   event.Path,
+			"size-in-bytes": event.SizeInBytes,
+		}).
+		Debug("file transfer started")
+}
+
+func (l *TransferLogger) BytesTransferred(ftp.TransferEvent, int) {}
+
+func (l *TransferLogger) FileCompleted(event ftp.TransferEvent) {
+	l.Logger.
+		WithFields(logging.Fields{
+			"remote-path":   event.Path,
+			"size-in-bytes": event.TransferredBytes,
+			"elapsed":       event.Elapsed.String(),
+		}).
+		Debug("file transfer completed")
+}
+
+func (l *TransferLogger) FileFailed(event ftp.TransferEvent, err error) {
+	l.Logger.
+		WithError(err).
+		WithFields(logging.Fields{
+			"remote-path":   event.Path,
+			"size-in-bytes": event.TransferredBytes,
+			"elapsed":       event.Elapsed.String(),
+		}).
+		Debug("file transfer failed")
+}
+
+func (l *TransferLogger) FileSkipped(event ftp.TransferEvent, reason string) {
+	l.Logger.
+		WithFields(logging.Fields{
+			"remote-path": event.Path,
+			"reason":      reason,
+		}).
+		Debug("file transfer skipped")
+}
+
+func (l *TransferLogger) DirListed(path string) {
+	l.Logger.WithField("remote-path", path).Debug("directory listed")
+}
+
+func (l *TransferLogger) EntryRemoved(path string) {
+	l.Logger.WithField("remote-path", path).Debug("entry removed")
+}
@@ -0,0 +1,364 @@
+package ftpclient
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/alexZaicev/go-ftp-client/internal/domain/entities"
+	"github.com/alexZaicev/go-ftp-client/internal/domain/errors"
+	"github.com/alexZaicev/go-ftp-client/internal/drivers/cli/models"
+)
+
+// outputRecord is a record of machine readable output, encoded by its JSON and YAML tags, or as a CSV row.
+type outputRecord interface {
+	csvRow() []string
+}
+
+// entryRecord is the machine readable form of entities.Entry, with dates in RFC3339 and sizes in bytes.
+type entryRecord struct {
+	Type                 string            `json:"type" yaml:"type"`
+	Name                 string            `json:"name" yaml:"name"`
+	LinkName             string            `json:"link_name,omitempty" yaml:"link_name,omitempty"`
+	Permissions          string            `json:"permissions" yaml:"permissions"`
+	Mode                 string            `json:"mode,omitempty" yaml:"mode,omitempty"`
+	Capabilities         string            `json:"capabilities,omitempty" yaml:"capabilities,omitempty"`
+	OwnerUser            string            `json:"owner_user" yaml:"owner_user"`
+	OwnerGroup           string            `json:"owner_group" yaml:"owner_group"`
+	UID                  *uint32           `json:"uid,omitempty" yaml:"uid,omitempty"`
+	GID                  *uint32           `json:"gid,omitempty" yaml:"gid,omitempty"`
+	SizeInBytes          uint64            `json:"size_in_bytes" yaml:"size_in_bytes"`
+	NumHardLinks         int               `json:"num_hard_links" yaml:"num_hard_links"`
+	LastModificationDate string            `json:"last_modification_date,omitempty" yaml:"last_modification_date,omitempty"`
+	CreationDate         string            `json:"creation_date,omitempty" yaml:"creation_date,omitempty"`
+	UniqueID             string            `json:"unique_id,omitempty" yaml:"unique_id,omitempty"`
+	Raw                  string            `json:"raw,omitempty" yaml:"raw,omitempty"`
+	Facts                map[string]string `json:"facts,omitempty" yaml:"facts,omitempty"`
+}
+
+var entryRecordHeader = []string{
+	"type",
+	"name",
+	"link_name",
+	"permissions",
+	"mode",
+	"capabilities",
+	"owner_user",
+	"owner_group",
+	"uid",
+	"gid",
+	"size_in_bytes",
+	"num_hard_links",
+	"last_modification_date",
+	"creation_date",
+	"unique_id",
+	"raw",
+	"facts",
+}
+
+func newEntryRecord(entry *entities.Entry) (*entryRecord, error) {
+	entryType, err := entryTypeName(entry.Type)
+	if err != nil {
+		return nil, err
+	}
+
+	record := &entryRecord{
+		Type:                 entryType,
+		Name:                 entry.Name,
+		LinkName:             entry.LinkName,
+		Permissions:          entry.Permissions,
+		Capabilities:         entry.Capabilities.String(),
+		OwnerUser:            entry.OwnerUser,
+		OwnerGroup:           entry.OwnerGroup,
+		UID:                  entry.UID,
+		GID:                  entry.GID,
+		SizeInBytes:          entry.SizeInBytes,
+		NumHardLinks:         entry.NumHardLinks,
+		LastModificationDate: formatRFC3339(entry.ResolvedModificationDate(time.Now())),
+		CreationDate:         formatRFC3339(entry.CreationDate),
+		UniqueID:             entry.UniqueID,
+		Raw:                  entry.Raw,
+		Facts:                entry.Facts,
+	}
+	if entry.Mode != 0 {
+		record.Mode = entry.Mode.String()
+	}
+	return record, nil
+}
+
+func (r *entryRecord) csvRow() []string {
+	return []string{
+		r.Type,
+		r.Name,
+		r.LinkName,
+		r.Permissions,
+		r.Mode,
+		r.Capabilities,
+		r.OwnerUser,
+		r.OwnerGroup,
+		formatOptionalID(r.UID),
+		formatOptionalID(r.GID),
+		strconv.FormatUint(r.SizeInBytes, 10),
+		strconv.Itoa(r.NumHardLinks),
+		r.LastModificationDate,
+		r.CreationDate,
+		r.UniqueID,
+		r.Raw,
+		FormatFacts(r.Facts),
+	}
+}
+
+// statusRecord is the machine readable form of entities.Status.
+type statusRecord struct {
+	RemoteAddress string `json:"remote_address" yaml:"remote_address"`
+	LoggedInUser  string `json:"logged_in_user" yaml:"logged_in_user"`
+	TLSEnabled    bool   `json:"tls_enabled" yaml:"tls_enabled"`
+	System        string `json:"system" yaml:"system"`
+}
+
+var statusRecordHeader = []string{"remote_address", "logged_in_user", "tls_enabled", "system"}
+
+func (r *statusRecord) csvRow() []string {
+	return []string{r.RemoteAddress, r.LoggedInUser, strconv.FormatBool(r.TLSEnabled), r.System}
+}
+
+// dirUsageRecord is the machine readable form of entities.DirUsage.
+type dirUsageRecord struct {
+	Path        string `json:"path" yaml:"path"`
+	Depth       int    `json:"depth" yaml:"depth"`
+	SizeInBytes uint64 `json:"size_in_bytes" yaml:"size_in_bytes"`
+	Files       int    `json:"files" yaml:"files"`
+	Dirs        int    `json:"dirs" yaml:"dirs"`
+}
+
+var dirUsageRecordHeader = []string{"path", "depth", "size_in_bytes", "files", "dirs"}
+
+func (r *dirUsageRecord) csvRow() []string {
+	return []string{
+		r.Path,
+		strconv.Itoa(r.Depth),
+		strconv.FormatUint(r.SizeInBytes, 10),
+		strconv.Itoa(r.Files),
+		strconv.Itoa(r.Dirs),
+	}
+}
+
+// transferRecord is the CSV row of entities.TransferResult, which is encoded as is otherwise.
+type transferRecord struct {
+	*entities.TransferResult
+}
+
+var transferRecordHeader = []string{"path", "status", "reason"}
+
+func (r transferRecord) csvRow() []string {
+	return []string{r.Path, string(r.Status), r.Reason}
+}
+
+// syncActionRecord is the machine readable form of entities.SyncAction, named by the command that planned it.
+type syncActionRecord struct {
+	Action      string `json:"action" yaml:"action"`
+	Path        string `json:"path" yaml:"path"`
+	NewPath     string `json:"new_path,omitempty" yaml:"new_path,omitempty"`
+	Reason      string `json:"reason" yaml:"reason"`
+	IsDir       bool   `json:"is_dir" yaml:"is_dir"`
+	SizeInBytes uint64 `json:"size_in_bytes" yaml:"size_in_bytes"`
+}
+
+var syncActionRecordHeader = []string{"action", "path", "new_path", "reason", "is_dir", "size_in_bytes"}
+
+func (r *syncActionRecord) csvRow() []string {
+	return []string{
+		r.Action,
+		r.Path,
+		r.NewPath,
+		r.Reason,
+		strconv.FormatBool(r.IsDir),
+		strconv.FormatUint(r.SizeInBytes, 10),
+	}
+}
+
+// WriteEntryRecords function writes entries in the machine readable format, as a list of entries for JSON
+// and YAML, or as a record per entry otherwise.
+func WriteEntryRecords(writer io.Writer, format models.OutputFormat, entries []*entities.Entry) error {
+	entryRecords := make([]*entryRecord, 0, len(entries))
+	records := make([]outputRecord, 0, len(entries))
+	for _, entry := range entries {
+		record, err := newEntryRecord(entry)
+		if err != nil {
+			return err
+		}
+		entryRecords = append(entryRecords, record)
+		records = append(records, record)
+	}
+	return writeRecords(writer, format, entryRecordHeader, entryRecords, records)
+}
+
+// WriteStatusRecord function writes the status in the machine readable format, as a single record.
+func WriteStatusRecord(writer io.Writer, format models.OutputFormat, status *entities.Status) error {
+	record := &statusRecord{
+		RemoteAddress: status.RemoteAddress,
+		LoggedInUser:  status.LoggedInUser,
+		TLSEnabled:    status.TLSEnabled,
+		System:        status.System,
+	}
+	return writeRecords(writer, format, statusRecordHeader, record, []outputRecord{record})
+}
+
+// WriteDirUsageRecords function writes usages of directories in the machine readable format, as a list of
+// usages for JSON and YAML, or as a record per directory otherwise.
+func WriteDirUsageRecords(writer io.Writer, format models.OutputFormat, usages []*entities.DirUsage) error {
+	usageRecords := make([]*dirUsageRecord, 0, len(usages))
+	records := make([]outputRecord, 0, len(usages))
+	for _, usage := range usages {
+		record := &dirUsageRecord{
+			Path:        usage.Path,
+			Depth:       usage.Depth,
+			SizeInBytes: usage.SizeInBytes,
+			Files:       usage.Files,
+			Dirs:        usage.Dirs,
+		}
+		usageRecords = append(usageRecords, record)
+		records = append(records, record)
+	}
+	return writeRecords(writer, format, dirUsageRecordHeader, usageRecords, records)
+}
+
+// WriteSyncActions function writes planned actions in the machine readable format, as a list of actions for
+// JSON and YAML, or as a record per action otherwise, where names are the names of the actions.
+func WriteSyncActions(
+	writer io.Writer,
+	format models.OutputFormat,
+	actions []*entities.SyncAction,
+	names []string,
+) error {
+	actionRecords := make([]*syncActionRecord, 0, len(actions))
+	records := make([]outputRecord, 0, len(actions))
+	for idx, action := range actions {
+		record := &syncActionRecord{
+			Action:      names[idx],
+			Path:        action.Path,
+			NewPath:     action.NewPath,
+			Reason:      string(action.Reason),
+			IsDir:       action.IsDir,
+			SizeInBytes: action.SizeInBytes,
+		}
+		actionRecords = append(actionRecords, record)
+		records = append(records, record)
+	}
+	return writeRecords(writer, format, syncActionRecordHeader, actionRecords, records)
+}
+
+// WriteTransferResults function writes the outcome of every entry in the machine readable format. JSON and
+// YAML documents are the same as the transfer report, while other formats have a record per entry.
+func WriteTransferResults(writer io.Writer, format models.OutputFormat, results []*entities.TransferResult) error {
+	records := make([]outputRecord, 0, len(results))
+	for _, result := range results {
+		records = append(records, transferRecord{result})
+	}
+	return writeRecords(writer, format, transferRecordHeader, newTransferReport(results), records)
+}
+
+// writeRecords function encodes the document for JSON and YAML, or the records one by one for JSON lines
+// and CSV, where the header is the first row.
+func writeRecords(
+	writer io.Writer,
+	format models.OutputFormat,
+	header []string,
+	document interface{},
+	records []outputRecord,
+) error {
+	var err error
+	switch format {
+	case models.OutputFormatJSON:
+		encoder := json.NewEncoder(writer)
+		encoder.SetIndent("", "  ")
+		err = encoder.Encode(document)
+	case models.OutputFormatJSONL:
+		encoder := json.NewEncoder(writer)
+		for _, record := range records {
+			if err = encoder.Encode(record); err != nil {
+				break
+			}
+		}
+	case models.OutputFormatCSV:
+		csvWriter := csv.NewWriter(writer)
+		if err = csvWriter.Write(header); err != nil {
+			break
+		}
+		for _, record := range records {
+			if err = csvWriter.Write(record.csvRow()); err != nil {
+				break
+			}
+		}
+		csvWriter.Flush()
+		if err == nil {
+			err = csvWriter.Error()
+		}
+	case models.OutputFormatYAML:
+		encoder := yaml.NewEncoder(writer)
+		encoder.SetIndent(2)
+		if err = encoder.Encode(document); err == nil {
+			err = encoder.Close()
+		}
+	default:
+		return errors.NewUnknownError(fmt.Sprintf("unexpected output format: %s", format), nil)
+	}
+
+	if err != nil {
+		return errors.NewInternalError("failed to write output", err)
+	}
+	return nil
+}
+
+// FormatFacts function formats facts the same way as they are listed in RFC3659 entries,
+// sorted by fact name to keep the output stable.
+func FormatFacts(facts map[string]string) string {
+	names := make([]string, 0, len(facts))
+	for name := range facts {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var sb strings.Builder
+	for _, name := range names {
+		sb.WriteString(fmt.Sprintf("%s=%s;", name, facts[name]))
+	}
+	return sb.String()
+}
+
+func entryTypeName(entryType entities.EntryType) (string, error) {
+	switch entryType {
+	case entities.EntryTypeFile:
+		return "file", nil
+	case entities.EntryTypeDir:
+		return "dir", nil
+	case entities.EntryTypeLink:
+		return "link", nil
+	default:
+		return "", errors.NewUnknownError(
+			fmt.Sprintf("unexpected entry type: %d", entryType),
+			nil,
+		)
+	}
+}
+
+func formatRFC3339(date time.Time) string {
+	if date.IsZero() {
+		return ""
+	}
+	return date.Format(time.RFC3339)
+}
+
+func formatOptionalID(id *uint32) string {
+	if id == nil {
+		return ""
+	}
+	return strconv.FormatUint(uint64(*id), 10)
+}
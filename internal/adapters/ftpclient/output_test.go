@@ -0,0 +1,310 @@
+package ftpclient_test
+
+import (
+	"bytes"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/alexZaicev/go-ftp-client/internal/adapters/ftpclient"
+	"github.com/alexZaicev/go-ftp-client/internal/domain/entities"
+	ftperrors "github.com/alexZaicev/go-ftp-client/internal/domain/errors"
+	"github.com/alexZaicev/go-ftp-client/internal/drivers/cli/models"
+)
+
+func getOutputEntries() []*entities.Entry {
+	uid := uint32(1000)
+	return []*entities.Entry{
+		{
+			Type:                 entities.EntryTypeFile,
+			Permissions:          "rw-r--r--",
+			Mode:                 0o644,
+			Name:                 "report.csv",
+			OwnerUser:            "user01",
+			OwnerGroup:           "group01",
+			UID:                  &uid,
+			SizeInBytes:          2048,
+			NumHardLinks:         1,
+			LastModificationDate: time.Date(2021, time.March, 4, 16, 23, 0, 0, time.UTC),
+			Facts:                map[string]string{"unix.mode": "0644", "lang": "en"},
+		},
+		{
+			Type:        entities.EntryTypeLink,
+			Permissions: "rwxrwxrwx",
+			Name:        "latest",
+			LinkName:    "/releases/v1",
+			OwnerUser:   "user01",
+			OwnerGroup:  "group01",
+		},
+	}
+}
+
+func Test_WriteEntryRecords_Success(t *testing.T) {
+	testCases := []struct {
+		name     string
+		format   models.OutputFormat
+		expected string
+	}{
+		{
+			name:   "json",
+			format: models.OutputFormatJSON,
+			expected: `[
+  {
+    "type": "file",
+    "name": "report.csv",
+    "permissions": "rw-r--r--",
+    "mode": "-rw-r--r--",
+    "owner_user": "user01",
+    "owner_group": "group01",
+    "uid": 1000,
+    "size_in_bytes": 2048,
+    "num_hard_links": 1,
+    "last_modification_date": "2021-03-04T16:23:00Z",
+    "facts": {
+      "lang": "en",
+      "unix.mode": "0644"
+    }
+  },
+  {
+    "type": "link",
+    "name": "latest",
+    "link_name": "/releases/v1",
+    "permissions": "rwxrwxrwx",
+    "owner_user": "user01",
+    "owner_group": "group01",
+    "size_in_bytes": 0,
+    "num_hard_links": 0
+  }
+]
+`,
+		},
+		{
+			name:   "jsonl",
+			format: models.OutputFormatJSONL,
+			expected: `{"type":"file","name":"report.csv","permissions":"rw-r--r--","mode":"-rw-r--r--",` +
+				`"owner_user":"user01","owner_group":"group01","uid":1000,"size_in_bytes":2048,"num_hard_links":1,` +
+				`"last_modification_date":"2021-03-04T16:23:00Z","facts":{"lang":"en","unix.mode":"0644"}}` + "\n" +
+				`{"type":"link","name":"latest","link_name":"/releases/v1","permissions":"rwxrwxrwx",` +
+				`"owner_user":"user01","owner_group":"group01","size_in_bytes":0,"num_hard_links":0}` + "\n",
+		},
+		{
+			name:   "csv",
+			format: models.OutputFormatCSV,
+			expected: "type,name,link_name,permissions,mode,capabilities,owner_user,owner_group,uid,gid," +
+				"size_in_bytes,num_hard_links,last_modification_date,creation_date,unique_id,raw,facts\n" +
+				"file,report.csv,,rw-r--r--,-rw-r--r--,,user01,group01,1000,,2048,1,2021-03-04T16:23:00Z,,,," +
+				"lang=en;unix.mode=0644;\n" +
+				"link,latest,/releases/v1,rwxrwxrwx,,,user01,group01,,,0,0,,,,,\n",
+		},
+		{
+			name:   "yaml",
+			format: models.OutputFormatYAML,
+			expected: `- type: file
+  name: report.csv
+  permissions: rw-r--r--
+  mode: -rw-r--r--
+  owner_user: user01
+  owner_group: group01
+  uid: 1000
+  size_in_bytes: 2048
+  num_hard_links: 1
+  last_modification_date: "2021-03-04T16:23:00Z"
+  facts:
+    lang: en
+    unix.mode: "0644"
+- type: link
+  name: latest
+  link_name: /releases/v1
+  permissions: rwxrwxrwx
+  owner_user: user01
+  owner_group: group01
+  size_in_bytes: 0
+  num_hard_links: 0
+`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			buffer := bytes.NewBufferString("")
+			err := ftpclient.WriteEntryRecords(buffer, tc.format, getOutputEntries())
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, buffer.String())
+		})
+	}
+}
+
+func Test_WriteEntryRecords_YearUnknown(t *testing.T) {
+	modified := time.Now().UTC().Add(-time.Hour).Truncate(time.Minute)
+	entries := []*entities.Entry{
+		{
+			Type:        entities.EntryTypeFile,
+			Permissions: "rw-r--r--",
+			Name:        "report.csv",
+			OwnerUser:   "user01",
+			OwnerGroup:  "group01",
+			SizeInBytes: 2048,
+			LastModificationDate: time.Date(
+				0, modified.Month(), modified.Day(), modified.Hour(), modified.Minute(), 0, 0, time.UTC,
+			),
+			YearUnknown: true,
+		},
+	}
+
+	buffer := bytes.NewBufferString("")
+	err := ftpclient.WriteEntryRecords(buffer, models.OutputFormatJSONL, entries)
+	require.NoError(t, err)
+	assert.Equal(t, `{"type":"file","name":"report.csv","permissions":"rw-r--r--","owner_user":"user01",`+
+		`"owner_group":"group01","size_in_bytes":2048,"num_hard_links":0,`+
+		`"last_modification_date":"`+modified.Format(time.RFC3339)+`"}`+"\n", buffer.String())
+}
+
+func Test_WriteEntryRecords_NoEntries(t *testing.T) {
+	buffer := bytes.NewBufferString("")
+	err := ftpclient.WriteEntryRecords(buffer, models.OutputFormatJSON, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "[]\n", buffer.String())
+}
+
+func Test_WriteEntryRecords_EntryTypeError(t *testing.T) {
+	buffer := bytes.NewBufferString("")
+	err := ftpclient.WriteEntryRecords(buffer, models.OutputFormatJSON, []*entities.Entry{{Mode: os.ModeDir}})
+	require.EqualError(t, err, "an unknown error occurred: unexpected entry type: 0")
+	assert.IsType(t, ftperrors.UnknownErrorType, err)
+	assert.Empty(t, buffer.String())
+}
+
+func Test_WriteStatusRecord_Success(t *testing.T) {
+	testCases := []struct {
+		name     string
+		format   models.OutputFormat
+		expected string
+	}{
+		{
+			name:   "json",
+			format: models.OutputFormatJSON,
+			expected: `{
+  "remote_address": "10.0.0.1:21",
+  "logged_in_user": "user01",
+  "tls_enabled": true,
+  "system": "UNIX Type: L8"
+}
+`,
+		},
+		{
+			name:     "jsonl",
+			format:   models.OutputFormatJSONL,
+			expected: `{"remote_address":"10.0.0.1:21","logged_in_user":"user01","tls_enabled":true,"system":"UNIX Type: L8"}` + "\n",
+		},
+		{
+			name:     "csv",
+			format:   models.OutputFormatCSV,
+			expected: "remote_address,logged_in_user,tls_enabled,system\n10.0.0.1:21,user01,true,UNIX Type: L8\n",
+		},
+		{
+			name:     "yaml",
+			format:   models.OutputFormatYAML,
+			expected: "remote_address: 10.0.0.1:21\nlogged_in_user: user01\ntls_enabled: true\nsystem: 'UNIX Type: L8'\n",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			buffer := bytes.NewBufferString("")
+			err := ftpclient.WriteStatusRecord(buffer, tc.format, &entities.Status{
+				RemoteAddress: "10.0.0.1:21",
+				LoggedInUser:  "user01",
+				TLSEnabled:    true,
+				System:        "UNIX Type: L8",
+			})
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, buffer.String())
+		})
+	}
+}
+
+func Test_WriteTransferResults_Success(t *testing.T) {
+	testCases := []struct {
+		name     string
+		format   models.OutputFormat
+		expected string
+	}{
+		{
+			name:   "json",
+			format: models.OutputFormatJSON,
+			expected: `{
+  "ok": 1,
+  "skipped": 1,
+  "failed": 1,
+  "results": [
+    {
+      "path": "/pub/file-1",
+      "status": "ok"
+    },
+    {
+      "path": "/pub/file-2",
+      "status": "skipped",
+      "reason": "already exists"
+    },
+    {
+      "path": "/pub/file-3",
+      "status": "failed",
+      "reason": "an internal error occurred: failed to download file: mock error"
+    }
+  ]
+}
+`,
+		},
+		{
+			name:   "jsonl",
+			format: models.OutputFormatJSONL,
+			expected: `{"path":"/pub/file-1","status":"ok"}` + "\n" +
+				`{"path":"/pub/file-2","status":"skipped","reason":"already exists"}` + "\n" +
+				`{"path":"/pub/file-3","status":"failed","reason":"an internal error occurred: failed to download file: mock error"}` + "\n",
+		},
+		{
+			name:   "csv",
+			format: models.OutputFormatCSV,
+			expected: "path,status,reason\n" +
+				"/pub/file-1,ok,\n" +
+				"/pub/file-2,skipped,already exists\n" +
+				"/pub/file-3,failed,an internal error occurred: failed to download file: mock error\n",
+		},
+		{
+			name:   "yaml",
+			format: models.OutputFormatYAML,
+			expected: `ok: 1
+skipped: 1
+failed: 1
+results:
+  - path: /pub/file-1
+    status: ok
+  - path: /pub/file-2
+    status: skipped
+    reason: already exists
+  - path: /pub/file-3
+    status: failed
+    reason: 'an internal error occurred: failed to download file: mock error'
+`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			buffer := bytes.NewBufferString("")
+			err := ftpclient.WriteTransferResults(buffer, tc.format, getTransferResults())
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, buffer.String())
+		})
+	}
+}
+
+func Test_WriteTransferResults_UnknownFormat(t *testing.T) {
+	buffer := bytes.NewBufferString("")
+	err := ftpclient.WriteTransferResults(buffer, models.OutputFormatTable, getTransferResults())
+	require.EqualError(t, err, "an unknown error occurred: unexpected output format: table")
+	assert.IsType(t, ftperrors.UnknownErrorType, err)
+}
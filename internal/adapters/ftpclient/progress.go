@@ -0,0 +1,190 @@
+package ftpclient
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"sync"
+	"sync/atomic"
+
+	"github.com/vbauerster/mpb/v8"
+	"github.com/vbauerster/mpb/v8/decor"
+
+	"github.com/alexZaicev/go-ftp-client/internal/usecases/ftp"
+)
+
+const (
+	progressBarWidth = 64
+)
+
+// ProgressBars is the transfer observer that draws a progress bar of every transferred file, with the
+// throughput and the estimated time left, along with counters of listed directories and removed entries.
+// Once more than one file is transferred, an aggregate bar of all files is drawn below the others.
+type ProgressBars struct {
+	progress *mpb.Progress
+	verb     string
+	// totalFiles is read by the decorator of the aggregate bar while it is drawn, so it is not guarded by
+	// the mutex
+	totalFiles int64
+
+	mu    sync.Mutex
+	files map[string]*mpb.Bar
+	// total is the aggregate bar of all files, which is added once the second file starts
+	total                  *mpb.Bar
+	totalSizeInBytes       int64
+	transferredSizeInBytes int64
+	listed                 *mpb.Bar
+	removed                *mpb.Bar
+}
+
+var _ ftp.TransferObserver = (*ProgressBars)(nil)
+
+// NewProgressBars function returns progress bars drawn to the writer, where verb describes the transfer,
+// such as "Downloading". Nothing is drawn if the writer is nil.
+func NewProgressBars(writer io.Writer, verb string) *ProgressBars {
+	return &ProgressBars{
+		progress: mpb.New(mpb.WithOutput(writer), mpb.WithWidth(progressBarWidth)),
+		files:    make(map[string]*mpb.Bar),
+		verb:     verb,
+	}
+}
+
+// FileStarted method adds the progress bar of the file.
+func (p *ProgressBars) FileStarted(event ftp.TransferEvent) {
+	bar := p.progress.New(
+		int64(event.SizeInBytes),
+		barStyle(),
+		mpb.PrependDecorators(
+			decor.Name(fmt.Sprintf("%s %s ", p.verb, event.Path)),
+		),
+		mpb.AppendDecorators(transferDecorators()...),
+	)
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.files[event.Path] = bar
+	totalFiles := atomic.AddInt64(&p.totalFiles, 1)
+	p.totalSizeInBytes += int64(event.SizeInBytes)
+	if p.total == nil && totalFiles > 1 {
+		// created without the total, so that the bar does not complete before the next file starts
+		p.total = p.progress.New(
+			0,
+			barStyle(),
+			mpb.BarPriority(math.MaxInt),
+			mpb.PrependDecorators(
+				decor.Any(func(decor.Statistics) string {
+					return fmt.Sprintf("Total of %d file(s) ", atomic.LoadInt64(&p.totalFiles))
+				}),
+			),
+			mpb.AppendDecorators(transferDecorators()...),
+		)
+		p.total.SetCurrent(p.transferredSizeInBytes)
+	}
+	if p.total != nil {
+		p.total.SetTotal(p.totalSizeInBytes, false)
+	}
+}
+
+// BytesTransferred method advances the progress bar of the file along with the aggregate bar.
+func (p *ProgressBars) BytesTransferred(event ftp.TransferEvent, n int) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if bar, ok := p.files[event.Path]; ok {
+		bar.IncrBy(n)
+	}
+	p.transferredSizeInBytes += int64(n)
+	if p.total != nil {
+		p.total.IncrBy(n)
+	}
+}
+
+// FileCompleted method completes the progress bar of the file.
+func (p *ProgressBars) FileCompleted(event ftp.TransferEvent) {
+	if bar := p.endFile(event.Path); bar != nil {
+		// bars of empty files are created without the total, so they are completed here
+		bar.SetTotal(-1, true)
+	}
+}
+
+// FileFailed method drops the progress bar of the file.
+func (p *ProgressBars) FileFailed(event ftp.TransferEvent, _ error) {
+	if bar := p.endFile(event.Path); bar != nil {
+		bar.Abort(true)
+	}
+}
+
+// FileSkipped method does nothing, as bars are only drawn for files that are transferred.
+func (p *ProgressBars) FileSkipped(ftp.TransferEvent, string) {}
+
+// DirListed method advances the counter of listed directories.
+func (p *ProgressBars) DirListed(string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.listed == nil {
+		p.listed = p.counter("Listed directories")
+	}
+	p.listed.Increment()
+}
+
+// EntryRemoved method advances the counter of removed entries.
+func (p *ProgressBars) EntryRemoved(string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.removed == nil {
+		p.removed = p.counter("Removed entries")
+	}
+	p.removed.Increment()
+}
+
+// Wait method completes the aggregate bar and the counters, and waits for the bars to be drawn. Bars of
+// files that did not end are dropped.
+func (p *ProgressBars) Wait() {
+	p.mu.Lock()
+	for path, bar := range p.files {
+		bar.Abort(true)
+		delete(p.files, path)
+	}
+	for _, bar := range []*mpb.Bar{p.total, p.listed, p.removed} {
+		if bar != nil {
+			bar.SetTotal(-1, true)
+		}
+	}
+	p.mu.Unlock()
+
+	p.progress.Wait()
+}
+
+// endFile method forgets the progress bar of the file and returns it, or nil if the file was not started.
+func (p *ProgressBars) endFile(path string) *mpb.Bar {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	bar := p.files[path]
+	delete(p.files, path)
+	return bar
+}
+
+// counter method adds the bar counting processed items, whose total is not known upfront.
+func (p *ProgressBars) counter(name string) *mpb.Bar {
+	return p.progress.New(
+		0,
+		mpb.NopStyle(),
+		mpb.PrependDecorators(
+			decor.Name(name+": "),
+			decor.CurrentNoUnit("%d"),
+		),
+	)
+}
+
+func barStyle() mpb.BarStyleComposer {
+	return mpb.BarStyle().Lbound("[").Filler("=").Tip(">").Padding("-").Rbound("]")
+}
+
+// transferDecorators function returns decorators of transferred bytes, the throughput and the estimated
+// time left.
+func transferDecorators() []decor.Decorator {
+	return []decor.Decorator{
+		decor.CountersKibiByte("% .1f / % .1f "),
+		decor.AverageSpeed(decor.UnitKiB, "% .1f "),
+		decor.OnComplete(decor.AverageETA(decor.ET_STYLE_GO), "done"),
+	}
+}
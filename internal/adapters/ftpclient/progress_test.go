@@ -0,0 +1,61 @@
+package ftpclient_test
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/alexZaicev/go-ftp-client/internal/adapters/ftpclient"
+	"github.com/alexZaicev/go-ftp-client/internal/usecases/ftp"
+)
+
+func Test_ProgressBars_Success(t *testing.T) {
+	buffer := bytes.NewBufferString("")
+	progressBars := ftpclient.NewProgressBars(buffer, "Downloading")
+
+	progressBars.DirListed("/pub")
+
+	file1 := ftp.TransferEvent{Path: "/pub/file-1", SizeInBytes: 4}
+	progressBars.FileStarted(file1)
+	progressBars.BytesTransferred(file1, 4)
+	progressBars.FileCompleted(file1)
+
+	file2 := ftp.TransferEvent{Path: "/pub/file-2", SizeInBytes: 4}
+	progressBars.FileStarted(file2)
+	progressBars.BytesTransferred(file2, 2)
+	progressBars.FileFailed(file2, errors.New("mock error"))
+
+	progressBars.FileSkipped(ftp.TransferEvent{Path: "/pub/file-3"}, "file already exists")
+
+	file4 := ftp.TransferEvent{Path: "/pub/file-4"}
+	progressBars.FileStarted(file4)
+	progressBars.FileCompleted(file4)
+
+	progressBars.EntryRemoved("/pub/file-1")
+	progressBars.EntryRemoved("/pub")
+
+	progressBars.Wait()
+
+	output := buffer.String()
+	assert.Contains(t, output, "Downloading /pub/file-1")
+	assert.Contains(t, output, "Downloading /pub/file-4")
+	assert.NotContains(t, output, "/pub/file-3")
+	assert.Contains(t, output, "Total of 3 file(s)")
+	assert.Contains(t, output, "Listed directories: 1")
+	assert.Contains(t, output, "Removed entries: 2")
+}
+
+func Test_ProgressBars_NilWriter(t *testing.T) {
+	progressBars := ftpclient.NewProgressBars(nil, "Downloading")
+
+	file1 := ftp.TransferEvent{Path: "/pub/file-1", SizeInBytes: 4}
+	progressBars.FileStarted(file1)
+	progressBars.BytesTransferred(file1, 4)
+	progressBars.FileCompleted(file1)
+	// file that did not end is dropped once the bars are done
+	progressBars.FileStarted(ftp.TransferEvent{Path: "/pub/file-2", SizeInBytes: 4})
+
+	progressBars.Wait()
+}
@@ -6,19 +6,38 @@ import (
 
 	"github.com/alexZaicev/go-ftp-client/internal/adapters/ftpclient"
 	"github.com/alexZaicev/go-ftp-client/internal/domain/connection"
+	"github.com/alexZaicev/go-ftp-client/internal/domain/entities"
+	"github.com/alexZaicev/go-ftp-client/internal/domain/repositories"
+	"github.com/alexZaicev/go-ftp-client/internal/drivers/cli/models"
 	"github.com/alexZaicev/go-ftp-client/internal/drivers/logging"
 	useCase "github.com/alexZaicev/go-ftp-client/internal/usecases/ftp"
 )
 
 type CmdRemoveInput struct {
 	Config ftpclient.ConnectorConfig
-	Path   string
+	// Paths are either literal paths or glob patterns, removed in the given order.
+	Paths []string
+	// Literal takes Paths as they are, without expanding glob patterns or unescaping backslashes.
+	Literal bool
+	// Filter selects entries of removed directories, if provided.
+	Filter *entities.FilterOptions
+	// ContinueOnError records entries that fail to be removed and carries on, printing a summary of
+	// failed entries at the end.
+	ContinueOnError bool
+	// ReportPath is the local path of the JSON report listing the outcome of every entry, if provided.
+	ReportPath string
+	// Output is the format the outcome of every entry is written in, only failed entries are summarized
+	// if it is not set.
+	Output models.OutputFormat
 }
 
 type Dependencies struct {
 	Connector ftpclient.Connector
 	UseCase   useCase.RemoveUseCase
+	FileStore repositories.FileStore
 	OutWriter io.Writer
+	// ProgressWriter is where progress bars are drawn, they are not drawn if it is nil.
+	ProgressWriter io.Writer
 }
 
 func PerformRemove(ctx context.Context, logger logging.Logger, deps *Dependencies, input *CmdRemoveInput) (err error) {
@@ -39,15 +58,75 @@ func PerformRemove(ctx context.Context, logger logging.Logger, deps *Dependencie
 		Connection: conn,
 	}
 
-	useCaseInput := &useCase.RemoveInput{
-		Path: input.Path,
+	observers := useCase.TransferObservers{&ftpclient.TransferLogger{Logger: logger}}
+	var progressBars *ftpclient.ProgressBars
+	if deps.ProgressWriter != nil {
+		progressBars = ftpclient.NewProgressBars(deps.ProgressWriter, "Removing")
+		observers = append(observers, progressBars)
 	}
+	useCaseRepos.Observer = observers
 
-	if useCaseErr := deps.UseCase.Execute(ctx, useCaseRepos, useCaseInput); useCaseErr != nil {
-		return useCaseErr
+	results, err := removePaths(ctx, deps, useCaseRepos, input)
+	if progressBars != nil {
+		progressBars.Wait()
+	}
+	if err != nil {
+		return err
+	}
+
+	if input.ReportPath != "" {
+		if reportErr := ftpclient.SaveTransferReport(
+			logger, deps.FileStore, input.ReportPath, results,
+		); reportErr != nil {
+			return reportErr
+		}
+	}
+
+	if input.Output.IsMachineReadable() {
+		if writeErr := ftpclient.WriteTransferResults(deps.OutWriter, input.Output, results); writeErr != nil {
+			return writeErr
+		}
+	} else if input.ContinueOnError {
+		if writeErr := ftpclient.WriteTransferSummary(deps.OutWriter, results); writeErr != nil {
+			return writeErr
+		}
+	}
+
+	if transferErr := ftpclient.TransferError(results); transferErr != nil {
+		return transferErr
 	}
 
 	logger.Info("OK!")
 
 	return nil
 }
+
+// removePaths function removes the paths in the given order and returns outcomes of every entry. A path that
+// fails is recorded as failed if the removal continues on errors, otherwise the removal is aborted.
+func removePaths(
+	ctx context.Context,
+	deps *Dependencies,
+	useCaseRepos *useCase.RemoveRepos,
+	input *CmdRemoveInput,
+) ([]*entities.TransferResult, error) {
+	var results []*entities.TransferResult
+	for _, path := range input.Paths {
+		useCaseInput := &useCase.RemoveInput{
+			Path:            path,
+			Literal:         input.Literal,
+			Filter:          input.Filter,
+			ContinueOnError: input.ContinueOnError,
+		}
+
+		output, err := deps.UseCase.Execute(ctx, useCaseRepos, useCaseInput)
+		if err != nil {
+			if !input.ContinueOnError {
+				return nil, err
+			}
+			results = append(results, entities.NewTransferFailed(path, err))
+			continue
+		}
+		results = append(results, output.Results...)
+	}
+	return results, nil
+}
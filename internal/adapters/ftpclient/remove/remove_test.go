@@ -8,14 +8,19 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 
 	"github.com/alexZaicev/go-ftp-client/internal/adapters/ftpclient"
 	"github.com/alexZaicev/go-ftp-client/internal/adapters/ftpclient/remove"
+	"github.com/alexZaicev/go-ftp-client/internal/domain/entities"
+	ftperrors "github.com/alexZaicev/go-ftp-client/internal/domain/errors"
+	"github.com/alexZaicev/go-ftp-client/internal/drivers/cli/models"
 	"github.com/alexZaicev/go-ftp-client/internal/drivers/logging/assertlogging"
 	"github.com/alexZaicev/go-ftp-client/internal/usecases/ftp"
 	ftpclientMocks "github.com/alexZaicev/go-ftp-client/mocks/adapters/ftpclient"
 	connectionMocks "github.com/alexZaicev/go-ftp-client/mocks/domain/connection"
+	repositoryMocks "github.com/alexZaicev/go-ftp-client/mocks/domain/repositories"
 	useCaseMocks "github.com/alexZaicev/go-ftp-client/mocks/usecases/ftp"
 )
 
@@ -53,6 +58,7 @@ func Test_PerformRemove_Success(t *testing.T) {
 	useCaseRepos := &ftp.RemoveRepos{
 		Logger:     logger,
 		Connection: ftpConnMock,
+		Observer:   ftp.TransferObservers{&ftpclient.TransferLogger{Logger: logger}},
 	}
 
 	useCaseInput := &ftp.RemoveInput{
@@ -62,7 +68,7 @@ func Test_PerformRemove_Success(t *testing.T) {
 	useCaseMock := useCaseMocks.NewRemoveUseCase(t)
 	useCaseMock.
 		On("Execute", ctx, useCaseRepos, useCaseInput).
-		Return(nil).
+		Return(&ftp.RemoveOutput{}, nil).
 		Once()
 
 	buffer := bytes.NewBufferString("")
@@ -80,7 +86,7 @@ func Test_PerformRemove_Success(t *testing.T) {
 			Verbose:  true,
 			Timeout:  timeout,
 		},
-		Path: path,
+		Paths: []string{path},
 	}
 
 	// act
@@ -90,6 +96,71 @@ func Test_PerformRemove_Success(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func Test_PerformRemove_Progress(t *testing.T) {
+	// arrange
+	ctx := context.Background()
+
+	logger := assertlogging.NewLogger(t)
+	logger.ExpectInfo("OK!")
+
+	ftpConnMock := connectionMocks.NewConnection(t)
+	ftpConnMock.On("Stop").Return(nil).Once()
+
+	config := ftpclient.ConnectorConfig{
+		Address:  address,
+		User:     user,
+		Password: password,
+		Verbose:  true,
+		Timeout:  timeout,
+	}
+	connMock := ftpclientMocks.NewConnector(t)
+	connMock.
+		On("Connect", ctx, config).
+		Return(ftpConnMock, nil).
+		Once()
+
+	useCaseInput := &ftp.RemoveInput{
+		Path: path,
+	}
+
+	useCaseMock := useCaseMocks.NewRemoveUseCase(t)
+	useCaseMock.
+		On("Execute", ctx, mock.MatchedBy(func(repos *ftp.RemoveRepos) bool {
+			return repos.Observer != nil
+		}), useCaseInput).
+		Run(func(args mock.Arguments) {
+			observer := args.Get(1).(*ftp.RemoveRepos).Observer
+			observer.DirListed(path)
+			observer.EntryRemoved(path + "/file-1")
+			observer.EntryRemoved(path)
+		}).
+		Return(&ftp.RemoveOutput{}, nil).
+		Once()
+
+	buffer := bytes.NewBufferString("")
+	progressBuffer := bytes.NewBufferString("")
+
+	deps := &remove.Dependencies{
+		Connector:      connMock,
+		UseCase:        useCaseMock,
+		OutWriter:      buffer,
+		ProgressWriter: progressBuffer,
+	}
+	input := &remove.CmdRemoveInput{
+		Config: config,
+		Paths:  []string{path},
+	}
+
+	// act
+	err := remove.PerformRemove(ctx, logger, deps, input)
+
+	// assert
+	require.NoError(t, err)
+	assert.Empty(t, buffer.String())
+	assert.Contains(t, progressBuffer.String(), "Listed directories: 1")
+	assert.Contains(t, progressBuffer.String(), "Removed entries: 2")
+}
+
 func Test_PerformRemove_ConnectError(t *testing.T) {
 	// arrange
 	ctx := context.Background()
@@ -129,7 +200,7 @@ func Test_PerformRemove_ConnectError(t *testing.T) {
 			Verbose:  true,
 			Timeout:  timeout,
 		},
-		Path: path,
+		Paths: []string{path},
 	}
 
 	// act
@@ -169,6 +240,7 @@ func Test_PerformRemove_ConnectStopError(t *testing.T) {
 	useCaseRepos := &ftp.RemoveRepos{
 		Logger:     logger,
 		Connection: ftpConnMock,
+		Observer:   ftp.TransferObservers{&ftpclient.TransferLogger{Logger: logger}},
 	}
 	useCaseInput := &ftp.RemoveInput{
 		Path: path,
@@ -177,7 +249,7 @@ func Test_PerformRemove_ConnectStopError(t *testing.T) {
 	useCaseMock := useCaseMocks.NewRemoveUseCase(t)
 	useCaseMock.
 		On("Execute", ctx, useCaseRepos, useCaseInput).
-		Return(nil).
+		Return(&ftp.RemoveOutput{}, nil).
 		Once()
 
 	buffer := bytes.NewBufferString("")
@@ -195,7 +267,7 @@ func Test_PerformRemove_ConnectStopError(t *testing.T) {
 			Verbose:  true,
 			Timeout:  timeout,
 		},
-		Path: path,
+		Paths: []string{path},
 	}
 
 	// act
@@ -231,6 +303,7 @@ func Test_PerformRemove_UseCaseError(t *testing.T) {
 	useCaseRepos := &ftp.RemoveRepos{
 		Logger:     logger,
 		Connection: ftpConnMock,
+		Observer:   ftp.TransferObservers{&ftpclient.TransferLogger{Logger: logger}},
 	}
 	useCaseInput := &ftp.RemoveInput{
 		Path: path,
@@ -239,7 +312,7 @@ func Test_PerformRemove_UseCaseError(t *testing.T) {
 	useCaseMock := useCaseMocks.NewRemoveUseCase(t)
 	useCaseMock.
 		On("Execute", ctx, useCaseRepos, useCaseInput).
-		Return(errors.New("mock error")).
+		Return(nil, errors.New("mock error")).
 		Once()
 
 	buffer := bytes.NewBufferString("")
@@ -257,7 +330,7 @@ func Test_PerformRemove_UseCaseError(t *testing.T) {
 			Verbose:  true,
 			Timeout:  timeout,
 		},
-		Path: path,
+		Paths: []string{path},
 	}
 
 	// act
@@ -267,3 +340,155 @@ func Test_PerformRemove_UseCaseError(t *testing.T) {
 	require.EqualError(t, err, "mock error")
 	assert.NoError(t, errors.Unwrap(err))
 }
+
+//nolint:funlen // test case can get a bit large
+func Test_PerformRemove_ContinueOnError(t *testing.T) {
+	// arrange
+	ctx := context.Background()
+
+	logger := assertlogging.NewLogger(t)
+
+	ftpConnMock := connectionMocks.NewConnection(t)
+	ftpConnMock.On("Stop").Return(nil).Once()
+
+	config := ftpclient.ConnectorConfig{
+		Address:  address,
+		User:     user,
+		Password: password,
+		Verbose:  true,
+		Timeout:  timeout,
+	}
+	connMock := ftpclientMocks.NewConnector(t)
+	connMock.
+		On("Connect", ctx, config).
+		Return(ftpConnMock, nil).
+		Once()
+
+	useCaseRepos := &ftp.RemoveRepos{
+		Logger:     logger,
+		Connection: ftpConnMock,
+		Observer:   ftp.TransferObservers{&ftpclient.TransferLogger{Logger: logger}},
+	}
+
+	useCaseMock := useCaseMocks.NewRemoveUseCase(t)
+	useCaseMock.
+		On("Execute", ctx, useCaseRepos, &ftp.RemoveInput{Path: "/foo", ContinueOnError: true}).
+		Return(nil, ftperrors.NewInternalError("failed to check if entry is a directory", nil)).
+		Once()
+	useCaseMock.
+		On("Execute", ctx, useCaseRepos, &ftp.RemoveInput{Path: path, ContinueOnError: true}).
+		Return(&ftp.RemoveOutput{
+			Results: []*entities.TransferResult{
+				entities.NewTransferOK(path),
+			},
+		}, nil).
+		Once()
+
+	fileStoreMock := repositoryMocks.NewFileStore(t)
+	fileStoreMock.
+		On("SaveFile", "/tmp/report.json", mock.Anything).
+		Return(nil).
+		Once()
+
+	buffer := bytes.NewBufferString("")
+
+	deps := &remove.Dependencies{
+		Connector: connMock,
+		UseCase:   useCaseMock,
+		FileStore: fileStoreMock,
+		OutWriter: buffer,
+	}
+	input := &remove.CmdRemoveInput{
+		Config:          config,
+		Paths:           []string{"/foo", path},
+		ContinueOnError: true,
+		ReportPath:      "/tmp/report.json",
+	}
+
+	// act
+	err := remove.PerformRemove(ctx, logger, deps, input)
+
+	// assert
+	require.EqualError(t, err, "an internal error occurred: 1 item(s) failed")
+	assert.IsType(t, ftperrors.InternalErrorType, err)
+	assert.Equal(
+		t,
+		"+--------+------+---------------------------------------------------------------------+\n"+
+			"| STATUS | PATH |                               REASON                                |\n"+
+			"+--------+------+---------------------------------------------------------------------+\n"+
+			"| failed | /foo | an internal error occurred: failed to check if entry is a directory |\n"+
+			"+--------+------+---------------------------------------------------------------------+\n"+
+			"1 ok, 0 skipped, 1 failed\n",
+		buffer.String(),
+	)
+}
+
+func Test_PerformRemove_Output_ContinueOnError(t *testing.T) {
+	// arrange
+	ctx := context.Background()
+
+	logger := assertlogging.NewLogger(t)
+
+	ftpConnMock := connectionMocks.NewConnection(t)
+	ftpConnMock.On("Stop").Return(nil).Once()
+
+	config := ftpclient.ConnectorConfig{
+		Address:  address,
+		User:     user,
+		Password: password,
+		Verbose:  true,
+		Timeout:  timeout,
+	}
+	connMock := ftpclientMocks.NewConnector(t)
+	connMock.
+		On("Connect", ctx, config).
+		Return(ftpConnMock, nil).
+		Once()
+
+	useCaseRepos := &ftp.RemoveRepos{
+		Logger:     logger,
+		Connection: ftpConnMock,
+		Observer:   ftp.TransferObservers{&ftpclient.TransferLogger{Logger: logger}},
+	}
+
+	useCaseMock := useCaseMocks.NewRemoveUseCase(t)
+	useCaseMock.
+		On("Execute", ctx, useCaseRepos, &ftp.RemoveInput{Path: "/foo", ContinueOnError: true}).
+		Return(nil, ftperrors.NewInternalError("failed to check if entry is a directory", nil)).
+		Once()
+	useCaseMock.
+		On("Execute", ctx, useCaseRepos, &ftp.RemoveInput{Path: path, ContinueOnError: true}).
+		Return(&ftp.RemoveOutput{
+			Results: []*entities.TransferResult{
+				entities.NewTransferOK(path),
+			},
+		}, nil).
+		Once()
+
+	buffer := bytes.NewBufferString("")
+
+	deps := &remove.Dependencies{
+		Connector: connMock,
+		UseCase:   useCaseMock,
+		OutWriter: buffer,
+	}
+	input := &remove.CmdRemoveInput{
+		Config:          config,
+		Paths:           []string{"/foo", path},
+		ContinueOnError: true,
+		Output:          models.OutputFormatJSONL,
+	}
+
+	// act
+	err := remove.PerformRemove(ctx, logger, deps, input)
+
+	// assert
+	require.EqualError(t, err, "an internal error occurred: 1 item(s) failed")
+	assert.IsType(t, ftperrors.InternalErrorType, err)
+	assert.Equal(
+		t,
+		`{"path":"/foo","status":"failed","reason":"an internal error occurred: failed to check if entry is a directory"}`+"\n"+
+			`{"path":"`+path+`","status":"ok"}`+"\n",
+		buffer.String(),
+	)
+}
@@ -0,0 +1,102 @@
+package ftpclient
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/hashicorp/go-multierror"
+	"github.com/olekukonko/tablewriter"
+
+	"github.com/alexZaicev/go-ftp-client/internal/domain/entities"
+	"github.com/alexZaicev/go-ftp-client/internal/domain/errors"
+	"github.com/alexZaicev/go-ftp-client/internal/domain/repositories"
+	"github.com/alexZaicev/go-ftp-client/internal/drivers/logging"
+)
+
+// transferReport is the JSON report of a transfer, listing the outcome of every entry.
+type transferReport struct {
+	OK      int                        `json:"ok" yaml:"ok"`
+	Skipped int                        `json:"skipped" yaml:"skipped"`
+	Failed  int                        `json:"failed" yaml:"failed"`
+	Results []*entities.TransferResult `json:"results" yaml:"results"`
+}
+
+func newTransferReport(results []*entities.TransferResult) *transferReport {
+	report := &transferReport{Results: results}
+	if report.Results == nil {
+		report.Results = []*entities.TransferResult{}
+	}
+	for _, result := range results {
+		switch result.Status {
+		case entities.TransferStatusOK:
+			report.OK++
+		case entities.TransferStatusSkipped:
+			report.Skipped++
+		case entities.TransferStatusFailed:
+			report.Failed++
+		}
+	}
+	return report
+}
+
+// WriteTransferSummary function writes a table of entries that were skipped or failed along with the reason,
+// followed by the number of entries of each status.
+func WriteTransferSummary(writer io.Writer, results []*entities.TransferResult) error {
+	report := newTransferReport(results)
+
+	if report.Skipped+report.Failed > 0 {
+		table := tablewriter.NewWriter(writer)
+		table.SetHeader([]string{"status", "path", "reason"})
+		// wrapped paths are hard to copy from the summary
+		table.SetAutoWrapText(false)
+		for _, result := range results {
+			if result.Status == entities.TransferStatusOK {
+				continue
+			}
+			table.Append([]string{string(result.Status), result.Path, result.Reason})
+		}
+		table.Render()
+	}
+
+	if _, err := fmt.Fprintf(
+		writer, "%d ok, %d skipped, %d failed\n", report.OK, report.Skipped, report.Failed,
+	); err != nil {
+		return errors.NewInternalError("failed to write transfer summary", err)
+	}
+	return nil
+}
+
+// SaveTransferReport function saves the outcome of every entry as a JSON report under the path.
+func SaveTransferReport(
+	logger logging.Logger,
+	fileStore repositories.FileStore,
+	path string,
+	results []*entities.TransferResult,
+) error {
+	data, err := json.MarshalIndent(newTransferReport(results), "", "  ")
+	if err != nil {
+		return errors.NewInternalError("failed to encode transfer report", err)
+	}
+
+	if err = fileStore.SaveFile(path, data); err != nil {
+		logger.WithError(err).WithField("path", path).Error("failed to save transfer report")
+		return errors.NewInternalError("failed to save transfer report", nil)
+	}
+	return nil
+}
+
+// TransferError function returns an error combining errors of the failed entries, or nil if no entry failed.
+func TransferError(results []*entities.TransferResult) error {
+	var multiErr *multierror.Error
+	for _, result := range results {
+		if result.Status == entities.TransferStatusFailed {
+			multiErr = multierror.Append(multiErr, fmt.Errorf("%s: %w", result.Path, result.Err))
+		}
+	}
+
+	if err := multiErr.ErrorOrNil(); err != nil {
+		return errors.NewInternalError(fmt.Sprintf("%d item(s) failed", multiErr.Len()), err)
+	}
+	return nil
+}
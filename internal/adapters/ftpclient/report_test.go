@@ -0,0 +1,137 @@
+package ftpclient_test
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/hashicorp/go-multierror"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/alexZaicev/go-ftp-client/internal/adapters/ftpclient"
+	"github.com/alexZaicev/go-ftp-client/internal/domain/entities"
+	ftperrors "github.com/alexZaicev/go-ftp-client/internal/domain/errors"
+	"github.com/alexZaicev/go-ftp-client/internal/drivers/logging/assertlogging"
+	repositoryMocks "github.com/alexZaicev/go-ftp-client/mocks/domain/repositories"
+)
+
+func getTransferResults() []*entities.TransferResult {
+	return []*entities.TransferResult{
+		entities.NewTransferOK("/pub/file-1"),
+		entities.NewTransferSkipped(&entities.SkippedTransfer{Path: "/pub/file-2", Reason: "already exists"}),
+		entities.NewTransferFailed(
+			"/pub/file-3",
+			ftperrors.NewInternalError("failed to download file", errors.New("mock error")),
+		),
+	}
+}
+
+func Test_WriteTransferSummary_Success(t *testing.T) {
+	testCases := []struct {
+		name     string
+		results  []*entities.TransferResult
+		expected string
+	}{
+		{
+			name:     "nothing processed",
+			expected: "0 ok, 0 skipped, 0 failed\n",
+		},
+		{
+			name:     "all ok",
+			results:  getTransferResults()[:1],
+			expected: "1 ok, 0 skipped, 0 failed\n",
+		},
+		{
+			name:    "skipped and failed",
+			results: getTransferResults(),
+			expected: "+---------+-------------+-----------------------------------------------------------------+\n" +
+				"| STATUS  |    PATH     |                             REASON                              |\n" +
+				"+---------+-------------+-----------------------------------------------------------------+\n" +
+				"| skipped | /pub/file-2 | already exists                                                  |\n" +
+				"| failed  | /pub/file-3 | an internal error occurred: failed to download file: mock error |\n" +
+				"+---------+-------------+-----------------------------------------------------------------+\n" +
+				"1 ok, 1 skipped, 1 failed\n",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			buffer := bytes.NewBufferString("")
+			require.NoError(t, ftpclient.WriteTransferSummary(buffer, tc.results))
+			assert.Equal(t, tc.expected, buffer.String())
+		})
+	}
+}
+
+func Test_SaveTransferReport_Success(t *testing.T) {
+	logger := assertlogging.NewLogger(t)
+
+	expected := `{
+  "ok": 1,
+  "skipped": 1,
+  "failed": 1,
+  "results": [
+    {
+      "path": "/pub/file-1",
+      "status": "ok"
+    },
+    {
+      "path": "/pub/file-2",
+      "status": "skipped",
+      "reason": "already exists"
+    },
+    {
+      "path": "/pub/file-3",
+      "status": "failed",
+      "reason": "an internal error occurred: failed to download file: mock error"
+    }
+  ]
+}`
+
+	fileStoreMock := repositoryMocks.NewFileStore(t)
+	fileStoreMock.
+		On("SaveFile", "/tmp/report.json", []byte(expected)).
+		Return(nil).
+		Once()
+
+	err := ftpclient.SaveTransferReport(logger, fileStoreMock, "/tmp/report.json", getTransferResults())
+	assert.NoError(t, err)
+}
+
+func Test_SaveTransferReport_Error(t *testing.T) {
+	logger := assertlogging.NewLogger(t)
+	logger.
+		ExpectError("failed to save transfer report").
+		WithError(assertlogging.EqualError("mock error")).
+		WithField("path", assertlogging.Equal("/tmp/report.json"))
+
+	fileStoreMock := repositoryMocks.NewFileStore(t)
+	fileStoreMock.
+		On("SaveFile", "/tmp/report.json", []byte(`{
+  "ok": 0,
+  "skipped": 0,
+  "failed": 0,
+  "results": []
+}`)).
+		Return(errors.New("mock error")).
+		Once()
+
+	err := ftpclient.SaveTransferReport(logger, fileStoreMock, "/tmp/report.json", nil)
+	require.EqualError(t, err, "an internal error occurred: failed to save transfer report")
+	assert.IsType(t, ftperrors.InternalErrorType, err)
+	assert.NoError(t, errors.Unwrap(err))
+}
+
+func Test_TransferError(t *testing.T) {
+	assert.NoError(t, ftpclient.TransferError(getTransferResults()[:2]))
+
+	err := ftpclient.TransferError(getTransferResults())
+	require.EqualError(t, err, "an internal error occurred: 1 item(s) failed")
+	assert.IsType(t, ftperrors.InternalErrorType, err)
+
+	var multiErr *multierror.Error
+	require.ErrorAs(t, errors.Unwrap(err), &multiErr)
+	require.Len(t, multiErr.Errors, 1)
+	assert.EqualError(t, multiErr.Errors[0], "/pub/file-3: an internal error occurred: failed to download file")
+}
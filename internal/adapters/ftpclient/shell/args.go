@@ -0,0 +1,67 @@
+package shell
+
+import (
+	"strings"
+	"unicode"
+
+	ftperrors "github.com/alexZaicev/go-ftp-client/internal/domain/errors"
+)
+
+// splitArgs function splits the command line into arguments separated by whitespace. Like in POSIX
+// shells, single quotes keep their content as is, double quotes keep whitespace but allow escaping
+// with a backslash, and a backslash outside quotes escapes the following character.
+func splitArgs(line string) ([]string, error) {
+	var (
+		args    []string
+		sb      strings.Builder
+		inArg   bool
+		quote   rune
+		escaped bool
+	)
+
+	for _, r := range line {
+		switch {
+		case escaped:
+			sb.WriteRune(r)
+			escaped = false
+		case quote == '\'':
+			if r == quote {
+				quote = 0
+			} else {
+				sb.WriteRune(r)
+			}
+		case quote == '"':
+			switch r {
+			case quote:
+				quote = 0
+			case '\\':
+				escaped = true
+			default:
+				sb.WriteRune(r)
+			}
+		case r == '\'' || r == '"':
+			quote = r
+			inArg = true
+		case r == '\\':
+			escaped = true
+			inArg = true
+		case unicode.IsSpace(r):
+			if inArg {
+				args = append(args, sb.String())
+				sb.Reset()
+				inArg = false
+			}
+		default:
+			sb.WriteRune(r)
+			inArg = true
+		}
+	}
+
+	if quote != 0 || escaped {
+		return nil, ftperrors.NewInvalidArgumentError("command", "contains unterminated quote or escape")
+	}
+	if inArg {
+		args = append(args, sb.String())
+	}
+	return args, nil
+}
@@ -0,0 +1,105 @@
+package shell
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/alexZaicev/go-ftp-client/internal/adapters/ftpclient"
+	"github.com/alexZaicev/go-ftp-client/internal/domain/connection"
+	ftperrors "github.com/alexZaicev/go-ftp-client/internal/domain/errors"
+	"github.com/alexZaicev/go-ftp-client/internal/drivers/logging"
+)
+
+// ignoreErrorPrefix marks script lines whose failure does not abort the batch.
+const ignoreErrorPrefix = "-"
+
+type CmdBatchInput struct {
+	Config ftpclient.ConnectorConfig
+	// LocalDir is the initial local working directory, which local paths are relative to.
+	LocalDir string
+}
+
+// batchResult counts outcomes of executed script lines.
+type batchResult struct {
+	succeeded int
+	failed    int
+	ignored   int
+}
+
+// PerformBatch function executes script lines read from the line reader over a single server
+// connection, reporting the result of each line. The batch is aborted on the first failure, unless the
+// line is prefixed with -, in which case its failure is reported and ignored.
+func PerformBatch(ctx context.Context, logger logging.Logger, deps *Dependencies, input *CmdBatchInput) (err error) {
+	conn, err := deps.Connector.Connect(ctx, input.Config)
+	if err != nil {
+		logger.WithError(err).Error("failed to connect to server")
+		return err
+	}
+	defer func(conn connection.Connection) {
+		if stopErr := conn.Stop(); stopErr != nil {
+			logger.WithError(stopErr).Error("failed to stop server connection")
+			err = stopErr
+		}
+	}(conn)
+
+	remoteDir, err := conn.Pwd()
+	if err != nil {
+		logger.WithError(err).Error("failed to get working directory")
+		return ftperrors.NewInternalError("failed to get working directory", nil)
+	}
+
+	session := NewSession(logger, conn, deps, remoteDir, input.LocalDir)
+	result := &batchResult{}
+
+	var batchErr error
+	for lineNumber := 1; batchErr == nil && !session.Closed(); lineNumber++ {
+		line, readErr := deps.LineReader.ReadLine("")
+		if errors.Is(readErr, io.EOF) {
+			break
+		}
+		if readErr != nil {
+			return ftperrors.NewInternalError("failed to read script", readErr)
+		}
+
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		ignoreErr := strings.HasPrefix(line, ignoreErrorPrefix)
+		execErr := session.Execute(ctx, strings.TrimPrefix(line, ignoreErrorPrefix))
+
+		var report string
+		switch {
+		case execErr == nil:
+			result.succeeded++
+			report = fmt.Sprintf("%d: %s: ok\n", lineNumber, line)
+		case ignoreErr:
+			result.ignored++
+			report = fmt.Sprintf("%d: %s: failed (ignored): %s\n", lineNumber, line, execErr)
+		default:
+			result.failed++
+			report = fmt.Sprintf("%d: %s: failed: %s\n", lineNumber, line, execErr)
+			batchErr = ftperrors.NewInternalError(fmt.Sprintf("batch aborted at line %d", lineNumber), execErr)
+		}
+		if _, writeErr := fmt.Fprint(deps.OutWriter, report); writeErr != nil {
+			return writeErr
+		}
+	}
+
+	if _, writeErr := fmt.Fprintf(
+		deps.OutWriter,
+		"Executed %d command(s): %d succeeded, %d failed, %d failure(s) ignored\n",
+		result.succeeded+result.failed+result.ignored,
+		result.succeeded,
+		result.failed,
+		result.ignored,
+	); writeErr != nil {
+		return writeErr
+	}
+
+	return batchErr
+}
@@ -0,0 +1,136 @@
+package shell_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/alexZaicev/go-ftp-client/internal/adapters/ftpclient/shell"
+	ftperrors "github.com/alexZaicev/go-ftp-client/internal/domain/errors"
+	"github.com/alexZaicev/go-ftp-client/internal/drivers/logging/assertlogging"
+	"github.com/alexZaicev/go-ftp-client/internal/usecases/ftp"
+	ftpclientMocks "github.com/alexZaicev/go-ftp-client/mocks/adapters/ftpclient"
+	shellMocks "github.com/alexZaicev/go-ftp-client/mocks/adapters/ftpclient/shell"
+	connectionMocks "github.com/alexZaicev/go-ftp-client/mocks/domain/connection"
+	useCaseMocks "github.com/alexZaicev/go-ftp-client/mocks/usecases/ftp"
+)
+
+//nolint:funlen // test case can get a bit large
+func Test_PerformBatch(t *testing.T) {
+	testCases := []struct {
+		name           string
+		lines          []string
+		moveErr        error
+		expectedOutput string
+		expectedErrMsg string
+	}{
+		{
+			name:  "success",
+			lines: []string{"# deploy", "mkdir /releases/1.2", "", "-rm /releases/latest", "mv /releases/1.2 /releases/latest"},
+			expectedOutput: "2: mkdir /releases/1.2: ok\n" +
+				"4: -rm /releases/latest: failed (ignored): not found error occurred: mock error\n" +
+				"5: mv /releases/1.2 /releases/latest: ok\n" +
+				"Executed 3 command(s): 2 succeeded, 0 failed, 1 failure(s) ignored\n",
+		},
+		{
+			name:    "aborted",
+			lines:   []string{"mkdir /releases/1.2", "-rm /releases/latest", "mv /releases/1.2 /releases/latest", "ls"},
+			moveErr: ftperrors.NewInternalError("failed to move file", nil),
+			expectedOutput: "1: mkdir /releases/1.2: ok\n" +
+				"2: -rm /releases/latest: failed (ignored): not found error occurred: mock error\n" +
+				"3: mv /releases/1.2 /releases/latest: failed: an internal error occurred: failed to move file\n" +
+				"Executed 3 command(s): 1 succeeded, 1 failed, 1 failure(s) ignored\n",
+			expectedErrMsg: "an internal error occurred: batch aborted at line 3",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			logger := assertlogging.NewLogger(t)
+
+			ftpConnMock := connectionMocks.NewConnection(t)
+			ftpConnMock.On("Stop").Return(nil).Once()
+			ftpConnMock.On("Pwd").Return("/", nil).Once()
+
+			connMock := ftpclientMocks.NewConnector(t)
+			connMock.
+				On("Connect", ctx, config).
+				Return(ftpConnMock, nil).
+				Once()
+
+			mkdirUseCaseMock := useCaseMocks.NewMkdirUseCase(t)
+			mkdirUseCaseMock.
+				On(
+					"Execute",
+					ctx,
+					&ftp.MkdirRepos{Logger: logger, Connection: ftpConnMock},
+					&ftp.MkdirInput{Path: "/releases/1.2"},
+				).
+				Return(nil).
+				Once()
+
+			removeUseCaseMock := useCaseMocks.NewRemoveUseCase(t)
+			removeUseCaseMock.
+				On(
+					"Execute",
+					ctx,
+					&ftp.RemoveRepos{Logger: logger, Connection: ftpConnMock},
+					&ftp.RemoveInput{Path: "/releases/latest"},
+				).
+				Return(nil, ftperrors.NewNotFoundError("mock error", nil)).
+				Once()
+
+			moveUseCaseMock := useCaseMocks.NewMoveUseCase(t)
+			moveUseCaseMock.
+				On(
+					"Execute",
+					ctx,
+					&ftp.MoveRepos{Logger: logger, Connection: ftpConnMock},
+					&ftp.MoveInput{OldPath: "/releases/1.2", NewPath: "/releases/latest"},
+				).
+				Return(tc.moveErr).
+				Once()
+
+			// lines after the failed one are not read, and neither is the end of input
+			linesToRead := tc.lines
+			prompts := make([]string, len(tc.lines)+1)
+			if tc.expectedErrMsg != "" {
+				linesToRead = tc.lines[:len(tc.lines)-1]
+				prompts = prompts[:len(linesToRead)]
+			}
+			lineReaderMock := shellMocks.NewLineReader(t)
+			setLines(lineReaderMock, prompts, linesToRead)
+
+			buffer := bytes.NewBufferString("")
+
+			deps := &shell.Dependencies{
+				Connector:     connMock,
+				MkdirUseCase:  mkdirUseCaseMock,
+				RemoveUseCase: removeUseCaseMock,
+				MoveUseCase:   moveUseCaseMock,
+				LineReader:    lineReaderMock,
+				OutWriter:     buffer,
+			}
+			input := &shell.CmdBatchInput{
+				Config:   config,
+				LocalDir: localDir,
+			}
+
+			err := shell.PerformBatch(ctx, logger, deps, input)
+			assert.Equal(t, tc.expectedOutput, buffer.String())
+			if tc.expectedErrMsg == "" {
+				assert.NoError(t, err)
+				return
+			}
+			require.EqualError(t, err, tc.expectedErrMsg)
+			assert.IsType(t, ftperrors.InternalErrorType, err)
+			assert.EqualError(t, errors.Unwrap(err), "an internal error occurred: failed to move file")
+		})
+	}
+}
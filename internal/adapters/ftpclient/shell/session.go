@@ -0,0 +1,402 @@
+package shell
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/spf13/pflag"
+
+	"github.com/alexZaicev/go-ftp-client/internal/adapters/ftpclient"
+	"github.com/alexZaicev/go-ftp-client/internal/adapters/ftpclient/list"
+	"github.com/alexZaicev/go-ftp-client/internal/domain/connection"
+	"github.com/alexZaicev/go-ftp-client/internal/domain/entities"
+	ftperrors "github.com/alexZaicev/go-ftp-client/internal/domain/errors"
+	"github.com/alexZaicev/go-ftp-client/internal/drivers/logging"
+	"github.com/alexZaicev/go-ftp-client/internal/usecases/ftp"
+)
+
+// command is a command of the session, accepting between minArgs and maxArgs arguments.
+type command struct {
+	usage   string
+	help    string
+	minArgs int
+	maxArgs int
+	run     func(s *Session, ctx context.Context, args []string) error
+}
+
+func newCommands() map[string]*command {
+	return map[string]*command{
+		"cd":    {usage: "cd [remote-dir]", help: "Change remote working directory, login directory by default", maxArgs: 1, run: (*Session).cd},
+		"pwd":   {usage: "pwd", help: "Print remote working directory", run: (*Session).pwd},
+		"lcd":   {usage: "lcd local-dir", help: "Change local working directory", minArgs: 1, maxArgs: 1, run: (*Session).lcd},
+		"lpwd":  {usage: "lpwd", help: "Print local working directory", run: (*Session).lpwd},
+		"ls":    {usage: "ls [-a] [remote-path]", help: "List remote entries", maxArgs: 2, run: (*Session).ls},
+		"get":   {usage: "get remote-path [local-path]", help: "Download a file or a directory", minArgs: 1, maxArgs: 2, run: (*Session).get},
+		"put":   {usage: "put local-file [remote-path]", help: "Upload a file", minArgs: 1, maxArgs: 2, run: (*Session).put},
+		"mkdir": {usage: "mkdir remote-dir", help: "Create remote directory", minArgs: 1, maxArgs: 1, run: (*Session).mkdir},
+		"rm":    {usage: "rm remote-path", help: "Remove remote file or directory", minArgs: 1, maxArgs: 1, run: (*Session).rm},
+		"mv":    {usage: "mv old-path new-path", help: "Move or rename remote entry", minArgs: 2, maxArgs: 2, run: (*Session).mv},
+		"help":  {usage: "help", help: "Print available commands", run: (*Session).help},
+		"exit":  {usage: "exit", help: "Close the session", run: (*Session).exit},
+	}
+}
+
+// commandAliases are alternative names of the commands.
+var commandAliases = map[string]string{
+	"quit": "exit",
+	"bye":  "exit",
+}
+
+// Session executes commands over a single server connection, resolving relative paths against its
+// remote and local working directories.
+type Session struct {
+	logger    logging.Logger
+	conn      connection.Connection
+	deps      *Dependencies
+	commands  map[string]*command
+	homeDir   string
+	remoteDir string
+	localDir  string
+	closed    bool
+}
+
+// NewSession function returns a session starting in the remote directory, which is expected to be
+// the login directory of the connection.
+func NewSession(
+	logger logging.Logger,
+	conn connection.Connection,
+	deps *Dependencies,
+	remoteDir, localDir string,
+) *Session {
+	return &Session{
+		logger:    logger,
+		conn:      conn,
+		deps:      deps,
+		commands:  newCommands(),
+		homeDir:   remoteDir,
+		remoteDir: remoteDir,
+		localDir:  localDir,
+	}
+}
+
+// Prompt function returns the prompt displaying remote working directory.
+func (s *Session) Prompt() string {
+	return fmt.Sprintf("gfc:%s> ", s.remoteDir)
+}
+
+// Closed function reports whether the session was exited.
+func (s *Session) Closed() bool {
+	return s.closed
+}
+
+// Execute function runs the command line, which consists of a command name followed by its arguments.
+// Blank lines and lines starting with # are ignored.
+func (s *Session) Execute(ctx context.Context, line string) error {
+	args, err := splitArgs(line)
+	if err != nil {
+		return err
+	}
+	if len(args) == 0 || strings.HasPrefix(args[0], "#") {
+		return nil
+	}
+
+	name := args[0]
+	if alias, ok := commandAliases[name]; ok {
+		name = alias
+	}
+	cmd, ok := s.commands[name]
+	if !ok {
+		return ftperrors.NewInvalidArgumentError(
+			"command",
+			fmt.Sprintf("%s is not supported, type help to list available commands", args[0]),
+		)
+	}
+
+	args = args[1:]
+	if len(args) < cmd.minArgs || len(args) > cmd.maxArgs {
+		return ftperrors.NewInvalidArgumentError("args", fmt.Sprintf("usage: %s", cmd.usage))
+	}
+	return cmd.run(s, ctx, args)
+}
+
+func (s *Session) cd(_ context.Context, args []string) error {
+	dirPath := s.homeDir
+	if len(args) == 1 {
+		dirPath = s.remotePath(args[0])
+	}
+
+	if err := s.conn.Cd(dirPath); err != nil {
+		var notFoundErr *ftperrors.NotFoundError
+		if errors.As(err, &notFoundErr) {
+			return err
+		}
+
+		s.logger.WithError(err).Error("failed to change directory")
+		return ftperrors.NewInternalError("failed to change directory", nil)
+	}
+
+	s.remoteDir = dirPath
+	return nil
+}
+
+func (s *Session) pwd(_ context.Context, _ []string) error {
+	_, err := fmt.Fprintln(s.deps.OutWriter, s.remoteDir)
+	return err
+}
+
+func (s *Session) lcd(_ context.Context, args []string) error {
+	dirPath := s.localPath(args[0])
+
+	entry, err := s.deps.FileStore.Stat(dirPath)
+	if err != nil {
+		return err
+	}
+	if entry.Type != entities.EntryTypeDir {
+		return ftperrors.NewInvalidArgumentError("args", fmt.Sprintf("%s is not a directory", dirPath))
+	}
+
+	s.localDir = dirPath
+	return nil
+}
+
+func (s *Session) lpwd(_ context.Context, _ []string) error {
+	_, err := fmt.Fprintln(s.deps.OutWriter, s.localDir)
+	return err
+}
+
+func (s *Session) ls(ctx context.Context, args []string) error {
+	flagSet := pflag.NewFlagSet("ls", pflag.ContinueOnError)
+	flagSet.SetOutput(s.deps.OutWriter)
+	showAll := flagSet.BoolP("all", "a", false, "Show hidden entries")
+	if err := flagSet.Parse(args); err != nil {
+		return ftperrors.NewInvalidArgumentError("args", err.Error())
+	}
+	if flagSet.NArg() > 1 {
+		return ftperrors.NewInvalidArgumentError("args", fmt.Sprintf("usage: %s", s.commands["ls"].usage))
+	}
+
+	listPath := entities.EscapeRemoteGlob(s.remoteDir)
+	if flagSet.NArg() == 1 {
+		listPath = s.remotePattern(flagSet.Arg(0))
+	}
+
+	repos := &ftp.ListFilesRepos{
+		Logger:     s.logger,
+		Connection: s.conn,
+	}
+	input := &ftp.ListFilesInput{
+		Path:     listPath,
+		ShowAll:  *showAll,
+		SortType: entities.SortTypeName,
+	}
+
+	entries, err := s.deps.ListUseCase.Execute(ctx, repos, input)
+	if err != nil {
+		var notFoundErr *ftperrors.NotFoundError
+		if errors.As(err, &notFoundErr) {
+			_, writeErr := fmt.Fprintln(s.deps.OutWriter, "no entries found")
+			return writeErr
+		}
+		return err
+	}
+
+	return list.WriteEntries(s.deps.OutWriter, entries, nil)
+}
+
+func (s *Session) get(ctx context.Context, args []string) error {
+	remotePath := s.remotePattern(args[0])
+
+	localPath := s.localDir
+	intoDir := true
+	if len(args) == 2 {
+		localPath = s.localPath(args[1])
+
+		var err error
+		if intoDir, err = s.isLocalDir(localPath); err != nil {
+			return err
+		}
+	}
+	// single entries are saved under their own name inside the directory, while entries matched
+	// by a pattern are saved under the directory by the use case
+	if intoDir && !entities.HasRemoteGlobMeta(remotePath) {
+		localPath = filepath.Join(localPath, entities.RemotePath(entities.UnescapeRemoteGlob(remotePath)).Base())
+	}
+
+	repos := &ftp.DownloadRepos{
+		Logger:     s.logger,
+		Connection: s.conn,
+		FileStore:  s.deps.FileStore,
+	}
+	input := &ftp.DownloadInput{
+		RemotePath: remotePath,
+		Path:       localPath,
+	}
+
+	output, err := s.deps.DownloadUseCase.Execute(ctx, repos, input)
+	if err != nil {
+		return err
+	}
+	return ftpclient.WriteSkippedSummary(s.deps.OutWriter, output.Skipped)
+}
+
+func (s *Session) put(ctx context.Context, args []string) (err error) {
+	localPath := s.localPath(args[0])
+
+	file, err := s.deps.Filesystem.Open(strings.TrimPrefix(filepath.ToSlash(localPath), "/"))
+	if err != nil {
+		return ftperrors.NewInternalError("failed to open file", err)
+	}
+	defer func() {
+		if closeErr := file.Close(); closeErr != nil {
+			s.logger.WithError(closeErr).Warn(fmt.Sprintf("failed to close file %s", localPath))
+		}
+	}()
+
+	info, err := file.Stat()
+	if err != nil {
+		return ftperrors.NewInternalError("failed to get file information", err)
+	}
+	if !info.Mode().IsRegular() {
+		return ftperrors.NewInvalidArgumentError("args", fmt.Sprintf("%s is not a regular file", localPath))
+	}
+
+	remotePath := entities.RemotePath(s.remoteDir).Join(info.Name()).String()
+	if len(args) == 2 {
+		remotePath = s.remotePath(args[1])
+
+		isDir, dirErr := s.isRemoteDir(ctx, remotePath)
+		if dirErr != nil {
+			return dirErr
+		}
+		if isDir {
+			remotePath = entities.RemotePath(remotePath).Join(info.Name()).String()
+		}
+	}
+
+	repos := &ftp.UploadFileRepos{
+		Logger:     s.logger,
+		Connection: s.conn,
+	}
+	input := &ftp.UploadFileInput{
+		FileReader:  file,
+		RemotePath:  remotePath,
+		SizeInBytes: uint64(info.Size()),
+		ModTime:     info.ModTime(),
+	}
+
+	_, err = s.deps.UploadUseCase.Execute(ctx, repos, input)
+	return err
+}
+
+func (s *Session) mkdir(ctx context.Context, args []string) error {
+	repos := &ftp.MkdirRepos{
+		Logger:     s.logger,
+		Connection: s.conn,
+	}
+	return s.deps.MkdirUseCase.Execute(ctx, repos, &ftp.MkdirInput{Path: s.remotePath(args[0])})
+}
+
+func (s *Session) rm(ctx context.Context, args []string) error {
+	repos := &ftp.RemoveRepos{
+		Logger:     s.logger,
+		Connection: s.conn,
+	}
+	_, err := s.deps.RemoveUseCase.Execute(ctx, repos, &ftp.RemoveInput{Path: s.remotePattern(args[0])})
+	return err
+}
+
+func (s *Session) mv(ctx context.Context, args []string) error {
+	repos := &ftp.MoveRepos{
+		Logger:     s.logger,
+		Connection: s.conn,
+	}
+	input := &ftp.MoveInput{
+		OldPath: s.remotePath(args[0]),
+		NewPath: s.remotePath(args[1]),
+	}
+	return s.deps.MoveUseCase.Execute(ctx, repos, input)
+}
+
+func (s *Session) help(_ context.Context, _ []string) error {
+	names := make([]string, 0, len(s.commands))
+	for name := range s.commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var sb strings.Builder
+	for _, name := range names {
+		sb.WriteString(fmt.Sprintf("  %-30s %s\n", s.commands[name].usage, s.commands[name].help))
+	}
+	_, err := fmt.Fprint(s.deps.OutWriter, sb.String())
+	return err
+}
+
+func (s *Session) exit(_ context.Context, _ []string) error {
+	s.closed = true
+	return nil
+}
+
+// remotePath function resolves the path against remote working directory, or the login directory if
+// it starts with "~".
+func (s *Session) remotePath(p string) string {
+	return entities.RemotePath(p).
+		Resolve(entities.RemotePath(s.remoteDir), entities.RemotePath(s.homeDir)).
+		Clean().
+		String()
+}
+
+// remotePattern function resolves the glob pattern like remotePath, escaping glob meta characters of
+// the working directories, so that they are matched literally.
+func (s *Session) remotePattern(p string) string {
+	return entities.RemotePath(p).
+		Resolve(
+			entities.RemotePath(entities.EscapeRemoteGlob(s.remoteDir)),
+			entities.RemotePath(entities.EscapeRemoteGlob(s.homeDir)),
+		).
+		Clean().
+		String()
+}
+
+// localPath function resolves the path against local working directory.
+func (s *Session) localPath(p string) string {
+	if filepath.IsAbs(p) {
+		return filepath.Clean(p)
+	}
+	return filepath.Join(s.localDir, p)
+}
+
+func (s *Session) isRemoteDir(ctx context.Context, remotePath string) (bool, error) {
+	// root directory has no parent it could be listed in
+	if entities.RemotePath(remotePath).IsRoot() {
+		return true, nil
+	}
+
+	entry, err := s.conn.Stat(ctx, remotePath)
+	if err != nil {
+		var notFoundErr *ftperrors.NotFoundError
+		if errors.As(err, &notFoundErr) {
+			return false, nil
+		}
+
+		s.logger.WithError(err).WithField("remote-path", remotePath).Error("failed to check if entry exists")
+		return false, ftperrors.NewInternalError("failed to check if entry exists", nil)
+	}
+	return entry.Type == entities.EntryTypeDir, nil
+}
+
+func (s *Session) isLocalDir(localPath string) (bool, error) {
+	entry, err := s.deps.FileStore.Stat(localPath)
+	if err != nil {
+		var notFoundErr *ftperrors.NotFoundError
+		if errors.As(err, &notFoundErr) {
+			return false, nil
+		}
+		return false, err
+	}
+	return entry.Type == entities.EntryTypeDir, nil
+}
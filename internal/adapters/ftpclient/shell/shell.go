@@ -0,0 +1,84 @@
+package shell
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+
+	"github.com/alexZaicev/go-ftp-client/internal/adapters/ftpclient"
+	"github.com/alexZaicev/go-ftp-client/internal/domain/connection"
+	ftperrors "github.com/alexZaicev/go-ftp-client/internal/domain/errors"
+	"github.com/alexZaicev/go-ftp-client/internal/domain/repositories"
+	"github.com/alexZaicev/go-ftp-client/internal/drivers/logging"
+	"github.com/alexZaicev/go-ftp-client/internal/usecases/ftp"
+)
+
+type CmdShellInput struct {
+	Config ftpclient.ConnectorConfig
+	// LocalDir is the initial local working directory, which local paths are relative to.
+	LocalDir string
+}
+
+// LineReader reads command lines typed by the user or read from a script, returning io.EOF once the
+// input is closed.
+type LineReader interface {
+	ReadLine(prompt string) (string, error)
+}
+
+type Dependencies struct {
+	Connector       ftpclient.Connector
+	ListUseCase     ftp.ListFilesUseCase
+	DownloadUseCase ftp.DownloadUseCase
+	UploadUseCase   ftp.UploadFileUseCase
+	MkdirUseCase    ftp.MkdirUseCase
+	RemoveUseCase   ftp.RemoveUseCase
+	MoveUseCase     ftp.MoveUseCase
+	FileStore       repositories.FileStore
+	Filesystem      fs.FS
+	LineReader      LineReader
+	OutWriter       io.Writer
+}
+
+// PerformShell function keeps a single server connection open and executes commands read from the line
+// reader until the input is closed or the session is exited. Failed commands are reported without
+// ending the session.
+func PerformShell(ctx context.Context, logger logging.Logger, deps *Dependencies, input *CmdShellInput) (err error) {
+	conn, err := deps.Connector.Connect(ctx, input.Config)
+	if err != nil {
+		logger.WithError(err).Error("failed to connect to server")
+		return err
+	}
+	defer func(conn connection.Connection) {
+		if stopErr := conn.Stop(); stopErr != nil {
+			logger.WithError(stopErr).Error("failed to stop server connection")
+			err = stopErr
+		}
+	}(conn)
+
+	remoteDir, err := conn.Pwd()
+	if err != nil {
+		logger.WithError(err).Error("failed to get working directory")
+		return ftperrors.NewInternalError("failed to get working directory", nil)
+	}
+
+	session := NewSession(logger, conn, deps, remoteDir, input.LocalDir)
+	for !session.Closed() {
+		line, readErr := deps.LineReader.ReadLine(session.Prompt())
+		if errors.Is(readErr, io.EOF) {
+			return nil
+		}
+		if readErr != nil {
+			return ftperrors.NewInternalError("failed to read command", readErr)
+		}
+
+		if execErr := session.Execute(ctx, line); execErr != nil {
+			if _, writeErr := fmt.Fprintf(deps.OutWriter, "error: %s\n", execErr); writeErr != nil {
+				return writeErr
+			}
+		}
+	}
+
+	return nil
+}
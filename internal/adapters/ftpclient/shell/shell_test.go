@@ -0,0 +1,381 @@
+package shell_test
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/alexZaicev/go-ftp-client/internal/adapters/ftpclient"
+	"github.com/alexZaicev/go-ftp-client/internal/adapters/ftpclient/shell"
+	"github.com/alexZaicev/go-ftp-client/internal/domain/entities"
+	ftperrors "github.com/alexZaicev/go-ftp-client/internal/domain/errors"
+	"github.com/alexZaicev/go-ftp-client/internal/drivers/logging/assertlogging"
+	"github.com/alexZaicev/go-ftp-client/internal/usecases/ftp"
+	ftpclientMocks "github.com/alexZaicev/go-ftp-client/mocks/adapters/ftpclient"
+	shellMocks "github.com/alexZaicev/go-ftp-client/mocks/adapters/ftpclient/shell"
+	connectionMocks "github.com/alexZaicev/go-ftp-client/mocks/domain/connection"
+	repositoriesMocks "github.com/alexZaicev/go-ftp-client/mocks/domain/repositories"
+	useCaseMocks "github.com/alexZaicev/go-ftp-client/mocks/usecases/ftp"
+)
+
+const (
+	address  = "10.0.0.1:21"
+	user     = "user01"
+	password = "pwd01"
+	timeout  = 5 * time.Second
+
+	homeDir  = "/home/user01"
+	localDir = "/home/user01"
+)
+
+var config = ftpclient.ConnectorConfig{
+	Address:  address,
+	User:     user,
+	Password: password,
+	Timeout:  timeout,
+}
+
+// setLines function expects the lines to be read with the prompts, followed by the end of input if
+// the last line does not exit the session.
+func setLines(lineReader *shellMocks.LineReader, prompts, lines []string) {
+	for i, line := range lines {
+		lineReader.
+			On("ReadLine", prompts[i]).
+			Return(line, nil).
+			Once()
+	}
+	if len(prompts) > len(lines) {
+		lineReader.
+			On("ReadLine", prompts[len(lines)]).
+			Return("", io.EOF).
+			Once()
+	}
+}
+
+//nolint:funlen // test case can get a bit large
+func Test_PerformShell_Success(t *testing.T) {
+	ctx := context.Background()
+
+	logger := assertlogging.NewLogger(t)
+
+	ftpConnMock := connectionMocks.NewConnection(t)
+	ftpConnMock.On("Stop").Return(nil).Once()
+	ftpConnMock.On("Pwd").Return("/", nil).Once()
+	ftpConnMock.On("Cd", "/pub/docs").Return(nil).Once()
+
+	connMock := ftpclientMocks.NewConnector(t)
+	connMock.
+		On("Connect", ctx, config).
+		Return(ftpConnMock, nil).
+		Once()
+
+	mkdirUseCaseMock := useCaseMocks.NewMkdirUseCase(t)
+	mkdirUseCaseMock.
+		On(
+			"Execute",
+			ctx,
+			&ftp.MkdirRepos{Logger: logger, Connection: ftpConnMock},
+			&ftp.MkdirInput{Path: "/pub/docs/new dir"},
+		).
+		Return(nil).
+		Once()
+
+	moveUseCaseMock := useCaseMocks.NewMoveUseCase(t)
+	moveUseCaseMock.
+		On(
+			"Execute",
+			ctx,
+			&ftp.MoveRepos{Logger: logger, Connection: ftpConnMock},
+			&ftp.MoveInput{OldPath: "/pub/docs/a.txt", NewPath: "/pub/b.txt"},
+		).
+		Return(nil).
+		Once()
+
+	removeUseCaseMock := useCaseMocks.NewRemoveUseCase(t)
+	removeUseCaseMock.
+		On(
+			"Execute",
+			ctx,
+			&ftp.RemoveRepos{Logger: logger, Connection: ftpConnMock},
+			&ftp.RemoveInput{Path: "/pub/docs/*.tmp"},
+		).
+		Return(&ftp.RemoveOutput{}, nil).
+		Once()
+
+	fileStoreMock := repositoriesMocks.NewFileStore(t)
+
+	downloadUseCaseMock := useCaseMocks.NewDownloadUseCase(t)
+	downloadUseCaseMock.
+		On(
+			"Execute",
+			ctx,
+			&ftp.DownloadRepos{Logger: logger, Connection: ftpConnMock, FileStore: fileStoreMock},
+			&ftp.DownloadInput{RemotePath: "/pub/docs/report.csv", Path: localDir + "/report.csv"},
+		).
+		Return(&ftp.DownloadOutput{}, nil).
+		Once()
+
+	uploadUseCaseMock := useCaseMocks.NewUploadFileUseCase(t)
+	uploadUseCaseMock.
+		On(
+			"Execute",
+			ctx,
+			&ftp.UploadFileRepos{Logger: logger, Connection: ftpConnMock},
+			mock.MatchedBy(func(input *ftp.UploadFileInput) bool {
+				return input.RemotePath == "/pub/docs/notes.txt" && input.SizeInBytes == 5
+			}),
+		).
+		Return(&ftp.UploadFileOutput{}, nil).
+		Once()
+
+	lineReaderMock := shellMocks.NewLineReader(t)
+	setLines(
+		lineReaderMock,
+		[]string{"gfc:/> ", "gfc:/> ", "gfc:/pub/docs> ", "gfc:/pub/docs> ", "gfc:/pub/docs> ",
+			"gfc:/pub/docs> ", "gfc:/pub/docs> ", "gfc:/pub/docs> ", "gfc:/pub/docs> ", "gfc:/pub/docs> "},
+		[]string{"", "cd pub/docs", "pwd", `mkdir "new dir"`, "mv a.txt ../b.txt", "rm '*.tmp'",
+			"get report.csv", "put notes.txt", "# comment", "exit"},
+	)
+
+	buffer := bytes.NewBufferString("")
+
+	deps := &shell.Dependencies{
+		Connector:       connMock,
+		DownloadUseCase: downloadUseCaseMock,
+		UploadUseCase:   uploadUseCaseMock,
+		MkdirUseCase:    mkdirUseCaseMock,
+		RemoveUseCase:   removeUseCaseMock,
+		MoveUseCase:     moveUseCaseMock,
+		FileStore:       fileStoreMock,
+		Filesystem: fstest.MapFS{
+			"home/user01/notes.txt": {Data: []byte("notes")},
+		},
+		LineReader: lineReaderMock,
+		OutWriter:  buffer,
+	}
+	input := &shell.CmdShellInput{
+		Config:   config,
+		LocalDir: localDir,
+	}
+
+	err := shell.PerformShell(ctx, logger, deps, input)
+	assert.NoError(t, err)
+	assert.Equal(t, "/pub/docs\n", buffer.String())
+}
+
+//nolint:funlen // test case can get a bit large
+func Test_PerformShell_CommandErrors(t *testing.T) {
+	ctx := context.Background()
+
+	logger := assertlogging.NewLogger(t)
+
+	ftpConnMock := connectionMocks.NewConnection(t)
+	ftpConnMock.On("Stop").Return(nil).Once()
+	ftpConnMock.On("Pwd").Return("/", nil).Once()
+	ftpConnMock.
+		On("Cd", "/missing").
+		Return(ftperrors.NewNotFoundError("path /missing does not exist", nil)).
+		Once()
+
+	connMock := ftpclientMocks.NewConnector(t)
+	connMock.
+		On("Connect", ctx, config).
+		Return(ftpConnMock, nil).
+		Once()
+
+	fileStoreMock := repositoriesMocks.NewFileStore(t)
+	fileStoreMock.
+		On("Stat", "/tmp/file.txt").
+		Return(&entities.Entry{Type: entities.EntryTypeFile}, nil).
+		Once()
+
+	lineReaderMock := shellMocks.NewLineReader(t)
+	setLines(
+		lineReaderMock,
+		[]string{"gfc:/> ", "gfc:/> ", "gfc:/> ", "gfc:/> ", "gfc:/> ", "gfc:/> "},
+		[]string{"foo", "cd missing", "mkdir", `ls "unterminated`, "lcd /tmp/file.txt"},
+	)
+
+	buffer := bytes.NewBufferString("")
+
+	deps := &shell.Dependencies{
+		Connector:  connMock,
+		FileStore:  fileStoreMock,
+		LineReader: lineReaderMock,
+		OutWriter:  buffer,
+	}
+	input := &shell.CmdShellInput{
+		Config:   config,
+		LocalDir: localDir,
+	}
+
+	err := shell.PerformShell(ctx, logger, deps, input)
+	assert.NoError(t, err)
+	assert.Equal(
+		t,
+		"error: an invalid argument error occurred: argument command foo is not supported, "+
+			"type help to list available commands\n"+
+			"error: not found error occurred: path /missing does not exist\n"+
+			"error: an invalid argument error occurred: argument args usage: mkdir remote-dir\n"+
+			"error: an invalid argument error occurred: argument command contains unterminated quote or escape\n"+
+			"error: an invalid argument error occurred: argument args /tmp/file.txt is not a directory\n",
+		buffer.String(),
+	)
+}
+
+func Test_PerformShell_List_Success(t *testing.T) {
+	ctx := context.Background()
+
+	logger := assertlogging.NewLogger(t)
+
+	ftpConnMock := connectionMocks.NewConnection(t)
+	ftpConnMock.On("Stop").Return(nil).Once()
+	ftpConnMock.On("Pwd").Return("/", nil).Once()
+
+	connMock := ftpclientMocks.NewConnector(t)
+	connMock.
+		On("Connect", ctx, config).
+		Return(ftpConnMock, nil).
+		Once()
+
+	listUseCaseMock := useCaseMocks.NewListFilesUseCase(t)
+	listUseCaseMock.
+		On(
+			"Execute",
+			ctx,
+			&ftp.ListFilesRepos{Logger: logger, Connection: ftpConnMock},
+			&ftp.ListFilesInput{Path: "/pub", ShowAll: true, SortType: entities.SortTypeName},
+		).
+		Return([]*entities.Entry{{Type: entities.EntryTypeFile, Name: "readme.txt", SizeInBytes: 12}}, nil).
+		Once()
+
+	lineReaderMock := shellMocks.NewLineReader(t)
+	setLines(lineReaderMock, []string{"gfc:/> ", "gfc:/> "}, []string{"ls -a pub"})
+
+	buffer := bytes.NewBufferString("")
+
+	deps := &shell.Dependencies{
+		Connector:   connMock,
+		ListUseCase: listUseCaseMock,
+		LineReader:  lineReaderMock,
+		OutWriter:   buffer,
+	}
+	input := &shell.CmdShellInput{
+		Config:   config,
+		LocalDir: localDir,
+	}
+
+	err := shell.PerformShell(ctx, logger, deps, input)
+	assert.NoError(t, err)
+	assert.Contains(t, buffer.String(), "readme.txt")
+	assert.Contains(t, buffer.String(), "12 B")
+}
+
+func Test_PerformShell_HomeDir_Success(t *testing.T) {
+	ctx := context.Background()
+
+	logger := assertlogging.NewLogger(t)
+
+	ftpConnMock := connectionMocks.NewConnection(t)
+	ftpConnMock.On("Stop").Return(nil).Once()
+	ftpConnMock.On("Pwd").Return(homeDir, nil).Once()
+	ftpConnMock.On("Cd", "/home/user01/docs").Return(nil).Once()
+	ftpConnMock.On("Cd", "/pub").Return(nil).Once()
+	ftpConnMock.On("Cd", homeDir).Return(nil).Once()
+
+	connMock := ftpclientMocks.NewConnector(t)
+	connMock.
+		On("Connect", ctx, config).
+		Return(ftpConnMock, nil).
+		Once()
+
+	lineReaderMock := shellMocks.NewLineReader(t)
+	setLines(
+		lineReaderMock,
+		[]string{"gfc:/home/user01> ", "gfc:/home/user01/docs> ", "gfc:/pub> ", "gfc:/home/user01> "},
+		[]string{"cd docs", "cd /pub", "cd"},
+	)
+
+	deps := &shell.Dependencies{
+		Connector:  connMock,
+		LineReader: lineReaderMock,
+		OutWriter:  bytes.NewBufferString(""),
+	}
+	input := &shell.CmdShellInput{
+		Config:   config,
+		LocalDir: localDir,
+	}
+
+	err := shell.PerformShell(ctx, logger, deps, input)
+	assert.NoError(t, err)
+}
+
+func Test_PerformShell_PwdError(t *testing.T) {
+	ctx := context.Background()
+
+	logger := assertlogging.NewLogger(t)
+	logger.
+		ExpectError("failed to get working directory").
+		WithError(assertlogging.EqualError("an internal error occurred: mock error"))
+
+	ftpConnMock := connectionMocks.NewConnection(t)
+	ftpConnMock.On("Stop").Return(nil).Once()
+	ftpConnMock.
+		On("Pwd").
+		Return("", ftperrors.NewInternalError("mock error", nil)).
+		Once()
+
+	connMock := ftpclientMocks.NewConnector(t)
+	connMock.
+		On("Connect", ctx, config).
+		Return(ftpConnMock, nil).
+		Once()
+
+	deps := &shell.Dependencies{
+		Connector:  connMock,
+		LineReader: shellMocks.NewLineReader(t),
+	}
+	input := &shell.CmdShellInput{
+		Config:   config,
+		LocalDir: localDir,
+	}
+
+	err := shell.PerformShell(ctx, logger, deps, input)
+	require.EqualError(t, err, "an internal error occurred: failed to get working directory")
+	assert.IsType(t, ftperrors.InternalErrorType, err)
+}
+
+func Test_PerformShell_ConnectError(t *testing.T) {
+	ctx := context.Background()
+
+	logger := assertlogging.NewLogger(t)
+	logger.
+		ExpectError("failed to connect to server").
+		WithError(assertlogging.EqualError("an internal error occurred: mock error"))
+
+	connMock := ftpclientMocks.NewConnector(t)
+	connMock.
+		On("Connect", ctx, config).
+		Return(nil, ftperrors.NewInternalError("mock error", nil)).
+		Once()
+
+	deps := &shell.Dependencies{
+		Connector:  connMock,
+		LineReader: shellMocks.NewLineReader(t),
+	}
+	input := &shell.CmdShellInput{
+		Config:   config,
+		LocalDir: localDir,
+	}
+
+	err := shell.PerformShell(ctx, logger, deps, input)
+	require.EqualError(t, err, "an internal error occurred: mock error")
+	assert.IsType(t, ftperrors.InternalErrorType, err)
+}
@@ -8,12 +8,15 @@ import (
 
 	"github.com/alexZaicev/go-ftp-client/internal/adapters/ftpclient"
 	"github.com/alexZaicev/go-ftp-client/internal/domain/connection"
+	"github.com/alexZaicev/go-ftp-client/internal/drivers/cli/models"
 	"github.com/alexZaicev/go-ftp-client/internal/drivers/logging"
 	useCase "github.com/alexZaicev/go-ftp-client/internal/usecases/ftp"
 )
 
 type CmdStatusInput struct {
 	Config ftpclient.ConnectorConfig
+	// Output is the format the status is written in, it is rendered as a table if it is not set.
+	Output models.OutputFormat
 }
 
 type Dependencies struct {
@@ -47,6 +50,10 @@ func PerformStatus(ctx context.Context, logger logging.Logger, deps *Dependencie
 		return err
 	}
 
+	if input.Output.IsMachineReadable() {
+		return ftpclient.WriteStatusRecord(deps.OutWriter, input.Output, status)
+	}
+
 	table := tablewriter.NewWriter(deps.OutWriter)
 	table.SetHeader([]string{
 		"status",
@@ -13,6 +13,7 @@ import (
 	"github.com/alexZaicev/go-ftp-client/internal/adapters/ftpclient"
 	"github.com/alexZaicev/go-ftp-client/internal/adapters/ftpclient/status"
 	"github.com/alexZaicev/go-ftp-client/internal/domain/entities"
+	"github.com/alexZaicev/go-ftp-client/internal/drivers/cli/models"
 	"github.com/alexZaicev/go-ftp-client/internal/drivers/logging/assertlogging"
 	"github.com/alexZaicev/go-ftp-client/internal/usecases/ftp"
 	ftpclientMocks "github.com/alexZaicev/go-ftp-client/mocks/adapters/ftpclient"
@@ -96,6 +97,77 @@ func Test_PerformStatus_Success(t *testing.T) {
 	assert.Equal(t, expectedStatusStr, buffer.String())
 }
 
+func Test_PerformStatus_Output_Success(t *testing.T) {
+	ctx := context.Background()
+
+	logger := assertlogging.NewLogger(t)
+
+	expectedStatus := &entities.Status{
+		RemoteAddress: address[:len(address)-3],
+		LoggedInUser:  user,
+		TLSEnabled:    true,
+		System:        "UNIX",
+	}
+
+	ftpConnMock := connectionMocks.NewConnection(t)
+	ftpConnMock.On("Stop").Return(nil).Once()
+
+	config := ftpclient.ConnectorConfig{
+		Address:  address,
+		User:     user,
+		Password: password,
+		Verbose:  true,
+		Timeout:  timeout,
+	}
+	connMock := ftpclientMocks.NewConnector(t)
+	connMock.
+		On("Connect", ctx, config).
+		Return(ftpConnMock, nil).
+		Once()
+
+	useCaseRepos := &ftp.StatusRepos{
+		Logger:     logger,
+		Connection: ftpConnMock,
+	}
+	useCaseInput := &ftp.StatusInput{}
+
+	useCaseMock := useCaseMocks.NewStatusUseCase(t)
+	useCaseMock.
+		On("Execute", ctx, useCaseRepos, useCaseInput).
+		Return(expectedStatus, nil).
+		Once()
+
+	buffer := bytes.NewBufferString("")
+
+	deps := &status.Dependencies{
+		Connector: connMock,
+		UseCase:   useCaseMock,
+		OutWriter: buffer,
+	}
+	input := &status.CmdStatusInput{
+		Config: ftpclient.ConnectorConfig{
+			Address:  address,
+			User:     user,
+			Password: password,
+			Verbose:  true,
+			Timeout:  timeout,
+		},
+		Output: models.OutputFormatJSON,
+	}
+
+	expectedStatusStr := `{
+  "remote_address": "10.0.0.1",
+  "logged_in_user": "user01",
+  "tls_enabled": true,
+  "system": "UNIX"
+}
+`
+
+	err := status.PerformStatus(ctx, logger, deps, input)
+	assert.NoError(t, err)
+	assert.Equal(t, expectedStatusStr, buffer.String())
+}
+
 func Test_PerformStatus_ConnectError(t *testing.T) {
 	ctx := context.Background()
 
@@ -0,0 +1,467 @@
+package sync
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/olekukonko/tablewriter"
+
+	"github.com/alexZaicev/go-ftp-client/internal/adapters/ftpclient"
+	"github.com/alexZaicev/go-ftp-client/internal/domain/connection"
+	"github.com/alexZaicev/go-ftp-client/internal/domain/entities"
+	ftperrors "github.com/alexZaicev/go-ftp-client/internal/domain/errors"
+	"github.com/alexZaicev/go-ftp-client/internal/domain/repositories"
+	"github.com/alexZaicev/go-ftp-client/internal/drivers/cli/models"
+	"github.com/alexZaicev/go-ftp-client/internal/drivers/logging"
+	"github.com/alexZaicev/go-ftp-client/internal/usecases/ftp"
+)
+
+type CmdSyncInput struct {
+	Config     ftpclient.ConnectorConfig
+	Path       string
+	RemotePath string
+	// Reverse mirrors remote tree onto the local filesystem instead of the other way around.
+	Reverse bool
+	// Delete removes destination entries that do not exist in the source tree.
+	Delete bool
+	// DryRun prints planned actions without performing them.
+	DryRun bool
+	// TwoWay propagates changes made on either side since the last synchronisation to the other side.
+	TwoWay         bool
+	ConflictPolicy entities.SyncConflictPolicy
+	// Output is the format the plan of a dry run, or the outcome of every action, is written in. The plan
+	// is rendered as a table if it is not set.
+	Output models.OutputFormat
+}
+
+type Dependencies struct {
+	Connector         ftpclient.Connector
+	Filesystem        fs.FS
+	FileStore         repositories.FileStore
+	PlanUseCase       ftp.SyncPlanUseCase
+	TwoWayPlanUseCase ftp.TwoWaySyncPlanUseCase
+	SnapshotUseCase   ftp.SyncSnapshotUseCase
+	UploadUseCase     ftp.UploadFileUseCase
+	DownloadUseCase   ftp.DownloadUseCase
+	MkdirUseCase      ftp.MkdirUseCase
+	RemoveUseCase     ftp.RemoveUseCase
+	MoveUseCase       ftp.MoveUseCase
+	OutWriter         io.Writer
+}
+
+func PerformSync(ctx context.Context, logger logging.Logger, deps *Dependencies, input *CmdSyncInput) (err error) {
+	conn, err := deps.Connector.Connect(ctx, input.Config)
+	if err != nil {
+		logger.WithError(err).Error("failed to connect to server")
+		return err
+	}
+	defer func(conn connection.Connection) {
+		if stopErr := conn.Stop(); stopErr != nil {
+			logger.WithError(stopErr).Error("failed to stop server connection")
+			err = stopErr
+		}
+	}(conn)
+
+	if input.TwoWay {
+		return performTwoWaySync(ctx, logger, conn, deps, input)
+	}
+
+	direction := entities.SyncDirectionUpload
+	if input.Reverse {
+		direction = entities.SyncDirectionDownload
+	}
+
+	planUseCaseRepos := &ftp.SyncPlanRepos{
+		Logger:     logger,
+		Connection: conn,
+		Filesystem: deps.Filesystem,
+	}
+	planUseCaseInput := &ftp.SyncPlanInput{
+		Path:       filesystemPath(input.Path),
+		RemotePath: input.RemotePath,
+		Direction:  direction,
+		Delete:     input.Delete,
+	}
+
+	actions, err := deps.PlanUseCase.Execute(ctx, planUseCaseRepos, planUseCaseInput)
+	if err != nil {
+		return err
+	}
+
+	if input.DryRun {
+		return writePlan(deps.OutWriter, input.Output, actions, func(action *entities.SyncAction) string {
+			return actionName(direction, action)
+		})
+	}
+
+	results := make([]*entities.TransferResult, 0, len(actions))
+	for _, action := range actions {
+		logger.
+			WithFields(logging.Fields{
+				"action": actionName(direction, action),
+				"path":   action.Path,
+				"reason": string(action.Reason),
+			}).
+			Info("synchronising entry")
+
+		if direction == entities.SyncDirectionUpload {
+			err = performUploadAction(ctx, logger, conn, deps, input, action)
+		} else {
+			err = performDownloadAction(ctx, logger, conn, deps, input, action)
+		}
+		if err != nil {
+			return err
+		}
+		results = append(results, entities.NewTransferOK(action.Path))
+	}
+
+	if err = writeResults(deps.OutWriter, input.Output, results); err != nil {
+		return err
+	}
+
+	logger.Info("OK!")
+
+	return nil
+}
+
+// performTwoWaySync function plans changes against the state of the last synchronisation, performs them
+// and records the new state. If an action fails, the state of entries that were not synchronised is kept,
+// so that they are planned again on the next run.
+func performTwoWaySync(
+	ctx context.Context,
+	logger logging.Logger,
+	conn connection.Connection,
+	deps *Dependencies,
+	input *CmdSyncInput,
+) error {
+	state, err := loadState(logger, deps.Filesystem, path.Join(filesystemPath(input.Path), entities.SyncStateFileName))
+	if err != nil {
+		return err
+	}
+
+	planUseCaseRepos := &ftp.TwoWaySyncPlanRepos{
+		Logger:     logger,
+		Connection: conn,
+		Filesystem: deps.Filesystem,
+	}
+	planUseCaseInput := &ftp.TwoWaySyncPlanInput{
+		Path:           filesystemPath(input.Path),
+		RemotePath:     input.RemotePath,
+		State:          state,
+		ConflictPolicy: input.ConflictPolicy,
+	}
+
+	actions, err := deps.TwoWayPlanUseCase.Execute(ctx, planUseCaseRepos, planUseCaseInput)
+	if err != nil {
+		return err
+	}
+
+	if input.DryRun {
+		return writePlan(deps.OutWriter, input.Output, actions, twoWayActionName)
+	}
+
+	results := make([]*entities.TransferResult, 0, len(actions))
+	for idx, action := range actions {
+		logger.
+			WithFields(logging.Fields{
+				"action": twoWayActionName(action),
+				"path":   action.Path,
+				"reason": string(action.Reason),
+			}).
+			Info("synchronising entry")
+
+		if action.Direction == entities.SyncDirectionUpload {
+			err = performUploadAction(ctx, logger, conn, deps, input, action)
+		} else {
+			err = performDownloadAction(ctx, logger, conn, deps, input, action)
+		}
+		if err != nil {
+			if saveErr := saveState(ctx, logger, conn, deps, input, state, actions[idx:]); saveErr != nil {
+				logger.WithError(saveErr).Error("failed to save sync state")
+			}
+			return err
+		}
+		results = append(results, entities.NewTransferOK(action.Path))
+	}
+
+	if err = saveState(ctx, logger, conn, deps, input, state, nil); err != nil {
+		return err
+	}
+
+	if err = writeResults(deps.OutWriter, input.Output, results); err != nil {
+		return err
+	}
+
+	logger.Info("OK!")
+
+	return nil
+}
+
+func loadState(logger logging.Logger, filesystem fs.FS, statePath string) (*entities.SyncState, error) {
+	data, err := fs.ReadFile(filesystem, statePath)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return &entities.SyncState{}, nil
+		}
+		logger.WithError(err).WithField("path", statePath).Error("failed to read sync state")
+		return nil, ftperrors.NewInternalError("failed to read sync state", nil)
+	}
+
+	state := &entities.SyncState{}
+	if err = json.Unmarshal(data, state); err != nil {
+		logger.WithError(err).WithField("path", statePath).Error("failed to parse sync state")
+		return nil, ftperrors.NewInternalError("failed to parse sync state", nil)
+	}
+	return state, nil
+}
+
+func saveState(
+	ctx context.Context,
+	logger logging.Logger,
+	conn connection.Connection,
+	deps *Dependencies,
+	input *CmdSyncInput,
+	previous *entities.SyncState,
+	pendingActions []*entities.SyncAction,
+) error {
+	var pending []string
+	for _, action := range pendingActions {
+		pending = append(pending, action.Path)
+		if action.NewPath != "" {
+			pending = append(pending, action.NewPath)
+		}
+	}
+
+	state, err := deps.SnapshotUseCase.Execute(
+		ctx,
+		&ftp.SyncSnapshotRepos{Logger: logger, Connection: conn, Filesystem: deps.Filesystem},
+		&ftp.SyncSnapshotInput{
+			Path:       filesystemPath(input.Path),
+			RemotePath: input.RemotePath,
+			Previous:   previous,
+			Pending:    pending,
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return ftperrors.NewInternalError("failed to encode sync state", err)
+	}
+
+	statePath := filepath.Join(input.Path, entities.SyncStateFileName)
+	if err = deps.FileStore.SaveFile(statePath, data); err != nil {
+		logger.WithError(err).WithField("path", statePath).Error("failed to save sync state")
+		return ftperrors.NewInternalError("failed to save sync state", nil)
+	}
+	return nil
+}
+
+func performUploadAction(
+	ctx context.Context,
+	logger logging.Logger,
+	conn connection.Connection,
+	deps *Dependencies,
+	input *CmdSyncInput,
+	action *entities.SyncAction,
+) error {
+	remotePath := path.Join(input.RemotePath, action.Path)
+
+	switch action.Type {
+	case entities.SyncActionCreateDir:
+		return deps.MkdirUseCase.Execute(
+			ctx,
+			&ftp.MkdirRepos{Logger: logger, Connection: conn},
+			&ftp.MkdirInput{Path: remotePath},
+		)
+	case entities.SyncActionCopy:
+		file, err := deps.Filesystem.Open(path.Join(filesystemPath(input.Path), action.Path))
+		if err != nil {
+			return ftperrors.NewInternalError("failed to open file", err)
+		}
+		defer func(file fs.File) {
+			if closeErr := file.Close(); closeErr != nil {
+				logger.WithError(closeErr).Warn(fmt.Sprintf("failed to close file %s", action.Path))
+			}
+		}(file)
+
+		_, err = deps.UploadUseCase.Execute(
+			ctx,
+			&ftp.UploadFileRepos{Logger: logger, Connection: conn},
+			&ftp.UploadFileInput{
+				FileReader:  file,
+				RemotePath:  remotePath,
+				SizeInBytes: action.SizeInBytes,
+			},
+		)
+		return err
+	case entities.SyncActionDelete:
+		_, err := deps.RemoveUseCase.Execute(
+			ctx,
+			&ftp.RemoveRepos{Logger: logger, Connection: conn},
+			&ftp.RemoveInput{Path: remotePath},
+		)
+		return err
+	case entities.SyncActionRename:
+		return deps.MoveUseCase.Execute(
+			ctx,
+			&ftp.MoveRepos{Logger: logger, Connection: conn},
+			&ftp.MoveInput{OldPath: remotePath, NewPath: path.Join(input.RemotePath, action.NewPath)},
+		)
+	default:
+		return newUnexpectedActionError(action)
+	}
+}
+
+func performDownloadAction(
+	ctx context.Context,
+	logger logging.Logger,
+	conn connection.Connection,
+	deps *Dependencies,
+	input *CmdSyncInput,
+	action *entities.SyncAction,
+) error {
+	localPath := filepath.Join(input.Path, filepath.FromSlash(action.Path))
+
+	switch action.Type {
+	case entities.SyncActionCreateDir:
+		if err := deps.FileStore.CreateDir(localPath); err != nil {
+			logger.WithError(err).WithField("path", localPath).Error("failed to create directory")
+			return ftperrors.NewInternalError("failed to create directory", nil)
+		}
+		return nil
+	case entities.SyncActionCopy:
+		_, err := deps.DownloadUseCase.Execute(
+			ctx,
+			&ftp.DownloadRepos{Logger: logger, Connection: conn, FileStore: deps.FileStore},
+			&ftp.DownloadInput{
+				RemotePath: path.Join(input.RemotePath, action.Path),
+				Path:       localPath,
+			},
+		)
+		return err
+	case entities.SyncActionDelete:
+		if err := deps.FileStore.Remove(localPath); err != nil {
+			logger.WithError(err).WithField("path", localPath).Error("failed to remove file")
+			return ftperrors.NewInternalError("failed to remove file", nil)
+		}
+		return nil
+	default:
+		return newUnexpectedActionError(action)
+	}
+}
+
+// writePlan function writes planned actions in the output format, or renders them as a table if the output
+// is not machine readable.
+func writePlan(
+	writer io.Writer,
+	format models.OutputFormat,
+	actions []*entities.SyncAction,
+	nameFunc func(action *entities.SyncAction) string,
+) error {
+	names := make([]string, 0, len(actions))
+	for _, action := range actions {
+		name := nameFunc(action)
+		if name == "" {
+			return newUnexpectedActionError(action)
+		}
+		names = append(names, name)
+	}
+
+	if format.IsMachineReadable() {
+		return ftpclient.WriteSyncActions(writer, format, actions, names)
+	}
+	renderPlan(writer, actions, names)
+	return nil
+}
+
+func renderPlan(writer io.Writer, actions []*entities.SyncAction, names []string) {
+	table := tablewriter.NewWriter(writer)
+	table.SetHeader([]string{"action", "path", "reason", "size"})
+	// wrapped paths are hard to copy from the plan
+	table.SetAutoWrapText(false)
+	for idx, action := range actions {
+		actionPath := action.Path
+		if action.NewPath != "" {
+			actionPath = fmt.Sprintf("%s -> %s", action.Path, action.NewPath)
+		}
+
+		size := ""
+		if !action.IsDir {
+			size = ftpclient.FormatSizeInBytes(action.SizeInBytes)
+		}
+
+		table.Append([]string{names[idx], actionPath, string(action.Reason), size})
+	}
+	table.Render()
+}
+
+// writeResults function writes the outcome of every performed action, if the output is machine readable.
+func writeResults(writer io.Writer, format models.OutputFormat, results []*entities.TransferResult) error {
+	if !format.IsMachineReadable() {
+		return nil
+	}
+	return ftpclient.WriteTransferResults(writer, format, results)
+}
+
+func actionName(direction entities.SyncDirection, action *entities.SyncAction) string {
+	switch action.Type {
+	case entities.SyncActionCreateDir:
+		return "mkdir"
+	case entities.SyncActionCopy:
+		if direction == entities.SyncDirectionDownload {
+			return "download"
+		}
+		return "upload"
+	case entities.SyncActionDelete:
+		return "delete"
+	default:
+		return ""
+	}
+}
+
+// twoWayActionName function names actions of two-way synchronisation along with the side they change.
+func twoWayActionName(action *entities.SyncAction) string {
+	side := "remote"
+	if action.Direction == entities.SyncDirectionDownload {
+		side = "local"
+	}
+
+	switch action.Type {
+	case entities.SyncActionCreateDir:
+		return "mkdir " + side
+	case entities.SyncActionCopy:
+		return actionName(action.Direction, action)
+	case entities.SyncActionDelete:
+		return "delete " + side
+	case entities.SyncActionRename:
+		return "rename " + side
+	default:
+		return ""
+	}
+}
+
+func newUnexpectedActionError(action *entities.SyncAction) error {
+	return ftperrors.NewUnknownError(
+		fmt.Sprintf("unexpected sync action: %d", action.Type),
+		nil,
+	)
+}
+
+// filesystemPath function converts absolute local path into a path within the filesystem rooted at "/".
+func filesystemPath(localPath string) string {
+	fsPath := strings.TrimPrefix(filepath.ToSlash(localPath), "/")
+	if fsPath == "" {
+		return "."
+	}
+	return fsPath
+}
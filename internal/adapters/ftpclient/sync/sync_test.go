@@ -0,0 +1,711 @@
+package sync_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/alexZaicev/go-ftp-client/internal/adapters/ftpclient"
+	"github.com/alexZaicev/go-ftp-client/internal/adapters/ftpclient/sync"
+	"github.com/alexZaicev/go-ftp-client/internal/domain/entities"
+	"github.com/alexZaicev/go-ftp-client/internal/drivers/cli/models"
+	"github.com/alexZaicev/go-ftp-client/internal/drivers/logging/assertlogging"
+	"github.com/alexZaicev/go-ftp-client/internal/usecases/ftp"
+	ftpclientMocks "github.com/alexZaicev/go-ftp-client/mocks/adapters/ftpclient"
+	connectionMocks "github.com/alexZaicev/go-ftp-client/mocks/domain/connection"
+	repositoriesMocks "github.com/alexZaicev/go-ftp-client/mocks/domain/repositories"
+	useCaseMocks "github.com/alexZaicev/go-ftp-client/mocks/usecases/ftp"
+)
+
+const (
+	address  = "10.0.0.1:21"
+	user     = "user01"
+	password = "pwd01"
+	timeout  = 5 * time.Second
+
+	localPath  = "/home/user01/site"
+	remotePath = "/var/www/site"
+)
+
+var (
+	config = ftpclient.ConnectorConfig{
+		Address:  address,
+		User:     user,
+		Password: password,
+		Verbose:  true,
+		Timeout:  timeout,
+	}
+
+	actions = []*entities.SyncAction{
+		{Type: entities.SyncActionCreateDir, Path: "css", IsDir: true, Reason: entities.SyncReasonNew},
+		{Type: entities.SyncActionCopy, Path: "css/main.css", SizeInBytes: 12, Reason: entities.SyncReasonNew},
+		{Type: entities.SyncActionDelete, Path: "old.html", SizeInBytes: 2048, Reason: entities.SyncReasonExtraneous},
+	}
+)
+
+func Test_PerformSync_DryRun_Success(t *testing.T) {
+	ctx := context.Background()
+
+	logger := assertlogging.NewLogger(t)
+
+	ftpConnMock := connectionMocks.NewConnection(t)
+	ftpConnMock.On("Stop").Return(nil).Once()
+
+	connMock := ftpclientMocks.NewConnector(t)
+	connMock.
+		On("Connect", ctx, config).
+		Return(ftpConnMock, nil).
+		Once()
+
+	filesystem := fstest.MapFS{}
+
+	planUseCaseMock := useCaseMocks.NewSyncPlanUseCase(t)
+	planUseCaseMock.
+		On(
+			"Execute",
+			ctx,
+			&ftp.SyncPlanRepos{Logger: logger, Connection: ftpConnMock, Filesystem: filesystem},
+			&ftp.SyncPlanInput{
+				Path:       "home/user01/site",
+				RemotePath: remotePath,
+				Direction:  entities.SyncDirectionUpload,
+				Delete:     true,
+			},
+		).
+		Return(actions, nil).
+		Once()
+
+	buffer := bytes.NewBufferString("")
+
+	deps := &sync.Dependencies{
+		Connector:   connMock,
+		Filesystem:  filesystem,
+		PlanUseCase: planUseCaseMock,
+		OutWriter:   buffer,
+	}
+	input := &sync.CmdSyncInput{
+		Config:     config,
+		Path:       localPath,
+		RemotePath: remotePath,
+		Delete:     true,
+		DryRun:     true,
+	}
+
+	expectedPlanStr := `+--------+--------------+------------+---------+
+| ACTION |     PATH     |   REASON   |  SIZE   |
++--------+--------------+------------+---------+
+| mkdir  | css          | new        |         |
+| upload | css/main.css | new        | 12 B    |
+| delete | old.html     | extraneous | 2.00 KB |
++--------+--------------+------------+---------+
+`
+
+	err := sync.PerformSync(ctx, logger, deps, input)
+	assert.NoError(t, err)
+	assert.Equal(t, expectedPlanStr, buffer.String())
+}
+
+func Test_PerformSync_DryRun_Output_Success(t *testing.T) {
+	ctx := context.Background()
+
+	logger := assertlogging.NewLogger(t)
+
+	ftpConnMock := connectionMocks.NewConnection(t)
+	ftpConnMock.On("Stop").Return(nil).Once()
+
+	connMock := ftpclientMocks.NewConnector(t)
+	connMock.
+		On("Connect", ctx, config).
+		Return(ftpConnMock, nil).
+		Once()
+
+	planUseCaseMock := useCaseMocks.NewSyncPlanUseCase(t)
+	planUseCaseMock.
+		On("Execute", ctx, mock.AnythingOfType("*ftp.SyncPlanRepos"), mock.AnythingOfType("*ftp.SyncPlanInput")).
+		Return(actions, nil).
+		Once()
+
+	buffer := bytes.NewBufferString("")
+
+	deps := &sync.Dependencies{
+		Connector:   connMock,
+		PlanUseCase: planUseCaseMock,
+		OutWriter:   buffer,
+	}
+	input := &sync.CmdSyncInput{
+		Config:     config,
+		Path:       localPath,
+		RemotePath: remotePath,
+		Delete:     true,
+		DryRun:     true,
+		Output:     models.OutputFormatJSONL,
+	}
+
+	err := sync.PerformSync(ctx, logger, deps, input)
+	assert.NoError(t, err)
+	assert.Equal(t,
+		`{"action":"mkdir","path":"css","reason":"new","is_dir":true,"size_in_bytes":0}`+"\n"+
+			`{"action":"upload","path":"css/main.css","reason":"new","is_dir":false,"size_in_bytes":12}`+"\n"+
+			`{"action":"delete","path":"old.html","reason":"extraneous","is_dir":false,"size_in_bytes":2048}`+"\n",
+		buffer.String(),
+	)
+}
+
+//nolint:funlen // test case can get a bit large
+func Test_PerformSync_Upload_Success(t *testing.T) {
+	ctx := context.Background()
+
+	logger := assertlogging.NewLogger(t)
+	logger.
+		ExpectInfo("synchronising entry").
+		WithField("action", assertlogging.Equal("mkdir")).
+		WithField("path", assertlogging.Equal("css")).
+		WithField("reason", assertlogging.Equal("new"))
+	logger.
+		ExpectInfo("synchronising entry").
+		WithField("action", assertlogging.Equal("upload")).
+		WithField("path", assertlogging.Equal("css/main.css")).
+		WithField("reason", assertlogging.Equal("new"))
+	logger.
+		ExpectInfo("synchronising entry").
+		WithField("action", assertlogging.Equal("delete")).
+		WithField("path", assertlogging.Equal("old.html")).
+		WithField("reason", assertlogging.Equal("extraneous"))
+	logger.ExpectInfo("OK!")
+
+	ftpConnMock := connectionMocks.NewConnection(t)
+	ftpConnMock.On("Stop").Return(nil).Once()
+
+	connMock := ftpclientMocks.NewConnector(t)
+	connMock.
+		On("Connect", ctx, config).
+		Return(ftpConnMock, nil).
+		Once()
+
+	filesystem := fstest.MapFS{
+		"home/user01/site/css/main.css": {Data: []byte("body {}\n    ")},
+	}
+
+	planUseCaseMock := useCaseMocks.NewSyncPlanUseCase(t)
+	planUseCaseMock.
+		On("Execute", ctx, mock.AnythingOfType("*ftp.SyncPlanRepos"), mock.AnythingOfType("*ftp.SyncPlanInput")).
+		Return(actions, nil).
+		Once()
+
+	mkdirUseCaseMock := useCaseMocks.NewMkdirUseCase(t)
+	mkdirUseCaseMock.
+		On(
+			"Execute",
+			ctx,
+			&ftp.MkdirRepos{Logger: logger, Connection: ftpConnMock},
+			&ftp.MkdirInput{Path: remotePath + "/css"},
+		).
+		Return(nil).
+		Once()
+
+	uploadUseCaseMock := useCaseMocks.NewUploadFileUseCase(t)
+	uploadUseCaseMock.
+		On("Execute", ctx, &ftp.UploadFileRepos{Logger: logger, Connection: ftpConnMock}, mock.AnythingOfType("*ftp.UploadFileInput")).
+		Run(func(args mock.Arguments) {
+			useCaseInput := args.Get(2).(*ftp.UploadFileInput)
+			assert.Equal(t, remotePath+"/css/main.css", useCaseInput.RemotePath)
+			assert.Equal(t, uint64(12), useCaseInput.SizeInBytes)
+		}).
+		Return(&ftp.UploadFileOutput{}, nil).
+		Once()
+
+	removeUseCaseMock := useCaseMocks.NewRemoveUseCase(t)
+	removeUseCaseMock.
+		On(
+			"Execute",
+			ctx,
+			&ftp.RemoveRepos{Logger: logger, Connection: ftpConnMock},
+			&ftp.RemoveInput{Path: remotePath + "/old.html"},
+		).
+		Return(&ftp.RemoveOutput{}, nil).
+		Once()
+
+	deps := &sync.Dependencies{
+		Connector:     connMock,
+		Filesystem:    filesystem,
+		PlanUseCase:   planUseCaseMock,
+		MkdirUseCase:  mkdirUseCaseMock,
+		UploadUseCase: uploadUseCaseMock,
+		RemoveUseCase: removeUseCaseMock,
+	}
+	input := &sync.CmdSyncInput{
+		Config:     config,
+		Path:       localPath,
+		RemotePath: remotePath,
+		Delete:     true,
+	}
+
+	err := sync.PerformSync(ctx, logger, deps, input)
+	assert.NoError(t, err)
+}
+
+func Test_PerformSync_Download_Success(t *testing.T) {
+	ctx := context.Background()
+
+	logger := assertlogging.NewLogger(t)
+	logger.
+		ExpectInfo("synchronising entry").
+		WithField("action", assertlogging.Equal("mkdir")).
+		WithField("path", assertlogging.Equal("css")).
+		WithField("reason", assertlogging.Equal("new"))
+	logger.
+		ExpectInfo("synchronising entry").
+		WithField("action", assertlogging.Equal("download")).
+		WithField("path", assertlogging.Equal("css/main.css")).
+		WithField("reason", assertlogging.Equal("new"))
+	logger.
+		ExpectInfo("synchronising entry").
+		WithField("action", assertlogging.Equal("delete")).
+		WithField("path", assertlogging.Equal("old.html")).
+		WithField("reason", assertlogging.Equal("extraneous"))
+	logger.ExpectInfo("OK!")
+
+	ftpConnMock := connectionMocks.NewConnection(t)
+	ftpConnMock.On("Stop").Return(nil).Once()
+
+	connMock := ftpclientMocks.NewConnector(t)
+	connMock.
+		On("Connect", ctx, config).
+		Return(ftpConnMock, nil).
+		Once()
+
+	planUseCaseMock := useCaseMocks.NewSyncPlanUseCase(t)
+	planUseCaseMock.
+		On("Execute", ctx, mock.AnythingOfType("*ftp.SyncPlanRepos"), mock.MatchedBy(func(input *ftp.SyncPlanInput) bool {
+			return input.Direction == entities.SyncDirectionDownload
+		})).
+		Return(actions, nil).
+		Once()
+
+	fileStoreMock := repositoriesMocks.NewFileStore(t)
+	fileStoreMock.
+		On("CreateDir", filepath.Join(localPath, "css")).
+		Return(nil).
+		Once()
+	fileStoreMock.
+		On("Remove", filepath.Join(localPath, "old.html")).
+		Return(nil).
+		Once()
+
+	downloadUseCaseMock := useCaseMocks.NewDownloadUseCase(t)
+	downloadUseCaseMock.
+		On(
+			"Execute",
+			ctx,
+			&ftp.DownloadRepos{Logger: logger, Connection: ftpConnMock, FileStore: fileStoreMock},
+			&ftp.DownloadInput{RemotePath: remotePath + "/css/main.css", Path: filepath.Join(localPath, "css", "main.css")},
+		).
+		Return(&ftp.DownloadOutput{}, nil).
+		Once()
+
+	buffer := bytes.NewBufferString("")
+
+	deps := &sync.Dependencies{
+		Connector:       connMock,
+		FileStore:       fileStoreMock,
+		PlanUseCase:     planUseCaseMock,
+		DownloadUseCase: downloadUseCaseMock,
+		OutWriter:       buffer,
+	}
+	input := &sync.CmdSyncInput{
+		Config:     config,
+		Path:       localPath,
+		RemotePath: remotePath,
+		Reverse:    true,
+		Delete:     true,
+		Output:     models.OutputFormatCSV,
+	}
+
+	err := sync.PerformSync(ctx, logger, deps, input)
+	assert.NoError(t, err)
+	assert.Equal(t, "path,status,reason\ncss,ok,\ncss/main.css,ok,\nold.html,ok,\n", buffer.String())
+}
+
+func Test_PerformSync_ConnectionError(t *testing.T) {
+	ctx := context.Background()
+
+	logger := assertlogging.NewLogger(t)
+	logger.
+		ExpectError("failed to connect to server").
+		WithError(assertlogging.EqualError("mock error"))
+
+	connMock := ftpclientMocks.NewConnector(t)
+	connMock.
+		On("Connect", ctx, config).
+		Return(nil, errors.New("mock error")).
+		Once()
+
+	deps := &sync.Dependencies{
+		Connector:   connMock,
+		PlanUseCase: useCaseMocks.NewSyncPlanUseCase(t),
+	}
+	input := &sync.CmdSyncInput{
+		Config:     config,
+		Path:       localPath,
+		RemotePath: remotePath,
+	}
+
+	err := sync.PerformSync(ctx, logger, deps, input)
+	require.EqualError(t, err, "mock error")
+}
+
+func Test_PerformSync_ActionError(t *testing.T) {
+	ctx := context.Background()
+
+	logger := assertlogging.NewLogger(t)
+	logger.
+		ExpectInfo("synchronising entry").
+		WithField("action", assertlogging.Equal("mkdir")).
+		WithField("path", assertlogging.Equal("css")).
+		WithField("reason", assertlogging.Equal("new"))
+
+	ftpConnMock := connectionMocks.NewConnection(t)
+	ftpConnMock.On("Stop").Return(nil).Once()
+
+	connMock := ftpclientMocks.NewConnector(t)
+	connMock.
+		On("Connect", ctx, config).
+		Return(ftpConnMock, nil).
+		Once()
+
+	planUseCaseMock := useCaseMocks.NewSyncPlanUseCase(t)
+	planUseCaseMock.
+		On("Execute", ctx, mock.AnythingOfType("*ftp.SyncPlanRepos"), mock.AnythingOfType("*ftp.SyncPlanInput")).
+		Return(actions, nil).
+		Once()
+
+	mkdirUseCaseMock := useCaseMocks.NewMkdirUseCase(t)
+	mkdirUseCaseMock.
+		On("Execute", ctx, mock.AnythingOfType("*ftp.MkdirRepos"), mock.AnythingOfType("*ftp.MkdirInput")).
+		Return(errors.New("mock error")).
+		Once()
+
+	deps := &sync.Dependencies{
+		Connector:    connMock,
+		PlanUseCase:  planUseCaseMock,
+		MkdirUseCase: mkdirUseCaseMock,
+	}
+	input := &sync.CmdSyncInput{
+		Config:     config,
+		Path:       localPath,
+		RemotePath: remotePath,
+	}
+
+	err := sync.PerformSync(ctx, logger, deps, input)
+	require.EqualError(t, err, "mock error")
+}
+
+var (
+	twoWayActions = []*entities.SyncAction{
+		{
+			Type:        entities.SyncActionRename,
+			Direction:   entities.SyncDirectionUpload,
+			Path:        "report.txt",
+			NewPath:     "report.conflict-20220115-1000.txt",
+			SizeInBytes: 5,
+			Reason:      entities.SyncReasonConflict,
+		},
+		{
+			Type:        entities.SyncActionCopy,
+			Direction:   entities.SyncDirectionDownload,
+			Path:        "report.conflict-20220115-1000.txt",
+			SizeInBytes: 5,
+			Reason:      entities.SyncReasonConflict,
+		},
+		{
+			Type:        entities.SyncActionCopy,
+			Direction:   entities.SyncDirectionUpload,
+			Path:        "report.txt",
+			SizeInBytes: 4,
+			Reason:      entities.SyncReasonConflict,
+		},
+		{
+			Type:      entities.SyncActionDelete,
+			Direction: entities.SyncDirectionDownload,
+			Path:      "old",
+			IsDir:     true,
+			Reason:    entities.SyncReasonDeleted,
+		},
+	}
+
+	previousState = &entities.SyncState{
+		Entries: map[string]*entities.SyncStateEntry{
+			"report.txt": {
+				Local:  entities.SyncFileState{SizeInBytes: 3, ModTime: time.Date(2022, 1, 10, 10, 0, 0, 0, time.UTC)},
+				Remote: entities.SyncFileState{SizeInBytes: 3, ModTime: time.Date(2022, 1, 10, 10, 0, 0, 0, time.UTC)},
+			},
+		},
+	}
+)
+
+func Test_PerformSync_TwoWay_DryRun_Success(t *testing.T) {
+	ctx := context.Background()
+
+	logger := assertlogging.NewLogger(t)
+
+	ftpConnMock := connectionMocks.NewConnection(t)
+	ftpConnMock.On("Stop").Return(nil).Once()
+
+	connMock := ftpclientMocks.NewConnector(t)
+	connMock.
+		On("Connect", ctx, config).
+		Return(ftpConnMock, nil).
+		Once()
+
+	filesystem := fstest.MapFS{}
+
+	planUseCaseMock := useCaseMocks.NewTwoWaySyncPlanUseCase(t)
+	planUseCaseMock.
+		On(
+			"Execute",
+			ctx,
+			&ftp.TwoWaySyncPlanRepos{Logger: logger, Connection: ftpConnMock, Filesystem: filesystem},
+			&ftp.TwoWaySyncPlanInput{
+				Path:           "home/user01/site",
+				RemotePath:     remotePath,
+				State:          &entities.SyncState{},
+				ConflictPolicy: entities.SyncConflictPolicyKeepBoth,
+			},
+		).
+		Return(twoWayActions, nil).
+		Once()
+
+	buffer := bytes.NewBufferString("")
+
+	deps := &sync.Dependencies{
+		Connector:         connMock,
+		Filesystem:        filesystem,
+		TwoWayPlanUseCase: planUseCaseMock,
+		OutWriter:         buffer,
+	}
+	input := &sync.CmdSyncInput{
+		Config:         config,
+		Path:           localPath,
+		RemotePath:     remotePath,
+		DryRun:         true,
+		TwoWay:         true,
+		ConflictPolicy: entities.SyncConflictPolicyKeepBoth,
+	}
+
+	expectedPlanStr := `+---------------+-------------------------------------------------+----------+------+
+|    ACTION     |                      PATH                       |  REASON  | SIZE |
++---------------+-------------------------------------------------+----------+------+
+| rename remote | report.txt -> report.conflict-20220115-1000.txt | conflict | 5 B  |
+| download      | report.conflict-20220115-1000.txt               | conflict | 5 B  |
+| upload        | report.txt                                      | conflict | 4 B  |
+| delete local  | old                                             | deleted  |      |
++---------------+-------------------------------------------------+----------+------+
+`
+
+	err := sync.PerformSync(ctx, logger, deps, input)
+	assert.NoError(t, err)
+	assert.Equal(t, expectedPlanStr, buffer.String())
+}
+
+//nolint:funlen // test case can get a bit large
+func Test_PerformSync_TwoWay_Success(t *testing.T) {
+	ctx := context.Background()
+
+	logger := assertlogging.NewLogger(t)
+	actionNames := []string{"rename remote", "download", "upload", "delete local"}
+	for idx, action := range twoWayActions {
+		logger.
+			ExpectInfo("synchronising entry").
+			WithField("action", assertlogging.Equal(actionNames[idx])).
+			WithField("path", assertlogging.Equal(action.Path)).
+			WithField("reason", assertlogging.Equal(string(action.Reason)))
+	}
+	logger.ExpectInfo("OK!")
+
+	ftpConnMock := connectionMocks.NewConnection(t)
+	ftpConnMock.On("Stop").Return(nil).Once()
+
+	connMock := ftpclientMocks.NewConnector(t)
+	connMock.
+		On("Connect", ctx, config).
+		Return(ftpConnMock, nil).
+		Once()
+
+	stateData, err := json.Marshal(previousState)
+	require.NoError(t, err)
+	filesystem := fstest.MapFS{
+		"home/user01/site/" + entities.SyncStateFileName: {Data: stateData},
+		"home/user01/site/report.txt":                    {Data: []byte("xxxx")},
+	}
+
+	planUseCaseMock := useCaseMocks.NewTwoWaySyncPlanUseCase(t)
+	planUseCaseMock.
+		On("Execute", ctx, mock.AnythingOfType("*ftp.TwoWaySyncPlanRepos"), mock.MatchedBy(func(input *ftp.TwoWaySyncPlanInput) bool {
+			return assert.Equal(t, previousState, input.State)
+		})).
+		Return(twoWayActions, nil).
+		Once()
+
+	moveUseCaseMock := useCaseMocks.NewMoveUseCase(t)
+	moveUseCaseMock.
+		On(
+			"Execute",
+			ctx,
+			&ftp.MoveRepos{Logger: logger, Connection: ftpConnMock},
+			&ftp.MoveInput{
+				OldPath: remotePath + "/report.txt",
+				NewPath: remotePath + "/report.conflict-20220115-1000.txt",
+			},
+		).
+		Return(nil).
+		Once()
+
+	fileStoreMock := repositoriesMocks.NewFileStore(t)
+
+	downloadUseCaseMock := useCaseMocks.NewDownloadUseCase(t)
+	downloadUseCaseMock.
+		On(
+			"Execute",
+			ctx,
+			&ftp.DownloadRepos{Logger: logger, Connection: ftpConnMock, FileStore: fileStoreMock},
+			&ftp.DownloadInput{
+				RemotePath: remotePath + "/report.conflict-20220115-1000.txt",
+				Path:       filepath.Join(localPath, "report.conflict-20220115-1000.txt"),
+			},
+		).
+		Return(&ftp.DownloadOutput{}, nil).
+		Once()
+
+	uploadUseCaseMock := useCaseMocks.NewUploadFileUseCase(t)
+	uploadUseCaseMock.
+		On("Execute", ctx, &ftp.UploadFileRepos{Logger: logger, Connection: ftpConnMock}, mock.AnythingOfType("*ftp.UploadFileInput")).
+		Return(&ftp.UploadFileOutput{}, nil).
+		Once()
+
+	fileStoreMock.
+		On("Remove", filepath.Join(localPath, "old")).
+		Return(nil).
+		Once()
+
+	newState := &entities.SyncState{Entries: map[string]*entities.SyncStateEntry{}}
+	snapshotUseCaseMock := useCaseMocks.NewSyncSnapshotUseCase(t)
+	snapshotUseCaseMock.
+		On(
+			"Execute",
+			ctx,
+			&ftp.SyncSnapshotRepos{Logger: logger, Connection: ftpConnMock, Filesystem: filesystem},
+			&ftp.SyncSnapshotInput{
+				Path:       "home/user01/site",
+				RemotePath: remotePath,
+				Previous:   previousState,
+			},
+		).
+		Return(newState, nil).
+		Once()
+
+	fileStoreMock.
+		On("SaveFile", filepath.Join(localPath, entities.SyncStateFileName), []byte("{\n  \"entries\": {}\n}")).
+		Return(nil).
+		Once()
+
+	deps := &sync.Dependencies{
+		Connector:         connMock,
+		Filesystem:        filesystem,
+		FileStore:         fileStoreMock,
+		TwoWayPlanUseCase: planUseCaseMock,
+		SnapshotUseCase:   snapshotUseCaseMock,
+		UploadUseCase:     uploadUseCaseMock,
+		DownloadUseCase:   downloadUseCaseMock,
+		MoveUseCase:       moveUseCaseMock,
+	}
+	input := &sync.CmdSyncInput{
+		Config:         config,
+		Path:           localPath,
+		RemotePath:     remotePath,
+		TwoWay:         true,
+		ConflictPolicy: entities.SyncConflictPolicyKeepBoth,
+	}
+
+	err = sync.PerformSync(ctx, logger, deps, input)
+	assert.NoError(t, err)
+}
+
+func Test_PerformSync_TwoWay_ActionError(t *testing.T) {
+	ctx := context.Background()
+
+	logger := assertlogging.NewLogger(t)
+	logger.
+		ExpectInfo("synchronising entry").
+		WithField("action", assertlogging.Equal("rename remote")).
+		WithField("path", assertlogging.Equal("report.txt")).
+		WithField("reason", assertlogging.Equal("conflict"))
+
+	ftpConnMock := connectionMocks.NewConnection(t)
+	ftpConnMock.On("Stop").Return(nil).Once()
+
+	connMock := ftpclientMocks.NewConnector(t)
+	connMock.
+		On("Connect", ctx, config).
+		Return(ftpConnMock, nil).
+		Once()
+
+	planUseCaseMock := useCaseMocks.NewTwoWaySyncPlanUseCase(t)
+	planUseCaseMock.
+		On("Execute", ctx, mock.AnythingOfType("*ftp.TwoWaySyncPlanRepos"), mock.AnythingOfType("*ftp.TwoWaySyncPlanInput")).
+		Return(twoWayActions, nil).
+		Once()
+
+	moveUseCaseMock := useCaseMocks.NewMoveUseCase(t)
+	moveUseCaseMock.
+		On("Execute", ctx, mock.AnythingOfType("*ftp.MoveRepos"), mock.AnythingOfType("*ftp.MoveInput")).
+		Return(errors.New("mock error")).
+		Once()
+
+	snapshotUseCaseMock := useCaseMocks.NewSyncSnapshotUseCase(t)
+	snapshotUseCaseMock.
+		On("Execute", ctx, mock.AnythingOfType("*ftp.SyncSnapshotRepos"), mock.MatchedBy(func(input *ftp.SyncSnapshotInput) bool {
+			return assert.Equal(t, []string{
+				"report.txt",
+				"report.conflict-20220115-1000.txt",
+				"report.conflict-20220115-1000.txt",
+				"report.txt",
+				"old",
+			}, input.Pending)
+		})).
+		Return(&entities.SyncState{}, nil).
+		Once()
+
+	fileStoreMock := repositoriesMocks.NewFileStore(t)
+	fileStoreMock.
+		On("SaveFile", filepath.Join(localPath, entities.SyncStateFileName), mock.Anything).
+		Return(nil).
+		Once()
+
+	deps := &sync.Dependencies{
+		Connector:         connMock,
+		Filesystem:        fstest.MapFS{},
+		FileStore:         fileStoreMock,
+		TwoWayPlanUseCase: planUseCaseMock,
+		SnapshotUseCase:   snapshotUseCaseMock,
+		MoveUseCase:       moveUseCaseMock,
+	}
+	input := &sync.CmdSyncInput{
+		Config:         config,
+		Path:           localPath,
+		RemotePath:     remotePath,
+		TwoWay:         true,
+		ConflictPolicy: entities.SyncConflictPolicyKeepBoth,
+	}
+
+	err := sync.PerformSync(ctx, logger, deps, input)
+	require.EqualError(t, err, "mock error")
+}
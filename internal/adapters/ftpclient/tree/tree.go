@@ -0,0 +1,131 @@
+package tree
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/alexZaicev/go-ftp-client/internal/adapters/ftpclient"
+	"github.com/alexZaicev/go-ftp-client/internal/domain/connection"
+	"github.com/alexZaicev/go-ftp-client/internal/domain/entities"
+	ftperrors "github.com/alexZaicev/go-ftp-client/internal/domain/errors"
+	"github.com/alexZaicev/go-ftp-client/internal/drivers/logging"
+	useCase "github.com/alexZaicev/go-ftp-client/internal/usecases/ftp"
+)
+
+const (
+	branchPrefix     = "├── "
+	lastBranchPrefix = "└── "
+	indentPrefix     = "│   "
+	lastIndentPrefix = "    "
+)
+
+type CmdTreeInput struct {
+	Config ftpclient.ConnectorConfig
+	Path   string
+	// Literal takes Path as is, without unescaping backslashes.
+	Literal bool
+	ShowAll bool
+	// Depth limits how many levels of directories are listed, where 1 only lists the directory at Path.
+	// Directories are listed to the bottom if it is not set.
+	Depth int
+}
+
+type Dependencies struct {
+	Connector ftpclient.Connector
+	UseCase   useCase.WalkUseCase
+	OutWriter io.Writer
+}
+
+// treeRenderer renders walked directories as a tree, once the whole tree is walked.
+type treeRenderer struct {
+	writer io.Writer
+	// dirs are entries of walked directories keyed by their paths
+	dirs  map[string][]*entities.Entry
+	files int
+	// subdirs is the number of directories below the root
+	subdirs int
+}
+
+func PerformTree(ctx context.Context, logger logging.Logger, deps *Dependencies, input *CmdTreeInput) (err error) {
+	conn, err := deps.Connector.Connect(ctx, input.Config)
+	if err != nil {
+		logger.WithError(err).Error("failed to connect to server")
+		return err
+	}
+	defer func(conn connection.Connection) {
+		if stopErr := conn.Stop(); stopErr != nil {
+			logger.WithError(stopErr).Error("failed to stop server connection")
+			err = stopErr
+		}
+	}(conn)
+
+	renderer := &treeRenderer{
+		writer: deps.OutWriter,
+		dirs:   make(map[string][]*entities.Entry),
+	}
+	rootPath := ""
+
+	useCaseRepos := &useCase.WalkRepos{
+		Logger:     logger,
+		Connection: conn,
+	}
+	useCaseInput := &useCase.WalkInput{
+		Path:     input.Path,
+		Literal:  input.Literal,
+		ShowAll:  input.ShowAll,
+		SortType: entities.SortTypeName,
+		MaxDepth: input.Depth,
+		Visit: func(dir *useCase.WalkedDir) error {
+			if dir.Depth == 0 {
+				rootPath = dir.Path
+			}
+			renderer.dirs[dir.Path] = dir.Entries
+			return nil
+		},
+	}
+
+	if err = deps.UseCase.Execute(ctx, useCaseRepos, useCaseInput); err != nil {
+		return err
+	}
+
+	return renderer.render(rootPath)
+}
+
+func (r *treeRenderer) render(rootPath string) error {
+	var sb strings.Builder
+	sb.WriteString(rootPath + "\n")
+	r.renderDir(&sb, rootPath, "")
+	sb.WriteString(fmt.Sprintf("\n%d directories, %d files\n", r.subdirs, r.files))
+
+	if _, err := io.WriteString(r.writer, sb.String()); err != nil {
+		return ftperrors.NewInternalError("failed to write tree", err)
+	}
+	return nil
+}
+
+// renderDir method writes a line for every entry of the directory, followed by entries of its
+// subdirectories, where prefix is the indentation of the directory's branches.
+func (r *treeRenderer) renderDir(sb *strings.Builder, dirPath, prefix string) {
+	entries := r.dirs[dirPath]
+	for idx, entry := range entries {
+		branch, indent := branchPrefix, indentPrefix
+		if idx == len(entries)-1 {
+			branch, indent = lastBranchPrefix, lastIndentPrefix
+		}
+
+		name := entry.Name
+		if entry.Type == entities.EntryTypeLink && entry.LinkName != "" {
+			name = fmt.Sprintf("%s -> %s", entry.Name, entry.LinkName)
+		}
+		sb.WriteString(prefix + branch + name + "\n")
+
+		if entry.Type != entities.EntryTypeDir {
+			r.files++
+			continue
+		}
+		r.subdirs++
+		r.renderDir(sb, entities.RemotePath(dirPath).Join(entry.Name).String(), prefix+indent)
+	}
+}
@@ -0,0 +1,212 @@
+package tree_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/alexZaicev/go-ftp-client/internal/adapters/ftpclient"
+	"github.com/alexZaicev/go-ftp-client/internal/adapters/ftpclient/tree"
+	"github.com/alexZaicev/go-ftp-client/internal/domain/entities"
+	ftperrors "github.com/alexZaicev/go-ftp-client/internal/domain/errors"
+	"github.com/alexZaicev/go-ftp-client/internal/drivers/logging/assertlogging"
+	"github.com/alexZaicev/go-ftp-client/internal/usecases/ftp"
+	ftpclientMocks "github.com/alexZaicev/go-ftp-client/mocks/adapters/ftpclient"
+	connectionMocks "github.com/alexZaicev/go-ftp-client/mocks/domain/connection"
+	useCaseMocks "github.com/alexZaicev/go-ftp-client/mocks/usecases/ftp"
+)
+
+const (
+	address  = "10.0.0.1:21"
+	user     = "user01"
+	password = "pwd01"
+	timeout  = 5 * time.Second
+	path     = "/foo/bar/baz"
+)
+
+func Test_PerformTree_Success(t *testing.T) {
+	ctx := context.Background()
+
+	logger := assertlogging.NewLogger(t)
+
+	ftpConnMock := connectionMocks.NewConnection(t)
+	ftpConnMock.On("Stop").Return(nil).Once()
+
+	config := ftpclient.ConnectorConfig{
+		Address:  address,
+		User:     user,
+		Password: password,
+		Timeout:  timeout,
+	}
+	connMock := ftpclientMocks.NewConnector(t)
+	connMock.
+		On("Connect", ctx, config).
+		Return(ftpConnMock, nil).
+		Once()
+
+	useCaseRepos := &ftp.WalkRepos{
+		Logger:     logger,
+		Connection: ftpConnMock,
+	}
+	useCaseMock := useCaseMocks.NewWalkUseCase(t)
+	useCaseMock.
+		On(
+			"Execute",
+			ctx,
+			useCaseRepos,
+			mock.MatchedBy(func(input *ftp.WalkInput) bool {
+				return input.Path == path && input.ShowAll && input.SortType == entities.SortTypeName &&
+					input.MaxDepth == 3
+			}),
+		).
+		Run(func(args mock.Arguments) {
+			input := args.Get(2).(*ftp.WalkInput)
+			require.NoError(t, input.Visit(&ftp.WalkedDir{
+				Path:  path,
+				Depth: 0,
+				Entries: []*entities.Entry{
+					{Type: entities.EntryTypeDir, Name: "dir-1"},
+					{Type: entities.EntryTypeDir, Name: "dir-2"},
+					{Type: entities.EntryTypeFile, Name: "file-1"},
+					{Type: entities.EntryTypeLink, Name: "link-1", LinkName: "/data"},
+				},
+			}))
+			require.NoError(t, input.Visit(&ftp.WalkedDir{
+				Path:  path + "/dir-1",
+				Depth: 1,
+				Entries: []*entities.Entry{
+					{Type: entities.EntryTypeDir, Name: "dir-3"},
+					{Type: entities.EntryTypeFile, Name: "file-2"},
+				},
+			}))
+			require.NoError(t, input.Visit(&ftp.WalkedDir{
+				Path:  path + "/dir-1/dir-3",
+				Depth: 2,
+				Entries: []*entities.Entry{
+					{Type: entities.EntryTypeFile, Name: "file-3"},
+				},
+			}))
+			require.NoError(t, input.Visit(&ftp.WalkedDir{
+				Path:  path + "/dir-2",
+				Depth: 1,
+			}))
+		}).
+		Return(nil).
+		Once()
+
+	buffer := bytes.NewBufferString("")
+
+	deps := &tree.Dependencies{
+		Connector: connMock,
+		UseCase:   useCaseMock,
+		OutWriter: buffer,
+	}
+	input := &tree.CmdTreeInput{
+		Config:  config,
+		Path:    path,
+		ShowAll: true,
+		Depth:   3,
+	}
+
+	expectedTree := `/foo/bar/baz
+├── dir-1
+│   ├── dir-3
+│   │   └── file-3
+│   └── file-2
+├── dir-2
+├── file-1
+└── link-1 -> /data
+
+3 directories, 4 files
+`
+
+	err := tree.PerformTree(ctx, logger, deps, input)
+	assert.NoError(t, err)
+	assert.Equal(t, expectedTree, buffer.String())
+}
+
+func Test_PerformTree_ConnectError(t *testing.T) {
+	ctx := context.Background()
+
+	logger := assertlogging.NewLogger(t)
+	logger.
+		ExpectError("failed to connect to server").
+		WithError(assertlogging.EqualError("mock error"))
+
+	config := ftpclient.ConnectorConfig{
+		Address:  address,
+		User:     user,
+		Password: password,
+		Timeout:  timeout,
+	}
+	connMock := ftpclientMocks.NewConnector(t)
+	connMock.
+		On("Connect", ctx, config).
+		Return(nil, errors.New("mock error")).
+		Once()
+
+	deps := &tree.Dependencies{
+		Connector: connMock,
+		UseCase:   useCaseMocks.NewWalkUseCase(t),
+		OutWriter: bytes.NewBufferString(""),
+	}
+	input := &tree.CmdTreeInput{
+		Config: config,
+		Path:   path,
+	}
+
+	err := tree.PerformTree(ctx, logger, deps, input)
+	require.EqualError(t, err, "mock error")
+	assert.NoError(t, errors.Unwrap(err))
+}
+
+func Test_PerformTree_UseCaseError(t *testing.T) {
+	ctx := context.Background()
+
+	logger := assertlogging.NewLogger(t)
+
+	ftpConnMock := connectionMocks.NewConnection(t)
+	ftpConnMock.On("Stop").Return(nil).Once()
+
+	config := ftpclient.ConnectorConfig{
+		Address:  address,
+		User:     user,
+		Password: password,
+		Timeout:  timeout,
+	}
+	connMock := ftpclientMocks.NewConnector(t)
+	connMock.
+		On("Connect", ctx, config).
+		Return(ftpConnMock, nil).
+		Once()
+
+	useCaseMock := useCaseMocks.NewWalkUseCase(t)
+	useCaseMock.
+		On("Execute", ctx, mock.Anything, mock.Anything).
+		Return(ftperrors.NewInternalError("failed to list directory", nil)).
+		Once()
+
+	buffer := bytes.NewBufferString("")
+
+	deps := &tree.Dependencies{
+		Connector: connMock,
+		UseCase:   useCaseMock,
+		OutWriter: buffer,
+	}
+	input := &tree.CmdTreeInput{
+		Config: config,
+		Path:   path,
+	}
+
+	err := tree.PerformTree(ctx, logger, deps, input)
+	require.EqualError(t, err, "an internal error occurred: failed to list directory")
+	assert.IsType(t, ftperrors.InternalErrorType, err)
+	assert.NoError(t, errors.Unwrap(err))
+	assert.Empty(t, buffer.String())
+}
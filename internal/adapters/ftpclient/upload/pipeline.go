@@ -0,0 +1,240 @@
+package upload
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"sync"
+
+	"github.com/alexZaicev/go-ftp-client/internal/adapters/ftpclient"
+	"github.com/alexZaicev/go-ftp-client/internal/domain/connection"
+	"github.com/alexZaicev/go-ftp-client/internal/domain/entities"
+	"github.com/alexZaicev/go-ftp-client/internal/domain/errors"
+	"github.com/alexZaicev/go-ftp-client/internal/drivers/logging"
+	"github.com/alexZaicev/go-ftp-client/internal/usecases/ftp"
+)
+
+// uploadPipeline uploads files handed out by a producer to workers, each of which uploads over its own
+// connection. A file is opened just before it is transferred and closed right after, so the number of
+// open files never exceeds the number of workers. The first error stops the producer, while files that
+// are being transferred by other workers are finished. Files that fail are recorded instead if the upload
+// continues on errors, in which case only connection errors stop the pipeline.
+type uploadPipeline struct {
+	logger   logging.Logger
+	deps     *Dependencies
+	input    *CmdUploadInput
+	files    []*fileToUpload
+	progress *ftpclient.ProgressBars
+	observer ftp.TransferObserver
+	// skipped and results are indexed by files, so that the summary keeps their order regardless of the
+	// workers.
+	skipped []*entities.SkippedTransfer
+	results []*entities.TransferResult
+
+	mu sync.Mutex
+	// createdDirs are remote directories that were created, each directory of the tree is created once,
+	// before the first file that is uploaded into it
+	createdDirs map[string]bool
+	err         error
+	stop        chan struct{}
+	stopOnce    sync.Once
+}
+
+func newUploadPipeline(
+	logger logging.Logger,
+	deps *Dependencies,
+	input *CmdUploadInput,
+	files []*fileToUpload,
+) *uploadPipeline {
+	return &uploadPipeline{
+		logger:      logger,
+		deps:        deps,
+		input:       input,
+		files:       files,
+		skipped:     make([]*entities.SkippedTransfer, len(files)),
+		results:     make([]*entities.TransferResult, len(files)),
+		createdDirs: make(map[string]bool),
+		stop:        make(chan struct{}),
+	}
+}
+
+// run method uploads the files and returns those that were skipped, as they already exist on the server,
+// along with the outcome of every file.
+func (p *uploadPipeline) run(ctx context.Context) ([]*entities.SkippedTransfer, []*entities.TransferResult, error) {
+	// FIXME: add ability to write to progress bar writer, so that logs would be visible during the upload
+	p.progress = ftpclient.NewProgressBars(p.deps.ProgressWriter, "Uploading")
+	p.observer = ftp.TransferObservers{&ftpclient.TransferLogger{Logger: p.logger}, p.progress}
+
+	jobs := make(chan int)
+	go p.produce(jobs)
+
+	var wg sync.WaitGroup
+	for i := 0; i < p.workers(); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := p.work(ctx, jobs); err != nil {
+				p.fail(err)
+			}
+		}()
+	}
+	wg.Wait()
+	p.progress.Wait()
+
+	if p.err != nil {
+		return nil, nil, p.err
+	}
+
+	var skipped []*entities.SkippedTransfer
+	for _, skippedTransfer := range p.skipped {
+		if skippedTransfer != nil {
+			skipped = append(skipped, skippedTransfer)
+		}
+	}
+	return skipped, p.results, nil
+}
+
+// workers method returns the number of workers, which is at least one, so that the connection is checked
+// even if there is nothing to upload, and at most one per file.
+func (p *uploadPipeline) workers() int {
+	workers := p.input.Parallel
+	if workers > len(p.files) {
+		workers = len(p.files)
+	}
+	if workers < 1 {
+		workers = 1
+	}
+	return workers
+}
+
+// produce method hands out indexes of the files to workers until all files are handed out or the
+// pipeline is stopped.
+func (p *uploadPipeline) produce(jobs chan<- int) {
+	defer close(jobs)
+	for idx := range p.files {
+		select {
+		case jobs <- idx:
+		case <-p.stop:
+			return
+		}
+	}
+}
+
+// fail method records the first error and stops the pipeline.
+func (p *uploadPipeline) fail(err error) {
+	p.mu.Lock()
+	if p.err == nil {
+		p.err = err
+	}
+	p.mu.Unlock()
+
+	p.stopOnce.Do(func() {
+		close(p.stop)
+	})
+}
+
+// work method connects to the server and uploads files handed out by the producer.
+func (p *uploadPipeline) work(ctx context.Context, jobs <-chan int) (err error) {
+	conn, err := p.deps.Connector.Connect(ctx, p.input.Config)
+	if err != nil {
+		p.logger.WithError(err).Error("failed to connect to server")
+		return err
+	}
+	defer func(conn connection.Connection) {
+		if stopErr := conn.Stop(); stopErr != nil {
+			p.logger.WithError(stopErr).Error("failed to stop server connection")
+			err = stopErr
+		}
+	}(conn)
+
+	for idx := range jobs {
+		select {
+		case <-p.stop:
+			return nil
+		default:
+		}
+		if uploadErr := p.upload(ctx, conn, idx); uploadErr != nil {
+			if !p.input.ContinueOnError {
+				return uploadErr
+			}
+			p.results[idx] = entities.NewTransferFailed(p.files[idx].path, uploadErr)
+		}
+	}
+	return nil
+}
+
+func (p *uploadPipeline) upload(ctx context.Context, conn connection.Connection, idx int) error {
+	ftu := p.files[idx]
+	remoteFilePath := entities.RemotePath(p.input.RemoteFilePath).
+		Join(filepath.ToSlash(ftu.path[len(p.input.FilePath):])).
+		String()
+
+	if mkdirErr := p.createDir(ctx, conn, entities.RemotePath(remoteFilePath).Dir()); mkdirErr != nil {
+		return mkdirErr
+	}
+
+	reader, err := p.deps.Filesystem.Open(ftu.fsPath)
+	if err != nil {
+		return errors.NewInternalError("failed to open file", err)
+	}
+	defer func() {
+		if closeErr := reader.Close(); closeErr != nil {
+			p.logger.WithError(closeErr).Warn(fmt.Sprintf("failed to close file %s", ftu.path))
+		}
+	}()
+
+	uploadUseCaseRepos := &ftp.UploadFileRepos{
+		Logger:     p.logger,
+		Connection: conn,
+		Observer:   p.observer,
+	}
+
+	uploadUseCaseInput := &ftp.UploadFileInput{
+		FileReader:  reader,
+		RemotePath:  remoteFilePath,
+		SizeInBytes: uint64(ftu.sizeInBytes),
+		ModTime:     ftu.modTime,
+		IfExists:    p.input.IfExists,
+		Atomic:      p.input.Atomic,
+	}
+
+	output, err := p.deps.UploadUseCase.Execute(ctx, uploadUseCaseRepos, uploadUseCaseInput)
+	if err != nil {
+		return err
+	}
+	if output.Skipped != nil {
+		p.skipped[idx] = output.Skipped
+		p.results[idx] = entities.NewTransferSkipped(output.Skipped)
+		return nil
+	}
+	p.results[idx] = entities.NewTransferOK(ftu.path)
+	return nil
+}
+
+// createDir method creates the remote directory, unless it was created already. Root and working
+// directory always exist.
+func (p *uploadPipeline) createDir(ctx context.Context, conn connection.Connection, dirPath entities.RemotePath) error {
+	if dirPath == "" || dirPath.IsRoot() {
+		return nil
+	}
+
+	// directory is created while holding the lock, so that workers do not create it at the same time
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.createdDirs[dirPath.String()] {
+		return nil
+	}
+
+	mkdirUseCaseInput := &ftp.MkdirInput{
+		Path: dirPath.String(),
+	}
+	mkdirUseCaseRepos := &ftp.MkdirRepos{
+		Logger:     p.logger,
+		Connection: conn,
+	}
+	if err := p.deps.MkdirUseCase.Execute(ctx, mkdirUseCaseRepos, mkdirUseCaseInput); err != nil {
+		return err
+	}
+	p.createdDirs[dirPath.String()] = true
+	return nil
+}
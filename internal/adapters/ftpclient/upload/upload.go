@@ -5,122 +5,120 @@ import (
 	"fmt"
 	"io"
 	"io/fs"
-	"path/filepath"
 	"strings"
-
-	"github.com/vbauerster/mpb/v8"
-	"github.com/vbauerster/mpb/v8/decor"
+	"time"
 
 	"github.com/alexZaicev/go-ftp-client/internal/adapters/ftpclient"
-	"github.com/alexZaicev/go-ftp-client/internal/domain/connection"
+	"github.com/alexZaicev/go-ftp-client/internal/domain/entities"
 	"github.com/alexZaicev/go-ftp-client/internal/domain/errors"
+	"github.com/alexZaicev/go-ftp-client/internal/domain/repositories"
+	"github.com/alexZaicev/go-ftp-client/internal/drivers/cli/models"
 	"github.com/alexZaicev/go-ftp-client/internal/drivers/logging"
 	"github.com/alexZaicev/go-ftp-client/internal/usecases/ftp"
 )
 
-const (
-	progressBarWidth = 64
-)
-
 type CmdUploadInput struct {
 	Config         ftpclient.ConnectorConfig
 	FilePath       string
 	RemoteFilePath string
 	Recursive      bool
+	// Filter selects files of recursively uploaded directories, if provided.
+	Filter *entities.FilterOptions
+	// IfExists decides what happens to files that already exist on the server.
+	IfExists entities.OverwritePolicy
+	// Atomic uploads files under temporary names and renames them into place once verified, if provided.
+	Atomic *entities.AtomicUploadOptions
+	// Links decides what happens to symbolic links of recursively uploaded directories.
+	Links entities.LinkPolicy
+	// Parallel is the number of files uploaded at once, each over its own connection. Files are uploaded
+	// one by one if it is not set.
+	Parallel int
+	// ContinueOnError records files that fail to be uploaded and carries on, printing a summary of
+	// skipped and failed files at the end.
+	ContinueOnError bool
+	// ReportPath is the local path of the JSON report listing the outcome of every file, if provided.
+	ReportPath string
+	// Output is the format the outcome of every file is written in, only skipped and failed files are
+	// summarized if it is not set.
+	Output models.OutputFormat
 }
 
 type Dependencies struct {
 	Connector     ftpclient.Connector
 	Filesystem    fs.FS
+	FileStore     repositories.FileStore
 	UploadUseCase ftp.UploadFileUseCase
 	MkdirUseCase  ftp.MkdirUseCase
+	OutWriter     io.Writer
+	// ProgressWriter is where progress bars are drawn, they are not drawn if it is nil.
+	ProgressWriter io.Writer
 }
 
+// fileToUpload is a local file found by Stat, which is opened just before it is transferred.
 type fileToUpload struct {
-	reader      fs.File
 	sizeInBytes int64
-	name        string
-	path        string
+	modTime     time.Time
+	// path is the local path of the file, while fsPath is its path within the filesystem.
+	path   string
+	fsPath string
 }
 
-func PerformUploadFile(ctx context.Context, logger logging.Logger, deps *Dependencies, input *CmdUploadInput) (err error) {
-	filesToUpload, err := getFilesToUpload(deps.Filesystem, input.FilePath, input.Recursive)
+func PerformUploadFile(ctx context.Context, logger logging.Logger, deps *Dependencies, input *CmdUploadInput) error {
+	filter, err := ftp.NewPathFilter(input.Filter)
 	if err != nil {
 		return err
 	}
 
-	conn, err := deps.Connector.Connect(ctx, input.Config)
+	filesToUpload, skipped, err := getFilesToUpload(logger, deps.Filesystem, input, filter)
 	if err != nil {
-		logger.WithError(err).Error("failed to connect to server")
 		return err
 	}
-	defer func(conn connection.Connection) {
-		if stopErr := conn.Stop(); stopErr != nil {
-			logger.WithError(stopErr).Error("failed to stop server connection")
-			err = stopErr
-		}
-	}(conn)
 
-	// FIXME: add a record of what directories have been created to avoid unnecessary calls
+	var totalSizeInBytes uint64
 	for _, ftu := range filesToUpload {
-		remoteFilePath := filepath.Join(input.RemoteFilePath, ftu.path[len(input.FilePath):])
-
-		dirPath, _ := filepath.Split(remoteFilePath)
-		if strings.HasSuffix(dirPath, string(filepath.Separator)) {
-			dirPath = dirPath[:len(dirPath)-1]
-		}
-
-		if dirPath != "" {
-			mkdirUseCaseInput := &ftp.MkdirInput{
-				Path: dirPath,
-			}
-			mkdirUseCaseRepos := &ftp.MkdirRepos{
-				Logger:     logger,
-				Connection: conn,
-			}
-
-			if mkdirErr := deps.MkdirUseCase.Execute(ctx, mkdirUseCaseRepos, mkdirUseCaseInput); mkdirErr != nil {
-				return mkdirErr
-			}
-		}
-
-		// FIXME: add ability to write to progress bar writer, so that logs would be visible during the upload
-		p := mpb.New(mpb.WithWidth(progressBarWidth))
-		bar := p.New(
-			ftu.sizeInBytes,
-			mpb.BarStyle().Lbound("[").Filler("=").Tip(">").Padding("-").Rbound("]"),
-			mpb.PrependDecorators(
-				// display our name with one space on the right
-				decor.Name(fmt.Sprintf("Uploading %s", ftu.name)),
-			),
-			mpb.AppendDecorators(decor.Percentage()),
-		)
-
-		cw := &ftpclient.CallbackWriter{
-			Callback: func(bytesRead int64) {
-				bar.IncrInt64(bytesRead)
-			},
-		}
+		totalSizeInBytes += uint64(ftu.sizeInBytes)
+	}
+	logger.Info(fmt.Sprintf(
+		"uploading %d file(s), %s",
+		len(filesToUpload),
+		ftpclient.FormatSizeInBytes(totalSizeInBytes),
+	))
+
+	results := make([]*entities.TransferResult, 0, len(skipped)+len(filesToUpload))
+	for _, skippedTransfer := range skipped {
+		results = append(results, entities.NewTransferSkipped(skippedTransfer))
+	}
 
-		uploadUseCaseRepos := &ftp.UploadFileRepos{
-			Logger:     logger,
-			Connection: conn,
+	pipeline := newUploadPipeline(logger, deps, input, filesToUpload)
+	uploadSkipped, uploadResults, err := pipeline.run(ctx)
+	if err != nil {
+		return err
+	}
+	skipped = append(skipped, uploadSkipped...)
+	results = append(results, uploadResults...)
+
+	if input.ReportPath != "" {
+		if reportErr := ftpclient.SaveTransferReport(
+			logger, deps.FileStore, input.ReportPath, results,
+		); reportErr != nil {
+			return reportErr
 		}
+	}
 
-		uploadUseCaseInput := &ftp.UploadFileInput{
-			FileReader:  io.TeeReader(ftu.reader, cw),
-			RemotePath:  remoteFilePath,
-			SizeInBytes: uint64(ftu.sizeInBytes),
+	if input.Output.IsMachineReadable() {
+		if writeErr := ftpclient.WriteTransferResults(deps.OutWriter, input.Output, results); writeErr != nil {
+			return writeErr
 		}
-
-		if uploadErr := deps.UploadUseCase.Execute(ctx, uploadUseCaseRepos, uploadUseCaseInput); uploadErr != nil {
-			return uploadErr
+	} else if input.ContinueOnError {
+		if writeErr := ftpclient.WriteTransferSummary(deps.OutWriter, results); writeErr != nil {
+			return writeErr
 		}
-		p.Wait()
+	} else if writeErr := ftpclient.WriteSkippedSummary(deps.OutWriter, skipped); writeErr != nil {
+		return writeErr
+	}
 
-		if closeErr := ftu.reader.Close(); closeErr != nil {
-			logger.WithError(closeErr).Warn(fmt.Sprintf("failed to close file %s", ftu.path))
-		}
+	if transferErr := ftpclient.TransferError(results); transferErr != nil {
+		return transferErr
 	}
 
 	logger.Info("OK!")
@@ -128,71 +126,51 @@ func PerformUploadFile(ctx context.Context, logger logging.Logger, deps *Depende
 	return nil
 }
 
-func getFilesToUpload(filesystem fs.FS, filePath string, recursive bool) ([]*fileToUpload, error) {
-	inputFile, err := filesystem.Open(trimLeadingSlash(filePath))
+func getFilesToUpload(
+	logger logging.Logger,
+	filesystem fs.FS,
+	input *CmdUploadInput,
+	filter *ftp.PathFilter,
+) ([]*fileToUpload, []*entities.SkippedTransfer, error) {
+	fsPath := trimLeadingSlash(input.FilePath)
+	inputFileInfo, err := fs.Stat(filesystem, fsPath)
 	if err != nil {
-		return nil, errors.NewInternalError("failed to open file", err)
-	}
-	inputFileInfo, err := inputFile.Stat()
-	if err != nil {
-		return nil, errors.NewInternalError("failed to get file information", err)
+		return nil, nil, errors.NewInternalError("failed to get file information", err)
 	}
 
-	var filesToUpload []*fileToUpload
-	if recursive {
-		if !inputFileInfo.Mode().IsDir() {
-			return nil, errors.NewInternalError("path is not a directory", nil)
-		}
-		filesToUploadSlice, getErr := getFilesToUploadRecursively(filesystem, filePath)
-		if getErr != nil {
-			return nil, getErr
-		}
-		filesToUpload = filesToUploadSlice
-	} else {
+	if !input.Recursive {
 		if !inputFileInfo.Mode().IsRegular() {
-			return nil, errors.NewInternalError("path is not a regular file", nil)
+			return nil, nil, errors.NewInternalError("path is not a regular file", nil)
 		}
-		filesToUpload = append(filesToUpload, &fileToUpload{
-			reader:      inputFile,
-			sizeInBytes: inputFileInfo.Size(),
-			name:        inputFileInfo.Name(),
-			path:        filePath,
-		})
+		return []*fileToUpload{
+			{
+				sizeInBytes: inputFileInfo.Size(),
+				modTime:     inputFileInfo.ModTime(),
+				path:        input.FilePath,
+				fsPath:      fsPath,
+			},
+		}, nil, nil
 	}
-	return filesToUpload, nil
-}
 
-func getFilesToUploadRecursively(filesystem fs.FS, path string) ([]*fileToUpload, error) {
-	var filePaths []*fileToUpload
-	if err := filepath.Walk(path, func(path string, info fs.FileInfo, err error) error {
-		if err != nil {
-			return err
-		}
-		if info.IsDir() {
-			return nil
-		}
-
-		reader, err := filesystem.Open(trimLeadingSlash(path))
-		if err != nil {
-			return errors.NewInternalError("failed to open file", err)
-		}
+	if !inputFileInfo.Mode().IsDir() {
+		return nil, nil, errors.NewInternalError("path is not a directory", nil)
+	}
 
-		filePaths = append(filePaths, &fileToUpload{
-			reader:      reader,
-			sizeInBytes: info.Size(),
-			name:        info.Name(),
-			path:        path,
-		})
-		return nil
-	}); err != nil {
-		return nil, errors.NewInternalError("failed to walk directory", err)
+	walker := &fileWalker{
+		logger:     logger,
+		filesystem: filesystem,
+		filter:     filter,
+		links:      input.Links,
+		root:       input.FilePath,
 	}
-	return filePaths, nil
+	return walker.collect()
 }
 
+// trimLeadingSlash function returns the path of the filesystem rooted at "/" for the local path.
 func trimLeadingSlash(path string) string {
-	if strings.HasPrefix(path, "/") {
-		return path[1:]
+	path = strings.TrimPrefix(path, "/")
+	if path == "" {
+		return "."
 	}
 	return path
 }
@@ -1,11 +1,16 @@
 package upload_test
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"io/fs"
+	"os"
 	"path/filepath"
+	"strings"
+	"sync"
 	"testing"
 	"testing/fstest"
 	"time"
@@ -16,7 +21,10 @@ import (
 
 	"github.com/alexZaicev/go-ftp-client/internal/adapters/ftpclient"
 	"github.com/alexZaicev/go-ftp-client/internal/adapters/ftpclient/upload"
+	"github.com/alexZaicev/go-ftp-client/internal/domain/connection"
+	"github.com/alexZaicev/go-ftp-client/internal/domain/entities"
 	ftperrors "github.com/alexZaicev/go-ftp-client/internal/domain/errors"
+	"github.com/alexZaicev/go-ftp-client/internal/drivers/logging"
 	"github.com/alexZaicev/go-ftp-client/internal/drivers/logging/assertlogging"
 	"github.com/alexZaicev/go-ftp-client/internal/usecases/ftp"
 	ftpclientMocks "github.com/alexZaicev/go-ftp-client/mocks/adapters/ftpclient"
@@ -41,6 +49,7 @@ func Test_PerformUploadFile_Success(t *testing.T) {
 	ctx := context.Background()
 
 	logger := assertlogging.NewLogger(t)
+	logger.ExpectInfo("uploading 1 file(s), 27 B")
 	logger.ExpectInfo("OK!")
 
 	ftpConnMock := connectionMocks.NewConnection(t)
@@ -73,10 +82,7 @@ func Test_PerformUploadFile_Success(t *testing.T) {
 		Return(nil).
 		Once()
 
-	uploadUseCaseRepos := &ftp.UploadFileRepos{
-		Logger:     logger,
-		Connection: ftpConnMock,
-	}
+	uploadUseCaseRepos := matchUploadFileRepos(logger, ftpConnMock)
 
 	uploadUseCaseMock := useCaseMocks.NewUploadFileUseCase(t)
 	uploadUseCaseMock.
@@ -86,9 +92,86 @@ func Test_PerformUploadFile_Success(t *testing.T) {
 			_, useCaseMockErr := args.Get(2).(*ftp.UploadFileInput).FileReader.Read(bytesToRead)
 			require.NoError(t, useCaseMockErr)
 		}).
+		Return(&ftp.UploadFileOutput{}, nil).
+		Once()
+
+	deps := &upload.Dependencies{
+		Filesystem: fstest.MapFS{
+			filePath: {Data: []byte("this is content of the file")},
+		},
+		Connector:     connMock,
+		UploadUseCase: uploadUseCaseMock,
+		MkdirUseCase:  mkdirUseCaseMock,
+	}
+	input := &upload.CmdUploadInput{
+		Config: ftpclient.ConnectorConfig{
+			Address:  address,
+			User:     user,
+			Password: password,
+			Verbose:  true,
+			Timeout:  timeout,
+		},
+		FilePath:       filePath,
+		RemoteFilePath: remoteFilePath,
+	}
+
+	err := upload.PerformUploadFile(ctx, logger, deps, input)
+	assert.NoError(t, err)
+}
+
+func Test_PerformUploadFile_Skipped_Success(t *testing.T) {
+	ctx := context.Background()
+
+	logger := assertlogging.NewLogger(t)
+	logger.ExpectInfo("uploading 1 file(s), 27 B")
+	logger.ExpectInfo("OK!")
+
+	ftpConnMock := connectionMocks.NewConnection(t)
+	ftpConnMock.On("Stop").Return(nil).Once()
+
+	config := ftpclient.ConnectorConfig{
+		Address:  address,
+		User:     user,
+		Password: password,
+		Verbose:  true,
+		Timeout:  timeout,
+	}
+	connMock := ftpclientMocks.NewConnector(t)
+	connMock.
+		On("Connect", ctx, config).
+		Return(ftpConnMock, nil).
+		Once()
+
+	mkdirUseCaseRepos := &ftp.MkdirRepos{
+		Logger:     logger,
+		Connection: ftpConnMock,
+	}
+	mkdirUseCaseInput := &ftp.MkdirInput{
+		Path: remotePath,
+	}
+
+	mkdirUseCaseMock := useCaseMocks.NewMkdirUseCase(t)
+	mkdirUseCaseMock.
+		On("Execute", ctx, mkdirUseCaseRepos, mkdirUseCaseInput).
 		Return(nil).
 		Once()
 
+	uploadUseCaseRepos := matchUploadFileRepos(logger, ftpConnMock)
+
+	uploadUseCaseMock := useCaseMocks.NewUploadFileUseCase(t)
+	uploadUseCaseMock.
+		On("Execute", ctx, uploadUseCaseRepos, mock.AnythingOfType("*ftp.UploadFileInput")).
+		Run(func(args mock.Arguments) {
+			assert.Equal(t, entities.OverwritePolicySkip, args.Get(2).(*ftp.UploadFileInput).IfExists)
+		}).
+		Return(&ftp.UploadFileOutput{
+			RemotePath: remoteFilePath,
+			Skipped:    &entities.SkippedTransfer{Path: remoteFilePath, Reason: "already exists"},
+		}, nil).
+		Once()
+
+	buffer := bytes.NewBufferString("")
+
 	deps := &upload.Dependencies{
 		Filesystem: fstest.MapFS{
 			filePath: {Data: []byte("this is content of the file")},
@@ -96,6 +179,7 @@ func Test_PerformUploadFile_Success(t *testing.T) {
 		Connector:     connMock,
 		UploadUseCase: uploadUseCaseMock,
 		MkdirUseCase:  mkdirUseCaseMock,
+		OutWriter:     buffer,
 	}
 	input := &upload.CmdUploadInput{
 		Config: ftpclient.ConnectorConfig{
@@ -107,16 +191,19 @@ func Test_PerformUploadFile_Success(t *testing.T) {
 		},
 		FilePath:       filePath,
 		RemoteFilePath: remoteFilePath,
+		IfExists:       entities.OverwritePolicySkip,
 	}
 
 	err := upload.PerformUploadFile(ctx, logger, deps, input)
 	assert.NoError(t, err)
+	assert.Equal(t, "Skipped 1 item(s):\n  /foo/bar/baz/file-1.txt (already exists)\n", buffer.String())
 }
 
 func Test_PerformUploadFile_Recursive_Success(t *testing.T) {
 	ctx := context.Background()
 
 	logger := assertlogging.NewLogger(t)
+	logger.ExpectInfo("uploading 6 file(s), 162 B")
 	logger.ExpectInfo("OK!")
 
 	ftpConnMock := connectionMocks.NewConnection(t)
@@ -146,25 +233,116 @@ func Test_PerformUploadFile_Recursive_Success(t *testing.T) {
 			Path: remotePath,
 		}).
 		Return(nil).
-		Twice()
+		Once()
 	mkdirUseCaseMock.
 		On("Execute", ctx, mkdirUseCaseRepos, &ftp.MkdirInput{
 			Path: fmt.Sprintf("%s/dir1", remotePath),
 		}).
 		Return(nil).
 		Once()
+	// directory is created once for all of its files
 	mkdirUseCaseMock.
 		On("Execute", ctx, mkdirUseCaseRepos, &ftp.MkdirInput{
 			Path: fmt.Sprintf("%s/dir2", remotePath),
 		}).
 		Return(nil).
-		Times(3)
+		Once()
+
+	uploadUseCaseRepos := matchUploadFileRepos(logger, ftpConnMock)
+
+	uploadUseCaseMock := useCaseMocks.NewUploadFileUseCase(t)
+	uploadUseCaseMock.
+		On("Execute", ctx, uploadUseCaseRepos, mock.AnythingOfType("*ftp.UploadFileInput")).
+		Run(func(args mock.Arguments) {
+			bytesToRead := make([]byte, 1024)
+			_, useCaseMockErr := args.Get(2).(*ftp.UploadFileInput).FileReader.Read(bytesToRead)
+			require.NoError(t, useCaseMockErr)
+		}).
+		Return(&ftp.UploadFileOutput{}, nil).
+		Times(6)
+
+	absFilePath, err := filepath.Abs(fmt.Sprintf("./%s", dirPath))
+	require.NoError(t, err)
+
+	fsabsFilePath := absFilePath[1:]
+	deps := &upload.Dependencies{
+		Filesystem: fstest.MapFS{
+			fsabsFilePath: {Mode: fs.ModeDir},
+			fmt.Sprintf("%s/file-1.txt", fsabsFilePath):      {Data: []byte("this is content of the file")},
+			fmt.Sprintf("%s/file-2.txt", fsabsFilePath):      {Data: []byte("this is content of the file")},
+			fmt.Sprintf("%s/dir1", fsabsFilePath):            {Mode: fs.ModeDir},
+			fmt.Sprintf("%s/dir1/file-1.txt", fsabsFilePath): {Data: []byte("this is content of the file")},
+			fmt.Sprintf("%s/dir2", fsabsFilePath):            {Mode: fs.ModeDir},
+			fmt.Sprintf("%s/dir2/file-1.txt", fsabsFilePath): {Data: []byte("this is content of the file")},
+			fmt.Sprintf("%s/dir2/file-2.txt", fsabsFilePath): {Data: []byte("this is content of the file")},
+			fmt.Sprintf("%s/dir2/file-3.txt", fsabsFilePath): {Data: []byte("this is content of the file")},
+		},
+		Connector:     connMock,
+		UploadUseCase: uploadUseCaseMock,
+		MkdirUseCase:  mkdirUseCaseMock,
+	}
+
+	input := &upload.CmdUploadInput{
+		Config: ftpclient.ConnectorConfig{
+			Address:  address,
+			User:     user,
+			Password: password,
+			Verbose:  true,
+			Timeout:  timeout,
+		},
+		FilePath:       absFilePath,
+		RemoteFilePath: remotePath,
+		Recursive:      true,
+	}
+
+	err = upload.PerformUploadFile(ctx, logger, deps, input)
+	assert.NoError(t, err)
+}
+
+func Test_PerformUploadFile_Recursive_Filter_Success(t *testing.T) {
+	ctx := context.Background()
+
+	logger := assertlogging.NewLogger(t)
+	logger.ExpectInfo("uploading 2 file(s), 54 B")
+	logger.ExpectInfo("OK!")
+
+	ftpConnMock := connectionMocks.NewConnection(t)
+	ftpConnMock.On("Stop").Return(nil).Once()
+
+	config := ftpclient.ConnectorConfig{
+		Address:  address,
+		User:     user,
+		Password: password,
+		Verbose:  true,
+		Timeout:  timeout,
+	}
+	connMock := ftpclientMocks.NewConnector(t)
+	connMock.
+		On("Connect", ctx, config).
+		Return(ftpConnMock, nil).
+		Once()
 
-	uploadUseCaseRepos := &ftp.UploadFileRepos{
+	mkdirUseCaseRepos := &ftp.MkdirRepos{
 		Logger:     logger,
 		Connection: ftpConnMock,
 	}
 
+	mkdirUseCaseMock := useCaseMocks.NewMkdirUseCase(t)
+	mkdirUseCaseMock.
+		On("Execute", ctx, mkdirUseCaseRepos, &ftp.MkdirInput{
+			Path: remotePath,
+		}).
+		Return(nil).
+		Once()
+	mkdirUseCaseMock.
+		On("Execute", ctx, mkdirUseCaseRepos, &ftp.MkdirInput{
+			Path: fmt.Sprintf("%s/dir1", remotePath),
+		}).
+		Return(nil).
+		Once()
+
+	uploadUseCaseRepos := matchUploadFileRepos(logger, ftpConnMock)
+
 	uploadUseCaseMock := useCaseMocks.NewUploadFileUseCase(t)
 	uploadUseCaseMock.
 		On("Execute", ctx, uploadUseCaseRepos, mock.AnythingOfType("*ftp.UploadFileInput")).
@@ -173,8 +351,8 @@ func Test_PerformUploadFile_Recursive_Success(t *testing.T) {
 			_, useCaseMockErr := args.Get(2).(*ftp.UploadFileInput).FileReader.Read(bytesToRead)
 			require.NoError(t, useCaseMockErr)
 		}).
-		Return(nil).
-		Times(6)
+		Return(&ftp.UploadFileOutput{}, nil).
+		Twice()
 
 	absFilePath, err := filepath.Abs(fmt.Sprintf("./%s", dirPath))
 	require.NoError(t, err)
@@ -208,13 +386,230 @@ func Test_PerformUploadFile_Recursive_Success(t *testing.T) {
 		FilePath:       absFilePath,
 		RemoteFilePath: remotePath,
 		Recursive:      true,
+		Filter: &entities.FilterOptions{
+			Exclude: []string{"dir2/", "file-2.txt"},
+		},
 	}
 
 	err = upload.PerformUploadFile(ctx, logger, deps, input)
 	assert.NoError(t, err)
 }
 
-func Test_PerformUploadFile_FileOpenError(t *testing.T) {
+//nolint:funlen // test case can get a bit large
+func Test_PerformUploadFile_Recursive_Links_Success(t *testing.T) {
+	absFilePath, err := filepath.Abs(fmt.Sprintf("./%s", dirPath))
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name                string
+		links               entities.LinkPolicy
+		expectedLogMsg      string
+		expectedRemotePaths []string
+		expectedDirs        []string
+		expectedSummary     string
+	}{
+		{
+			name:           "skip by default",
+			expectedLogMsg: "uploading 2 file(s), 54 B",
+			expectedRemotePaths: []string{
+				remotePath + "/dir1/file-1.txt",
+				remotePath + "/file-1.txt",
+			},
+			expectedDirs: []string{remotePath + "/dir1", remotePath},
+			expectedSummary: fmt.Sprintf(
+				"Skipped 3 item(s):\n  %[1]s/broken (symbolic link)\n  %[1]s/current.txt (symbolic link)\n"+
+					"  %[1]s/latest (symbolic link)\n",
+				absFilePath,
+			),
+		},
+		{
+			name:           "follow",
+			links:          entities.LinkPolicyFollow,
+			expectedLogMsg: "uploading 4 file(s), 108 B",
+			expectedRemotePaths: []string{
+				remotePath + "/current.txt",
+				remotePath + "/dir1/file-1.txt",
+				remotePath + "/file-1.txt",
+				remotePath + "/latest/file-1.txt",
+			},
+			expectedDirs:    []string{remotePath, remotePath + "/dir1", remotePath + "/latest"},
+			expectedSummary: fmt.Sprintf("Skipped 1 item(s):\n  %s/broken (link target not found)\n", absFilePath),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			logger := assertlogging.NewLogger(t)
+			logger.
+				ExpectWarn("skipped entry that is not a regular file").
+				WithField("path", assertlogging.Equal(absFilePath+"/sock"))
+			logger.ExpectInfo(tc.expectedLogMsg)
+			logger.ExpectInfo("OK!")
+
+			ftpConnMock := connectionMocks.NewConnection(t)
+			ftpConnMock.On("Stop").Return(nil).Once()
+
+			connMock := ftpclientMocks.NewConnector(t)
+			connMock.
+				On("Connect", ctx, mock.AnythingOfType("ftpclient.ConnectorConfig")).
+				Return(ftpConnMock, nil).
+				Once()
+
+			mkdirUseCaseRepos := &ftp.MkdirRepos{
+				Logger:     logger,
+				Connection: ftpConnMock,
+			}
+			mkdirUseCaseMock := useCaseMocks.NewMkdirUseCase(t)
+			for _, dir := range tc.expectedDirs {
+				mkdirUseCaseMock.
+					On("Execute", ctx, mkdirUseCaseRepos, &ftp.MkdirInput{Path: dir}).
+					Return(nil).
+					Once()
+			}
+
+			var remotePaths []string
+			uploadUseCaseMock := useCaseMocks.NewUploadFileUseCase(t)
+			uploadUseCaseMock.
+				On("Execute", ctx, mock.Anything, mock.AnythingOfType("*ftp.UploadFileInput")).
+				Run(func(args mock.Arguments) {
+					uploadInput := args.Get(2).(*ftp.UploadFileInput)
+					_, readErr := io.ReadAll(uploadInput.FileReader)
+					require.NoError(t, readErr)
+					remotePaths = append(remotePaths, uploadInput.RemotePath)
+				}).
+				Return(&ftp.UploadFileOutput{}, nil)
+
+			buffer := bytes.NewBufferString("")
+
+			fsabsFilePath := absFilePath[1:]
+			deps := &upload.Dependencies{
+				Filesystem: fstest.MapFS{
+					fsabsFilePath + "/file-1.txt":      {Data: []byte("this is content of the file")},
+					fsabsFilePath + "/dir1/file-1.txt": {Data: []byte("this is content of the file")},
+					fsabsFilePath + "/latest":          {Mode: fs.ModeSymlink, Data: []byte("dir1")},
+					fsabsFilePath + "/current.txt":     {Mode: fs.ModeSymlink, Data: []byte("file-1.txt")},
+					fsabsFilePath + "/broken":          {Mode: fs.ModeSymlink, Data: []byte("missing")},
+					fsabsFilePath + "/sock":            {Mode: fs.ModeSocket},
+				},
+				Connector:     connMock,
+				UploadUseCase: uploadUseCaseMock,
+				MkdirUseCase:  mkdirUseCaseMock,
+				OutWriter:     buffer,
+			}
+
+			input := &upload.CmdUploadInput{
+				FilePath:       absFilePath,
+				RemoteFilePath: remotePath,
+				Recursive:      true,
+				Links:          tc.links,
+			}
+
+			err := upload.PerformUploadFile(ctx, logger, deps, input)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedRemotePaths, remotePaths)
+			assert.Equal(t, tc.expectedSummary, buffer.String())
+		})
+	}
+}
+
+func Test_PerformUploadFile_Recursive_LinkLoop_Success(t *testing.T) {
+	ctx := context.Background()
+
+	root := t.TempDir()
+	require.NoError(t, os.Mkdir(filepath.Join(root, "dir1"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(root, "dir1", "file-1.txt"), []byte("content"), 0o600))
+	require.NoError(t, os.Symlink("..", filepath.Join(root, "dir1", "up")))
+
+	logger := assertlogging.NewLogger(t)
+	logger.ExpectInfo("uploading 1 file(s), 7 B")
+	logger.ExpectInfo("OK!")
+
+	ftpConnMock := connectionMocks.NewConnection(t)
+	ftpConnMock.On("Stop").Return(nil).Once()
+
+	connMock := ftpclientMocks.NewConnector(t)
+	connMock.
+		On("Connect", ctx, mock.AnythingOfType("ftpclient.ConnectorConfig")).
+		Return(ftpConnMock, nil).
+		Once()
+
+	mkdirUseCaseMock := useCaseMocks.NewMkdirUseCase(t)
+	mkdirUseCaseMock.
+		On("Execute", ctx, mock.Anything, &ftp.MkdirInput{Path: remotePath + "/dir1"}).
+		Return(nil).
+		Once()
+
+	uploadUseCaseMock := useCaseMocks.NewUploadFileUseCase(t)
+	uploadUseCaseMock.
+		On("Execute", ctx, mock.Anything, mock.AnythingOfType("*ftp.UploadFileInput")).
+		Run(func(args mock.Arguments) {
+			uploadInput := args.Get(2).(*ftp.UploadFileInput)
+			_, readErr := io.ReadAll(uploadInput.FileReader)
+			require.NoError(t, readErr)
+			assert.Equal(t, remotePath+"/dir1/file-1.txt", uploadInput.RemotePath)
+		}).
+		Return(&ftp.UploadFileOutput{}, nil).
+		Once()
+
+	buffer := bytes.NewBufferString("")
+	deps := &upload.Dependencies{
+		Filesystem:    os.DirFS("/"),
+		Connector:     connMock,
+		UploadUseCase: uploadUseCaseMock,
+		MkdirUseCase:  mkdirUseCaseMock,
+		OutWriter:     buffer,
+	}
+
+	input := &upload.CmdUploadInput{
+		FilePath:       root,
+		RemoteFilePath: remotePath,
+		Recursive:      true,
+		Links:          entities.LinkPolicyFollow,
+	}
+
+	err := upload.PerformUploadFile(ctx, logger, deps, input)
+	assert.NoError(t, err)
+	assert.Equal(t, fmt.Sprintf("Skipped 1 item(s):\n  %s (link loop)\n", filepath.Join(root, "dir1", "up")), buffer.String())
+}
+
+func Test_PerformUploadFile_Recursive_LinksError(t *testing.T) {
+	ctx := context.Background()
+
+	logger := assertlogging.NewLogger(t)
+
+	absFilePath, err := filepath.Abs(fmt.Sprintf("./%s", dirPath))
+	require.NoError(t, err)
+
+	fsabsFilePath := absFilePath[1:]
+	deps := &upload.Dependencies{
+		Filesystem: fstest.MapFS{
+			fsabsFilePath + "/file-1.txt": {Data: []byte("this is content of the file")},
+			fsabsFilePath + "/latest":     {Mode: fs.ModeSymlink, Data: []byte("file-1.txt")},
+		},
+		Connector:     ftpclientMocks.NewConnector(t),
+		UploadUseCase: useCaseMocks.NewUploadFileUseCase(t),
+		MkdirUseCase:  useCaseMocks.NewMkdirUseCase(t),
+	}
+
+	input := &upload.CmdUploadInput{
+		FilePath:       absFilePath,
+		RemoteFilePath: remotePath,
+		Recursive:      true,
+		Links:          entities.LinkPolicyError,
+	}
+
+	err = upload.PerformUploadFile(ctx, logger, deps, input)
+	require.EqualError(
+		t,
+		err,
+		fmt.Sprintf("an invalid argument error occurred: argument links symbolic link %s/latest is not allowed", absFilePath),
+	)
+	assert.IsType(t, ftperrors.InvalidArgumentErrorType, err)
+}
+
+func Test_PerformUploadFile_FileStatError(t *testing.T) {
 	ctx := context.Background()
 
 	logger := assertlogging.NewLogger(t)
@@ -242,7 +637,7 @@ func Test_PerformUploadFile_FileOpenError(t *testing.T) {
 	}
 
 	err := upload.PerformUploadFile(ctx, logger, deps, input)
-	require.EqualError(t, err, "an internal error occurred: failed to open file")
+	require.EqualError(t, err, "an internal error occurred: failed to get file information")
 	assert.IsType(t, ftperrors.InternalErrorType, err)
 	assert.EqualError(t, errors.Unwrap(err), "open testdata/file-1.txt: file does not exist")
 }
@@ -318,6 +713,147 @@ func Test_PerformUploadFile_RecursiveNonDirUploadError(t *testing.T) {
 	assert.NoError(t, errors.Unwrap(err))
 }
 
+// countingFS tracks the highest number of files of the filesystem that were open at the same time.
+type countingFS struct {
+	fstest.MapFS
+	mu      *sync.Mutex
+	open    *int
+	maxOpen *int
+}
+
+func (f countingFS) Open(name string) (fs.File, error) {
+	file, err := f.MapFS.Open(name)
+	if err != nil {
+		return nil, err
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	*f.open++
+	if *f.open > *f.maxOpen {
+		*f.maxOpen = *f.open
+	}
+	return countingFile{File: file, fs: f}, nil
+}
+
+type countingFile struct {
+	fs.File
+	fs countingFS
+}
+
+func (f countingFile) Close() error {
+	f.fs.mu.Lock()
+	defer f.fs.mu.Unlock()
+	*f.fs.open--
+	return f.File.Close()
+}
+
+//nolint:funlen // test case can get a bit large
+func Test_PerformUploadFile_Recursive_Parallel_Success(t *testing.T) {
+	ctx := context.Background()
+
+	logger := assertlogging.NewLogger(t)
+	logger.ExpectInfo("uploading 4 file(s), 108 B")
+	logger.ExpectInfo("OK!")
+
+	absFilePath, err := filepath.Abs(fmt.Sprintf("./%s", dirPath))
+	require.NoError(t, err)
+
+	// each worker uploads over its own connection
+	ftpConnMock := connectionMocks.NewConnection(t)
+	ftpConnMock.On("Stop").Return(nil).Twice()
+
+	connMock := ftpclientMocks.NewConnector(t)
+	connMock.
+		On("Connect", ctx, mock.AnythingOfType("ftpclient.ConnectorConfig")).
+		Return(ftpConnMock, nil).
+		Twice()
+
+	mkdirUseCaseMock := useCaseMocks.NewMkdirUseCase(t)
+	mkdirUseCaseMock.
+		On("Execute", ctx, mock.Anything, &ftp.MkdirInput{Path: remotePath}).
+		Return(nil).
+		Once()
+
+	uploadUseCaseMock := useCaseMocks.NewUploadFileUseCase(t)
+	uploadUseCaseMock.
+		On("Execute", ctx, mock.Anything, mock.AnythingOfType("*ftp.UploadFileInput")).
+		Return(func(_ context.Context, _ *ftp.UploadFileRepos, input *ftp.UploadFileInput) *ftp.UploadFileOutput {
+			_, readErr := io.ReadAll(input.FileReader)
+			require.NoError(t, readErr)
+			return &ftp.UploadFileOutput{
+				RemotePath: input.RemotePath,
+				Skipped:    &entities.SkippedTransfer{Path: input.RemotePath, Reason: "already exists"},
+			}
+		}, nil).
+		Times(4)
+
+	var open, maxOpen int
+	fsabsFilePath := absFilePath[1:]
+	buffer := bytes.NewBufferString("")
+	deps := &upload.Dependencies{
+		Filesystem: countingFS{
+			MapFS: fstest.MapFS{
+				fsabsFilePath + "/file-1.txt": {Data: []byte("this is content of the file")},
+				fsabsFilePath + "/file-2.txt": {Data: []byte("this is content of the file")},
+				fsabsFilePath + "/file-3.txt": {Data: []byte("this is content of the file")},
+				fsabsFilePath + "/file-4.txt": {Data: []byte("this is content of the file")},
+			},
+			mu:      &sync.Mutex{},
+			open:    &open,
+			maxOpen: &maxOpen,
+		},
+		Connector:     connMock,
+		UploadUseCase: uploadUseCaseMock,
+		MkdirUseCase:  mkdirUseCaseMock,
+		OutWriter:     buffer,
+	}
+
+	input := &upload.CmdUploadInput{
+		FilePath:       absFilePath,
+		RemoteFilePath: remotePath,
+		Recursive:      true,
+		IfExists:       entities.OverwritePolicySkip,
+		Parallel:       2,
+	}
+
+	err = upload.PerformUploadFile(ctx, logger, deps, input)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, open)
+	assert.LessOrEqual(t, maxOpen, 2)
+	// summary keeps the order of files regardless of the worker that uploaded them
+	assert.Equal(
+		t,
+		fmt.Sprintf(
+			"Skipped 4 item(s):\n  %[1]s/file-1.txt (already exists)\n  %[1]s/file-2.txt (already exists)\n"+
+				"  %[1]s/file-3.txt (already exists)\n  %[1]s/file-4.txt (already exists)\n",
+			remotePath,
+		),
+		buffer.String(),
+	)
+}
+
+// readDirErrorFS fails to read directories of the filesystem.
+type readDirErrorFS struct {
+	fstest.MapFS
+}
+
+func (f readDirErrorFS) ReadDir(string) ([]fs.DirEntry, error) {
+	return nil, errors.New("mock error")
+}
+
+// openFileErrorFS fails to open files of the filesystem, while directories can be opened.
+type openFileErrorFS struct {
+	fstest.MapFS
+}
+
+func (f openFileErrorFS) Open(name string) (fs.File, error) {
+	if strings.HasSuffix(name, ".txt") {
+		return nil, errors.New("mock error")
+	}
+	return f.MapFS.Open(name)
+}
+
 func Test_PerformUploadFile_WalkError(t *testing.T) {
 	ctx := context.Background()
 
@@ -327,12 +863,14 @@ func Test_PerformUploadFile_WalkError(t *testing.T) {
 	mkdirUseCaseMock := useCaseMocks.NewMkdirUseCase(t)
 	uploadUseCaseMock := useCaseMocks.NewUploadFileUseCase(t)
 
-	absFilePath := "/non-existing-dir"
+	absFilePath := "/non-readable-dir"
 
 	fsabsFilePath := absFilePath[1:]
 	deps := &upload.Dependencies{
-		Filesystem: fstest.MapFS{
-			fsabsFilePath: {Mode: fs.ModeDir},
+		Filesystem: readDirErrorFS{
+			MapFS: fstest.MapFS{
+				fsabsFilePath: {Mode: fs.ModeDir},
+			},
 		},
 		Connector:     connMock,
 		UploadUseCase: uploadUseCaseMock,
@@ -355,16 +893,30 @@ func Test_PerformUploadFile_WalkError(t *testing.T) {
 	err := upload.PerformUploadFile(ctx, logger, deps, input)
 	require.EqualError(t, err, "an internal error occurred: failed to walk directory")
 	assert.IsType(t, ftperrors.InternalErrorType, err)
-	assert.EqualError(t, errors.Unwrap(err), "lstat /non-existing-dir: no such file or directory")
+	assert.EqualError(t, errors.Unwrap(err), "mock error")
 }
 
 func Test_PerformUploadFile_RecursiveFileOpenErrorError(t *testing.T) {
 	ctx := context.Background()
 
 	logger := assertlogging.NewLogger(t)
+	logger.ExpectInfo("uploading 1 file(s), 27 B")
+
+	ftpConnMock := connectionMocks.NewConnection(t)
+	ftpConnMock.On("Stop").Return(nil).Once()
 
 	connMock := ftpclientMocks.NewConnector(t)
+	connMock.
+		On("Connect", ctx, mock.AnythingOfType("ftpclient.ConnectorConfig")).
+		Return(ftpConnMock, nil).
+		Once()
+
+	// files are opened once the connection is made, just before they are uploaded
 	mkdirUseCaseMock := useCaseMocks.NewMkdirUseCase(t)
+	mkdirUseCaseMock.
+		On("Execute", ctx, mock.Anything, &ftp.MkdirInput{Path: remotePath}).
+		Return(nil).
+		Once()
 	uploadUseCaseMock := useCaseMocks.NewUploadFileUseCase(t)
 
 	absFilePath, err := filepath.Abs(fmt.Sprintf("./%s", dirPath))
@@ -372,8 +924,11 @@ func Test_PerformUploadFile_RecursiveFileOpenErrorError(t *testing.T) {
 
 	fsabsFilePath := absFilePath[1:]
 	deps := &upload.Dependencies{
-		Filesystem: fstest.MapFS{
-			fsabsFilePath: {Mode: fs.ModeDir},
+		Filesystem: openFileErrorFS{
+			MapFS: fstest.MapFS{
+				fsabsFilePath: {Mode: fs.ModeDir},
+				fmt.Sprintf("%s/file-1.txt", fsabsFilePath): {Data: []byte("this is content of the file")},
+			},
 		},
 		Connector:     connMock,
 		UploadUseCase: uploadUseCaseMock,
@@ -394,15 +949,110 @@ func Test_PerformUploadFile_RecursiveFileOpenErrorError(t *testing.T) {
 	}
 
 	err = upload.PerformUploadFile(ctx, logger, deps, input)
-	require.EqualError(t, err, "an internal error occurred: failed to walk directory")
+	require.EqualError(t, err, "an internal error occurred: failed to open file")
 	assert.IsType(t, ftperrors.InternalErrorType, err)
-	assert.EqualError(t, errors.Unwrap(err), "an internal error occurred: failed to open file")
+	assert.EqualError(t, errors.Unwrap(err), "mock error")
+}
+
+//nolint:funlen // test case can get a bit large
+func Test_PerformUploadFile_Recursive_ContinueOnError(t *testing.T) {
+	ctx := context.Background()
+
+	logger := assertlogging.NewLogger(t)
+	logger.ExpectInfo("uploading 3 file(s), 81 B")
+
+	ftpConnMock := connectionMocks.NewConnection(t)
+	ftpConnMock.On("Stop").Return(nil).Once()
+
+	connMock := ftpclientMocks.NewConnector(t)
+	connMock.
+		On("Connect", ctx, mock.AnythingOfType("ftpclient.ConnectorConfig")).
+		Return(ftpConnMock, nil).
+		Once()
+
+	mkdirUseCaseMock := useCaseMocks.NewMkdirUseCase(t)
+	mkdirUseCaseMock.
+		On("Execute", ctx, mock.Anything, &ftp.MkdirInput{Path: fmt.Sprintf("%s/dir1", remotePath)}).
+		Return(ftperrors.NewInternalError("failed to create directory", errors.New("mock error"))).
+		Once()
+	mkdirUseCaseMock.
+		On("Execute", ctx, mock.Anything, &ftp.MkdirInput{Path: remotePath}).
+		Return(nil).
+		Once()
+
+	readAll := func(args mock.Arguments) {
+		_, useCaseMockErr := io.ReadAll(args.Get(2).(*ftp.UploadFileInput).FileReader)
+		require.NoError(t, useCaseMockErr)
+	}
+	remotePathIs := func(path string) interface{} {
+		return mock.MatchedBy(func(input *ftp.UploadFileInput) bool {
+			return input.RemotePath == path
+		})
+	}
+	uploadUseCaseMock := useCaseMocks.NewUploadFileUseCase(t)
+	uploadUseCaseMock.
+		On("Execute", ctx, mock.Anything, remotePathIs(fmt.Sprintf("%s/file-1.txt", remotePath))).
+		Run(readAll).
+		Return(&ftp.UploadFileOutput{}, nil).
+		Once()
+	uploadUseCaseMock.
+		On("Execute", ctx, mock.Anything, remotePathIs(fmt.Sprintf("%s/file-2.txt", remotePath))).
+		Run(readAll).
+		Return(nil, errors.New("mock error")).
+		Once()
+
+	absFilePath, err := filepath.Abs(fmt.Sprintf("./%s", dirPath))
+	require.NoError(t, err)
+
+	fsabsFilePath := absFilePath[1:]
+	buffer := bytes.NewBufferString("")
+	deps := &upload.Dependencies{
+		Filesystem: fstest.MapFS{
+			fsabsFilePath: {Mode: fs.ModeDir},
+			fmt.Sprintf("%s/file-1.txt", fsabsFilePath):      {Data: []byte("this is content of the file")},
+			fmt.Sprintf("%s/file-2.txt", fsabsFilePath):      {Data: []byte("this is content of the file")},
+			fmt.Sprintf("%s/dir1", fsabsFilePath):            {Mode: fs.ModeDir},
+			fmt.Sprintf("%s/dir1/file-3.txt", fsabsFilePath): {Data: []byte("this is content of the file")},
+		},
+		Connector:     connMock,
+		UploadUseCase: uploadUseCaseMock,
+		MkdirUseCase:  mkdirUseCaseMock,
+		OutWriter:     buffer,
+	}
+
+	input := &upload.CmdUploadInput{
+		Config: ftpclient.ConnectorConfig{
+			Address:  address,
+			User:     user,
+			Password: password,
+			Verbose:  true,
+			Timeout:  timeout,
+		},
+		FilePath:        absFilePath,
+		RemoteFilePath:  remotePath,
+		Recursive:       true,
+		ContinueOnError: true,
+	}
+
+	err = upload.PerformUploadFile(ctx, logger, deps, input)
+	require.EqualError(t, err, "an internal error occurred: 2 item(s) failed")
+	assert.IsType(t, ftperrors.InternalErrorType, err)
+
+	// files that failed are listed in the order they were found
+	lines := strings.Split(buffer.String(), "\n")
+	require.Len(t, lines, 8)
+	assert.Contains(t, lines[3], fmt.Sprintf("| failed | %s/dir1/file-3.txt ", absFilePath))
+	assert.Contains(t, lines[3], "| an internal error occurred: failed to create directory: mock error |")
+	assert.Contains(t, lines[4], fmt.Sprintf("| failed | %s/file-2.txt ", absFilePath))
+	assert.Contains(t, lines[4], "| mock error ")
+	assert.Equal(t, "1 ok, 0 skipped, 2 failed", lines[6])
 }
 
 func Test_PerformUploadFile_ConnectionError(t *testing.T) {
 	ctx := context.Background()
 
 	logger := assertlogging.NewLogger(t)
+	logger.ExpectInfo("uploading 1 file(s), 27 B")
 	logger.
 		ExpectError("failed to connect to server").
 		WithError(assertlogging.EqualError("mock error"))
@@ -451,7 +1101,7 @@ func Test_PerformUploadFile_ConnectionStopError(t *testing.T) {
 	ctx := context.Background()
 
 	logger := assertlogging.NewLogger(t)
-	logger.ExpectInfo("OK!")
+	logger.ExpectInfo("uploading 1 file(s), 27 B")
 	logger.
 		ExpectError("failed to stop server connection").
 		WithError(assertlogging.EqualError("mock error"))
@@ -486,10 +1136,7 @@ func Test_PerformUploadFile_ConnectionStopError(t *testing.T) {
 		Return(nil).
 		Once()
 
-	uploadUseCaseRepos := &ftp.UploadFileRepos{
-		Logger:     logger,
-		Connection: ftpConnMock,
-	}
+	uploadUseCaseRepos := matchUploadFileRepos(logger, ftpConnMock)
 
 	uploadUseCaseMock := useCaseMocks.NewUploadFileUseCase(t)
 	uploadUseCaseMock.
@@ -499,7 +1146,7 @@ func Test_PerformUploadFile_ConnectionStopError(t *testing.T) {
 			_, useCaseMockErr := args.Get(2).(*ftp.UploadFileInput).FileReader.Read(bytesToRead)
 			require.NoError(t, useCaseMockErr)
 		}).
-		Return(nil).
+		Return(&ftp.UploadFileOutput{}, nil).
 		Once()
 
 	deps := &upload.Dependencies{
@@ -530,6 +1177,7 @@ func Test_PerformUploadFile_MkdirError(t *testing.T) {
 	ctx := context.Background()
 
 	logger := assertlogging.NewLogger(t)
+	logger.ExpectInfo("uploading 1 file(s), 27 B")
 
 	ftpConnMock := connectionMocks.NewConnection(t)
 	ftpConnMock.On("Stop").Return(nil).Once()
@@ -591,6 +1239,7 @@ func Test_PerformUploadFile_UploadError(t *testing.T) {
 	ctx := context.Background()
 
 	logger := assertlogging.NewLogger(t)
+	logger.ExpectInfo("uploading 1 file(s), 27 B")
 
 	ftpConnMock := connectionMocks.NewConnection(t)
 	ftpConnMock.On("Stop").Return(nil).Once()
@@ -622,15 +1271,12 @@ func Test_PerformUploadFile_UploadError(t *testing.T) {
 		Return(nil).
 		Once()
 
-	uploadUseCaseRepos := &ftp.UploadFileRepos{
-		Logger:     logger,
-		Connection: ftpConnMock,
-	}
+	uploadUseCaseRepos := matchUploadFileRepos(logger, ftpConnMock)
 
 	uploadUseCaseMock := useCaseMocks.NewUploadFileUseCase(t)
 	uploadUseCaseMock.
 		On("Execute", ctx, uploadUseCaseRepos, mock.AnythingOfType("*ftp.UploadFileInput")).
-		Return(errors.New("mock error")).
+		Return(nil, errors.New("mock error")).
 		Once()
 
 	deps := &upload.Dependencies{
@@ -657,3 +1303,11 @@ func Test_PerformUploadFile_UploadError(t *testing.T) {
 	err := upload.PerformUploadFile(ctx, logger, deps, input)
 	assert.EqualError(t, err, "mock error")
 }
+
+// matchUploadFileRepos function matches repositories of the upload use case, whose observer reports to
+// progress bars and logs.
+func matchUploadFileRepos(logger logging.Logger, conn connection.Connection) interface{} {
+	return mock.MatchedBy(func(repos *ftp.UploadFileRepos) bool {
+		return repos.Logger == logger && repos.Connection == conn && repos.Observer != nil
+	})
+}
@@ -0,0 +1,166 @@
+package upload
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/alexZaicev/go-ftp-client/internal/domain/entities"
+	ftperrors "github.com/alexZaicev/go-ftp-client/internal/domain/errors"
+	"github.com/alexZaicev/go-ftp-client/internal/drivers/logging"
+	"github.com/alexZaicev/go-ftp-client/internal/usecases/ftp"
+)
+
+const (
+	reasonLinkSkipped        = "symbolic link"
+	reasonLinkLoop           = "link loop"
+	reasonLinkTargetNotFound = "link target not found"
+	reasonLinkBroken         = "broken link"
+
+	// maxFollowedLinks limits nesting of followed directory links, as loops can only be detected on
+	// filesystems reporting the identity of their files, such as the OS one.
+	maxFollowedLinks = 40
+)
+
+// fileWalker collects regular files of a local directory tree to upload, without opening them. Symbolic links are handled
+// according to the link policy, while other special files, such as sockets or devices, are skipped with
+// a warning, so that the upload does not fail once it has started.
+type fileWalker struct {
+	logger     logging.Logger
+	filesystem fs.FS
+	filter     *ftp.PathFilter
+	links      entities.LinkPolicy
+	// root is the local path of the uploaded directory, files are uploaded relative to it.
+	root           string
+	followedLinks  int
+	filesToUpload  []*fileToUpload
+	skippedEntries []*entities.SkippedTransfer
+}
+
+// collect method walks the uploaded directory, returning files to upload and entries that were skipped.
+func (w *fileWalker) collect() ([]*fileToUpload, []*entities.SkippedTransfer, error) {
+	if err := w.walk(trimLeadingSlash(filepath.ToSlash(w.root)), ""); err != nil {
+		var invalidArgErr *ftperrors.InvalidArgumentError
+		if errors.As(err, &invalidArgErr) {
+			return nil, nil, invalidArgErr
+		}
+		return nil, nil, ftperrors.NewInternalError("failed to walk directory", err)
+	}
+	return w.filesToUpload, w.skippedEntries, nil
+}
+
+// walk method walks the directory of the filesystem, where relRoot is its path relative to the uploaded
+// directory. Directories reached through followed links are walked by nested calls.
+func (w *fileWalker) walk(dirPath, relRoot string) error {
+	return fs.WalkDir(w.filesystem, dirPath, func(filePath string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		relPath := path.Join(relRoot, strings.TrimPrefix(strings.TrimPrefix(filePath, dirPath), "/"))
+		switch {
+		case d.IsDir():
+			if relPath != "" && !w.filter.MatchDir(relPath) {
+				return fs.SkipDir
+			}
+			return nil
+		case d.Type()&fs.ModeSymlink != 0:
+			return w.walkLink(filePath, relPath)
+		case !d.Type().IsRegular():
+			w.logger.WithField("path", w.localPath(relPath)).Warn("skipped entry that is not a regular file")
+			return nil
+		}
+
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		return w.addFile(filePath, relPath, info)
+	})
+}
+
+// walkLink method applies the link policy to the symbolic link.
+func (w *fileWalker) walkLink(filePath, relPath string) error {
+	switch w.links {
+	case entities.LinkPolicyFollow:
+	case entities.LinkPolicyError:
+		return ftperrors.NewInvalidArgumentError(
+			"links",
+			fmt.Sprintf("symbolic link %s is not allowed", w.localPath(relPath)),
+		)
+	default:
+		w.skip(relPath, reasonLinkSkipped)
+		return nil
+	}
+
+	info, err := fs.Stat(w.filesystem, filePath)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			w.skip(relPath, reasonLinkTargetNotFound)
+		} else {
+			w.skip(relPath, reasonLinkBroken)
+		}
+		return nil
+	}
+
+	switch {
+	case info.IsDir():
+		if !w.filter.MatchDir(relPath) {
+			return nil
+		}
+		if w.followedLinks >= maxFollowedLinks || w.isAncestor(filePath, info) {
+			w.skip(relPath, reasonLinkLoop)
+			return nil
+		}
+		w.followedLinks++
+		defer func() {
+			w.followedLinks--
+		}()
+		return w.walk(filePath, relPath)
+	case !info.Mode().IsRegular():
+		w.logger.WithField("path", w.localPath(relPath)).Warn("skipped entry that is not a regular file")
+		return nil
+	}
+	return w.addFile(filePath, relPath, info)
+}
+
+// isAncestor method reports whether the directory is one of the parent directories of the path, in which
+// case following a link to it would loop.
+func (w *fileWalker) isAncestor(filePath string, info fs.FileInfo) bool {
+	for dirPath := path.Dir(filePath); ; dirPath = path.Dir(dirPath) {
+		if dirInfo, err := fs.Stat(w.filesystem, dirPath); err == nil && os.SameFile(dirInfo, info) {
+			return true
+		}
+		if dirPath == "." {
+			return false
+		}
+	}
+}
+
+// addFile method adds the file to upload, which is only opened once it is about to be transferred.
+func (w *fileWalker) addFile(filePath, relPath string, info fs.FileInfo) error {
+	if !w.filter.MatchFile(relPath, uint64(info.Size()), info.ModTime()) {
+		return nil
+	}
+
+	w.filesToUpload = append(w.filesToUpload, &fileToUpload{
+		sizeInBytes: info.Size(),
+		modTime:     info.ModTime(),
+		path:        w.localPath(relPath),
+		fsPath:      filePath,
+	})
+	return nil
+}
+
+func (w *fileWalker) skip(relPath, reason string) {
+	w.skippedEntries = append(w.skippedEntries, &entities.SkippedTransfer{Path: w.localPath(relPath), Reason: reason})
+}
+
+// localPath method returns the local path of the entry relative to the uploaded directory.
+func (w *fileWalker) localPath(relPath string) string {
+	return filepath.Join(w.root, filepath.FromSlash(relPath))
+}
@@ -2,6 +2,7 @@ package ftpclient
 
 import (
 	"fmt"
+	"io"
 
 	"github.com/alexZaicev/go-ftp-client/internal/domain/entities"
 	"github.com/alexZaicev/go-ftp-client/internal/domain/errors"
@@ -30,16 +31,6 @@ const (
 	TB SizePostfix = "TB"
 )
 
-type CallbackWriter struct {
-	Callback func(bytesRead int64)
-}
-
-func (cw *CallbackWriter) Write(p []byte) (n int, err error) {
-	n, err = len(p), nil
-	cw.Callback(int64(n))
-	return
-}
-
 func EntryTypeToStr(entryType entities.EntryType) (string, error) {
 	switch entryType {
 	case entities.EntryTypeFile:
@@ -74,3 +65,21 @@ func FormatSizeInBytes(bytes uint64) string {
 	}
 	return fmt.Sprintf("%d %s", bytes, B)
 }
+
+// WriteSkippedSummary function writes the list of entries that were not transferred, along with the reason,
+// such as their destination already exists. Nothing is written if no entries were skipped.
+func WriteSkippedSummary(writer io.Writer, skipped []*entities.SkippedTransfer) error {
+	if len(skipped) == 0 {
+		return nil
+	}
+
+	if _, err := fmt.Fprintf(writer, "Skipped %d item(s):\n", len(skipped)); err != nil {
+		return errors.NewInternalError("failed to write skipped files", err)
+	}
+	for _, skippedTransfer := range skipped {
+		if _, err := fmt.Fprintf(writer, "  %s (%s)\n", skippedTransfer.Path, skippedTransfer.Reason); err != nil {
+			return errors.NewInternalError("failed to write skipped files", err)
+		}
+	}
+	return nil
+}
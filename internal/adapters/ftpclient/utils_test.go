@@ -12,22 +12,6 @@ import (
 	ftperrors "github.com/alexZaicev/go-ftp-client/internal/domain/errors"
 )
 
-func Test_CallbackWriter_Write_Success(t *testing.T) {
-	bytesReadActual := int64(0)
-
-	cw := ftpclient.CallbackWriter{
-		Callback: func(bytesRead int64) {
-			bytesReadActual = bytesRead
-		},
-	}
-
-	s := "hello world"
-
-	n, err := cw.Write([]byte(s))
-	assert.NoError(t, err)
-	assert.Equal(t, int64(n), bytesReadActual)
-}
-
 func Test_FormatSizeInBytes_Success(t *testing.T) {
 	testCases := []struct {
 		name           string
@@ -4,19 +4,40 @@ import (
 	"fmt"
 
 	"github.com/alexZaicev/go-ftp-client/internal/adapters/ftpconnection/models"
+	"github.com/alexZaicev/go-ftp-client/internal/domain/entities"
 	ftperrors "github.com/alexZaicev/go-ftp-client/internal/domain/errors"
 )
 
-func (c *ServerConnection) Cd(path string) error {
-	code, msg, err := c.cmd(models.StatusNoCheck, models.CommandChangeWorkDir, path)
+func (c *ServerConnection) Cd(dirPath string) error {
+	dirPath, err := c.expandHome(dirPath)
+	if err != nil {
+		return err
+	}
+
+	code, msg, err := c.cmd(models.StatusNoCheck, models.CommandChangeWorkDir, dirPath)
 	if err != nil {
 		return ftperrors.NewInternalError("failed to change working directory", err)
 	}
 	if code == models.StatusRequestedFileActionOK {
+		c.dirChanged = true
+		// relative paths can only be tracked if the previous working directory is known, while the
+		// server has already resolved ".." elements, so cleaning the path matches its view
+		remotePath := entities.RemotePath(dirPath)
+		switch {
+		case remotePath.IsAbs():
+			c.workDir = remotePath.Clean().String()
+		case c.workDir != "" && !remotePath.IsHome():
+			c.workDir = remotePath.Resolve(entities.RemotePath(c.workDir), "").Clean().String()
+		default:
+			c.workDir = ""
+		}
+		if c.workDir != "" {
+			c.knownDirs[c.workDir] = true
+		}
 		return nil
 	}
 	if code == models.StatusFileUnavailable {
-		return ftperrors.NewNotFoundError(fmt.Sprintf("path %s does not exist", path), nil)
+		return ftperrors.NewNotFoundError(fmt.Sprintf("path %s does not exist", dirPath), nil)
 	}
 	return ftperrors.NewInternalError(msg, nil)
 }
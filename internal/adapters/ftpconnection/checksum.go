@@ -0,0 +1,51 @@
+package ftpconnection
+
+import (
+	"strings"
+
+	"github.com/alexZaicev/go-ftp-client/internal/adapters/ftpconnection/models"
+	"github.com/alexZaicev/go-ftp-client/internal/domain/entities"
+	ftperrors "github.com/alexZaicev/go-ftp-client/internal/domain/errors"
+)
+
+// hashReplyTokens is the number of tokens of the HASH command reply: algorithm, byte range, hash and
+// file name, which may contain spaces.
+const hashReplyTokens = 4
+
+// Checksum function fetches the hash of the file computed by the server with the HASH command, see
+// https://datatracker.ietf.org/doc/html/draft-bryan-ftpext-hash-02. An empty checksum is returned if
+// the server does not advertise the algorithm.
+func (c *ServerConnection) Checksum(path string, algorithm entities.HashAlgorithm) (string, error) {
+	if !c.features.SupportHASH || !c.features.SupportsHashAlgorithm(string(algorithm)) {
+		return "", nil
+	}
+
+	path, err := c.expandHome(path)
+	if err != nil {
+		return "", err
+	}
+
+	if !strings.EqualFold(c.features.HashAlgorithm, string(algorithm)) {
+		if _, _, err := c.cmd(
+			models.StatusCommandOK,
+			models.CommandOptions,
+			models.FeatureHASH,
+			string(algorithm),
+		); err != nil {
+			return "", ftperrors.NewInternalError("failed to select hash algorithm", err)
+		}
+		c.features.HashAlgorithm = string(algorithm)
+	}
+
+	_, msg, err := c.cmd(models.StatusFile, models.CommandHash, path)
+	if err != nil {
+		return "", ftperrors.NewInternalError("failed to fetch file hash", err)
+	}
+
+	// reply has the following format: SHA-256 0-49 169cd22282da7f147cb491e559e9dd filename
+	tokens := strings.SplitN(strings.TrimSpace(msg), " ", hashReplyTokens)
+	if len(tokens) < hashReplyTokens-1 || !strings.EqualFold(tokens[0], string(algorithm)) {
+		return "", ftperrors.NewInternalError("failed to parse file hash", nil)
+	}
+	return strings.ToLower(tokens[2]), nil
+}
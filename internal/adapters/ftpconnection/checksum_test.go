@@ -0,0 +1,175 @@
+package ftpconnection_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/alexZaicev/go-ftp-client/internal/adapters/ftpconnection"
+	"github.com/alexZaicev/go-ftp-client/internal/adapters/ftpconnection/models"
+	"github.com/alexZaicev/go-ftp-client/internal/domain/entities"
+	ftperrors "github.com/alexZaicev/go-ftp-client/internal/domain/errors"
+	ftpConnectionMocks "github.com/alexZaicev/go-ftp-client/mocks/adapters/ftpconnection"
+)
+
+const hashReply = "SHA-256 0-1023 A1B2C3D4E5F6 baz"
+
+func setMocksForLoginWithHASH(connMock *ftpConnectionMocks.TextConnection) {
+	connMock.
+		On("Cmd", models.CommandUser, user).
+		Return(uid, nil).
+		Once()
+	connMock.
+		On("ReadResponse", models.StatusNoCheck).
+		Return(models.StatusLoggedIn, "", nil).
+		Once()
+	connMock.
+		On("Cmd", models.CommandFeat).
+		Return(uid, nil).
+		Once()
+	connMock.
+		On("ReadResponse", models.StatusNoCheck).
+		Return(models.StatusSystem, featureMsgWithHASH, nil).
+		Once()
+	connMock.
+		On("Cmd", models.CommandType).
+		Return(uid, nil).
+		Once()
+	connMock.
+		On("ReadResponse", models.StatusCommandOK).
+		Return(models.StatusCommandOK, "", nil).
+		Once()
+}
+
+func Test_ServerConnection_Checksum_Success(t *testing.T) {
+	tcpConn := ftpConnectionMocks.NewConn(t)
+	dialer := ftpConnectionMocks.NewDialer(t)
+	connMock := ftpConnectionMocks.NewTextConnection(t)
+	setMocksForLoginWithHASH(connMock)
+	// algorithm is selected only once, as SHA-1 is selected by default
+	connMock.
+		On("Cmd", models.CommandOptions, models.FeatureHASH, "SHA-256").
+		Return(uid, nil).
+		Once()
+	connMock.
+		On("ReadResponse", models.StatusCommandOK).
+		Return(models.StatusCommandOK, "", nil).
+		Once()
+	connMock.
+		On("Cmd", models.CommandHash, remotePath).
+		Return(uid, nil).
+		Twice()
+	connMock.
+		On("ReadResponse", models.StatusFile).
+		Return(models.StatusFile, hashReply, nil).
+		Twice()
+
+	serverConn, err := ftpconnection.NewConnection(host, dialer, tcpConn, connMock)
+	require.NoError(t, err)
+
+	// this is required to feed the feature map
+	err = serverConn.Login(user, password)
+	require.NoError(t, err)
+
+	for i := 0; i < 2; i++ {
+		checksum, checksumErr := serverConn.Checksum(remotePath, entities.HashAlgorithmSHA256)
+		assert.NoError(t, checksumErr)
+		assert.Equal(t, "a1b2c3d4e5f6", checksum)
+	}
+}
+
+func Test_ServerConnection_Checksum_NotSupported(t *testing.T) {
+	tcpConn := ftpConnectionMocks.NewConn(t)
+	dialer := ftpConnectionMocks.NewDialer(t)
+	connMock := ftpConnectionMocks.NewTextConnection(t)
+
+	serverConn, err := ftpconnection.NewConnection(host, dialer, tcpConn, connMock)
+	require.NoError(t, err)
+
+	checksum, err := serverConn.Checksum(remotePath, entities.HashAlgorithmSHA256)
+	assert.NoError(t, err)
+	assert.Empty(t, checksum)
+}
+
+//nolint:funlen // test case can get a bit large
+func Test_ServerConnection_Checksum_Errors(t *testing.T) {
+	testCases := []struct {
+		name           string
+		optsErr        error
+		hashErr        error
+		hashReply      string
+		expectedErrMsg string
+		expectedErr    error
+	}{
+		{
+			name:           "select algorithm error",
+			optsErr:        errors.New("mock error"),
+			expectedErrMsg: "an internal error occurred: failed to select hash algorithm",
+			expectedErr:    errors.New("mock error"),
+		},
+		{
+			name:           "hash error",
+			hashErr:        errors.New("mock error"),
+			expectedErrMsg: "an internal error occurred: failed to fetch file hash",
+			expectedErr:    errors.New("mock error"),
+		},
+		{
+			name:           "unexpected algorithm",
+			hashReply:      "SHA-1 0-1023 A1B2C3D4E5F6 baz",
+			expectedErrMsg: "an internal error occurred: failed to parse file hash",
+		},
+		{
+			name:           "malformed reply",
+			hashReply:      "SHA-256",
+			expectedErrMsg: "an internal error occurred: failed to parse file hash",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tcpConn := ftpConnectionMocks.NewConn(t)
+			dialer := ftpConnectionMocks.NewDialer(t)
+			connMock := ftpConnectionMocks.NewTextConnection(t)
+			setMocksForLoginWithHASH(connMock)
+			connMock.
+				On("Cmd", models.CommandOptions, models.FeatureHASH, "SHA-256").
+				Return(uid, tc.optsErr).
+				Once()
+			if tc.optsErr == nil {
+				connMock.
+					On("ReadResponse", models.StatusCommandOK).
+					Return(models.StatusCommandOK, "", nil).
+					Once()
+				connMock.
+					On("Cmd", models.CommandHash, remotePath).
+					Return(uid, tc.hashErr).
+					Once()
+			}
+			if tc.optsErr == nil && tc.hashErr == nil {
+				connMock.
+					On("ReadResponse", models.StatusFile).
+					Return(models.StatusFile, tc.hashReply, nil).
+					Once()
+			}
+
+			serverConn, err := ftpconnection.NewConnection(host, dialer, tcpConn, connMock)
+			require.NoError(t, err)
+
+			// this is required to feed the feature map
+			err = serverConn.Login(user, password)
+			require.NoError(t, err)
+
+			checksum, err := serverConn.Checksum(remotePath, entities.HashAlgorithmSHA256)
+			assert.Empty(t, checksum)
+			require.EqualError(t, err, tc.expectedErrMsg)
+			assert.IsType(t, ftperrors.InternalErrorType, err)
+			if tc.expectedErr != nil {
+				assert.EqualError(t, errors.Unwrap(err), tc.expectedErr.Error())
+			} else {
+				assert.NoError(t, errors.Unwrap(err))
+			}
+		})
+	}
+}
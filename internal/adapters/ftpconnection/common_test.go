@@ -1,6 +1,8 @@
 package ftpconnection_test
 
 import (
+	"fmt"
+
 	"github.com/alexZaicev/go-ftp-client/internal/adapters/ftpconnection/models"
 	mocks "github.com/alexZaicev/go-ftp-client/mocks/adapters/ftpconnection"
 )
@@ -9,8 +11,9 @@ const (
 	uid uint = 1
 
 	remotePath       = "/foo/bar/baz"
-	remoteParentPath = "/foo/bar/"
+	remoteParentPath = "/foo/bar"
 	newRemotePath    = "/baz/bar/foo"
+	homeDirPath      = "/home/user01"
 
 	host     = "ftp-dev-client"
 	user     = "user01"
@@ -43,6 +46,26 @@ const (
  PRET
 211 End`
 
+	featureMsgWithLANG = `211-Features:
+ EPRT
+ EPSV
+ MDTM
+ PASV
+ LANG fr-FR*;en-US;ru-RU
+ SIZE
+ MLST
+211 End`
+
+	featureMsgWithHASH = `211-Features:
+ EPRT
+ EPSV
+ MDTM
+ PASV
+ HASH SHA-1*;SHA-256;MD5
+ SIZE
+ MLST
+211 End`
+
 	featureMsgWithoutUTF8 = `211-Features:
  EPRT
  EPSV
@@ -129,3 +152,38 @@ func setMocksForLogin(connMock *mocks.TextConnection, useTLS bool) {
 			Twice()
 	}
 }
+
+// setMocksForNoCheckCmd function expects the command to be sent with the argument and replied with the
+// status code, which is not checked by the connection.
+func setMocksForNoCheckCmd(connMock *mocks.TextConnection, cmd, arg string, code int, msg string) {
+	connMock.
+		On("Cmd", cmd, arg).
+		Return(uid, nil).
+		Once()
+	connMock.
+		On("ReadResponse", models.StatusNoCheck).
+		Return(code, msg, nil).
+		Once()
+}
+
+func setMocksForPwd(connMock *mocks.TextConnection, dirPath string) {
+	connMock.
+		On("Cmd", models.CommandPrintWorkDir).
+		Return(uid, nil).
+		Once()
+	connMock.
+		On("ReadResponse", models.StatusPathCreated).
+		Return(models.StatusPathCreated, fmt.Sprintf("%q is the current directory", dirPath), nil).
+		Once()
+}
+
+func setMocksForSystem(connMock *mocks.TextConnection) {
+	connMock.
+		On("Cmd", models.CommandSystem).
+		Return(uid, nil).
+		Once()
+	connMock.
+		On("ReadResponse", models.StatusName).
+		Return(models.StatusName, systemMsg, nil).
+		Once()
+}
@@ -54,16 +54,35 @@ type ServerConnection struct {
 	conn    TextConnection
 	tcpConn net.Conn
 
+	// parser is used to parse LIST command entries. It is resolved lazily upon the first
+	// listing based on the server system type, unless provided with WithListParser option.
 	parser parsers.Parser
+	// mlsdParser is used to parse MLSD command entries which are standardised by RFC3659.
+	mlsdParser parsers.Parser
+	system     string
 
 	features *models.ServerFeatures
-
-	disableUTF8   bool
-	disableEPSV   bool
-	verboseWriter io.Writer
-	tlsConfig     *tls.Config
-	shutTimeout   time.Duration
-	location      *time.Location
+	// workDir is the working directory, which is empty until it is fetched from the server.
+	workDir string
+	// homeDir is the login directory, which is known if it is fetched before the directory is changed.
+	homeDir    string
+	dirChanged bool
+	// knownDirs caches directories known to exist during the session, so that creating many files
+	// in the same tree does not query the server for the same directories repeatedly.
+	knownDirs map[string]bool
+
+	disableUTF8    bool
+	disableEPSV    bool
+	lenientListing bool
+	listLanguage   string
+	verboseWriter  io.Writer
+	tlsConfig      *tls.Config
+	shutTimeout    time.Duration
+	// location of the server that LIST command entry dates are listed in. If detectLocation
+	// is set, it is derived from the first listing that contains a recently modified file.
+	location         *time.Location
+	detectLocation   bool
+	locationDetected bool
 }
 
 func NewConnection(
@@ -92,8 +111,9 @@ func NewConnection(
 		dialer:      dialer,
 		tcpConn:     conn,
 		conn:        textConn,
-		parser:      parsers.NewGenericListParser(),
+		mlsdParser:  parsers.NewRFC3659ListParser(),
 		features:    &models.ServerFeatures{},
+		knownDirs:   make(map[string]bool),
 		shutTimeout: defaultShutTimeout,
 	}
 
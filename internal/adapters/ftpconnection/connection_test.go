@@ -5,11 +5,13 @@ import (
 	"crypto/tls"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"github.com/alexZaicev/go-ftp-client/internal/adapters/ftpconnection"
+	"github.com/alexZaicev/go-ftp-client/internal/adapters/parsers"
 	ftpErrors "github.com/alexZaicev/go-ftp-client/internal/domain/errors"
 	ftpConnectionMocks "github.com/alexZaicev/go-ftp-client/mocks/adapters/ftpconnection"
 )
@@ -24,6 +26,10 @@ func Test_NewConnection_Success(t *testing.T) {
 		ftpconnection.WithVerboseWriter(bytes.NewBufferString("")),
 		ftpconnection.WithDisabledEPSV(),
 		ftpconnection.WithDisabledUTF8(),
+		ftpconnection.WithListParser(parsers.ListFormatUnix),
+		ftpconnection.WithListLanguage(parsers.LanguageGerman),
+		ftpconnection.WithLocation(time.UTC),
+		ftpconnection.WithLocationDetection(),
 	}
 
 	serverConn, err := ftpconnection.NewConnection(
@@ -114,6 +120,27 @@ func Test_NewConnection_OptionErrors(t *testing.T) {
 			},
 			expectedErrMsg: "an invalid argument error occurred: argument writer cannot be nil",
 		},
+		{
+			name: "invalid list parser option",
+			options: []ftpconnection.Option{
+				ftpconnection.WithListParser(parsers.ListFormat("not-valid")),
+			},
+			expectedErrMsg: "an invalid argument error occurred: argument format must be one of auto, unix, msdos, hosted, rfc3659",
+		},
+		{
+			name: "invalid list language option",
+			options: []ftpconnection.Option{
+				ftpconnection.WithListLanguage("not-valid"),
+			},
+			expectedErrMsg: "an invalid argument error occurred: argument language must be one of en, de, fr, es, ru, ja",
+		},
+		{
+			name: "invalid location option",
+			options: []ftpconnection.Option{
+				ftpconnection.WithLocation(nil),
+			},
+			expectedErrMsg: "an invalid argument error occurred: argument location cannot be nil",
+		},
 	}
 
 	for _, tc := range testCases {
@@ -7,14 +7,23 @@ import (
 	"github.com/hashicorp/go-multierror"
 
 	"github.com/alexZaicev/go-ftp-client/internal/adapters/ftpconnection/models"
+	"github.com/alexZaicev/go-ftp-client/internal/domain/connection"
 	ftperrors "github.com/alexZaicev/go-ftp-client/internal/domain/errors"
 )
 
-func (c *ServerConnection) Download(ctx context.Context, path string) ([]byte, error) {
-	if path == "" {
+func (c *ServerConnection) Download(ctx context.Context, options *connection.DownloadOptions) ([]byte, error) {
+	if options == nil {
+		return nil, ftperrors.NewInvalidArgumentError("options", ftperrors.ErrMsgCannotBeNil)
+	}
+	if options.Path == "" {
 		return nil, ftperrors.NewInvalidArgumentError("path", ftperrors.ErrMsgCannotBeBlank)
 	}
 
+	path, err := c.expandHome(options.Path)
+	if err != nil {
+		return nil, err
+	}
+
 	conn, err := c.cmdWithDataConn(ctx, 0, models.CommandRetrieve, path)
 	if err != nil {
 		return nil, ftperrors.NewInternalError("failed to open data transfer connection", err)
@@ -22,7 +31,12 @@ func (c *ServerConnection) Download(ctx context.Context, path string) ([]byte, e
 
 	var multiErr *multierror.Error
 
-	data, err := io.ReadAll(conn)
+	var reader io.Reader = conn
+	if options.ProgressWriter != nil {
+		reader = io.TeeReader(conn, options.ProgressWriter)
+	}
+
+	data, err := io.ReadAll(reader)
 	if err != nil {
 		multiErr = multierror.Append(multiErr, err)
 	}
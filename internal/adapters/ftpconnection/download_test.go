@@ -14,6 +14,7 @@ import (
 
 	"github.com/alexZaicev/go-ftp-client/internal/adapters/ftpconnection"
 	"github.com/alexZaicev/go-ftp-client/internal/adapters/ftpconnection/models"
+	"github.com/alexZaicev/go-ftp-client/internal/domain/connection"
 	ftperrors "github.com/alexZaicev/go-ftp-client/internal/domain/errors"
 	ftpConnectionMocks "github.com/alexZaicev/go-ftp-client/mocks/adapters/ftpconnection"
 )
@@ -85,34 +86,60 @@ func Test_ServerConnection_Download_Success(t *testing.T) {
 	err = serverConn.Login(user, password)
 	require.NoError(t, err)
 
+	progressBuffer := bytes.NewBufferString("")
+
 	// act
-	data, err := serverConn.Download(ctx, remotePath)
+	data, err := serverConn.Download(ctx, &connection.DownloadOptions{
+		Path:           remotePath,
+		ProgressWriter: progressBuffer,
+	})
 
 	// assert
 	assert.NoError(t, err)
 	assert.Len(t, data, buffer.Len())
+	assert.Equal(t, data, progressBuffer.Bytes())
 }
 
 func Test_ServerConnection_Download_InvalidArgumentError(t *testing.T) {
-	// arrange
-	ctx := context.Background()
-
-	tcpConn := ftpConnectionMocks.NewConn(t)
-	dialer := ftpConnectionMocks.NewDialer(t)
-	connMock := ftpConnectionMocks.NewTextConnection(t)
-
-	serverConn, err := ftpconnection.NewConnection(host, dialer, tcpConn, connMock)
-	require.NoError(t, err)
-
-	// act
-	data, err := serverConn.Download(ctx, "")
-
-	// assert
-	assert.Nil(t, data)
-
-	require.EqualError(t, err, "an invalid argument error occurred: argument path cannot be blank")
-	assert.IsType(t, ftperrors.InvalidArgumentErrorType, err)
-	assert.NoError(t, errors.Unwrap(err))
+	testCases := []struct {
+		name    string
+		options *connection.DownloadOptions
+		errMsg  string
+	}{
+		{
+			name:   "nil options",
+			errMsg: "an invalid argument error occurred: argument options cannot be nil",
+		},
+		{
+			name:    "blank path",
+			options: &connection.DownloadOptions{},
+			errMsg:  "an invalid argument error occurred: argument path cannot be blank",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// arrange
+			ctx := context.Background()
+
+			tcpConn := ftpConnectionMocks.NewConn(t)
+			dialer := ftpConnectionMocks.NewDialer(t)
+			connMock := ftpConnectionMocks.NewTextConnection(t)
+
+			serverConn, err := ftpconnection.NewConnection(host, dialer, tcpConn, connMock)
+			require.NoError(t, err)
+
+			// act
+			data, err := serverConn.Download(ctx, tc.options)
+
+			// assert
+			assert.Nil(t, data)
+
+			require.EqualError(t, err, tc.errMsg)
+			assert.IsType(t, ftperrors.InvalidArgumentErrorType, err)
+			assert.NoError(t, errors.Unwrap(err))
+		})
+	}
 }
 
 func Test_ServerConnection_Download_CmdError(t *testing.T) {
@@ -165,7 +192,7 @@ func Test_ServerConnection_Download_CmdError(t *testing.T) {
 	require.NoError(t, err)
 
 	// act
-	data, err := serverConn.Download(ctx, remotePath)
+	data, err := serverConn.Download(ctx, &connection.DownloadOptions{Path: remotePath})
 
 	// assert
 	assert.Nil(t, data)
@@ -243,7 +270,7 @@ func Test_ServerConnection_Download_ReadError(t *testing.T) {
 	require.NoError(t, err)
 
 	// act
-	data, err := serverConn.Download(ctx, remotePath)
+	data, err := serverConn.Download(ctx, &connection.DownloadOptions{Path: remotePath})
 
 	// assert
 	assert.Nil(t, data)
@@ -321,7 +348,7 @@ func Test_ServerConnection_Download_CloseError(t *testing.T) {
 	require.NoError(t, err)
 
 	// act
-	data, err := serverConn.Download(ctx, remotePath)
+	data, err := serverConn.Download(ctx, &connection.DownloadOptions{Path: remotePath})
 
 	// assert
 	assert.Nil(t, data)
@@ -399,7 +426,7 @@ func Test_ServerConnection_Download_CheckConnShutError(t *testing.T) {
 	require.NoError(t, err)
 
 	// act
-	data, err := serverConn.Download(ctx, remotePath)
+	data, err := serverConn.Download(ctx, &connection.DownloadOptions{Path: remotePath})
 
 	// assert
 	assert.Nil(t, data)
@@ -3,7 +3,6 @@ package ftpconnection
 import (
 	"context"
 	"fmt"
-	"path/filepath"
 
 	"github.com/alexZaicev/go-ftp-client/internal/domain/connection"
 	"github.com/alexZaicev/go-ftp-client/internal/domain/entities"
@@ -11,28 +10,32 @@ import (
 )
 
 func (c *ServerConnection) IsDir(ctx context.Context, path string) (bool, error) {
-	parentDir, fileToRemove := filepath.Split(path)
-	entries, err := c.List(ctx, &connection.ListOptions{
-		Path:    parentDir,
-		ShowAll: true,
-	})
+	entry, err := c.findEntry(ctx, path)
 	if err != nil {
 		return false, err
 	}
 
-	var entry *entities.Entry
-	for _, e := range entries {
-		if e.Name == fileToRemove {
-			entry = e
-			break
-		}
-	}
+	isDir := entry.Type == entities.EntryTypeDir
+
+	return isDir, nil
+}
 
-	if entry == nil {
-		return false, ftperrors.NewNotFoundError(fmt.Sprintf("entry not found under %q path", path), nil)
+// findEntry function lists the parent directory of the path and returns the entry with matching name.
+func (c *ServerConnection) findEntry(ctx context.Context, path string) (*entities.Entry, error) {
+	parentDir, name := entities.RemotePath(path).Split()
+	result, err := c.List(ctx, &connection.ListOptions{
+		Path:    parentDir.String(),
+		ShowAll: true,
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	isDir := entry.Type == entities.EntryTypeDir
+	for _, entry := range result.Entries {
+		if entry.Name == name {
+			return entry, nil
+		}
+	}
 
-	return isDir, nil
+	return nil, ftperrors.NewNotFoundError(fmt.Sprintf("entry not found under %q path", path), nil)
 }
@@ -53,6 +53,7 @@ func Test_ServerConnection_IsDir_Success(t *testing.T) {
 	connMock := ftpConnectionMocks.NewTextConnection(t)
 	// mock setup for login
 	setMocksForLogin(connMock, false)
+	setMocksForSystem(connMock)
 	// mock setup for list
 	connMock.
 		On("Cmd", fmt.Sprintf(models.CommandPreTransfer, models.CommandListHidden), remoteParentPath).
@@ -107,6 +108,7 @@ func Test_ServerConnection_IsDir_ListError(t *testing.T) {
 	connMock := ftpConnectionMocks.NewTextConnection(t)
 	// mock setup for login
 	setMocksForLogin(connMock, false)
+	setMocksForSystem(connMock)
 	// mock setup for list
 	connMock.
 		On("Cmd", fmt.Sprintf(models.CommandPreTransfer, models.CommandListHidden), remoteParentPath).
@@ -166,6 +168,7 @@ func Test_ServerConnection_IsDir_NotFoundError(t *testing.T) {
 	connMock := ftpConnectionMocks.NewTextConnection(t)
 	// mock setup for login
 	setMocksForLogin(connMock, false)
+	setMocksForSystem(connMock)
 	// mock setup for list
 	connMock.
 		On("Cmd", fmt.Sprintf(models.CommandPreTransfer, models.CommandListHidden), remoteParentPath).
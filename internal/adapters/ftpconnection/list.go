@@ -3,6 +3,7 @@ package ftpconnection
 import (
 	"bufio"
 	"context"
+	"time"
 
 	"github.com/hashicorp/go-multierror"
 
@@ -13,32 +14,56 @@ import (
 	ftperrors "github.com/alexZaicev/go-ftp-client/internal/domain/errors"
 )
 
-func (c *ServerConnection) List(ctx context.Context, options *connection.ListOptions) (entries []*entities.Entry, err error) {
+// List function lists entries under the provided path. Entries that cannot be parsed fail the listing,
+// unless the connection is in lenient listing mode, in which case such entries are skipped and
+// returned as raw lines alongside successfully parsed entries.
+func (c *ServerConnection) List(ctx context.Context, options *connection.ListOptions) (*connection.ListResult, error) {
 	if options == nil {
 		return nil, ftperrors.NewInvalidArgumentError("options", ftperrors.ErrMsgCannotBeNil)
 	}
 
+	listPath, err := c.expandHome(options.Path)
+	if err != nil {
+		return nil, err
+	}
+
 	cmd := models.CommandList
+	parser := c.mlsdParser
+	// MLSD entry dates are in UTC by definition
+	location := time.UTC
 	if c.features.SupportMLST {
 		cmd = models.CommandListMachineReadable
-	} else if options.ShowAll {
-		cmd = models.CommandListHidden
+	} else {
+		if options.ShowAll {
+			cmd = models.CommandListHidden
+		}
+		// parser must be resolved prior to opening data connection as it may
+		// require to query the server
+		parser = c.listParser()
+		location = c.location
 	}
 
-	conn, err := c.cmdWithDataConn(ctx, 0, cmd, options.Path)
+	conn, err := c.cmdWithDataConn(ctx, 0, cmd, listPath)
 	if err != nil {
 		return nil, ftperrors.NewInternalError("failed to list files", err)
 	}
 
 	var multiErr *multierror.Error
+	var entries []*entities.Entry
+	var skippedLines []string
 
 	scanner := bufio.NewScanner(conn)
 	for scanner.Scan() {
 		entryStr := scanner.Text()
-		entry, parseErr := c.parser.Parse(entryStr, &parsers.Options{
-			Location: c.location,
+		entry, parseErr := parser.Parse(entryStr, &parsers.Options{
+			Location: location,
+			Language: c.listLanguage,
 		})
 		if parseErr != nil {
+			if c.lenientListing {
+				skippedLines = append(skippedLines, entryStr)
+				continue
+			}
 			multiErr = multierror.Append(multiErr, parseErr)
 			break
 		}
@@ -62,5 +87,30 @@ func (c *ServerConnection) List(ctx context.Context, options *connection.ListOpt
 		return nil, ftperrors.NewInternalError("failed to list files", err)
 	}
 
-	return entries, nil
+	if cmd != models.CommandListMachineReadable && c.detectLocation && !c.locationDetected {
+		c.detectServerLocation(listPath, entries)
+	}
+
+	return &connection.ListResult{
+		Entries:      entries,
+		SkippedLines: skippedLines,
+	}, nil
+}
+
+// listParser function returns the parser for LIST command entries. Unless set explicitly, the parser
+// is selected based on the server system type and then locks in the format of the first successfully
+// parsed entry for the rest of the session.
+func (c *ServerConnection) listParser() parsers.Parser {
+	if c.parser != nil {
+		return c.parser
+	}
+	system, err := c.systemType()
+	if err != nil {
+		// servers are not required to support SYST command, in which case
+		// all known formats are tried in the default order
+		c.parser = parsers.NewGenericListParser()
+		return c.parser
+	}
+	c.parser = parsers.NewSystemListParser(system)
+	return c.parser
 }
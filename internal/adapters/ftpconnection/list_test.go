@@ -16,6 +16,7 @@ import (
 
 	"github.com/alexZaicev/go-ftp-client/internal/adapters/ftpconnection"
 	"github.com/alexZaicev/go-ftp-client/internal/adapters/ftpconnection/models"
+	"github.com/alexZaicev/go-ftp-client/internal/adapters/parsers"
 	"github.com/alexZaicev/go-ftp-client/internal/domain/connection"
 	"github.com/alexZaicev/go-ftp-client/internal/domain/entities"
 	ftperrors "github.com/alexZaicev/go-ftp-client/internal/domain/errors"
@@ -53,12 +54,15 @@ func Test_ServerConnection_List_Success(t *testing.T) {
 			expectedEntry := &entities.Entry{
 				Type:                 entities.EntryTypeFile,
 				Permissions:          "rw-r--r--",
+				Mode:                 0o644,
 				OwnerGroup:           "ftp",
 				OwnerUser:            "ftp",
 				Name:                 "file-1.txt",
 				NumHardLinks:         1,
 				SizeInBytes:          187,
 				LastModificationDate: time.Date(0, 9, 16, 14, 34, 0, 0, time.UTC),
+				YearUnknown:          true,
+				Raw:                  entryFileMessage,
 			}
 
 			tcpConn := ftpConnectionMocks.NewConn(t)
@@ -94,6 +98,7 @@ func Test_ServerConnection_List_Success(t *testing.T) {
 			connMock := ftpConnectionMocks.NewTextConnection(t)
 			// mock setup for login
 			setMocksForLogin(connMock, false)
+			setMocksForSystem(connMock)
 			// mock setup for list
 			connMock.
 				On("Cmd", fmt.Sprintf(models.CommandPreTransfer, tc.command), remotePath).
@@ -131,10 +136,10 @@ func Test_ServerConnection_List_Success(t *testing.T) {
 			err = serverConn.Login(user, password)
 			require.NoError(t, err)
 
-			entries, err := serverConn.List(ctx, tc.options)
+			result, err := serverConn.List(ctx, tc.options)
 			assert.NoError(t, err)
-			if assert.Len(t, entries, 1) {
-				assert.Equal(t, expectedEntry, entries[0])
+			if assert.Len(t, result.Entries, 1) {
+				assert.Equal(t, expectedEntry, result.Entries[0])
 			}
 		})
 	}
@@ -147,12 +152,15 @@ func Test_ServerConnection_List_WithTLS_Success(t *testing.T) {
 	expectedEntry := &entities.Entry{
 		Type:                 entities.EntryTypeFile,
 		Permissions:          "rw-r--r--",
+		Mode:                 0o644,
 		OwnerGroup:           "ftp",
 		OwnerUser:            "ftp",
 		Name:                 "file-1.txt",
 		NumHardLinks:         1,
 		SizeInBytes:          187,
 		LastModificationDate: time.Date(0, 9, 16, 14, 34, 0, 0, time.UTC),
+		YearUnknown:          true,
+		Raw:                  entryFileMessage,
 	}
 
 	tlsConfig := &tls.Config{
@@ -192,6 +200,7 @@ func Test_ServerConnection_List_WithTLS_Success(t *testing.T) {
 	connMock := ftpConnectionMocks.NewTextConnection(t)
 	// mock setup for login
 	setMocksForLogin(connMock, true)
+	setMocksForSystem(connMock)
 	// mock setup for list
 	connMock.
 		On("Cmd", fmt.Sprintf(models.CommandPreTransfer, models.CommandList), remotePath).
@@ -239,10 +248,10 @@ func Test_ServerConnection_List_WithTLS_Success(t *testing.T) {
 		Path: remotePath,
 	}
 
-	entries, err := serverConn.List(ctx, options)
+	result, err := serverConn.List(ctx, options)
 	assert.NoError(t, err)
-	if assert.Len(t, entries, 1) {
-		assert.Equal(t, expectedEntry, entries[0])
+	if assert.Len(t, result.Entries, 1) {
+		assert.Equal(t, expectedEntry, result.Entries[0])
 	}
 }
 
@@ -252,12 +261,15 @@ func Test_ServerConnection_List_WithDisabledEPSV_Success(t *testing.T) {
 	expectedEntry := &entities.Entry{
 		Type:                 entities.EntryTypeFile,
 		Permissions:          "rw-r--r--",
+		Mode:                 0o644,
 		OwnerGroup:           "ftp",
 		OwnerUser:            "ftp",
 		Name:                 "file-1.txt",
 		NumHardLinks:         1,
 		SizeInBytes:          187,
 		LastModificationDate: time.Date(0, 9, 16, 14, 34, 0, 0, time.UTC),
+		YearUnknown:          true,
+		Raw:                  entryFileMessage,
 	}
 
 	tcpConn := ftpConnectionMocks.NewConn(t)
@@ -293,6 +305,7 @@ func Test_ServerConnection_List_WithDisabledEPSV_Success(t *testing.T) {
 	connMock := ftpConnectionMocks.NewTextConnection(t)
 	// mock setup for login
 	setMocksForLogin(connMock, false)
+	setMocksForSystem(connMock)
 	// mock setup for list
 	connMock.
 		On("Cmd", fmt.Sprintf(models.CommandPreTransfer, models.CommandList), remotePath).
@@ -340,10 +353,356 @@ func Test_ServerConnection_List_WithDisabledEPSV_Success(t *testing.T) {
 		Path: remotePath,
 	}
 
-	entries, err := serverConn.List(ctx, options)
+	result, err := serverConn.List(ctx, options)
 	assert.NoError(t, err)
-	if assert.Len(t, entries, 1) {
-		assert.Equal(t, expectedEntry, entries[0])
+	if assert.Len(t, result.Entries, 1) {
+		assert.Equal(t, expectedEntry, result.Entries[0])
+	}
+}
+
+//nolint:funlen // test case can get a bit large
+func Test_ServerConnection_List_ParserSelection_Success(t *testing.T) {
+	testCases := []struct {
+		name            string
+		options         []ftpconnection.Option
+		setMocksForSyst func(connMock *ftpConnectionMocks.TextConnection)
+	}{
+		{
+			name: "list parser option",
+			options: []ftpconnection.Option{
+				ftpconnection.WithListParser(parsers.ListFormatUnix),
+			},
+			setMocksForSyst: func(connMock *ftpConnectionMocks.TextConnection) {},
+		},
+		{
+			name: "system command not supported",
+			setMocksForSyst: func(connMock *ftpConnectionMocks.TextConnection) {
+				connMock.
+					On("Cmd", models.CommandSystem).
+					Return(uid, nil).
+					Once()
+				connMock.
+					On("ReadResponse", models.StatusName).
+					Return(models.StatusBadCommand, "", errors.New("mock error")).
+					Once()
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			tcpConn := ftpConnectionMocks.NewConn(t)
+			tcpConn.
+				On("SetDeadline", mock.AnythingOfType("time.Time")).
+				Return(nil).
+				Once()
+
+			dataConnMock := ftpConnectionMocks.NewConn(t)
+			dataConnMock.
+				On("Read", mock.Anything).
+				Run(func(args mock.Arguments) {
+					bytes := args.Get(0).([]byte)
+					copy(bytes, entryFileMessage)
+				}).
+				Return(len(entryFileMessage), nil).
+				Once()
+			dataConnMock.
+				On("Read", mock.Anything).
+				Return(0, io.EOF).
+				Once()
+			dataConnMock.
+				On("Close").
+				Return(nil).
+				Once()
+
+			dialer := ftpConnectionMocks.NewDialer(t)
+			dialer.
+				On("DialContext", ctx, "tcp", fmt.Sprintf("%s:21103", host)).
+				Return(dataConnMock, nil).
+				Once()
+
+			connMock := ftpConnectionMocks.NewTextConnection(t)
+			// mock setup for login
+			setMocksForLogin(connMock, false)
+			tc.setMocksForSyst(connMock)
+			// mock setup for list
+			connMock.
+				On("Cmd", fmt.Sprintf(models.CommandPreTransfer, models.CommandList), remotePath).
+				Return(uid, nil).
+				Once()
+			connMock.
+				On("ReadResponse", models.StatusCommandOK).
+				Return(models.StatusCommandOK, "", nil).
+				Once()
+			connMock.
+				On("Cmd", models.CommandExtendedPassiveMode).
+				Return(uid, nil).
+				Once()
+			connMock.
+				On("ReadResponse", models.StatusExtendedPassiveMode).
+				Return(models.StatusExtendedPassiveMode, extendedPassiveModeMessage, nil).
+				Once()
+			connMock.
+				On("Cmd", models.CommandList, remotePath).
+				Return(uid, nil).
+				Once()
+			connMock.
+				On("ReadResponse", models.StatusNoCheck).
+				Return(models.StatusAboutToSend, listMessage, nil).
+				Once()
+			connMock.
+				On("ReadResponse", models.StatusClosingDataConnection).
+				Return(models.StatusClosingDataConnection, "", nil).
+				Once()
+
+			serverConn, err := ftpconnection.NewConnection(host, dialer, tcpConn, connMock, tc.options...)
+			require.NoError(t, err)
+
+			// this is required to feed the feature map
+			err = serverConn.Login(user, password)
+			require.NoError(t, err)
+
+			result, err := serverConn.List(ctx, &connection.ListOptions{
+				Path: remotePath,
+			})
+			assert.NoError(t, err)
+			if assert.Len(t, result.Entries, 1) {
+				assert.Equal(t, "file-1.txt", result.Entries[0].Name)
+			}
+		})
+	}
+}
+
+//nolint:funlen // test case can get a bit large
+func Test_ServerConnection_List_WithLenientListing_Success(t *testing.T) {
+	ctx := context.Background()
+
+	listData := fmt.Sprintf("total 8\n%s\nnot-valid-entry\n", entryFileMessage)
+
+	tcpConn := ftpConnectionMocks.NewConn(t)
+	tcpConn.
+		On("SetDeadline", mock.AnythingOfType("time.Time")).
+		Return(nil).
+		Once()
+
+	dataConnMock := ftpConnectionMocks.NewConn(t)
+	dataConnMock.
+		On("Read", mock.Anything).
+		Run(func(args mock.Arguments) {
+			bytes := args.Get(0).([]byte)
+			copy(bytes, listData)
+		}).
+		Return(len(listData), nil).
+		Once()
+	dataConnMock.
+		On("Read", mock.Anything).
+		Return(0, io.EOF).
+		Once()
+	dataConnMock.
+		On("Close").
+		Return(nil).
+		Once()
+
+	dialer := ftpConnectionMocks.NewDialer(t)
+	dialer.
+		On("DialContext", ctx, "tcp", fmt.Sprintf("%s:21103", host)).
+		Return(dataConnMock, nil).
+		Once()
+
+	connMock := ftpConnectionMocks.NewTextConnection(t)
+	// mock setup for login
+	setMocksForLogin(connMock, false)
+	setMocksForSystem(connMock)
+	// mock setup for list
+	connMock.
+		On("Cmd", fmt.Sprintf(models.CommandPreTransfer, models.CommandList), remotePath).
+		Return(uid, nil).
+		Once()
+	connMock.
+		On("ReadResponse", models.StatusCommandOK).
+		Return(models.StatusCommandOK, "", nil).
+		Once()
+	connMock.
+		On("Cmd", models.CommandExtendedPassiveMode).
+		Return(uid, nil).
+		Once()
+	connMock.
+		On("ReadResponse", models.StatusExtendedPassiveMode).
+		Return(models.StatusExtendedPassiveMode, extendedPassiveModeMessage, nil).
+		Once()
+	connMock.
+		On("Cmd", models.CommandList, remotePath).
+		Return(uid, nil).
+		Once()
+	connMock.
+		On("ReadResponse", models.StatusNoCheck).
+		Return(models.StatusAboutToSend, listMessage, nil).
+		Once()
+	connMock.
+		On("ReadResponse", models.StatusClosingDataConnection).
+		Return(models.StatusClosingDataConnection, "", nil).
+		Once()
+
+	serverConn, err := ftpconnection.NewConnection(
+		host,
+		dialer,
+		tcpConn,
+		connMock,
+		ftpconnection.WithLenientListing(),
+	)
+	require.NoError(t, err)
+
+	// this is required to feed the feature map
+	err = serverConn.Login(user, password)
+	require.NoError(t, err)
+
+	result, err := serverConn.List(ctx, &connection.ListOptions{
+		Path: remotePath,
+	})
+	assert.NoError(t, err)
+	require.NotNil(t, result)
+	if assert.Len(t, result.Entries, 1) {
+		assert.Equal(t, "file-1.txt", result.Entries[0].Name)
+	}
+	assert.Equal(t, []string{"total 8", "not-valid-entry"}, result.SkippedLines)
+}
+
+//nolint:funlen // test case can get a bit large
+func Test_ServerConnection_List_WithLocation_Success(t *testing.T) {
+	testCases := []struct {
+		name         string
+		option       ftpconnection.Option
+		mdtmReply    string
+		mdtmErr      error
+		expectedDate time.Time
+	}{
+		{
+			name:         "provided location",
+			option:       ftpconnection.WithLocation(time.FixedZone("", 2*60*60)),
+			expectedDate: time.Date(0, 9, 16, 12, 34, 0, 0, time.UTC),
+		},
+		{
+			name:         "detected location",
+			option:       ftpconnection.WithLocationDetection(),
+			mdtmReply:    "20220916113412",
+			expectedDate: time.Date(0, 9, 16, 11, 34, 0, 0, time.UTC),
+		},
+		{
+			name:         "detected location with fractional seconds",
+			option:       ftpconnection.WithLocationDetection(),
+			mdtmReply:    "20220916113412.123",
+			expectedDate: time.Date(0, 9, 16, 11, 34, 0, 0, time.UTC),
+		},
+		{
+			name:         "detected location with MDTM error",
+			option:       ftpconnection.WithLocationDetection(),
+			mdtmErr:      errors.New("mock error"),
+			expectedDate: time.Date(0, 9, 16, 14, 34, 0, 0, time.UTC),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			tcpConn := ftpConnectionMocks.NewConn(t)
+			tcpConn.
+				On("SetDeadline", mock.AnythingOfType("time.Time")).
+				Return(nil).
+				Once()
+
+			dataConnMock := ftpConnectionMocks.NewConn(t)
+			dataConnMock.
+				On("Read", mock.Anything).
+				Run(func(args mock.Arguments) {
+					bytes := args.Get(0).([]byte)
+					copy(bytes, entryFileMessage)
+				}).
+				Return(len(entryFileMessage), nil).
+				Once()
+			dataConnMock.
+				On("Read", mock.Anything).
+				Return(0, io.EOF).
+				Once()
+			dataConnMock.
+				On("Close").
+				Return(nil).
+				Once()
+
+			dialer := ftpConnectionMocks.NewDialer(t)
+			dialer.
+				On("DialContext", ctx, "tcp", fmt.Sprintf("%s:21103", host)).
+				Return(dataConnMock, nil).
+				Once()
+
+			connMock := ftpConnectionMocks.NewTextConnection(t)
+			// mock setup for login
+			setMocksForLogin(connMock, false)
+			setMocksForSystem(connMock)
+			// mock setup for list
+			connMock.
+				On("Cmd", fmt.Sprintf(models.CommandPreTransfer, models.CommandList), remotePath).
+				Return(uid, nil).
+				Once()
+			connMock.
+				On("ReadResponse", models.StatusCommandOK).
+				Return(models.StatusCommandOK, "", nil).
+				Once()
+			connMock.
+				On("Cmd", models.CommandExtendedPassiveMode).
+				Return(uid, nil).
+				Once()
+			connMock.
+				On("ReadResponse", models.StatusExtendedPassiveMode).
+				Return(models.StatusExtendedPassiveMode, extendedPassiveModeMessage, nil).
+				Once()
+			connMock.
+				On("Cmd", models.CommandList, remotePath).
+				Return(uid, nil).
+				Once()
+			connMock.
+				On("ReadResponse", models.StatusNoCheck).
+				Return(models.StatusAboutToSend, listMessage, nil).
+				Once()
+			connMock.
+				On("ReadResponse", models.StatusClosingDataConnection).
+				Return(models.StatusClosingDataConnection, "", nil).
+				Once()
+			if tc.mdtmReply != "" || tc.mdtmErr != nil {
+				connMock.
+					On("Cmd", models.CommandModificationTime, remotePath+"/file-1.txt").
+					Return(uid, nil).
+					Once()
+				connMock.
+					On("ReadResponse", models.StatusFile).
+					Return(models.StatusFile, tc.mdtmReply, tc.mdtmErr).
+					Once()
+			}
+
+			serverConn, err := ftpconnection.NewConnection(
+				host,
+				dialer,
+				tcpConn,
+				connMock,
+				tc.option,
+			)
+			require.NoError(t, err)
+
+			// this is required to feed the feature map
+			err = serverConn.Login(user, password)
+			require.NoError(t, err)
+
+			result, err := serverConn.List(ctx, &connection.ListOptions{
+				Path: remotePath,
+			})
+			assert.NoError(t, err)
+			require.NotNil(t, result)
+			if assert.Len(t, result.Entries, 1) {
+				assert.Equal(t, tc.expectedDate, result.Entries[0].LastModificationDate)
+			}
+		})
 	}
 }
 
@@ -358,8 +717,8 @@ func Test_ServerConnection_List_InvalidArgumentError(t *testing.T) {
 	serverConn, err := ftpconnection.NewConnection(host, dialer, tcpConn, connMock)
 	require.NoError(t, err)
 
-	entries, err := serverConn.List(ctx, nil)
-	assert.Nil(t, entries)
+	result, err := serverConn.List(ctx, nil)
+	assert.Nil(t, result)
 	require.EqualError(t, err, "an invalid argument error occurred: argument options cannot be nil")
 	assert.IsType(t, ftperrors.InvalidArgumentErrorType, err)
 	assert.NoError(t, errors.Unwrap(err))
@@ -374,6 +733,7 @@ func Test_ServerConnection_List_PreTransferCmdError(t *testing.T) {
 	connMock := ftpConnectionMocks.NewTextConnection(t)
 	// mock setup for login
 	setMocksForLogin(connMock, false)
+	setMocksForSystem(connMock)
 	// mock setup for list
 	connMock.
 		On("Cmd", fmt.Sprintf(models.CommandPreTransfer, models.CommandList), remotePath).
@@ -391,8 +751,8 @@ func Test_ServerConnection_List_PreTransferCmdError(t *testing.T) {
 		Path: remotePath,
 	}
 
-	entries, err := serverConn.List(ctx, options)
-	assert.Nil(t, entries)
+	result, err := serverConn.List(ctx, options)
+	assert.Nil(t, result)
 	require.EqualError(t, err, "an internal error occurred: failed to list files")
 	assert.IsType(t, ftperrors.InternalErrorType, err)
 	assert.EqualError(t, errors.Unwrap(err), "an internal error occurred: failed to issue pre-transfer configuration")
@@ -407,6 +767,7 @@ func Test_ServerConnection_List_ExtendedPassiveModeError(t *testing.T) {
 	connMock := ftpConnectionMocks.NewTextConnection(t)
 	// mock setup for login
 	setMocksForLogin(connMock, false)
+	setMocksForSystem(connMock)
 	// mock setup for list
 	connMock.
 		On("Cmd", fmt.Sprintf(models.CommandPreTransfer, models.CommandList), remotePath).
@@ -432,8 +793,8 @@ func Test_ServerConnection_List_ExtendedPassiveModeError(t *testing.T) {
 		Path: remotePath,
 	}
 
-	entries, err := serverConn.List(ctx, options)
-	assert.Nil(t, entries)
+	result, err := serverConn.List(ctx, options)
+	assert.Nil(t, result)
 	require.EqualError(t, err, "an internal error occurred: failed to list files")
 	assert.IsType(t, ftperrors.InternalErrorType, err)
 	assert.EqualError(t, errors.Unwrap(err), "an internal error occurred: failed to set extended passive mode")
@@ -472,6 +833,7 @@ func Test_ServerConnection_List_ExtendedPassiveModeMessageError(t *testing.T) {
 			connMock := ftpConnectionMocks.NewTextConnection(t)
 			// mock setup for login
 			setMocksForLogin(connMock, false)
+			setMocksForSystem(connMock)
 			// mock setup for list
 			connMock.
 				On("Cmd", fmt.Sprintf(models.CommandPreTransfer, models.CommandList), remotePath).
@@ -501,8 +863,8 @@ func Test_ServerConnection_List_ExtendedPassiveModeMessageError(t *testing.T) {
 				Path: remotePath,
 			}
 
-			entries, err := serverConn.List(ctx, options)
-			assert.Nil(t, entries)
+			result, err := serverConn.List(ctx, options)
+			assert.Nil(t, result)
 			require.EqualError(t, err, "an internal error occurred: failed to list files")
 			assert.IsType(t, ftperrors.InternalErrorType, err)
 			assert.EqualError(t, errors.Unwrap(err), tc.wrappedErrMsg)
@@ -519,6 +881,7 @@ func Test_ServerConnection_List_WithDisabledEPSV_PassiveModeCmdError(t *testing.
 	connMock := ftpConnectionMocks.NewTextConnection(t)
 	// mock setup for login
 	setMocksForLogin(connMock, false)
+	setMocksForSystem(connMock)
 	// mock setup for list
 	connMock.
 		On("Cmd", fmt.Sprintf(models.CommandPreTransfer, models.CommandList), remotePath).
@@ -550,8 +913,8 @@ func Test_ServerConnection_List_WithDisabledEPSV_PassiveModeCmdError(t *testing.
 		Path: remotePath,
 	}
 
-	entries, err := serverConn.List(ctx, options)
-	assert.Nil(t, entries)
+	result, err := serverConn.List(ctx, options)
+	assert.Nil(t, result)
 	require.EqualError(t, err, "an internal error occurred: failed to list files")
 	assert.IsType(t, ftperrors.InternalErrorType, err)
 	assert.EqualError(t, errors.Unwrap(err), "an internal error occurred: failed to set passive mode")
@@ -595,6 +958,7 @@ func Test_ServerConnection_List_WithDisabledEPSV_InvalidPassiveModeMessageError(
 			connMock := ftpConnectionMocks.NewTextConnection(t)
 			// mock setup for login
 			setMocksForLogin(connMock, false)
+			setMocksForSystem(connMock)
 			// mock setup for list
 			connMock.
 				On("Cmd", fmt.Sprintf(models.CommandPreTransfer, models.CommandList), remotePath).
@@ -630,8 +994,8 @@ func Test_ServerConnection_List_WithDisabledEPSV_InvalidPassiveModeMessageError(
 				Path: remotePath,
 			}
 
-			entries, err := serverConn.List(ctx, options)
-			assert.Nil(t, entries)
+			result, err := serverConn.List(ctx, options)
+			assert.Nil(t, result)
 			require.EqualError(t, err, "an internal error occurred: failed to list files")
 			assert.IsType(t, ftperrors.InternalErrorType, err)
 			assert.EqualError(t, errors.Unwrap(err), tc.wrappedErrMsg)
@@ -659,6 +1023,7 @@ func Test_ServerConnection_List_ListCmdError(t *testing.T) {
 	connMock := ftpConnectionMocks.NewTextConnection(t)
 	// mock setup for login
 	setMocksForLogin(connMock, false)
+	setMocksForSystem(connMock)
 	// mock setup for list
 	connMock.
 		On("Cmd", fmt.Sprintf(models.CommandPreTransfer, models.CommandList), remotePath).
@@ -692,8 +1057,8 @@ func Test_ServerConnection_List_ListCmdError(t *testing.T) {
 		Path: remotePath,
 	}
 
-	entries, err := serverConn.List(ctx, options)
-	assert.Nil(t, entries)
+	result, err := serverConn.List(ctx, options)
+	assert.Nil(t, result)
 	require.EqualError(t, err, "an internal error occurred: failed to list files")
 	assert.IsType(t, ftperrors.InternalErrorType, err)
 	assert.EqualError(t, errors.Unwrap(err), "mock error")
@@ -719,6 +1084,7 @@ func Test_ServerConnection_List_ListCmdFailedWithCloseError(t *testing.T) {
 	connMock := ftpConnectionMocks.NewTextConnection(t)
 	// mock setup for login
 	setMocksForLogin(connMock, false)
+	setMocksForSystem(connMock)
 	// mock setup for list
 	connMock.
 		On("Cmd", fmt.Sprintf(models.CommandPreTransfer, models.CommandList), remotePath).
@@ -752,8 +1118,8 @@ func Test_ServerConnection_List_ListCmdFailedWithCloseError(t *testing.T) {
 		Path: remotePath,
 	}
 
-	entries, err := serverConn.List(ctx, options)
-	assert.Nil(t, entries)
+	result, err := serverConn.List(ctx, options)
+	assert.Nil(t, result)
 	require.EqualError(t, err, "an internal error occurred: failed to list files")
 	assert.IsType(t, ftperrors.InternalErrorType, err)
 	assert.EqualError(t, errors.Unwrap(err), "mock close error")
@@ -779,6 +1145,7 @@ func Test_ServerConnection_List_InvalidStatusFromListCmd(t *testing.T) {
 	connMock := ftpConnectionMocks.NewTextConnection(t)
 	// mock setup for login
 	setMocksForLogin(connMock, false)
+	setMocksForSystem(connMock)
 	// mock setup for list
 	connMock.
 		On("Cmd", fmt.Sprintf(models.CommandPreTransfer, models.CommandList), remotePath).
@@ -816,8 +1183,8 @@ func Test_ServerConnection_List_InvalidStatusFromListCmd(t *testing.T) {
 		Path: remotePath,
 	}
 
-	entries, err := serverConn.List(ctx, options)
-	assert.Nil(t, entries)
+	result, err := serverConn.List(ctx, options)
+	assert.Nil(t, result)
 	require.EqualError(t, err, "an internal error occurred: failed to list files")
 	assert.IsType(t, ftperrors.InternalErrorType, err)
 	assert.EqualError(t, errors.Unwrap(err), "an internal error occurred: mock error")
@@ -843,6 +1210,7 @@ func Test_ServerConnection_List_InvalidStatusFromListCmdCloseError(t *testing.T)
 	connMock := ftpConnectionMocks.NewTextConnection(t)
 	// mock setup for login
 	setMocksForLogin(connMock, false)
+	setMocksForSystem(connMock)
 	// mock setup for list
 	connMock.
 		On("Cmd", fmt.Sprintf(models.CommandPreTransfer, models.CommandList), remotePath).
@@ -880,8 +1248,8 @@ func Test_ServerConnection_List_InvalidStatusFromListCmdCloseError(t *testing.T)
 		Path: remotePath,
 	}
 
-	entries, err := serverConn.List(ctx, options)
-	assert.Nil(t, entries)
+	result, err := serverConn.List(ctx, options)
+	assert.Nil(t, result)
 	require.EqualError(t, err, "an internal error occurred: failed to list files")
 	assert.IsType(t, ftperrors.InternalErrorType, err)
 	assert.EqualError(t, errors.Unwrap(err), "mock close error")
@@ -925,6 +1293,7 @@ func Test_ServerConnection_List_ParserError(t *testing.T) {
 	connMock := ftpConnectionMocks.NewTextConnection(t)
 	// mock setup for login
 	setMocksForLogin(connMock, false)
+	setMocksForSystem(connMock)
 	// mock setup for list
 	connMock.
 		On("Cmd", fmt.Sprintf(models.CommandPreTransfer, models.CommandList), remotePath).
@@ -966,8 +1335,8 @@ func Test_ServerConnection_List_ParserError(t *testing.T) {
 		Path: remotePath,
 	}
 
-	entries, err := serverConn.List(ctx, options)
-	assert.Nil(t, entries)
+	result, err := serverConn.List(ctx, options)
+	assert.Nil(t, result)
 	require.EqualError(t, err, "an internal error occurred: failed to list files")
 	assert.IsType(t, ftperrors.InternalErrorType, err)
 	assert.EqualError(t, errors.Unwrap(err), "1 error occurred:\n\t* an internal error occurred: unsupported entry format\n\n")
@@ -1001,6 +1370,7 @@ func Test_ServerConnection_List_ScannerError(t *testing.T) {
 	connMock := ftpConnectionMocks.NewTextConnection(t)
 	// mock setup for login
 	setMocksForLogin(connMock, false)
+	setMocksForSystem(connMock)
 	// mock setup for list
 	connMock.
 		On("Cmd", fmt.Sprintf(models.CommandPreTransfer, models.CommandList), remotePath).
@@ -1042,8 +1412,8 @@ func Test_ServerConnection_List_ScannerError(t *testing.T) {
 		Path: remotePath,
 	}
 
-	entries, err := serverConn.List(ctx, options)
-	assert.Nil(t, entries)
+	result, err := serverConn.List(ctx, options)
+	assert.Nil(t, result)
 	require.EqualError(t, err, "an internal error occurred: failed to list files")
 	assert.IsType(t, ftperrors.InternalErrorType, err)
 	assert.EqualError(t, errors.Unwrap(err), "1 error occurred:\n\t* bufio.Scanner: Read returned impossible count\n\n")
@@ -1085,6 +1455,7 @@ func Test_ServerConnection_List_ConnCloseError(t *testing.T) {
 	connMock := ftpConnectionMocks.NewTextConnection(t)
 	// mock setup for login
 	setMocksForLogin(connMock, false)
+	setMocksForSystem(connMock)
 	// mock setup for list
 	connMock.
 		On("Cmd", fmt.Sprintf(models.CommandPreTransfer, models.CommandList), remotePath).
@@ -1126,8 +1497,8 @@ func Test_ServerConnection_List_ConnCloseError(t *testing.T) {
 		Path: remotePath,
 	}
 
-	entries, err := serverConn.List(ctx, options)
-	assert.Nil(t, entries)
+	result, err := serverConn.List(ctx, options)
+	assert.Nil(t, result)
 	require.EqualError(t, err, "an internal error occurred: failed to list files")
 	assert.IsType(t, ftperrors.InternalErrorType, err)
 	assert.EqualError(t, errors.Unwrap(err), "1 error occurred:\n\t* mock error\n\n")
@@ -1169,6 +1540,7 @@ func Test_ServerConnection_List_SetDeadlineError(t *testing.T) {
 	connMock := ftpConnectionMocks.NewTextConnection(t)
 	// mock setup for login
 	setMocksForLogin(connMock, false)
+	setMocksForSystem(connMock)
 	// mock setup for list
 	connMock.
 		On("Cmd", fmt.Sprintf(models.CommandPreTransfer, models.CommandList), remotePath).
@@ -1206,8 +1578,8 @@ func Test_ServerConnection_List_SetDeadlineError(t *testing.T) {
 		Path: remotePath,
 	}
 
-	entries, err := serverConn.List(ctx, options)
-	assert.Nil(t, entries)
+	result, err := serverConn.List(ctx, options)
+	assert.Nil(t, result)
 	require.EqualError(t, err, "an internal error occurred: failed to list files")
 	assert.IsType(t, ftperrors.InternalErrorType, err)
 	assert.EqualError(t, errors.Unwrap(err), "1 error occurred:\n\t* mock error\n\n")
@@ -1249,6 +1621,7 @@ func Test_ServerConnection_List_CheckConnShutError(t *testing.T) {
 	connMock := ftpConnectionMocks.NewTextConnection(t)
 	// mock setup for login
 	setMocksForLogin(connMock, false)
+	setMocksForSystem(connMock)
 	// mock setup for list
 	connMock.
 		On("Cmd", fmt.Sprintf(models.CommandPreTransfer, models.CommandList), remotePath).
@@ -1290,8 +1663,8 @@ func Test_ServerConnection_List_CheckConnShutError(t *testing.T) {
 		Path: remotePath,
 	}
 
-	entries, err := serverConn.List(ctx, options)
-	assert.Nil(t, entries)
+	result, err := serverConn.List(ctx, options)
+	assert.Nil(t, result)
 	require.EqualError(t, err, "an internal error occurred: failed to list files")
 	assert.IsType(t, ftperrors.InternalErrorType, err)
 	assert.EqualError(t, errors.Unwrap(err), "1 error occurred:\n\t* mock error\n\n")
@@ -0,0 +1,76 @@
+package ftpconnection
+
+import (
+	"strings"
+	"time"
+
+	"github.com/alexZaicev/go-ftp-client/internal/adapters/ftpconnection/models"
+	"github.com/alexZaicev/go-ftp-client/internal/domain/entities"
+	ftperrors "github.com/alexZaicev/go-ftp-client/internal/domain/errors"
+)
+
+const (
+	modificationTimeFormat = "20060102150405"
+
+	// time zone offsets are multiples of 15 minutes, ranging from UTC-12:00 to UTC+14:00
+	locationOffsetPrecision = 15 * time.Minute
+	maxLocationOffset       = 14 * time.Hour
+)
+
+// detectServerLocation function derives the location of the server by comparing the LIST date of a
+// recently modified file, which is listed with time of the day, with its MDTM date in UTC. Provided
+// entries, which were parsed prior to the detection, are adjusted to the detected location.
+func (c *ServerConnection) detectServerLocation(dirPath string, entries []*entities.Entry) {
+	if !c.features.SupportMDTM {
+		c.locationDetected = true
+		return
+	}
+
+	for _, entry := range entries {
+		// entries older than six months are listed with a year instead of time of the day
+		if entry.Type != entities.EntryTypeFile || !entry.YearUnknown {
+			continue
+		}
+
+		// only a single file is checked to limit the number of round trips to the server
+		c.locationDetected = true
+
+		modTime, err := c.modificationTime(entities.RemotePath(dirPath).Join(entry.Name).String())
+		if err != nil {
+			return
+		}
+
+		listed := entry.LastModificationDate
+		listedTime := time.Date(
+			modTime.Year(), listed.Month(), listed.Day(), listed.Hour(), listed.Minute(), 0, 0, time.UTC,
+		)
+		offset := listedTime.Sub(modTime.Truncate(time.Minute)).Round(locationOffsetPrecision)
+		if offset > maxLocationOffset || offset < -maxLocationOffset {
+			// dates are too far apart, most likely the file was modified around the new year
+			return
+		}
+
+		c.location = time.FixedZone("", int(offset.Seconds()))
+		for _, e := range entries {
+			e.LastModificationDate = e.LastModificationDate.Add(-offset)
+		}
+		return
+	}
+}
+
+// modificationTime function fetches last modification time of the file in UTC.
+func (c *ServerConnection) modificationTime(filePath string) (time.Time, error) {
+	_, msg, err := c.cmd(models.StatusFile, models.CommandModificationTime, filePath)
+	if err != nil {
+		return time.Time{}, ftperrors.NewInternalError("failed to fetch file modification time", err)
+	}
+	// RFC3659 allows fractions of a second, which are dropped as listed dates are accurate to minutes
+	if idx := strings.IndexByte(msg, '.'); idx >= 0 {
+		msg = msg[:idx]
+	}
+	modTime, err := time.Parse(modificationTimeFormat, msg)
+	if err != nil {
+		return time.Time{}, ftperrors.NewInternalError("failed to parse file modification time", err)
+	}
+	return modTime, nil
+}
@@ -5,6 +5,7 @@ import (
 	"strings"
 
 	"github.com/alexZaicev/go-ftp-client/internal/adapters/ftpconnection/models"
+	"github.com/alexZaicev/go-ftp-client/internal/adapters/parsers"
 	ftperrors "github.com/alexZaicev/go-ftp-client/internal/domain/errors"
 )
 
@@ -25,6 +26,9 @@ func (c *ServerConnection) Login(user, password string) error {
 	default:
 		return ftperrors.NewInternalError(msg, nil)
 	}
+	// user starts in its home directory, which is fetched from the server once needed
+	c.workDir, c.homeDir, c.dirChanged = "", "", false
+	c.knownDirs = make(map[string]bool)
 
 	if updateErr := c.updateFeatures(); updateErr != nil {
 		return updateErr
@@ -61,6 +65,12 @@ func (c *ServerConnection) updateFeatures() error {
 		}
 	}
 
+	if c.features.SupportLANG && c.listLanguage == parsers.LanguageAuto {
+		if langErr := c.setEnglishLanguage(); langErr != nil {
+			return ftperrors.NewInternalError("failed to set English language", langErr)
+		}
+	}
+
 	// If using implicit TLS, make data connections also use TLS
 	if c.tlsConfig != nil {
 		if _, _, err = c.cmd(models.StatusCommandOK, models.CommandProtectionBufferSize); err != nil {
@@ -121,3 +131,22 @@ func (c *ServerConnection) setUTF8() error {
 	}
 	return nil
 }
+
+// setEnglishLanguage function negotiates English language on servers that advertise it with
+// the LANG feature, so that month abbreviations in LIST command entries are not localized. If
+// the server rejects the language, it's ignored and all supported languages are tried upon parsing.
+func (c *ServerConnection) setEnglishLanguage() error {
+	if !c.features.SupportsLanguage(parsers.LanguageEnglish) {
+		return nil
+	}
+
+	code, _, err := c.cmd(models.StatusNoCheck, models.CommandLanguage, parsers.LanguageEnglish)
+	if err != nil {
+		return err
+	}
+
+	if code == models.StatusCommandOK {
+		c.listLanguage = parsers.LanguageEnglish
+	}
+	return nil
+}
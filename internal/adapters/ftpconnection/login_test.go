@@ -10,6 +10,7 @@ import (
 
 	"github.com/alexZaicev/go-ftp-client/internal/adapters/ftpconnection"
 	"github.com/alexZaicev/go-ftp-client/internal/adapters/ftpconnection/models"
+	"github.com/alexZaicev/go-ftp-client/internal/adapters/parsers"
 	ftperrors "github.com/alexZaicev/go-ftp-client/internal/domain/errors"
 	ftpConnectionMocks "github.com/alexZaicev/go-ftp-client/mocks/adapters/ftpconnection"
 )
@@ -334,6 +335,77 @@ func Test_ServerConnection_Login_UTF8Disabled_Success(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func Test_ServerConnection_Login_LANGFeature_Success(t *testing.T) {
+	testCases := []struct {
+		name           string
+		options        []ftpconnection.Option
+		langStatusCode int
+	}{
+		{
+			name:           "english language accepted",
+			langStatusCode: models.StatusCommandOK,
+		},
+		{
+			name:           "english language rejected",
+			langStatusCode: models.StatusNotImplementedParameter,
+		},
+		{
+			name: "list language set",
+			options: []ftpconnection.Option{
+				ftpconnection.WithListLanguage(parsers.LanguageFrench),
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tcpConn := ftpConnectionMocks.NewConn(t)
+			dialer := ftpConnectionMocks.NewDialer(t)
+			connMock := ftpConnectionMocks.NewTextConnection(t)
+			connMock.
+				On("Cmd", models.CommandUser, user).
+				Return(uid, nil).
+				Once()
+			connMock.
+				On("ReadResponse", models.StatusNoCheck).
+				Return(models.StatusLoggedIn, "", nil).
+				Once()
+			connMock.
+				On("Cmd", models.CommandFeat).
+				Return(uid, nil).
+				Once()
+			connMock.
+				On("ReadResponse", models.StatusNoCheck).
+				Return(models.StatusSystem, featureMsgWithLANG, nil).
+				Once()
+			connMock.
+				On("Cmd", models.CommandType).
+				Return(uid, nil).
+				Once()
+			connMock.
+				On("ReadResponse", models.StatusCommandOK).
+				Return(models.StatusCommandOK, "", nil).
+				Once()
+			if tc.langStatusCode != 0 {
+				connMock.
+					On("Cmd", models.CommandLanguage, parsers.LanguageEnglish).
+					Return(uid, nil).
+					Once()
+				connMock.
+					On("ReadResponse", models.StatusNoCheck).
+					Return(tc.langStatusCode, "", nil).
+					Once()
+			}
+
+			serverConn, err := ftpconnection.NewConnection(host, dialer, tcpConn, connMock, tc.options...)
+			require.NoError(t, err)
+
+			err = serverConn.Login(user, password)
+			assert.NoError(t, err)
+		})
+	}
+}
+
 //nolint:dupl // similar to Test_ServerConnection_Move_PrepareCmdError
 func Test_ServerConnection_Login_UserCmdError(t *testing.T) {
 	tcpConn := ftpConnectionMocks.NewConn(t)
@@ -2,49 +2,124 @@ package ftpconnection
 
 import (
 	"errors"
-	"path/filepath"
+	"fmt"
 	"strings"
 
 	"github.com/alexZaicev/go-ftp-client/internal/adapters/ftpconnection/models"
+	"github.com/alexZaicev/go-ftp-client/internal/domain/entities"
 	ftperrors "github.com/alexZaicev/go-ftp-client/internal/domain/errors"
 )
 
-func (c *ServerConnection) Mkdir(path string) error {
-	if path == "" {
+// Mkdir function creates the directory along with its missing parents. Relative paths are resolved
+// against the working directory. The directory is created straight away, and only if that fails are
+// its parents created, so that existing trees cost a single round trip. Directories known to exist
+// are cached for the rest of the session.
+func (c *ServerConnection) Mkdir(dirPath string) error {
+	if dirPath == "" {
 		return ftperrors.NewInvalidArgumentError("path", ftperrors.ErrMsgCannotBeBlank)
 	}
 
-	if strings.HasPrefix(path, string(filepath.Separator)) {
-		path = path[1:]
+	dirPath, err := c.resolvePath(dirPath)
+	if err != nil {
+		return err
 	}
-	pathTokens := strings.Split(path, string(filepath.Separator))
 
-	var builder strings.Builder
-	builder.WriteString("/")
-	for idx, pathToken := range pathTokens {
-		if idx > 0 {
-			builder.WriteRune(filepath.Separator)
-		}
-		builder.WriteString(pathToken)
+	return c.mkdirAll(entities.RemotePath(dirPath).TrimTrailingSlash())
+}
 
-		pathToCreate := builder.String()
+func (c *ServerConnection) mkdirAll(dirPath entities.RemotePath) error {
+	if dirPath == "" || dirPath.IsRoot() || c.knownDirs[dirPath.String()] {
+		return nil
+	}
 
-		if err := c.Cd(pathToCreate); err != nil {
-			var notFoundErr *ftperrors.NotFoundError
-			if !errors.As(err, &notFoundErr) {
+	created, err := c.makeDir(dirPath)
+	if err != nil {
+		return err
+	}
+	if !created {
+		// a parent may be missing, unless it is known to exist already
+		parentDir := dirPath.Dir()
+		parentKnown := parentDir == "" || parentDir.IsRoot() || c.knownDirs[parentDir.String()]
+		if !parentKnown {
+			if err = c.mkdirAll(parentDir); err != nil {
 				return err
 			}
-
-			_, _, mkdErr := c.cmd(models.StatusPathCreated, models.CommandMakeDir, pathToCreate)
-			if mkdErr != nil {
-				return ftperrors.NewInternalError("failed to create directory", mkdErr)
+			if created, err = c.makeDir(dirPath); err != nil {
+				return err
 			}
 		}
 	}
-
-	if err := c.Cd("/"); err != nil {
-		return err
+	if !created {
+		// reply is ambiguous, as some servers do not tell existing directories apart from other failures
+		exists, existsErr := c.dirExists(dirPath)
+		if existsErr != nil {
+			return existsErr
+		}
+		if !exists {
+			return ftperrors.NewInternalError(fmt.Sprintf("failed to create directory %s", dirPath), nil)
+		}
 	}
 
+	c.knownDirs[dirPath.String()] = true
 	return nil
 }
+
+// makeDir function sends MKD command, reporting whether the directory was created or already exists.
+// Other permanent failures are reported as not created, since they may be caused by a missing parent.
+func (c *ServerConnection) makeDir(dirPath entities.RemotePath) (bool, error) {
+	code, msg, err := c.cmd(models.StatusNoCheck, models.CommandMakeDir, dirPath.String())
+	if err != nil {
+		return false, ftperrors.NewInternalError("failed to create directory", err)
+	}
+
+	switch {
+	case code == models.StatusPathCreated, code == models.StatusDirectoryExists:
+		return true, nil
+	case code == models.StatusFileUnavailable:
+		return strings.Contains(strings.ToLower(msg), "exists"), nil
+	case code >= 500:
+		return false, nil
+	}
+	return false, ftperrors.NewInternalError(msg, nil)
+}
+
+// dirExists function checks whether the directory exists by changing into it, after which the working
+// directory is restored.
+func (c *ServerConnection) dirExists(dirPath entities.RemotePath) (bool, error) {
+	prevDir, err := c.Pwd()
+	if err != nil {
+		return false, err
+	}
+
+	if cdErr := c.Cd(dirPath.String()); cdErr != nil {
+		var notFoundErr *ftperrors.NotFoundError
+		if errors.As(cdErr, &notFoundErr) {
+			return false, nil
+		}
+		return false, cdErr
+	}
+	if cdErr := c.Cd(prevDir); cdErr != nil {
+		return false, cdErr
+	}
+	return true, nil
+}
+
+// forgetDirs function drops the directory and its descendants from the cache of known directories once
+// it is removed or moved.
+func (c *ServerConnection) forgetDirs(dirPath string) {
+	remotePath := entities.RemotePath(dirPath)
+	if !remotePath.IsAbs() {
+		if c.workDir == "" {
+			c.knownDirs = make(map[string]bool)
+			return
+		}
+		remotePath = remotePath.Resolve(entities.RemotePath(c.workDir), "")
+	}
+
+	remotePath = remotePath.Clean()
+	for knownDir := range c.knownDirs {
+		if _, ok := entities.RemotePath(knownDir).Rel(remotePath); ok {
+			delete(c.knownDirs, knownDir)
+		}
+	}
+}
@@ -26,178 +26,222 @@ func Test_ServerConnection_Mkdir_InvalidArgumentError(t *testing.T) {
 	assert.NoError(t, errors.Unwrap(err))
 }
 
-func Test_ServerConnection_Mkdir_1dPath_Success(t *testing.T) {
-	const path = "/foo"
-
-	tcpConn := ftpConnectionMocks.NewConn(t)
-	dialer := ftpConnectionMocks.NewDialer(t)
-	connMock := ftpConnectionMocks.NewTextConnection(t)
-	connMock.
-		On("Cmd", models.CommandChangeWorkDir, path).
-		Return(uid, nil).
-		Once()
-	connMock.
-		On("ReadResponse", models.StatusNoCheck).
-		Return(models.StatusFileUnavailable, "", nil).
-		Once()
-	connMock.
-		On("Cmd", models.CommandMakeDir, path).
-		Return(uid, nil).
-		Once()
-	connMock.
-		On("ReadResponse", models.StatusPathCreated).
-		Return(models.StatusPathCreated, "", nil).
-		Once()
-	connMock.
-		On("Cmd", models.CommandChangeWorkDir, "/").
-		Return(uid, nil).
-		Once()
-	connMock.
-		On("ReadResponse", models.StatusNoCheck).
-		Return(models.StatusRequestedFileActionOK, "", nil).
-		Once()
-
-	serverConn, err := ftpconnection.NewConnection(host, dialer, tcpConn, connMock)
-	require.NoError(t, err)
-
-	err = serverConn.Mkdir(path)
-	assert.NoError(t, err)
+//nolint:funlen // test case can get a bit large
+func Test_ServerConnection_Mkdir_Success(t *testing.T) {
+	testCases := []struct {
+		name     string
+		path     string
+		setMocks func(connMock *ftpConnectionMocks.TextConnection)
+	}{
+		{
+			name: "directory created",
+			path: "/foo/bar/",
+			setMocks: func(connMock *ftpConnectionMocks.TextConnection) {
+				setMocksForNoCheckCmd(connMock, models.CommandMakeDir, "/foo/bar", models.StatusPathCreated, "")
+			},
+		},
+		{
+			name: "directory exists",
+			path: "/foo/bar",
+			setMocks: func(connMock *ftpConnectionMocks.TextConnection) {
+				setMocksForNoCheckCmd(connMock, models.CommandMakeDir, "/foo/bar", models.StatusDirectoryExists, "")
+			},
+		},
+		{
+			name: "directory exists reply",
+			path: "/foo/bar",
+			setMocks: func(connMock *ftpConnectionMocks.TextConnection) {
+				setMocksForNoCheckCmd(
+					connMock, models.CommandMakeDir, "/foo/bar", models.StatusFileUnavailable, "/foo/bar: File exists",
+				)
+			},
+		},
+		{
+			name: "parents created",
+			path: "/foo/bar",
+			setMocks: func(connMock *ftpConnectionMocks.TextConnection) {
+				setMocksForNoCheckCmd(connMock, models.CommandMakeDir, "/foo/bar", models.StatusFileUnavailable, "")
+				setMocksForNoCheckCmd(connMock, models.CommandMakeDir, "/foo", models.StatusPathCreated, "")
+				setMocksForNoCheckCmd(connMock, models.CommandMakeDir, "/foo/bar", models.StatusPathCreated, "")
+			},
+		},
+		{
+			name: "ambiguous reply checked",
+			path: "/foo",
+			setMocks: func(connMock *ftpConnectionMocks.TextConnection) {
+				setMocksForNoCheckCmd(
+					connMock, models.CommandMakeDir, "/foo", models.StatusFileUnavailable, "Create directory operation failed.",
+				)
+				setMocksForPwd(connMock, homeDirPath)
+				setMocksForNoCheckCmd(
+					connMock, models.CommandChangeWorkDir, "/foo", models.StatusRequestedFileActionOK, "",
+				)
+				setMocksForNoCheckCmd(
+					connMock, models.CommandChangeWorkDir, homeDirPath, models.StatusRequestedFileActionOK, "",
+				)
+			},
+		},
+		{
+			name: "relative path",
+			path: "foo/bar",
+			setMocks: func(connMock *ftpConnectionMocks.TextConnection) {
+				setMocksForPwd(connMock, homeDirPath)
+				setMocksForNoCheckCmd(
+					connMock, models.CommandMakeDir, homeDirPath+"/foo/bar", models.StatusPathCreated, "",
+				)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tcpConn := ftpConnectionMocks.NewConn(t)
+			dialer := ftpConnectionMocks.NewDialer(t)
+			connMock := ftpConnectionMocks.NewTextConnection(t)
+			tc.setMocks(connMock)
+
+			serverConn, err := ftpconnection.NewConnection(host, dialer, tcpConn, connMock)
+			require.NoError(t, err)
+
+			// directory is known to exist after it is created, so it is not created again
+			for i := 0; i < 2; i++ {
+				assert.NoError(t, serverConn.Mkdir(tc.path))
+			}
+		})
+	}
 }
 
-func Test_ServerConnection_Mkdir_2dPath_Success(t *testing.T) {
-	const path = "foo/bar"
-
+func Test_ServerConnection_Mkdir_RemovedDirForgotten(t *testing.T) {
 	tcpConn := ftpConnectionMocks.NewConn(t)
 	dialer := ftpConnectionMocks.NewDialer(t)
 	connMock := ftpConnectionMocks.NewTextConnection(t)
+	setMocksForNoCheckCmd(connMock, models.CommandMakeDir, "/foo/bar", models.StatusFileUnavailable, "")
+	setMocksForNoCheckCmd(connMock, models.CommandMakeDir, "/foo", models.StatusPathCreated, "")
+	setMocksForNoCheckCmd(connMock, models.CommandMakeDir, "/foo/bar", models.StatusPathCreated, "")
 	connMock.
-		On("Cmd", models.CommandChangeWorkDir, "/foo").
-		Return(uid, nil).
-		Once()
-	connMock.
-		On("ReadResponse", models.StatusNoCheck).
-		Return(models.StatusFileUnavailable, "", nil).
-		Twice()
-	connMock.
-		On("Cmd", models.CommandMakeDir, "/foo").
-		Return(uid, nil).
-		Once()
-	connMock.
-		On("ReadResponse", models.StatusPathCreated).
-		Return(models.StatusPathCreated, "", nil).
-		Twice()
-	connMock.
-		On("Cmd", models.CommandChangeWorkDir, "/foo/bar").
-		Return(uid, nil).
-		Once()
-	connMock.
-		On("Cmd", models.CommandMakeDir, "/foo/bar").
+		On("Cmd", models.CommandRemoveDir, "/foo/bar").
 		Return(uid, nil).
 		Once()
 	connMock.
-		On("Cmd", models.CommandChangeWorkDir, "/").
-		Return(uid, nil).
-		Once()
-	connMock.
-		On("ReadResponse", models.StatusNoCheck).
+		On("ReadResponse", models.StatusRequestedFileActionOK).
 		Return(models.StatusRequestedFileActionOK, "", nil).
 		Once()
+	setMocksForNoCheckCmd(connMock, models.CommandMakeDir, "/foo/bar", models.StatusPathCreated, "")
 
 	serverConn, err := ftpconnection.NewConnection(host, dialer, tcpConn, connMock)
 	require.NoError(t, err)
 
-	err = serverConn.Mkdir(path)
-	assert.NoError(t, err)
-}
-
-func Test_ServerConnection_Mkdir_CheckDirExistsError(t *testing.T) {
-	const path = "/foo"
-
-	tcpConn := ftpConnectionMocks.NewConn(t)
-	dialer := ftpConnectionMocks.NewDialer(t)
-	connMock := ftpConnectionMocks.NewTextConnection(t)
-	connMock.
-		On("Cmd", models.CommandChangeWorkDir, path).
-		Return(uid, nil).
-		Once()
-	connMock.
-		On("ReadResponse", models.StatusNoCheck).
-		Return(models.StatusBadCommand, "mock error", errors.New("mock error")).
-		Once()
-
-	serverConn, err := ftpconnection.NewConnection(host, dialer, tcpConn, connMock)
-	require.NoError(t, err)
-
-	err = serverConn.Mkdir(path)
-	require.EqualError(t, err, "an internal error occurred: failed to change working directory")
-	assert.IsType(t, ftperrors.InternalErrorType, err)
-	assert.EqualError(t, errors.Unwrap(err), "mock error")
+	require.NoError(t, serverConn.Mkdir("/foo/bar"))
+	require.NoError(t, serverConn.RemoveDir("/foo/bar"))
+	// parent directory is still known to exist
+	require.NoError(t, serverConn.Mkdir("/foo/bar"))
 }
 
-func Test_ServerConnection_Mkdir_MakeDirError(t *testing.T) {
-	const path = "/foo"
-
-	tcpConn := ftpConnectionMocks.NewConn(t)
-	dialer := ftpConnectionMocks.NewDialer(t)
-	connMock := ftpConnectionMocks.NewTextConnection(t)
-	connMock.
-		On("Cmd", models.CommandChangeWorkDir, path).
-		Return(uid, nil).
-		Once()
-	connMock.
-		On("ReadResponse", models.StatusNoCheck).
-		Return(models.StatusFileUnavailable, "", nil).
-		Once()
-	connMock.
-		On("Cmd", models.CommandMakeDir, path).
-		Return(uid, nil).
-		Once()
-	connMock.
-		On("ReadResponse", models.StatusPathCreated).
-		Return(models.StatusBadCommand, "mock error", errors.New("mock error")).
-		Once()
-
-	serverConn, err := ftpconnection.NewConnection(host, dialer, tcpConn, connMock)
-	require.NoError(t, err)
-
-	err = serverConn.Mkdir(path)
-	require.EqualError(t, err, "an internal error occurred: failed to create directory")
-	assert.IsType(t, ftperrors.InternalErrorType, err)
-	assert.EqualError(t, errors.Unwrap(err), "mock error")
-}
-
-func Test_ServerConnection_Mkdir_CdError(t *testing.T) {
-	const path = "/foo"
-
-	tcpConn := ftpConnectionMocks.NewConn(t)
-	dialer := ftpConnectionMocks.NewDialer(t)
-	connMock := ftpConnectionMocks.NewTextConnection(t)
-	connMock.
-		On("Cmd", models.CommandChangeWorkDir, path).
-		Return(uid, nil).
-		Once()
-	connMock.
-		On("ReadResponse", models.StatusNoCheck).
-		Return(models.StatusFileUnavailable, "", nil).
-		Once()
-	connMock.
-		On("Cmd", models.CommandMakeDir, path).
-		Return(uid, nil).
-		Once()
-	connMock.
-		On("ReadResponse", models.StatusPathCreated).
-		Return(models.StatusPathCreated, "", nil).
-		Once()
-	connMock.
-		On("Cmd", models.CommandChangeWorkDir, "/").
-		Return(uid, errors.New("mock error")).
-		Once()
-
-	serverConn, err := ftpconnection.NewConnection(host, dialer, tcpConn, connMock)
-	require.NoError(t, err)
-
-	err = serverConn.Mkdir(path)
-	require.EqualError(t, err, "an internal error occurred: failed to change working directory")
-	assert.IsType(t, ftperrors.InternalErrorType, err)
-	assert.EqualError(t, errors.Unwrap(err), "mock error")
+//nolint:funlen // test case can get a bit large
+func Test_ServerConnection_Mkdir_Errors(t *testing.T) {
+	testCases := []struct {
+		name           string
+		path           string
+		setMocks       func(connMock *ftpConnectionMocks.TextConnection)
+		expectedErrMsg string
+		expectedErr    string
+	}{
+		{
+			name: "make dir command error",
+			path: "/foo",
+			setMocks: func(connMock *ftpConnectionMocks.TextConnection) {
+				connMock.
+					On("Cmd", models.CommandMakeDir, "/foo").
+					Return(uid, errors.New("mock error")).
+					Once()
+			},
+			expectedErrMsg: "an internal error occurred: failed to create directory",
+			expectedErr:    "mock error",
+		},
+		{
+			name: "unexpected reply",
+			path: "/foo",
+			setMocks: func(connMock *ftpConnectionMocks.TextConnection) {
+				setMocksForNoCheckCmd(
+					connMock, models.CommandMakeDir, "/foo", models.StatusRequestFilePending, "File action pending",
+				)
+			},
+			expectedErrMsg: "an internal error occurred: File action pending",
+		},
+		{
+			name: "directory does not exist",
+			path: "/foo",
+			setMocks: func(connMock *ftpConnectionMocks.TextConnection) {
+				setMocksForNoCheckCmd(
+					connMock, models.CommandMakeDir, "/foo", models.StatusFileUnavailable, "Permission denied",
+				)
+				setMocksForPwd(connMock, homeDirPath)
+				setMocksForNoCheckCmd(connMock, models.CommandChangeWorkDir, "/foo", models.StatusFileUnavailable, "")
+			},
+			expectedErrMsg: "an internal error occurred: failed to create directory /foo",
+		},
+		{
+			name: "parent error",
+			path: "/foo/bar",
+			setMocks: func(connMock *ftpConnectionMocks.TextConnection) {
+				setMocksForNoCheckCmd(connMock, models.CommandMakeDir, "/foo/bar", models.StatusFileUnavailable, "")
+				connMock.
+					On("Cmd", models.CommandMakeDir, "/foo").
+					Return(uid, errors.New("mock error")).
+					Once()
+			},
+			expectedErrMsg: "an internal error occurred: failed to create directory",
+			expectedErr:    "mock error",
+		},
+		{
+			name: "working directory error",
+			path: "foo",
+			setMocks: func(connMock *ftpConnectionMocks.TextConnection) {
+				connMock.
+					On("Cmd", models.CommandPrintWorkDir).
+					Return(uid, errors.New("mock error")).
+					Once()
+			},
+			expectedErrMsg: "an internal error occurred: failed to fetch working directory",
+			expectedErr:    "mock error",
+		},
+		{
+			name: "restore working directory error",
+			path: "/foo",
+			setMocks: func(connMock *ftpConnectionMocks.TextConnection) {
+				setMocksForNoCheckCmd(connMock, models.CommandMakeDir, "/foo", models.StatusFileUnavailable, "")
+				setMocksForPwd(connMock, homeDirPath)
+				setMocksForNoCheckCmd(
+					connMock, models.CommandChangeWorkDir, "/foo", models.StatusRequestedFileActionOK, "",
+				)
+				connMock.
+					On("Cmd", models.CommandChangeWorkDir, homeDirPath).
+					Return(uid, errors.New("mock error")).
+					Once()
+			},
+			expectedErrMsg: "an internal error occurred: failed to change working directory",
+			expectedErr:    "mock error",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tcpConn := ftpConnectionMocks.NewConn(t)
+			dialer := ftpConnectionMocks.NewDialer(t)
+			connMock := ftpConnectionMocks.NewTextConnection(t)
+			tc.setMocks(connMock)
+
+			serverConn, err := ftpconnection.NewConnection(host, dialer, tcpConn, connMock)
+			require.NoError(t, err)
+
+			err = serverConn.Mkdir(tc.path)
+			require.EqualError(t, err, tc.expectedErrMsg)
+			assert.IsType(t, ftperrors.InternalErrorType, err)
+			if tc.expectedErr != "" {
+				assert.EqualError(t, errors.Unwrap(err), tc.expectedErr)
+			} else {
+				assert.NoError(t, errors.Unwrap(err))
+			}
+		})
+	}
 }
@@ -23,10 +23,14 @@ const (
 	CommandStore                = "STOR %s"
 	CommandMakeDir              = "MKD %s"
 	CommandChangeWorkDir        = "CWD %s"
+	CommandPrintWorkDir         = "PWD"
 	CommandSize                 = "SIZE %s"
 	CommandRemoveFile           = "DELE %s"
 	CommandRemoveDir            = "RMD %s"
 	CommandRenameFrom           = "RNFR %s"
 	CommandRenameTo             = "RNTO %s"
 	CommandRetrieve             = "RETR %s"
+	CommandLanguage             = "LANG %s"
+	CommandModificationTime     = "MDTM %s"
+	CommandHash                 = "HASH %s"
 )
@@ -1,5 +1,7 @@
 package models
 
+import "strings"
+
 const (
 	FeatureMLST = "MLST"
 	FeatureMDTM = "MDTM"
@@ -8,6 +10,8 @@ const (
 	FeatureUTF8 = "UTF8"
 	FeatureEPSV = "EPSV"
 	FeatureAUTH = "AUTH"
+	FeatureLANG = "LANG"
+	FeatureHASH = "HASH"
 )
 
 type ServerFeatures struct {
@@ -18,6 +22,14 @@ type ServerFeatures struct {
 	SupportEPSV bool
 	SupportUTF8 bool
 	AuthTLS     bool
+	SupportLANG bool
+	// Languages contains language tags advertised by the LANG feature (e.g. en-US, fr-FR).
+	Languages   []string
+	SupportHASH bool
+	// HashAlgorithms contains algorithms advertised by the HASH feature (e.g. SHA-256, MD5).
+	HashAlgorithms []string
+	// HashAlgorithm is the algorithm currently selected for the HASH command.
+	HashAlgorithm string
 }
 
 func NewServerFeatures(featureMap map[string]string) *ServerFeatures {
@@ -38,5 +50,58 @@ func NewServerFeatures(featureMap map[string]string) *ServerFeatures {
 		sf.AuthTLS = true
 	}
 
+	// LANG feature lists supported languages separated by semicolons, where the language
+	// currently in use is marked with an asterisk: LANG en-US*;fr-FR
+	if languages, ok := featureMap[FeatureLANG]; ok {
+		sf.SupportLANG = true
+		for _, language := range strings.Split(languages, ";") {
+			language = strings.TrimSpace(strings.TrimSuffix(language, "*"))
+			if language != "" {
+				sf.Languages = append(sf.Languages, language)
+			}
+		}
+	}
+
+	// HASH feature lists supported algorithms in the same way, marking the selected one with an asterisk:
+	// HASH SHA-256*;SHA-1;MD5
+	if algorithms, ok := featureMap[FeatureHASH]; ok {
+		sf.SupportHASH = true
+		for _, algorithm := range strings.Split(algorithms, ";") {
+			algorithm = strings.TrimSpace(algorithm)
+			selected := strings.HasSuffix(algorithm, "*")
+			algorithm = strings.ToUpper(strings.TrimSuffix(algorithm, "*"))
+			if algorithm == "" {
+				continue
+			}
+			sf.HashAlgorithms = append(sf.HashAlgorithms, algorithm)
+			if selected {
+				sf.HashAlgorithm = algorithm
+			}
+		}
+	}
+
 	return sf
 }
+
+// SupportsLanguage function checks whether server advertises the provided language, either as
+// a language on its own or as a language with a region (e.g. en matches en-US).
+func (sf *ServerFeatures) SupportsLanguage(language string) bool {
+	language = strings.ToLower(language)
+	for _, lang := range sf.Languages {
+		lang = strings.ToLower(lang)
+		if lang == language || strings.HasPrefix(lang, language+"-") {
+			return true
+		}
+	}
+	return false
+}
+
+// SupportsHashAlgorithm function checks whether server advertises the provided hash algorithm.
+func (sf *ServerFeatures) SupportsHashAlgorithm(algorithm string) bool {
+	for _, alg := range sf.HashAlgorithms {
+		if strings.EqualFold(alg, algorithm) {
+			return true
+		}
+	}
+	return false
+}
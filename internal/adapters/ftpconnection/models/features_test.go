@@ -33,3 +33,41 @@ func Test_NewServerFeatures_Success(t *testing.T) {
 	assert.True(t, sf.SupportUTF8)
 	assert.True(t, sf.SupportEPSV)
 }
+
+func Test_NewServerFeatures_LANG_Success(t *testing.T) {
+	// arrange
+	featureMap := map[string]string{
+		"LANG": "fr-FR*;en-US;ru",
+	}
+
+	// act
+	sf := models.NewServerFeatures(featureMap)
+
+	// assert
+	require.NotNil(t, sf)
+
+	assert.True(t, sf.SupportLANG)
+	assert.Equal(t, []string{"fr-FR", "en-US", "ru"}, sf.Languages)
+	assert.True(t, sf.SupportsLanguage("en"))
+	assert.True(t, sf.SupportsLanguage("ru"))
+	assert.False(t, sf.SupportsLanguage("de"))
+}
+
+func Test_NewServerFeatures_HASH_Success(t *testing.T) {
+	// arrange
+	featureMap := map[string]string{
+		"HASH": "SHA-1;sha-256*;MD5",
+	}
+
+	// act
+	sf := models.NewServerFeatures(featureMap)
+
+	// assert
+	require.NotNil(t, sf)
+
+	assert.True(t, sf.SupportHASH)
+	assert.Equal(t, []string{"SHA-1", "SHA-256", "MD5"}, sf.HashAlgorithms)
+	assert.Equal(t, "SHA-256", sf.HashAlgorithm)
+	assert.True(t, sf.SupportsHashAlgorithm("sha-1"))
+	assert.False(t, sf.SupportsHashAlgorithm("CRC32"))
+}
@@ -56,5 +56,7 @@ const (
 	StatusBadCommand              = 500
 	StatusBadArguments            = 501
 	StatusNotImplementedParameter = 504
-	StatusFileUnavailable         = 550
+	// StatusDirectoryExists is not part of RFC 959, but is replied by some servers to MKD command.
+	StatusDirectoryExists = 521
+	StatusFileUnavailable = 550
 )
@@ -13,13 +13,24 @@ func (c *ServerConnection) Move(oldPath string, newPath string) error {
 		return ftperrors.NewInvalidArgumentError("newPath", ftperrors.ErrMsgCannotBeBlank)
 	}
 
-	if _, _, err := c.cmd(models.StatusRequestFilePending, models.CommandRenameFrom, oldPath); err != nil {
+	oldPath, err := c.expandHome(oldPath)
+	if err != nil {
+		return err
+	}
+	newPath, err = c.expandHome(newPath)
+	if err != nil {
+		return err
+	}
+
+	if _, _, err = c.cmd(models.StatusRequestFilePending, models.CommandRenameFrom, oldPath); err != nil {
 		return ftperrors.NewInternalError("failed to prepare file", err)
 	}
 
-	if _, _, err := c.cmd(models.StatusRequestedFileActionOK, models.CommandRenameTo, newPath); err != nil {
+	if _, _, err = c.cmd(models.StatusRequestedFileActionOK, models.CommandRenameTo, newPath); err != nil {
 		return ftperrors.NewInternalError("failed to move file", err)
 	}
+	// moved entry may be a directory
+	c.forgetDirs(oldPath)
 
 	return nil
 }
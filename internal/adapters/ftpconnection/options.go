@@ -4,7 +4,9 @@ import (
 	"crypto/tls"
 	"io"
 	"net/textproto"
+	"time"
 
+	"github.com/alexZaicev/go-ftp-client/internal/adapters/parsers"
 	"github.com/alexZaicev/go-ftp-client/internal/domain/errors"
 )
 
@@ -45,3 +47,60 @@ func WithDisabledEPSV() Option {
 		return nil
 	}
 }
+
+// WithLenientListing option skips list entries that cannot be parsed instead of failing the whole listing.
+func WithLenientListing() Option {
+	return func(conn *ServerConnection) error {
+		conn.lenientListing = true
+		return nil
+	}
+}
+
+// WithListParser option overrides the format of LIST command entries instead of detecting it from
+// the server system type, which is useful for servers that report misleading SYST replies.
+func WithListParser(format parsers.ListFormat) Option {
+	return func(conn *ServerConnection) error {
+		if format == parsers.ListFormatAuto {
+			return nil
+		}
+		parser, err := parsers.NewListParser(format)
+		if err != nil {
+			return err
+		}
+		conn.parser = parser
+		return nil
+	}
+}
+
+// WithListLanguage option sets the language of month abbreviations in LIST command entries instead
+// of trying all supported languages.
+func WithListLanguage(language string) Option {
+	return func(conn *ServerConnection) error {
+		if err := parsers.ValidateLanguage(language); err != nil {
+			return err
+		}
+		conn.listLanguage = language
+		return nil
+	}
+}
+
+// WithLocation option sets the location of the server that LIST command entry dates are listed in,
+// which is UTC by default.
+func WithLocation(location *time.Location) Option {
+	return func(conn *ServerConnection) error {
+		if location == nil {
+			return errors.NewInvalidArgumentError("location", errors.ErrMsgCannotBeNil)
+		}
+		conn.location = location
+		return nil
+	}
+}
+
+// WithLocationDetection option derives the location of the server by comparing LIST command entry
+// dates with MDTM command replies, which are always in UTC.
+func WithLocationDetection() Option {
+	return func(conn *ServerConnection) error {
+		conn.detectLocation = true
+		return nil
+	}
+}
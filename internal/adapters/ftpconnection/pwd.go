@@ -0,0 +1,94 @@
+package ftpconnection
+
+import (
+	"strings"
+
+	"github.com/alexZaicev/go-ftp-client/internal/adapters/ftpconnection/models"
+	"github.com/alexZaicev/go-ftp-client/internal/domain/entities"
+	ftperrors "github.com/alexZaicev/go-ftp-client/internal/domain/errors"
+)
+
+// Pwd function returns the working directory. It is fetched from the server once and then tracked by
+// the connection as the directory is changed.
+func (c *ServerConnection) Pwd() (string, error) {
+	if c.workDir != "" {
+		return c.workDir, nil
+	}
+
+	_, msg, err := c.cmd(models.StatusPathCreated, models.CommandPrintWorkDir)
+	if err != nil {
+		return "", ftperrors.NewInternalError("failed to fetch working directory", err)
+	}
+
+	dirPath, ok := parseQuotedPath(msg)
+	if !ok || !entities.RemotePath(dirPath).IsAbs() {
+		return "", ftperrors.NewInternalError("failed to parse working directory", nil)
+	}
+
+	c.workDir = dirPath
+	c.knownDirs[dirPath] = true
+	if !c.dirChanged {
+		c.homeDir = dirPath
+	}
+	return dirPath, nil
+}
+
+// resolvePath function resolves the path against the working directory or, if it starts with "~",
+// the login directory.
+func (c *ServerConnection) resolvePath(p string) (string, error) {
+	remotePath := entities.RemotePath(p)
+	if remotePath.IsAbs() {
+		return p, nil
+	}
+
+	workDir, err := c.Pwd()
+	if err != nil {
+		return "", err
+	}
+	return remotePath.Resolve(entities.RemotePath(workDir), entities.RemotePath(c.homeDir)).String(), nil
+}
+
+// expandHome function replaces leading "~" of the path with the login directory, which servers do not
+// expand consistently. Other paths are sent to the server as is. If the login directory is unknown, as
+// the directory was changed before it was fetched, the path is left to the server as well.
+func (c *ServerConnection) expandHome(p string) (string, error) {
+	remotePath := entities.RemotePath(p)
+	if remotePath.Root() != "~" {
+		return p, nil
+	}
+
+	if c.homeDir == "" && !c.dirChanged {
+		if _, err := c.Pwd(); err != nil {
+			return "", err
+		}
+	}
+	if c.homeDir == "" {
+		return p, nil
+	}
+	return remotePath.Resolve("", entities.RemotePath(c.homeDir)).String(), nil
+}
+
+// parseQuotedPath function extracts the path from 257 reply, where it is enclosed in double quotes and
+// double quotes of the path itself are doubled (e.g. "/foo ""bar""" is current directory).
+func parseQuotedPath(msg string) (string, bool) {
+	msg = strings.TrimSpace(msg)
+	if !strings.HasPrefix(msg, `"`) {
+		return "", false
+	}
+
+	var sb strings.Builder
+	for i := 1; i < len(msg); i++ {
+		if msg[i] != '"' {
+			sb.WriteByte(msg[i])
+			continue
+		}
+		if i+1 < len(msg) && msg[i+1] == '"' {
+			sb.WriteByte('"')
+			i++
+			continue
+		}
+		return sb.String(), true
+	}
+
+	return "", false
+}
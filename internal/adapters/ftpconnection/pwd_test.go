@@ -0,0 +1,202 @@
+package ftpconnection_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/alexZaicev/go-ftp-client/internal/adapters/ftpconnection"
+	"github.com/alexZaicev/go-ftp-client/internal/adapters/ftpconnection/models"
+	ftperrors "github.com/alexZaicev/go-ftp-client/internal/domain/errors"
+	ftpConnectionMocks "github.com/alexZaicev/go-ftp-client/mocks/adapters/ftpconnection"
+)
+
+func Test_ServerConnection_Pwd_Success(t *testing.T) {
+	testCases := []struct {
+		name     string
+		reply    string
+		expected string
+	}{
+		{
+			name:     "plain path",
+			reply:    `"/home/user01" is the current directory`,
+			expected: "/home/user01",
+		},
+		{
+			name:     "path with quotes",
+			reply:    `"/home/""quoted"" dir" is the current directory`,
+			expected: `/home/"quoted" dir`,
+		},
+		{
+			name:     "drive root",
+			reply:    `"C:/inetpub" is the current directory`,
+			expected: "C:/inetpub",
+		},
+		{
+			name:     "path without comment",
+			reply:    `"/"`,
+			expected: "/",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tcpConn := ftpConnectionMocks.NewConn(t)
+			dialer := ftpConnectionMocks.NewDialer(t)
+			connMock := ftpConnectionMocks.NewTextConnection(t)
+			connMock.
+				On("Cmd", models.CommandPrintWorkDir).
+				Return(uid, nil).
+				Once()
+			connMock.
+				On("ReadResponse", models.StatusPathCreated).
+				Return(models.StatusPathCreated, tc.reply, nil).
+				Once()
+
+			serverConn, err := ftpconnection.NewConnection(host, dialer, tcpConn, connMock)
+			require.NoError(t, err)
+
+			// working directory is fetched from the server only once
+			for i := 0; i < 2; i++ {
+				workDir, pwdErr := serverConn.Pwd()
+				assert.NoError(t, pwdErr)
+				assert.Equal(t, tc.expected, workDir)
+			}
+		})
+	}
+}
+
+func Test_ServerConnection_Pwd_TracksCd(t *testing.T) {
+	tcpConn := ftpConnectionMocks.NewConn(t)
+	dialer := ftpConnectionMocks.NewDialer(t)
+	connMock := ftpConnectionMocks.NewTextConnection(t)
+	setMocksForPwd(connMock, homeDirPath)
+	connMock.
+		On("Cmd", models.CommandChangeWorkDir, "docs/../reports").
+		Return(uid, nil).
+		Once()
+	connMock.
+		On("Cmd", models.CommandChangeWorkDir, "/var/www/").
+		Return(uid, nil).
+		Once()
+	connMock.
+		On("ReadResponse", models.StatusNoCheck).
+		Return(models.StatusRequestedFileActionOK, "", nil).
+		Twice()
+
+	serverConn, err := ftpconnection.NewConnection(host, dialer, tcpConn, connMock)
+	require.NoError(t, err)
+
+	workDir, err := serverConn.Pwd()
+	require.NoError(t, err)
+	assert.Equal(t, homeDirPath, workDir)
+
+	require.NoError(t, serverConn.Cd("docs/../reports"))
+	workDir, err = serverConn.Pwd()
+	require.NoError(t, err)
+	assert.Equal(t, homeDirPath+"/reports", workDir)
+
+	require.NoError(t, serverConn.Cd("/var/www/"))
+	workDir, err = serverConn.Pwd()
+	require.NoError(t, err)
+	assert.Equal(t, "/var/www", workDir)
+}
+
+func Test_ServerConnection_ExpandHome(t *testing.T) {
+	tcpConn := ftpConnectionMocks.NewConn(t)
+	dialer := ftpConnectionMocks.NewDialer(t)
+	connMock := ftpConnectionMocks.NewTextConnection(t)
+	setMocksForPwd(connMock, homeDirPath)
+	connMock.
+		On("Cmd", models.CommandSize, homeDirPath+"/file.txt").
+		Return(uid, nil).
+		Once()
+	connMock.
+		On("ReadResponse", models.StatusFile).
+		Return(models.StatusFile, "1024", nil).
+		Once()
+	connMock.
+		On("Cmd", models.CommandChangeWorkDir, "/var/www").
+		Return(uid, nil).
+		Once()
+	connMock.
+		On("Cmd", models.CommandChangeWorkDir, homeDirPath+"/docs").
+		Return(uid, nil).
+		Once()
+	connMock.
+		On("ReadResponse", models.StatusNoCheck).
+		Return(models.StatusRequestedFileActionOK, "", nil).
+		Twice()
+
+	serverConn, err := ftpconnection.NewConnection(host, dialer, tcpConn, connMock)
+	require.NoError(t, err)
+
+	// login directory is fetched on the first use of "~" and stays known after the directory changes
+	sizeInBytes, err := serverConn.Size("~/file.txt")
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1024), sizeInBytes)
+
+	require.NoError(t, serverConn.Cd("/var/www"))
+	require.NoError(t, serverConn.Cd("~/docs"))
+	workDir, err := serverConn.Pwd()
+	require.NoError(t, err)
+	assert.Equal(t, homeDirPath+"/docs", workDir)
+}
+
+func Test_ServerConnection_Pwd_Errors(t *testing.T) {
+	testCases := []struct {
+		name           string
+		cmdErr         error
+		reply          string
+		expectedErrMsg string
+	}{
+		{
+			name:           "command error",
+			cmdErr:         errors.New("mock error"),
+			expectedErrMsg: "an internal error occurred: failed to fetch working directory",
+		},
+		{
+			name:           "unquoted path",
+			reply:          "/home/user01 is the current directory",
+			expectedErrMsg: "an internal error occurred: failed to parse working directory",
+		},
+		{
+			name:           "unterminated quote",
+			reply:          `"/home/user01 is the current directory`,
+			expectedErrMsg: "an internal error occurred: failed to parse working directory",
+		},
+		{
+			name:           "relative path",
+			reply:          `"home" is the current directory`,
+			expectedErrMsg: "an internal error occurred: failed to parse working directory",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tcpConn := ftpConnectionMocks.NewConn(t)
+			dialer := ftpConnectionMocks.NewDialer(t)
+			connMock := ftpConnectionMocks.NewTextConnection(t)
+			connMock.
+				On("Cmd", models.CommandPrintWorkDir).
+				Return(uid, tc.cmdErr).
+				Once()
+			if tc.cmdErr == nil {
+				connMock.
+					On("ReadResponse", models.StatusPathCreated).
+					Return(models.StatusPathCreated, tc.reply, nil).
+					Once()
+			}
+
+			serverConn, err := ftpconnection.NewConnection(host, dialer, tcpConn, connMock)
+			require.NoError(t, err)
+
+			workDir, err := serverConn.Pwd()
+			assert.Empty(t, workDir)
+			require.EqualError(t, err, tc.expectedErrMsg)
+			assert.IsType(t, ftperrors.InternalErrorType, err)
+		})
+	}
+}
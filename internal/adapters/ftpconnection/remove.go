@@ -9,8 +9,12 @@ func (c *ServerConnection) RemoveFile(path string) error {
 	if path == "" {
 		return ftperrors.NewInvalidArgumentError("path", ftperrors.ErrMsgCannotBeBlank)
 	}
+	path, err := c.expandHome(path)
+	if err != nil {
+		return err
+	}
 
-	if _, _, err := c.cmd(models.StatusRequestedFileActionOK, models.CommandRemoveFile, path); err != nil {
+	if _, _, err = c.cmd(models.StatusRequestedFileActionOK, models.CommandRemoveFile, path); err != nil {
 		return ftperrors.NewInternalError("failed to remove file", err)
 	}
 	return nil
@@ -20,9 +24,14 @@ func (c *ServerConnection) RemoveDir(path string) error {
 	if path == "" {
 		return ftperrors.NewInvalidArgumentError("path", ftperrors.ErrMsgCannotBeBlank)
 	}
+	path, err := c.expandHome(path)
+	if err != nil {
+		return err
+	}
 
-	if _, _, err := c.cmd(models.StatusRequestedFileActionOK, models.CommandRemoveDir, path); err != nil {
+	if _, _, err = c.cmd(models.StatusRequestedFileActionOK, models.CommandRemoveDir, path); err != nil {
 		return ftperrors.NewInternalError("failed to remove directory", err)
 	}
+	c.forgetDirs(path)
 	return nil
 }
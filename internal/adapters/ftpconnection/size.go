@@ -8,6 +8,11 @@ import (
 )
 
 func (c *ServerConnection) Size(path string) (uint64, error) {
+	path, err := c.expandHome(path)
+	if err != nil {
+		return 0, err
+	}
+
 	_, msg, err := c.cmd(models.StatusFile, models.CommandSize, path)
 	if err != nil {
 		return 0, ftperrors.NewInternalError("failed to fetch file size", err)
@@ -0,0 +1,29 @@
+package ftpconnection
+
+import (
+	"context"
+
+	"github.com/alexZaicev/go-ftp-client/internal/domain/entities"
+)
+
+func (c *ServerConnection) Stat(ctx context.Context, path string) (*entities.Entry, error) {
+	path, err := c.expandHome(path)
+	if err != nil {
+		return nil, err
+	}
+
+	entry, err := c.findEntry(ctx, path)
+	if err != nil {
+		return nil, err
+	}
+
+	// listed dates lack seconds and, for recently modified files, the year; MDTM reply is precise
+	if entry.Type == entities.EntryTypeFile && c.features.SupportMDTM {
+		if modTime, mdtmErr := c.modificationTime(path); mdtmErr == nil {
+			entry.LastModificationDate = modTime
+			entry.YearUnknown = false
+		}
+	}
+
+	return entry, nil
+}
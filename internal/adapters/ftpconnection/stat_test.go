@@ -0,0 +1,187 @@
+package ftpconnection_test
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/alexZaicev/go-ftp-client/internal/adapters/ftpconnection"
+	"github.com/alexZaicev/go-ftp-client/internal/adapters/ftpconnection/models"
+	"github.com/alexZaicev/go-ftp-client/internal/domain/entities"
+	ftperrors "github.com/alexZaicev/go-ftp-client/internal/domain/errors"
+	ftpConnectionMocks "github.com/alexZaicev/go-ftp-client/mocks/adapters/ftpconnection"
+)
+
+func Test_ServerConnection_Stat_Success(t *testing.T) {
+	testCases := []struct {
+		name         string
+		listEntry    string
+		path         string
+		mdtmReply    string
+		mdtmErr      error
+		expectedType entities.EntryType
+		expectedDate time.Time
+		yearUnknown  bool
+	}{
+		{
+			name:         "directory",
+			listEntry:    entryDirMessage,
+			path:         remotePath,
+			expectedType: entities.EntryTypeDir,
+			expectedDate: time.Date(0, time.September, 16, 14, 34, 0, 0, time.UTC),
+			yearUnknown:  true,
+		},
+		{
+			name:         "file with modification time",
+			listEntry:    entryFileMessage,
+			path:         remoteParentPath + "/file-1.txt",
+			mdtmReply:    "20230916143412",
+			expectedType: entities.EntryTypeFile,
+			expectedDate: time.Date(2023, time.September, 16, 14, 34, 12, 0, time.UTC),
+		},
+		{
+			name:         "file with listed date",
+			listEntry:    entryFileMessage,
+			path:         remoteParentPath + "/file-1.txt",
+			mdtmErr:      ftperrors.NewInternalError("mock error", nil),
+			expectedType: entities.EntryTypeFile,
+			expectedDate: time.Date(0, time.September, 16, 14, 34, 0, 0, time.UTC),
+			yearUnknown:  true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			tcpConn, dialer, connMock := setMocksForStatListing(ctx, t, tc.listEntry)
+			if tc.expectedType == entities.EntryTypeFile {
+				connMock.
+					On("Cmd", models.CommandModificationTime, tc.path).
+					Return(uid, nil).
+					Once()
+				connMock.
+					On("ReadResponse", models.StatusFile).
+					Return(models.StatusFile, tc.mdtmReply, tc.mdtmErr).
+					Once()
+			}
+
+			serverConn, err := ftpconnection.NewConnection(host, dialer, tcpConn, connMock)
+			require.NoError(t, err)
+
+			// this is required to feed the feature map
+			err = serverConn.Login(user, password)
+			require.NoError(t, err)
+
+			// act
+			entry, err := serverConn.Stat(ctx, tc.path)
+
+			// assert
+			assert.NoError(t, err)
+			require.NotNil(t, entry)
+			assert.Equal(t, tc.expectedType, entry.Type)
+			assert.Equal(t, uint64(187), entry.SizeInBytes)
+			assert.Equal(t, tc.expectedDate, entry.LastModificationDate)
+			assert.Equal(t, tc.yearUnknown, entry.YearUnknown)
+		})
+	}
+}
+
+func Test_ServerConnection_Stat_NotFoundError(t *testing.T) {
+	ctx := context.Background()
+
+	tcpConn, dialer, connMock := setMocksForStatListing(ctx, t, entryDirMessage)
+
+	serverConn, err := ftpconnection.NewConnection(host, dialer, tcpConn, connMock)
+	require.NoError(t, err)
+
+	// this is required to feed the feature map
+	err = serverConn.Login(user, password)
+	require.NoError(t, err)
+
+	// act
+	entry, err := serverConn.Stat(ctx, remoteParentPath+"/file-1.txt")
+
+	// assert
+	assert.Nil(t, entry)
+	require.EqualError(t, err, `not found error occurred: entry not found under "/foo/bar/file-1.txt" path`)
+	assert.IsType(t, ftperrors.NotFoundErrorType, err)
+}
+
+func setMocksForStatListing(
+	ctx context.Context,
+	t *testing.T,
+	listEntry string,
+) (*ftpConnectionMocks.Conn, *ftpConnectionMocks.Dialer, *ftpConnectionMocks.TextConnection) {
+	tcpConn := ftpConnectionMocks.NewConn(t)
+	tcpConn.
+		On("SetDeadline", mock.AnythingOfType("time.Time")).
+		Return(nil).
+		Once()
+
+	dataConnMock := ftpConnectionMocks.NewConn(t)
+	dataConnMock.
+		On("Read", mock.Anything).
+		Run(func(args mock.Arguments) {
+			bytes := args.Get(0).([]byte)
+			copy(bytes, listEntry)
+		}).
+		Return(len(listEntry), nil).
+		Once()
+	dataConnMock.
+		On("Read", mock.Anything).
+		Return(0, io.EOF).
+		Once()
+	dataConnMock.
+		On("Close").
+		Return(nil).
+		Once()
+
+	dialer := ftpConnectionMocks.NewDialer(t)
+	dialer.
+		On("DialContext", ctx, "tcp", fmt.Sprintf("%s:21103", host)).
+		Return(dataConnMock, nil).
+		Once()
+
+	connMock := ftpConnectionMocks.NewTextConnection(t)
+	// mock setup for login
+	setMocksForLogin(connMock, false)
+	setMocksForSystem(connMock)
+	// mock setup for list
+	connMock.
+		On("Cmd", fmt.Sprintf(models.CommandPreTransfer, models.CommandListHidden), remoteParentPath).
+		Return(uid, nil).
+		Once()
+	connMock.
+		On("ReadResponse", models.StatusCommandOK).
+		Return(models.StatusCommandOK, "", nil).
+		Once()
+	connMock.
+		On("Cmd", models.CommandExtendedPassiveMode).
+		Return(uid, nil).
+		Once()
+	connMock.
+		On("ReadResponse", models.StatusExtendedPassiveMode).
+		Return(models.StatusExtendedPassiveMode, extendedPassiveModeMessage, nil).
+		Once()
+	connMock.
+		On("Cmd", models.CommandListHidden, remoteParentPath).
+		Return(uid, nil).
+		Once()
+	connMock.
+		On("ReadResponse", models.StatusNoCheck).
+		Return(models.StatusAboutToSend, listMessage, nil).
+		Once()
+	connMock.
+		On("ReadResponse", models.StatusClosingDataConnection).
+		Return(models.StatusClosingDataConnection, "", nil).
+		Once()
+
+	return tcpConn, dialer, connMock
+}
@@ -61,15 +61,31 @@ func (c *ServerConnection) Status() (*entities.Status, error) {
 		status.TLSEnabled = c.features.AuthTLS
 	}
 
-	_, msg, err = c.cmd(models.StatusName, models.CommandSystem)
+	system, err := c.systemType()
 	if err != nil {
-		return nil, ftperrors.NewInternalError("failed to fetch system type", err)
+		return nil, err
+	}
+	status.System = system
+
+	return status, nil
+}
+
+// systemType function fetches the system type of the server with the SYST command. The system type
+// does not change during the session, hence the result is cached on the connection.
+func (c *ServerConnection) systemType() (string, error) {
+	if c.system != "" {
+		return c.system, nil
+	}
+
+	_, msg, err := c.cmd(models.StatusName, models.CommandSystem)
+	if err != nil {
+		return "", ftperrors.NewInternalError("failed to fetch system type", err)
 	}
 
 	msg = strings.TrimSpace(msg)
 	const tokenSize = 2
 	tokens := strings.SplitN(msg, " ", tokenSize)
-	status.System = tokens[0]
+	c.system = tokens[0]
 
-	return status, nil
+	return c.system, nil
 }
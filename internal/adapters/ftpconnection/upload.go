@@ -16,7 +16,12 @@ func (c *ServerConnection) Upload(ctx context.Context, options *connection.Uploa
 		return ftperrors.NewInvalidArgumentError("options", ftperrors.ErrMsgCannotBeNil)
 	}
 
-	conn, err := c.cmdWithDataConn(ctx, 0, models.CommandStore, options.Path)
+	filePath, err := c.expandHome(options.Path)
+	if err != nil {
+		return err
+	}
+
+	conn, err := c.cmdWithDataConn(ctx, 0, models.CommandStore, filePath)
 	if err != nil {
 		return ftperrors.NewInternalError("failed to open data transfer connection", err)
 	}
@@ -0,0 +1,104 @@
+package parsers
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/alexZaicev/go-ftp-client/internal/domain/entities"
+)
+
+const (
+	octalBase = 8
+	// unixPermissionsLen is the length of permission bits in UNIX list entries (e.g. rwxr-xr-x)
+	unixPermissionsLen = 9
+
+	unixModeSetuid = 0o4000
+	unixModeSetgid = 0o2000
+	unixModeSticky = 0o1000
+	unixModePerm   = 0o0777
+	unixModeMax    = 0o7777
+)
+
+// parseUnixPermissions function converts permission bits of UNIX list entries into file mode.
+// Trailing characters past the permission bits, such as ACL (+) or extended attribute (@)
+// markers, are ignored.
+func parseUnixPermissions(permissions string) (os.FileMode, error) {
+	if len(permissions) < unixPermissionsLen {
+		return 0, fmt.Errorf("unexpected permissions: %s", permissions)
+	}
+
+	var mode os.FileMode
+	for idx, ch := range permissions[:unixPermissionsLen] {
+		bit := os.FileMode(1) << (unixPermissionsLen - 1 - idx)
+		switch {
+		case ch == '-':
+		case ch == rune("rwxrwxrwx"[idx]):
+			mode |= bit
+		case idx == 2 && (ch == 's' || ch == 'S'):
+			mode |= os.ModeSetuid | executeBit(ch, bit)
+		case idx == 5 && (ch == 's' || ch == 'S'):
+			mode |= os.ModeSetgid | executeBit(ch, bit)
+		case idx == 8 && (ch == 't' || ch == 'T'):
+			mode |= os.ModeSticky | executeBit(ch, bit)
+		default:
+			return 0, fmt.Errorf("unexpected permissions: %s", permissions)
+		}
+	}
+	return mode, nil
+}
+
+// executeBit function returns the execute bit for lower-case special permission characters,
+// which denote that the execute permission is set as well.
+func executeBit(ch rune, bit os.FileMode) os.FileMode {
+	if ch == 's' || ch == 't' {
+		return bit
+	}
+	return 0
+}
+
+// parseOctalMode function converts the octal mode of RFC3659 UNIX.mode fact (e.g. 0755) into
+// file mode.
+func parseOctalMode(value string) (os.FileMode, error) {
+	unixMode, err := strconv.ParseUint(value, octalBase, bitSize32)
+	if err != nil {
+		return 0, err
+	}
+	if unixMode > unixModeMax {
+		return 0, fmt.Errorf("unexpected mode: %s", value)
+	}
+
+	mode := os.FileMode(unixMode & unixModePerm)
+	if unixMode&unixModeSetuid != 0 {
+		mode |= os.ModeSetuid
+	}
+	if unixMode&unixModeSetgid != 0 {
+		mode |= os.ModeSetgid
+	}
+	if unixMode&unixModeSticky != 0 {
+		mode |= os.ModeSticky
+	}
+	return mode, nil
+}
+
+// modeType function returns the file mode type bits of the entry type.
+func modeType(entryType entities.EntryType) os.FileMode {
+	switch entryType {
+	case entities.EntryTypeDir:
+		return os.ModeDir
+	case entities.EntryTypeLink:
+		return os.ModeSymlink
+	default:
+		return 0
+	}
+}
+
+// parseID function converts numeric owner user or group ID, returning nil for names.
+func parseID(value string) *uint32 {
+	id, err := strconv.ParseUint(value, decimalBase, bitSize32)
+	if err != nil {
+		return nil
+	}
+	id32 := uint32(id)
+	return &id32
+}
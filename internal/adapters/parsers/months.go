@@ -0,0 +1,105 @@
+package parsers
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	ftperrors "github.com/alexZaicev/go-ftp-client/internal/domain/errors"
+)
+
+// Languages of month abbreviations that can appear in UNIX list entries of servers running with a
+// non-English locale.
+const (
+	LanguageAuto     = ""
+	LanguageEnglish  = "en"
+	LanguageGerman   = "de"
+	LanguageFrench   = "fr"
+	LanguageSpanish  = "es"
+	LanguageRussian  = "ru"
+	LanguageJapanese = "ja"
+)
+
+// monthNames maps lower-cased month abbreviations, as printed by ls in each of the supported
+// locales, to months. Trailing dots are stripped prior to the lookup.
+var monthNames = map[string]map[string]time.Month{
+	LanguageEnglish: {
+		"jan": time.January, "feb": time.February, "mar": time.March, "apr": time.April,
+		"may": time.May, "jun": time.June, "jul": time.July, "aug": time.August,
+		"sep": time.September, "oct": time.October, "nov": time.November, "dec": time.December,
+	},
+	LanguageGerman: {
+		"jan": time.January, "feb": time.February, "mär": time.March, "mrz": time.March,
+		"apr": time.April, "mai": time.May, "jun": time.June, "jul": time.July,
+		"aug": time.August, "sep": time.September, "okt": time.October, "nov": time.November,
+		"dez": time.December,
+	},
+	LanguageFrench: {
+		"janv": time.January, "févr": time.February, "fév": time.February, "mars": time.March,
+		"avr": time.April, "mai": time.May, "juin": time.June, "juil": time.July,
+		"août": time.August, "sept": time.September, "oct": time.October, "nov": time.November,
+		"déc": time.December,
+	},
+	LanguageSpanish: {
+		"ene": time.January, "feb": time.February, "mar": time.March, "abr": time.April,
+		"may": time.May, "jun": time.June, "jul": time.July, "ago": time.August,
+		"sep": time.September, "sept": time.September, "set": time.September, "oct": time.October,
+		"nov": time.November, "dic": time.December,
+	},
+	LanguageRussian: {
+		"янв": time.January, "фев": time.February, "мар": time.March, "апр": time.April,
+		"мая": time.May, "май": time.May, "июн": time.June, "июл": time.July,
+		"авг": time.August, "сен": time.September, "окт": time.October, "ноя": time.November,
+		"дек": time.December,
+	},
+	LanguageJapanese: {
+		"1月": time.January, "2月": time.February, "3月": time.March, "4月": time.April,
+		"5月": time.May, "6月": time.June, "7月": time.July, "8月": time.August,
+		"9月": time.September, "10月": time.October, "11月": time.November, "12月": time.December,
+	},
+}
+
+// Languages function returns all languages supported in month abbreviations of UNIX list entries.
+func Languages() []string {
+	return []string{
+		LanguageEnglish,
+		LanguageGerman,
+		LanguageFrench,
+		LanguageSpanish,
+		LanguageRussian,
+		LanguageJapanese,
+	}
+}
+
+// ValidateLanguage function checks whether month abbreviations of the provided language are supported.
+// Blank language stands for automatic detection.
+func ValidateLanguage(language string) error {
+	if language == LanguageAuto {
+		return nil
+	}
+	if _, ok := monthNames[language]; !ok {
+		return ftperrors.NewInvalidArgumentError(
+			"language",
+			fmt.Sprintf("must be one of %s", strings.Join(Languages(), ", ")),
+		)
+	}
+	return nil
+}
+
+// lookupMonth function converts month abbreviation into a month. If language is not set, English
+// is tried first, followed by all other supported languages.
+func lookupMonth(value, language string) (time.Month, bool) {
+	value = strings.TrimSuffix(strings.ToLower(value), ".")
+
+	if language != LanguageAuto {
+		month, ok := monthNames[language][value]
+		return month, ok
+	}
+
+	for _, lang := range Languages() {
+		if month, ok := monthNames[lang][value]; ok {
+			return month, true
+		}
+	}
+	return time.Month(0), false
+}
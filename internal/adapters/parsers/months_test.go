@@ -0,0 +1,25 @@
+package parsers_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/alexZaicev/go-ftp-client/internal/adapters/parsers"
+	ftperrors "github.com/alexZaicev/go-ftp-client/internal/domain/errors"
+)
+
+func Test_ValidateLanguage_Success(t *testing.T) {
+	for _, language := range append(parsers.Languages(), parsers.LanguageAuto) {
+		assert.NoError(t, parsers.ValidateLanguage(language))
+	}
+}
+
+func Test_ValidateLanguage_InvalidArgumentError(t *testing.T) {
+	err := parsers.ValidateLanguage("xx")
+	require.EqualError(t, err, "an invalid argument error occurred: argument language must be one of en, de, fr, es, ru, ja")
+	assert.IsType(t, ftperrors.InvalidArgumentErrorType, err)
+	assert.NoError(t, errors.Unwrap(err))
+}
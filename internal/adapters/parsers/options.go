@@ -3,5 +3,10 @@ package parsers
 import "time"
 
 type Options struct {
+	// Location of the server that UNIX list entry dates are listed in. If nil, UTC is assumed.
+	// RFC3659 entry dates are in UTC by definition.
 	Location *time.Location
+	// Language of month abbreviations in UNIX list entries. If blank, all supported
+	// languages are tried.
+	Language string
 }
@@ -1,6 +1,7 @@
 package parsers
 
 import (
+	"fmt"
 	"strings"
 
 	"github.com/alexZaicev/go-ftp-client/internal/domain/entities"
@@ -13,12 +14,35 @@ const (
 	bitSize32   = 32
 )
 
+// ListFormat describes the format of the entries returned by the server in the LIST/MLSD
+// command responses.
+type ListFormat string
+
+const (
+	ListFormatAuto    ListFormat = "auto"
+	ListFormatUnix    ListFormat = "unix"
+	ListFormatMsDos   ListFormat = "msdos"
+	ListFormatHosted  ListFormat = "hosted"
+	ListFormatRFC3659 ListFormat = "rfc3659"
+)
+
+// System types as returned by the SYST command that are used as a hint to select the
+// most likely listing parser.
+const (
+	systemUnix      = "UNIX"
+	systemWindowsNT = "WINDOWS_NT"
+)
+
 type Parser interface {
 	Parse(data string, options *Options) (*entities.Entry, error)
 }
 
+// genericListParser tries each of the candidate parsers in order until one of them manages
+// to parse the entry. The first parser to succeed is then locked in and used exclusively for
+// all subsequent entries, hence a single instance should not be shared between connections.
 type genericListParser struct {
-	parsers []Parser
+	parsers  []Parser
+	detected Parser
 }
 
 func NewGenericListParser() Parser {
@@ -32,6 +56,99 @@ func NewGenericListParser() Parser {
 	}
 }
 
+// NewSystemListParser function creates a generic list parser that prioritises candidate parsers
+// based on the system type returned by the SYST command. Blank or unknown system types result in
+// the default order of NewGenericListParser.
+func NewSystemListParser(system string) Parser {
+	system = strings.ToUpper(strings.TrimSpace(system))
+
+	switch {
+	case strings.HasPrefix(system, systemUnix):
+		return &genericListParser{
+			parsers: []Parser{
+				&unixListParser{},
+				&rfc3659ListParser{},
+				&msDosListParser{},
+				&hostedListParser{},
+			},
+		}
+	case strings.HasPrefix(system, systemWindowsNT):
+		return &genericListParser{
+			parsers: []Parser{
+				&msDosListParser{},
+				&unixListParser{},
+				&rfc3659ListParser{},
+				&hostedListParser{},
+			},
+		}
+	default:
+		return NewGenericListParser()
+	}
+}
+
+// NewRFC3659ListParser function creates a parser that only accepts RFC3659 entries, as returned
+// by the MLSD command.
+func NewRFC3659ListParser() Parser {
+	return newSingleListParser(&rfc3659ListParser{})
+}
+
+// NewListParser function creates a parser for the provided list format. For the auto format
+// a generic list parser is returned, otherwise only entries of the specified format are accepted.
+func NewListParser(format ListFormat) (Parser, error) {
+	var parser Parser
+	switch format {
+	case ListFormatAuto:
+		return NewGenericListParser(), nil
+	case ListFormatUnix:
+		parser = &unixListParser{}
+	case ListFormatMsDos:
+		parser = &msDosListParser{}
+	case ListFormatHosted:
+		parser = &hostedListParser{}
+	case ListFormatRFC3659:
+		return NewRFC3659ListParser(), nil
+	default:
+		return nil, newInvalidListFormatError()
+	}
+	return newSingleListParser(parser), nil
+}
+
+// newSingleListParser function wraps a single parser to reuse input validation and entry format
+// checks of the generic list parser.
+func newSingleListParser(parser Parser) Parser {
+	return &genericListParser{
+		parsers: []Parser{parser},
+	}
+}
+
+// ParseListFormat function converts provided value into a supported list format.
+func ParseListFormat(value string) (ListFormat, error) {
+	for _, format := range ListFormats() {
+		if value == format {
+			return ListFormat(value), nil
+		}
+	}
+	return "", newInvalidListFormatError()
+}
+
+func newInvalidListFormatError() error {
+	return ftperrors.NewInvalidArgumentError(
+		"format",
+		fmt.Sprintf("must be one of %s", strings.Join(ListFormats(), ", ")),
+	)
+}
+
+// ListFormats function returns all supported list formats.
+func ListFormats() []string {
+	return []string{
+		string(ListFormatAuto),
+		string(ListFormatUnix),
+		string(ListFormatMsDos),
+		string(ListFormatHosted),
+		string(ListFormatRFC3659),
+	}
+}
+
 func (p *genericListParser) Parse(data string, options *Options) (*entities.Entry, error) {
 	data = strings.TrimSpace(data)
 	if data == "" {
@@ -40,9 +157,21 @@ func (p *genericListParser) Parse(data string, options *Options) (*entities.Entr
 	if options == nil {
 		return nil, ftperrors.NewInvalidArgumentError("options", ftperrors.ErrMsgCannotBeNil)
 	}
+
+	if p.detected != nil {
+		entry, err := p.detected.Parse(data, options)
+		if entry != nil && err == nil {
+			entry.Raw = data
+			return entry, nil
+		}
+		return nil, ftperrors.NewInternalError("unsupported entry format", nil)
+	}
+
 	for _, parser := range p.parsers {
 		entry, err := parser.Parse(data, options)
 		if entry != nil && err == nil {
+			p.detected = parser
+			entry.Raw = data
 			return entry, nil
 		}
 	}
@@ -21,12 +21,15 @@ func Test_GenericListParser_Parse_Success(t *testing.T) {
 	expectedEntry := &entities.Entry{
 		Type:                 entities.EntryTypeFile,
 		Permissions:          "rwxr-xr-x",
+		Mode:                 0o755,
 		NumHardLinks:         1,
 		OwnerUser:            "ftp",
 		OwnerGroup:           "ftpg",
 		SizeInBytes:          672,
 		LastModificationDate: time.Date(0, 9, 8, 15, 15, 0, 0, time.UTC),
+		YearUnknown:          true,
 		Name:                 "docker-compose.yaml",
+		Raw:                  "-rwxr-xr-x   1 ftp      ftpg           672 Sep 08 15:15 docker-compose.yaml",
 	}
 
 	p := parsers.NewGenericListParser()
@@ -75,3 +78,119 @@ func Test_GenericListParser_Parse_Error(t *testing.T) {
 	assert.IsType(t, ftperrors.InternalErrorType, err)
 	assert.NoError(t, errors.Unwrap(err), nil)
 }
+
+func Test_NewSystemListParser_LocksDetectedFormat(t *testing.T) {
+	testCases := []struct {
+		name   string
+		system string
+	}{
+		{
+			name:   "unix system",
+			system: "UNIX",
+		},
+		{
+			name:   "windows system",
+			system: "Windows_NT",
+		},
+		{
+			name: "unknown system",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			p := parsers.NewSystemListParser(tc.system)
+
+			entry, err := p.Parse("-rwxr-xr-x   1 ftp      ftpg           672 Sep 08 15:15 docker-compose.yaml", &parsers.Options{})
+			assert.NoError(t, err)
+			require.NotNil(t, entry)
+			assert.Equal(t, "docker-compose.yaml", entry.Name)
+
+			// once UNIX format is detected, other formats are no longer accepted
+			entry, err = p.Parse("Type=file;Size=1024990;Perm=r; cap60.pl198.tar.gz", &parsers.Options{})
+			assert.Nil(t, entry)
+			require.EqualError(t, err, "an internal error occurred: unsupported entry format")
+			assert.IsType(t, ftperrors.InternalErrorType, err)
+		})
+	}
+}
+
+func Test_NewListParser_Success(t *testing.T) {
+	testCases := []struct {
+		name          string
+		format        parsers.ListFormat
+		input         string
+		expectedEntry bool
+	}{
+		{
+			name:          "auto format",
+			format:        parsers.ListFormatAuto,
+			input:         "Type=file;Size=1024990;Perm=r; cap60.pl198.tar.gz",
+			expectedEntry: true,
+		},
+		{
+			name:          "unix format",
+			format:        parsers.ListFormatUnix,
+			input:         "-rwxr-xr-x   1 ftp      ftpg           672 Sep 08 15:15 docker-compose.yaml",
+			expectedEntry: true,
+		},
+		{
+			name:   "unix format with RFC3659 entry",
+			format: parsers.ListFormatUnix,
+			input:  "Type=file;Size=1024990;Perm=r; cap60.pl198.tar.gz",
+		},
+		{
+			name:          "rfc3659 format",
+			format:        parsers.ListFormatRFC3659,
+			input:         "Type=file;Size=1024990;Perm=r; cap60.pl198.tar.gz",
+			expectedEntry: true,
+		},
+		{
+			name:   "rfc3659 format with UNIX entry",
+			format: parsers.ListFormatRFC3659,
+			input:  "-rwxr-xr-x   1 ftp      ftpg           672 Sep 08 15:15 docker-compose.yaml",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			p, err := parsers.NewListParser(tc.format)
+			require.NoError(t, err)
+			require.NotNil(t, p)
+
+			entry, err := p.Parse(tc.input, &parsers.Options{})
+			if tc.expectedEntry {
+				assert.NoError(t, err)
+				assert.NotNil(t, entry)
+			} else {
+				assert.Nil(t, entry)
+				assert.EqualError(t, err, "an internal error occurred: unsupported entry format")
+			}
+		})
+	}
+}
+
+func Test_NewListParser_InvalidArgumentError(t *testing.T) {
+	p, err := parsers.NewListParser(parsers.ListFormat("not-valid"))
+	assert.Nil(t, p)
+	require.EqualError(t, err, "an invalid argument error occurred: argument format must be one of auto, unix, msdos, hosted, rfc3659")
+	assert.IsType(t, ftperrors.InvalidArgumentErrorType, err)
+	assert.NoError(t, errors.Unwrap(err))
+}
+
+func Test_ParseListFormat_Success(t *testing.T) {
+	format, err := parsers.ParseListFormat("unix")
+	assert.NoError(t, err)
+	assert.Equal(t, parsers.ListFormatUnix, format)
+}
+
+func Test_ParseListFormat_InvalidArgumentError(t *testing.T) {
+	format, err := parsers.ParseListFormat("not-valid")
+	assert.Empty(t, format)
+	require.EqualError(t, err, "an invalid argument error occurred: argument format must be one of auto, unix, msdos, hosted, rfc3659")
+	assert.IsType(t, ftperrors.InvalidArgumentErrorType, err)
+}
+
+func uint32Ptr(value uint32) *uint32 {
+	return &value
+}
@@ -2,6 +2,7 @@ package parsers
 
 import (
 	"fmt"
+	"os"
 	"strconv"
 	"strings"
 	"time"
@@ -11,6 +12,8 @@ import (
 )
 
 const (
+	// rfc3659LastModificationDateFormat is the format of time facts, which are always in UTC
+	// regardless of the server location.
 	rfc3659LastModificationDateFormat = "20060102150405"
 )
 
@@ -21,6 +24,15 @@ const (
 	MetadataSize             Metadata = "size"
 	MetadataPermissions      Metadata = "perm"
 	MetadataLastModifiedDate Metadata = "modify"
+	MetadataCreationDate     Metadata = "create"
+	MetadataUniqueID         Metadata = "unique"
+	MetadataUnixMode         Metadata = "unix.mode"
+	MetadataUnixOwner        Metadata = "unix.owner"
+	MetadataUnixGroup        Metadata = "unix.group"
+	MetadataUnixOwnerName    Metadata = "unix.ownername"
+	MetadataUnixGroupName    Metadata = "unix.groupname"
+	MetadataUnixUID          Metadata = "unix.uid"
+	MetadataUnixGID          Metadata = "unix.gid"
 )
 
 type MetadataEntryType string
@@ -47,7 +59,8 @@ func (p *rfc3659ListParser) Parse(data string, options *Options) (entry *entitie
 	}
 	entry.Name = tokens[1]
 
-	metadata := strings.Split(strings.ToLower(tokens[0]), ";")
+	var permissions os.FileMode
+	metadata := strings.Split(tokens[0], ";")
 	for _, md := range metadata {
 		if md == "" {
 			continue
@@ -65,12 +78,13 @@ func (p *rfc3659ListParser) Parse(data string, options *Options) (entry *entitie
 				nil,
 			)
 		}
-		mdName := mdTokens[0]
+		// fact names are case-insensitive, however values such as owner names are not
+		mdName := strings.ToLower(mdTokens[0])
 		mdValue := mdTokens[1]
 
 		switch Metadata(mdName) {
 		case MetadataType:
-			entryType, convertErr := p.entryTypeFromMetadata(mdValue)
+			entryType, convertErr := p.entryTypeFromMetadata(strings.ToLower(mdValue))
 			if convertErr != nil {
 				return nil, convertErr
 			}
@@ -82,24 +96,65 @@ func (p *rfc3659ListParser) Parse(data string, options *Options) (entry *entitie
 			}
 			entry.SizeInBytes = sizeInByte
 		case MetadataLastModifiedDate:
-			modifyDate, convertErr := time.ParseInLocation(rfc3659LastModificationDateFormat, mdValue, options.Location)
+			modifyDate, convertErr := time.Parse(rfc3659LastModificationDateFormat, mdValue)
 			if convertErr != nil {
 				return nil, ftperrors.NewInternalError("failed to parse last modification date", convertErr)
 			}
 			entry.LastModificationDate = modifyDate
+		case MetadataCreationDate:
+			createDate, convertErr := time.Parse(rfc3659LastModificationDateFormat, mdValue)
+			if convertErr != nil {
+				return nil, ftperrors.NewInternalError("failed to parse creation date", convertErr)
+			}
+			entry.CreationDate = createDate
 		case MetadataPermissions:
-			entry.Permissions = mdValue
+			entry.Permissions = strings.ToLower(mdValue)
+			entry.Capabilities = p.capabilitiesFromMetadata(entry.Permissions)
+		case MetadataUnixMode:
+			mode, convertErr := parseOctalMode(mdValue)
+			if convertErr != nil {
+				return nil, ftperrors.NewInternalError("failed to parse mode", convertErr)
+			}
+			permissions = mode
+		case MetadataUniqueID:
+			entry.UniqueID = mdValue
+		case MetadataUnixOwner, MetadataUnixUID:
+			// some servers list owner name instead of numeric ID
+			if entry.UID = parseID(mdValue); entry.UID == nil {
+				entry.OwnerUser = mdValue
+			}
+		case MetadataUnixGroup, MetadataUnixGID:
+			if entry.GID = parseID(mdValue); entry.GID == nil {
+				entry.OwnerGroup = mdValue
+			}
+		case MetadataUnixOwnerName:
+			entry.OwnerUser = mdValue
+		case MetadataUnixGroupName:
+			entry.OwnerGroup = mdValue
 		default:
-			return nil, ftperrors.NewUnknownError(
-				fmt.Sprintf("unexpected entry metadata: %s", md),
-				nil,
-			)
+			if entry.Facts == nil {
+				entry.Facts = make(map[string]string)
+			}
+			entry.Facts[mdName] = mdValue
 		}
 	}
+	entry.Mode = modeType(entry.Type) | permissions
 
 	return entry, nil
 }
 
+// capabilitiesFromMetadata method converts perm fact into capabilities. Unknown characters are
+// ignored as servers are free to extend the perm fact.
+func (p *rfc3659ListParser) capabilitiesFromMetadata(perm string) entities.Capabilities {
+	var capabilities entities.Capabilities
+	for _, ch := range perm {
+		if capability, ok := entities.CapabilityFromChar(ch); ok {
+			capabilities |= capability
+		}
+	}
+	return capabilities
+}
+
 func (p *rfc3659ListParser) entryTypeFromMetadata(entryType string) (entities.EntryType, error) {
 	switch MetadataEntryType(entryType) {
 	case MetadataEntryTypeDir, MetadataEntryTypeParentDir, MetadataEntryTypeListedDir:
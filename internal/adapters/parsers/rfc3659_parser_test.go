@@ -1,6 +1,7 @@
 package parsers_test
 
 import (
+	"os"
 	"testing"
 	"time"
 
@@ -11,11 +12,6 @@ import (
 )
 
 func Test_rfc3659ListParser_Success(t *testing.T) {
-	// TODO: add below test cases:
-	// modify=20150813224845;perm=fle;type=cdir;unique=119FBB87U4;UNIX.group=0;UNIX.mode=0755;UNIX.owner=0; .
-	// modify=20150814172949;perm=flcdmpe;type=dir;unique=85A0C168U4;UNIX.group=0;UNIX.mode=0777;UNIX.owner=0; _upload
-	// Modify=20150813175250;Perm=adfr;Size=951;Type=file;Unique=119FBB87UE;UNIX.group=0;UNIX.mode=0644;UNIX.owner=0; welcome.msg
-
 	testCases := []struct {
 		name          string
 		input         string
@@ -27,9 +23,11 @@ func Test_rfc3659ListParser_Success(t *testing.T) {
 			expectedEntry: &entities.Entry{
 				Type:                 entities.EntryTypeFile,
 				Permissions:          "r",
+				Capabilities:         entities.CapabilityRead,
 				SizeInBytes:          1024990,
 				LastModificationDate: time.Time{},
 				Name:                 "/tmp/cap60.pl198.tar.gz",
+				Raw:                  "Type=file;Size=1024990;Perm=r; /tmp/cap60.pl198.tar.gz",
 			},
 		},
 		{
@@ -38,39 +36,101 @@ func Test_rfc3659ListParser_Success(t *testing.T) {
 			expectedEntry: &entities.Entry{
 				Type:                 entities.EntryTypeDir,
 				Permissions:          "el",
+				Mode:                 os.ModeDir,
+				Capabilities:         entities.CapabilityEnter | entities.CapabilityList,
 				LastModificationDate: time.Date(1998, 11, 7, 8, 52, 15, 0, time.UTC),
 				Name:                 "/tmp",
+				Raw:                  "Type=cdir;Modify=19981107085215;Perm=el; /tmp",
 			},
 		},
 		{
-			name:  "hidden . directory",
+			name:  "hidden .. directory",
 			input: "Type=pdir;Modify=19981107085215;Perm=el; ..",
 			expectedEntry: &entities.Entry{
 				Type:                 entities.EntryTypeDir,
 				Permissions:          "el",
+				Mode:                 os.ModeDir,
+				Capabilities:         entities.CapabilityEnter | entities.CapabilityList,
 				LastModificationDate: time.Date(1998, 11, 7, 8, 52, 15, 0, time.UTC),
 				Name:                 "..",
+				Raw:                  "Type=pdir;Modify=19981107085215;Perm=el; ..",
 			},
 		},
 		{
-			name:  "hidden . directory",
+			name:  "file entry with last modification date",
 			input: "Type=file;Size=1024990;Modify=19981107085215;Perm=r; cap60.pl198.tar.gz",
 			expectedEntry: &entities.Entry{
 				Type:                 entities.EntryTypeFile,
 				Permissions:          "r",
+				Capabilities:         entities.CapabilityRead,
 				SizeInBytes:          1024990,
 				LastModificationDate: time.Date(1998, 11, 7, 8, 52, 15, 0, time.UTC),
 				Name:                 "cap60.pl198.tar.gz",
+				Raw:                  "Type=file;Size=1024990;Modify=19981107085215;Perm=r; cap60.pl198.tar.gz",
 			},
 		},
 		{
-			name:  "hidden . directory",
+			name:  "file entry with blank permissions",
 			input: "Type=file;Size=1024990;Modify=19981107085215;Perm=; cap60.pl198.tar.gz",
 			expectedEntry: &entities.Entry{
 				Type:                 entities.EntryTypeFile,
 				SizeInBytes:          1024990,
 				LastModificationDate: time.Date(1998, 11, 7, 8, 52, 15, 0, time.UTC),
 				Name:                 "cap60.pl198.tar.gz",
+				Raw:                  "Type=file;Size=1024990;Modify=19981107085215;Perm=; cap60.pl198.tar.gz",
+			},
+		},
+		{
+			name:  "directory entry with UNIX facts",
+			input: "modify=20150814172949;perm=flcdmpe;type=dir;unique=85A0C168U4;UNIX.group=0;UNIX.mode=0777;UNIX.owner=0; _upload",
+			expectedEntry: &entities.Entry{
+				Type:        entities.EntryTypeDir,
+				Permissions: "flcdmpe",
+				Mode:        os.ModeDir | 0o777,
+				Capabilities: entities.CapabilityRename | entities.CapabilityList | entities.CapabilityCreate |
+					entities.CapabilityDelete | entities.CapabilityMkdir | entities.CapabilityPurge |
+					entities.CapabilityEnter,
+				UID:                  uint32Ptr(0),
+				GID:                  uint32Ptr(0),
+				LastModificationDate: time.Date(2015, 8, 14, 17, 29, 49, 0, time.UTC),
+				UniqueID:             "85A0C168U4",
+				Name:                 "_upload",
+				Raw:                  "modify=20150814172949;perm=flcdmpe;type=dir;unique=85A0C168U4;UNIX.group=0;UNIX.mode=0777;UNIX.owner=0; _upload",
+			},
+		},
+		{
+			name:  "file entry with UNIX facts",
+			input: "Modify=20150813175250;Perm=adfr;Size=951;Type=file;Unique=119FBB87UE;UNIX.group=0;UNIX.mode=0644;UNIX.owner=0; welcome.msg",
+			expectedEntry: &entities.Entry{
+				Type:        entities.EntryTypeFile,
+				Permissions: "adfr",
+				Mode:        0o644,
+				Capabilities: entities.CapabilityAppend | entities.CapabilityDelete | entities.CapabilityRename |
+					entities.CapabilityRead,
+				UID:                  uint32Ptr(0),
+				GID:                  uint32Ptr(0),
+				SizeInBytes:          951,
+				LastModificationDate: time.Date(2015, 8, 13, 17, 52, 50, 0, time.UTC),
+				UniqueID:             "119FBB87UE",
+				Name:                 "welcome.msg",
+				Raw:                  "Modify=20150813175250;Perm=adfr;Size=951;Type=file;Unique=119FBB87UE;UNIX.group=0;UNIX.mode=0644;UNIX.owner=0; welcome.msg",
+			},
+		},
+		{
+			name:  "file entry with owner names, creation date and extra facts",
+			input: "Type=file;Size=12;Create=20200102030405;UNIX.mode=4755;UNIX.ownername=Alice;UNIX.groupname=Staff;Media-Type=text/plain; run.sh",
+			expectedEntry: &entities.Entry{
+				Type:         entities.EntryTypeFile,
+				Mode:         os.ModeSetuid | 0o755,
+				OwnerUser:    "Alice",
+				OwnerGroup:   "Staff",
+				SizeInBytes:  12,
+				CreationDate: time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC),
+				Name:         "run.sh",
+				Raw:          "Type=file;Size=12;Create=20200102030405;UNIX.mode=4755;UNIX.ownername=Alice;UNIX.groupname=Staff;Media-Type=text/plain; run.sh",
+				Facts: map[string]string{
+					"media-type": "text/plain",
+				},
 			},
 		},
 	}
@@ -121,8 +181,12 @@ func Test_rfc3659ListParser_Errors(t *testing.T) {
 			input: "Type=file;Size=1024990;Modify=not-valid;Perm=r; /tmp",
 		},
 		{
-			name:  "unknown metadata key=value",
-			input: "Type=file;Size=1024990;Perm=r;Foo=bar; /tmp",
+			name:  "invalid entry creation date",
+			input: "Type=file;Size=1024990;Create=not-valid;Perm=r; /tmp",
+		},
+		{
+			name:  "invalid entry mode",
+			input: "Type=file;Size=1024990;UNIX.mode=0999;Perm=r; /tmp",
 		},
 	}
 
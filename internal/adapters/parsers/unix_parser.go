@@ -11,13 +11,15 @@ import (
 )
 
 const (
-	lastModificationDateFormat = "Jan 2 15:04"
+	lastModificationTimeFormat = "15:04"
+	// daySuffix is appended to the day of month by ls in Japanese locale (e.g. 5月 10日)
+	daySuffix = "日"
 )
 
 type unixListParser struct {
 }
 
-func (p *unixListParser) Parse(data string, _ *Options) (*entities.Entry, error) {
+func (p *unixListParser) Parse(data string, options *Options) (*entities.Entry, error) {
 	entry := &entities.Entry{}
 	var token string
 
@@ -29,6 +31,12 @@ func (p *unixListParser) Parse(data string, _ *Options) (*entities.Entry, error)
 	}
 	entry.Type = entryType
 	entry.Permissions = token[1:]
+	entry.Mode = modeType(entryType)
+	// permissions of broken links (e.g. l?????????) or with platform specific bits, such as Solaris
+	// mandatory locking (l), are kept as listed without permission bits in the mode
+	if permissions, permErr := parseUnixPermissions(entry.Permissions); permErr == nil {
+		entry.Mode |= permissions
+	}
 
 	// hard links
 	data, token = p.nextToken(data)
@@ -41,6 +49,8 @@ func (p *unixListParser) Parse(data string, _ *Options) (*entities.Entry, error)
 	// owner user and group
 	data, entry.OwnerUser = p.nextToken(data)
 	data, entry.OwnerGroup = p.nextToken(data)
+	entry.UID = parseID(entry.OwnerUser)
+	entry.GID = parseID(entry.OwnerGroup)
 
 	// size in bytes
 	data, token = p.nextToken(data)
@@ -51,18 +61,16 @@ func (p *unixListParser) Parse(data string, _ *Options) (*entities.Entry, error)
 	entry.SizeInBytes = sizeInBytes
 
 	// last modification date
-	const tokenSize = 3
-	dateTokens := make([]string, 0, tokenSize)
-	for idx := 0; idx < tokenSize; idx++ {
-		data, token = p.nextToken(data)
-		dateTokens = append(dateTokens, token)
-	}
-	dateStr := strings.Join(dateTokens, " ")
-	lastModificationDate, err := time.Parse(lastModificationDateFormat, dateStr)
+	var monthToken, dayToken, timeToken string
+	data, monthToken = p.nextToken(data)
+	data, dayToken = p.nextToken(data)
+	data, timeToken = p.nextToken(data)
+	lastModificationDate, yearUnknown, err := p.parseDate(monthToken, dayToken, timeToken, options)
 	if err != nil {
 		return nil, errors.NewInternalError("failed to parse last modification date", err)
 	}
 	entry.LastModificationDate = lastModificationDate
+	entry.YearUnknown = yearUnknown
 
 	// name
 	name := strings.TrimSpace(data)
@@ -81,6 +89,57 @@ func (p *unixListParser) Parse(data string, _ *Options) (*entities.Entry, error)
 	return entry, nil
 }
 
+// parseDate function parses last modification date of the entry. Month abbreviations are looked up
+// in the language set in options, or in all supported languages if none is set. The last token is
+// either a time of the day for recent entries or a year for older ones. Dates are listed in the server
+// location set in options and are returned in UTC. Dates listed with time of the day are in year 0 and
+// are reported as year unknown, as shifting them to UTC may move them to an adjacent year.
+func (p *unixListParser) parseDate(
+	monthToken, dayToken, timeToken string,
+	options *Options,
+) (date time.Time, yearUnknown bool, err error) {
+	language := LanguageAuto
+	location := time.UTC
+	if options != nil {
+		language = options.Language
+		if options.Location != nil {
+			location = options.Location
+		}
+	}
+
+	month, ok := lookupMonth(monthToken, language)
+	if !ok {
+		return time.Time{}, false, fmt.Errorf("unexpected month: %s", monthToken)
+	}
+
+	const maxDay = 31
+	day, err := strconv.ParseUint(strings.TrimSuffix(dayToken, daySuffix), decimalBase, bitSize32)
+	if err != nil {
+		return time.Time{}, false, err
+	}
+	if day == 0 || day > maxDay {
+		return time.Time{}, false, fmt.Errorf("unexpected day of month: %s", dayToken)
+	}
+
+	if strings.Contains(timeToken, ":") {
+		timeOfDay, parseErr := time.Parse(lastModificationTimeFormat, timeToken)
+		if parseErr != nil {
+			return time.Time{}, false, parseErr
+		}
+		// year is not listed for recent entries, hence the location offset is taken from
+		// the same day of the current year to account for daylight saving time
+		_, offset := time.Date(time.Now().Year(), month, int(day), timeOfDay.Hour(), timeOfDay.Minute(), 0, 0, location).Zone()
+		date = time.Date(0, month, int(day), timeOfDay.Hour(), timeOfDay.Minute(), 0, 0, time.UTC)
+		return date.Add(-time.Duration(offset) * time.Second), true, nil
+	}
+
+	year, err := strconv.ParseInt(timeToken, decimalBase, bitSize32)
+	if err != nil {
+		return time.Time{}, false, err
+	}
+	return time.Date(int(year), month, int(day), 0, 0, 0, 0, location).UTC(), false, nil
+}
+
 func (p *unixListParser) nextToken(data string) (newData, token string) {
 	var start int
 	var end int
@@ -1,6 +1,7 @@
 package parsers_test
 
 import (
+	"os"
 	"testing"
 	"time"
 
@@ -12,7 +13,6 @@ import (
 
 func Test_unixListParser_Parse_Success(t *testing.T) {
 	// TODO: add below test cases:
-	// drwxr-xr-x    3 110      1002            3 Dec 02  2009 pub
 	// drwxr-xr-x    3 110      1002            3 Dec 02  2009 p u b
 	// -rw-r--r--   1 marketwired marketwired    12016 Mar 16  2016 2016031611G087802-001.newsml
 	// drwxr-xr-x    3 110      1002            3 Dec 02  2009 spaces   dir   name
@@ -31,12 +31,15 @@ func Test_unixListParser_Parse_Success(t *testing.T) {
 			expectedEntry: &entities.Entry{
 				Type:                 entities.EntryTypeFile,
 				Permissions:          "rwxr-xr-x",
+				Mode:                 0o755,
 				NumHardLinks:         1,
 				OwnerUser:            "ftp",
 				OwnerGroup:           "ftpg",
 				SizeInBytes:          672,
 				LastModificationDate: time.Date(0, 9, 8, 15, 15, 0, 0, time.UTC),
+				YearUnknown:          true,
 				Name:                 "docker-compose.yaml",
+				Raw:                  "-rwxr-xr-x   1 ftp      ftpg           672 Sep 08 15:15 docker-compose.yaml",
 			},
 		},
 		{
@@ -45,12 +48,15 @@ func Test_unixListParser_Parse_Success(t *testing.T) {
 			expectedEntry: &entities.Entry{
 				Type:                 entities.EntryTypeDir,
 				Permissions:          "r--------",
+				Mode:                 os.ModeDir | 0o400,
 				NumHardLinks:         23,
 				OwnerUser:            "root",
 				OwnerGroup:           "root",
 				SizeInBytes:          2,
 				LastModificationDate: time.Date(0, 9, 8, 15, 15, 0, 0, time.UTC),
+				YearUnknown:          true,
 				Name:                 ".",
+				Raw:                  "dr--------   23 root      root           2 Sep 08 15:15 .",
 			},
 		},
 		{
@@ -59,13 +65,85 @@ func Test_unixListParser_Parse_Success(t *testing.T) {
 			expectedEntry: &entities.Entry{
 				Type:                 entities.EntryTypeLink,
 				Permissions:          "r--------",
+				Mode:                 os.ModeSymlink | 0o400,
 				NumHardLinks:         23,
 				OwnerUser:            "root",
 				OwnerGroup:           "root",
 				SizeInBytes:          2,
 				LastModificationDate: time.Date(0, 9, 8, 15, 15, 0, 0, time.UTC),
+				YearUnknown:          true,
 				Name:                 "logs",
 				LinkName:             "/var/logs",
+				Raw:                  "lr--------   23 root      root           2 Sep 08 15:15 logs -> /var/logs",
+			},
+		},
+		{
+			name:  "numeric owner user and group",
+			input: "drwxr-xr-x    3 110      1002            3 Dec 02  2009 pub",
+			expectedEntry: &entities.Entry{
+				Type:                 entities.EntryTypeDir,
+				Permissions:          "rwxr-xr-x",
+				Mode:                 os.ModeDir | 0o755,
+				NumHardLinks:         3,
+				OwnerUser:            "110",
+				OwnerGroup:           "1002",
+				UID:                  uint32Ptr(110),
+				GID:                  uint32Ptr(1002),
+				SizeInBytes:          3,
+				LastModificationDate: time.Date(2009, 12, 2, 0, 0, 0, 0, time.UTC),
+				Name:                 "pub",
+				Raw:                  "drwxr-xr-x    3 110      1002            3 Dec 02  2009 pub",
+			},
+		},
+		{
+			name:  "special permission bits with ACL marker",
+			input: "-rwsr-Sr-t+   1 ftp      ftpg           672 Sep 08 15:15 special",
+			expectedEntry: &entities.Entry{
+				Type:                 entities.EntryTypeFile,
+				Permissions:          "rwsr-Sr-t+",
+				Mode:                 os.ModeSetuid | os.ModeSetgid | os.ModeSticky | 0o745,
+				NumHardLinks:         1,
+				OwnerUser:            "ftp",
+				OwnerGroup:           "ftpg",
+				SizeInBytes:          672,
+				LastModificationDate: time.Date(0, 9, 8, 15, 15, 0, 0, time.UTC),
+				YearUnknown:          true,
+				Name:                 "special",
+				Raw:                  "-rwsr-Sr-t+   1 ftp      ftpg           672 Sep 08 15:15 special",
+			},
+		},
+		{
+			name:  "unknown permission characters",
+			input: "-rw-r-lr--   1 ftp      ftpg           672 Sep 08 15:15 locked",
+			expectedEntry: &entities.Entry{
+				Type:                 entities.EntryTypeFile,
+				Permissions:          "rw-r-lr--",
+				NumHardLinks:         1,
+				OwnerUser:            "ftp",
+				OwnerGroup:           "ftpg",
+				SizeInBytes:          672,
+				LastModificationDate: time.Date(0, 9, 8, 15, 15, 0, 0, time.UTC),
+				YearUnknown:          true,
+				Name:                 "locked",
+				Raw:                  "-rw-r-lr--   1 ftp      ftpg           672 Sep 08 15:15 locked",
+			},
+		},
+		{
+			name:  "link with unknown permissions",
+			input: "l?????????   1 ftp      ftpg           7 Sep 08 15:15 broken -> missing",
+			expectedEntry: &entities.Entry{
+				Type:                 entities.EntryTypeLink,
+				Permissions:          "?????????",
+				Mode:                 os.ModeSymlink,
+				NumHardLinks:         1,
+				OwnerUser:            "ftp",
+				OwnerGroup:           "ftpg",
+				SizeInBytes:          7,
+				LastModificationDate: time.Date(0, 9, 8, 15, 15, 0, 0, time.UTC),
+				YearUnknown:          true,
+				Name:                 "broken",
+				LinkName:             "missing",
+				Raw:                  "l?????????   1 ftp      ftpg           7 Sep 08 15:15 broken -> missing",
 			},
 		},
 	}
@@ -111,3 +189,138 @@ func Test_unixListParser_Errors(t *testing.T) {
 		})
 	}
 }
+
+func Test_unixListParser_Parse_LocalizedMonths_Success(t *testing.T) {
+	testCases := []struct {
+		name         string
+		input        string
+		language     string
+		expectedDate time.Time
+	}{
+		{
+			name:         "english month with year",
+			input:        "drwxr-xr-x    3 110      1002            3 Dec 02  2009 pub",
+			expectedDate: time.Date(2009, 12, 2, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:         "german month",
+			input:        "-rw-r--r--    1 ftp      ftp           187 Mär 16 14:34 file-1.txt",
+			expectedDate: time.Date(0, 3, 16, 14, 34, 0, 0, time.UTC),
+		},
+		{
+			name:         "french month",
+			input:        "-rw-r--r--    1 ftp      ftp           187 déc. 16 14:34 file-1.txt",
+			expectedDate: time.Date(0, 12, 16, 14, 34, 0, 0, time.UTC),
+		},
+		{
+			name:         "spanish month",
+			input:        "-rw-r--r--    1 ftp      ftp           187 ago 16  2021 file-1.txt",
+			expectedDate: time.Date(2021, 8, 16, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:         "russian month",
+			input:        "-rw-r--r--    1 ftp      ftp           187 дек 16 14:34 file-1.txt",
+			expectedDate: time.Date(0, 12, 16, 14, 34, 0, 0, time.UTC),
+		},
+		{
+			name:         "japanese month",
+			input:        "-rw-r--r--    1 ftp      ftp           187 5月 16日 14:34 file-1.txt",
+			expectedDate: time.Date(0, 5, 16, 14, 34, 0, 0, time.UTC),
+		},
+		{
+			name:         "month of selected language",
+			input:        "-rw-r--r--    1 ftp      ftp           187 Okt 16 14:34 file-1.txt",
+			language:     parsers.LanguageGerman,
+			expectedDate: time.Date(0, 10, 16, 14, 34, 0, 0, time.UTC),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			p := parsers.NewGenericListParser()
+			actual, err := p.Parse(tc.input, &parsers.Options{
+				Language: tc.language,
+			})
+			assert.NoError(t, err)
+			if assert.NotNil(t, actual) {
+				assert.Equal(t, tc.expectedDate, actual.LastModificationDate)
+			}
+		})
+	}
+}
+
+func Test_unixListParser_Parse_Location_Success(t *testing.T) {
+	location := time.FixedZone("", -5*60*60)
+
+	testCases := []struct {
+		name                string
+		input               string
+		expectedDate        time.Time
+		expectedYearUnknown bool
+	}{
+		{
+			name:                "entry with time of the day",
+			input:               "-rw-r--r--    1 ftp      ftp           187 Sep 16 22:34 file-1.txt",
+			expectedDate:        time.Date(0, 9, 17, 3, 34, 0, 0, time.UTC),
+			expectedYearUnknown: true,
+		},
+		{
+			name:                "entry with time of the day near new year",
+			input:               "-rw-r--r--    1 ftp      ftp           187 Dec 31 22:34 file-1.txt",
+			expectedDate:        time.Date(1, 1, 1, 3, 34, 0, 0, time.UTC),
+			expectedYearUnknown: true,
+		},
+		{
+			name:         "entry with year",
+			input:        "-rw-r--r--    1 ftp      ftp           187 Dec 31  2021 file-1.txt",
+			expectedDate: time.Date(2021, 12, 31, 5, 0, 0, 0, time.UTC),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			p := parsers.NewGenericListParser()
+			actual, err := p.Parse(tc.input, &parsers.Options{
+				Location: location,
+			})
+			assert.NoError(t, err)
+			if assert.NotNil(t, actual) {
+				assert.Equal(t, tc.expectedDate, actual.LastModificationDate)
+				assert.Equal(t, tc.expectedYearUnknown, actual.YearUnknown)
+			}
+		})
+	}
+}
+
+func Test_unixListParser_Parse_LocalizedMonths_Errors(t *testing.T) {
+	testCases := []struct {
+		name     string
+		input    string
+		language string
+	}{
+		{
+			name:     "month of another language",
+			input:    "-rw-r--r--    1 ftp      ftp           187 дек 16 14:34 file-1.txt",
+			language: parsers.LanguageEnglish,
+		},
+		{
+			name:  "invalid day of month",
+			input: "-rw-r--r--    1 ftp      ftp           187 Dec 32 14:34 file-1.txt",
+		},
+		{
+			name:  "invalid year",
+			input: "-rw-r--r--    1 ftp      ftp           187 Dec 16 not-valid file-1.txt",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			p := parsers.NewGenericListParser()
+			actual, err := p.Parse(tc.input, &parsers.Options{
+				Language: tc.language,
+			})
+			assert.Nil(t, actual)
+			assert.EqualError(t, err, "an internal error occurred: unsupported entry format")
+		})
+	}
+}
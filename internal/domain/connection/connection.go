@@ -12,25 +12,45 @@ type ListOptions struct {
 	ShowAll bool
 }
 
+type ListResult struct {
+	Entries []*entities.Entry
+	// SkippedLines contains raw entries that could not be parsed and were skipped
+	// in lenient listing mode.
+	SkippedLines []string
+}
+
 type UploadOptions struct {
 	FileReader io.Reader
 	Path       string
 }
 
+type DownloadOptions struct {
+	Path string
+	// ProgressWriter receives the downloaded bytes as they are transferred, if provided.
+	ProgressWriter io.Writer
+}
+
 type Connection interface {
 	Ready() error
 	Stop() error
 	Login(user, password string) error
 	EnableExplicitTLSMode() error
-	List(ctx context.Context, options *ListOptions) ([]*entities.Entry, error)
+	List(ctx context.Context, options *ListOptions) (*ListResult, error)
 	Status() (*entities.Status, error)
 	Mkdir(path string) error
 	Upload(ctx context.Context, options *UploadOptions) error
 	Cd(path string) error
+	// Pwd returns the absolute path of the working directory.
+	Pwd() (string, error)
 	Size(path string) (uint64, error)
 	RemoveFile(path string) error
 	RemoveDir(path string) error
 	Move(oldPath string, newPath string) error
-	Download(ctx context.Context, path string) ([]byte, error)
+	Download(ctx context.Context, options *DownloadOptions) ([]byte, error)
 	IsDir(ctx context.Context, path string) (bool, error)
+	// Stat returns the entry under the path, or a NotFoundError if it does not exist.
+	Stat(ctx context.Context, path string) (*entities.Entry, error)
+	// Checksum returns the hex encoded hash of the file computed by the server, or an empty string if
+	// the server cannot compute it with the algorithm.
+	Checksum(path string, algorithm entities.HashAlgorithm) (string, error)
 }
@@ -1,6 +1,14 @@
 package entities
 
-import "time"
+import (
+	"os"
+	"strings"
+	"time"
+)
+
+// maxClockSkew is the tolerated difference between client and server clocks when resolving the year of
+// listed entries.
+const maxClockSkew = 24 * time.Hour
 
 type EntryType int
 
@@ -18,14 +26,111 @@ const (
 	SortTypeDate
 )
 
+// Capabilities is a set of operations the server allows on the entry, as advertised by the
+// perm fact of RFC3659 (MLSD) entries.
+type Capabilities uint
+
+const (
+	CapabilityAppend Capabilities = 1 << iota
+	CapabilityCreate
+	CapabilityDelete
+	CapabilityEnter
+	CapabilityRename
+	CapabilityList
+	CapabilityMkdir
+	CapabilityPurge
+	CapabilityRead
+	CapabilityWrite
+)
+
+// capabilityChars maps each capability to the character used for it in the perm fact.
+var capabilityChars = []struct {
+	capability Capabilities
+	char       rune
+}{
+	{CapabilityAppend, 'a'},
+	{CapabilityCreate, 'c'},
+	{CapabilityDelete, 'd'},
+	{CapabilityEnter, 'e'},
+	{CapabilityRename, 'f'},
+	{CapabilityList, 'l'},
+	{CapabilityMkdir, 'm'},
+	{CapabilityPurge, 'p'},
+	{CapabilityRead, 'r'},
+	{CapabilityWrite, 'w'},
+}
+
+// CapabilityFromChar function returns the capability of the perm fact character, or false if the
+// character is unknown.
+func CapabilityFromChar(ch rune) (Capabilities, bool) {
+	for _, cc := range capabilityChars {
+		if cc.char == ch {
+			return cc.capability, true
+		}
+	}
+	return 0, false
+}
+
+// Has method reports whether all provided capabilities are in the set.
+func (c Capabilities) Has(capabilities Capabilities) bool {
+	return c&capabilities == capabilities
+}
+
+// String method formats the set the same way as the perm fact (e.g. adfr).
+func (c Capabilities) String() string {
+	var sb strings.Builder
+	for _, cc := range capabilityChars {
+		if c.Has(cc.capability) {
+			sb.WriteRune(cc.char)
+		}
+	}
+	return sb.String()
+}
+
 type Entry struct {
-	Type                 EntryType
-	Permissions          string
-	Name                 string
-	LinkName             string
-	OwnerUser            string
-	OwnerGroup           string
+	Type EntryType
+	// Permissions is the permission string as listed by the server, either Unix permission
+	// bits (e.g. rwxr-xr-x) or RFC3659 perm fact (e.g. adfr).
+	Permissions string
+	// Mode holds the type and permission bits of the entry, if known.
+	Mode os.FileMode
+	// Capabilities holds the operations allowed on the entry as listed in RFC3659 perm fact.
+	Capabilities Capabilities
+	Name         string
+	LinkName     string
+	OwnerUser    string
+	OwnerGroup   string
+	// UID and GID are numeric owner user and group IDs, nil if not listed by the server.
+	UID                  *uint32
+	GID                  *uint32
 	SizeInBytes          uint64
 	NumHardLinks         int
 	LastModificationDate time.Time
+	// YearUnknown is set for recently modified entries listed with time of the day instead of a year.
+	// LastModificationDate of such entries is in year 0, or in an adjacent year once shifted to UTC.
+	YearUnknown  bool
+	CreationDate time.Time
+	// UniqueID identifies the entry on the server (e.g. device and inode), regardless of its path.
+	UniqueID string
+	// Raw is the listing line the entry was parsed from.
+	Raw string
+	// Facts holds the RFC3659 facts that do not map to any of the fields above, keyed by
+	// lower-cased fact name.
+	Facts map[string]string
+}
+
+// ResolvedModificationDate method returns the modification date with the year of recently modified
+// entries, which are listed with time of the day instead of a year, resolved. Such entries are assumed to
+// be modified within the last year.
+func (e *Entry) ResolvedModificationDate(now time.Time) time.Time {
+	if !e.YearUnknown {
+		return e.LastModificationDate
+	}
+
+	// the date is in year 0 or, once shifted from the server time zone to UTC, in an adjacent year
+	resolved := e.LastModificationDate.AddDate(now.Year(), 0, 0)
+	if resolved.After(now.Add(maxClockSkew)) {
+		resolved = resolved.AddDate(-1, 0, 0)
+	}
+	return resolved
 }
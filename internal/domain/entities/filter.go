@@ -0,0 +1,20 @@
+package entities
+
+import "time"
+
+// FilterOptions select entries of a directory tree that take part in a recursive transfer or removal.
+// Patterns follow gitignore semantics and are matched against slash separated paths relative to the
+// root of the tree.
+type FilterOptions struct {
+	// Include patterns, when provided, limit files to those matching any of the patterns or located
+	// in a directory that matches one.
+	Include []string
+	// Exclude patterns are evaluated in order and the last matching pattern wins, so a pattern
+	// prefixed with "!" includes entries excluded by an earlier pattern. Entries of an excluded
+	// directory are never included.
+	Exclude []string
+	// MinSizeInBytes skips files smaller than the given size.
+	MinSizeInBytes uint64
+	// ModifiedAfter skips files that were last modified before the given time, unless it is zero.
+	ModifiedAfter time.Time
+}
@@ -0,0 +1,234 @@
+package entities
+
+import (
+	"strings"
+)
+
+const (
+	// RemoteSeparator separates elements of remote paths regardless of the local OS.
+	RemoteSeparator = "/"
+	// RemoteRoot is the root directory of the server.
+	RemoteRoot RemotePath = "/"
+
+	remoteHomePrefix = "~"
+	remoteGlobMeta   = `*?[\`
+)
+
+// RemotePath is a slash separated path on the server. Unlike local paths, remote paths are never
+// cleaned implicitly, since resolving ".." lexically may not match what the server does, and trailing
+// slashes are kept as they denote a directory. The following roots are recognised:
+//   - "/", the root of the server;
+//   - drive roots, such as "C:/" or "/C:/", reported by Windows servers;
+//   - "~" and "~user", home directories, which are resolved against the login directory or left to
+//     the server respectively.
+type RemotePath string
+
+func (p RemotePath) String() string {
+	return string(p)
+}
+
+// Root method returns the root the path starts with, or an empty string if the path is relative.
+func (p RemotePath) Root() string {
+	s := string(p)
+	switch {
+	case strings.HasPrefix(s, remoteHomePrefix):
+		if idx := strings.Index(s, RemoteSeparator); idx >= 0 {
+			return s[:idx]
+		}
+		return s
+	case isDriveRoot(s):
+		return s[:3]
+	case strings.HasPrefix(s, RemoteSeparator) && isDriveRoot(s[1:]):
+		return s[:4]
+	case strings.HasPrefix(s, RemoteSeparator):
+		return RemoteSeparator
+	}
+	return ""
+}
+
+// IsAbs method reports whether the path starts at a root of the server. Home directory paths are not
+// absolute, as they need to be resolved against the home directory first.
+func (p RemotePath) IsAbs() bool {
+	return p.Root() != "" && !p.IsHome()
+}
+
+// IsHome method reports whether the path starts at a home directory, such as "~/docs" or "~user".
+func (p RemotePath) IsHome() bool {
+	return strings.HasPrefix(string(p), remoteHomePrefix)
+}
+
+// IsRoot method reports whether the path is a root itself, ignoring trailing slashes.
+func (p RemotePath) IsRoot() bool {
+	root := p.Root()
+	return root != "" && strings.TrimRight(string(p[len(root):]), RemoteSeparator) == ""
+}
+
+// HasTrailingSlash method reports whether the path ends with a slash, which denotes a directory.
+func (p RemotePath) HasTrailingSlash() bool {
+	return strings.HasSuffix(string(p), RemoteSeparator)
+}
+
+// TrimTrailingSlash method returns the path without trailing slashes, unless it is a root.
+func (p RemotePath) TrimTrailingSlash() RemotePath {
+	root := p.Root()
+	trimmed := strings.TrimRight(string(p[len(root):]), RemoteSeparator)
+	if trimmed == "" && root != "" {
+		return RemotePath(root)
+	}
+	return RemotePath(root + trimmed)
+}
+
+// Join method appends the elements to the path separated by a single slash. Empty elements are
+// skipped and no other cleaning takes place.
+func (p RemotePath) Join(elems ...string) RemotePath {
+	joined := string(p)
+	for _, elem := range elems {
+		elem = strings.TrimLeft(elem, RemoteSeparator)
+		if elem == "" {
+			continue
+		}
+		joined = string(RemotePath(joined).TrimTrailingSlash())
+		if joined != "" && !strings.HasSuffix(joined, RemoteSeparator) {
+			joined += RemoteSeparator
+		}
+		joined += elem
+	}
+	return RemotePath(joined)
+}
+
+// Split method splits the path into its parent directory and the last element, ignoring trailing
+// slashes. The parent directory of a relative path with a single element is empty.
+func (p RemotePath) Split() (RemotePath, string) {
+	trimmed := p.TrimTrailingSlash()
+	root := trimmed.Root()
+	if trimmed.IsRoot() {
+		return trimmed, ""
+	}
+
+	rest := string(trimmed[len(root):])
+	idx := strings.LastIndex(rest, RemoteSeparator)
+	if idx < 0 {
+		return RemotePath(root), rest
+	}
+	return RemotePath(root + rest[:idx]).TrimTrailingSlash(), rest[idx+1:]
+}
+
+// Dir method returns the parent directory of the path.
+func (p RemotePath) Dir() RemotePath {
+	dir, _ := p.Split()
+	return dir
+}
+
+// Base method returns the last element of the path.
+func (p RemotePath) Base() string {
+	_, name := p.Split()
+	return name
+}
+
+// Clean method resolves "." and ".." elements lexically, never going above the root, and drops
+// duplicate and trailing slashes. It is meant for paths that were already resolved by the server.
+func (p RemotePath) Clean() RemotePath {
+	root := p.Root()
+
+	var elems []string
+	for _, elem := range strings.Split(string(p[len(root):]), RemoteSeparator) {
+		switch {
+		case elem == "" || elem == ".":
+		case elem == ".." && len(elems) > 0 && elems[len(elems)-1] != "..":
+			elems = elems[:len(elems)-1]
+		case elem == ".." && root != "":
+		default:
+			elems = append(elems, elem)
+		}
+	}
+
+	cleaned := RemotePath(root).Join(elems...)
+	if cleaned == "" {
+		return "."
+	}
+	return cleaned
+}
+
+// Resolve method resolves the path against the working directory, or the home directory if the path
+// starts with "~". Paths starting at home directories of other users are left to the server.
+func (p RemotePath) Resolve(workDir, homeDir RemotePath) RemotePath {
+	switch {
+	case p.IsAbs():
+		return p
+	case p.Root() == remoteHomePrefix:
+		return homeDir.Join(string(p[len(remoteHomePrefix):]))
+	case p.IsHome():
+		return p
+	}
+	return workDir.Join(string(p))
+}
+
+// Rel method returns the path relative to the base directory, reporting false if the path is not
+// located under it.
+func (p RemotePath) Rel(base RemotePath) (string, bool) {
+	trimmedBase := string(base.TrimTrailingSlash())
+	trimmed := string(p.TrimTrailingSlash())
+	switch {
+	case trimmed == trimmedBase:
+		return "", true
+	case trimmedBase == "":
+		return trimmed, !p.IsAbs() && !p.IsHome()
+	case strings.HasSuffix(trimmedBase, RemoteSeparator):
+		return strings.TrimPrefix(trimmed, trimmedBase), strings.HasPrefix(trimmed, trimmedBase)
+	}
+	return strings.TrimPrefix(trimmed, trimmedBase+RemoteSeparator), strings.HasPrefix(trimmed, trimmedBase+RemoteSeparator)
+}
+
+// EscapeRemoteGlob function escapes glob meta characters of the path with a backslash, so that it is
+// matched literally when used as part of a glob pattern.
+func EscapeRemoteGlob(p string) string {
+	var sb strings.Builder
+	for _, r := range p {
+		if strings.ContainsRune(remoteGlobMeta, r) {
+			sb.WriteRune('\\')
+		}
+		sb.WriteRune(r)
+	}
+	return sb.String()
+}
+
+// UnescapeRemoteGlob function removes backslashes escaping characters of the path.
+func UnescapeRemoteGlob(p string) string {
+	var sb strings.Builder
+	escaped := false
+	for _, r := range p {
+		if r == '\\' && !escaped {
+			escaped = true
+			continue
+		}
+		sb.WriteRune(r)
+		escaped = false
+	}
+	return sb.String()
+}
+
+// HasRemoteGlobMeta function reports whether the path contains glob meta characters that are not
+// escaped with a backslash.
+func HasRemoteGlobMeta(p string) bool {
+	escaped := false
+	for _, r := range p {
+		switch {
+		case escaped:
+			escaped = false
+		case r == '\\':
+			escaped = true
+		case strings.ContainsRune("*?[", r):
+			return true
+		}
+	}
+	return false
+}
+
+// isDriveRoot function reports whether the path starts with a drive root, such as "C:/".
+func isDriveRoot(s string) bool {
+	if len(s) < 3 || s[1] != ':' || s[2] != '/' {
+		return false
+	}
+	c := s[0]
+	return ('a' <= c && c <= 'z') || ('A' <= c && c <= 'Z')
+}
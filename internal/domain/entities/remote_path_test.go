@@ -0,0 +1,167 @@
+package entities_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/alexZaicev/go-ftp-client/internal/domain/entities"
+)
+
+func Test_RemotePath_Root(t *testing.T) {
+	testCases := []struct {
+		path   entities.RemotePath
+		root   string
+		isAbs  bool
+		isHome bool
+		isRoot bool
+	}{
+		{path: "/", root: "/", isAbs: true, isRoot: true},
+		{path: "//", root: "/", isAbs: true, isRoot: true},
+		{path: "/foo/bar", root: "/", isAbs: true},
+		{path: "foo/bar", root: ""},
+		{path: "", root: ""},
+		{path: "C:/", root: "C:/", isAbs: true, isRoot: true},
+		{path: "/c:/foo", root: "/c:/", isAbs: true},
+		{path: "C:foo", root: ""},
+		{path: "~", root: "~", isHome: true, isRoot: true},
+		{path: "~/docs", root: "~", isHome: true},
+		{path: "~user01/docs", root: "~user01", isHome: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(string(tc.path), func(t *testing.T) {
+			assert.Equal(t, tc.root, tc.path.Root())
+			assert.Equal(t, tc.isAbs, tc.path.IsAbs())
+			assert.Equal(t, tc.isHome, tc.path.IsHome())
+			assert.Equal(t, tc.isRoot, tc.path.IsRoot())
+		})
+	}
+}
+
+func Test_RemotePath_Join(t *testing.T) {
+	testCases := []struct {
+		path     entities.RemotePath
+		elems    []string
+		expected entities.RemotePath
+	}{
+		{path: "/", elems: []string{"foo"}, expected: "/foo"},
+		{path: "/foo/", elems: []string{"bar", "baz.txt"}, expected: "/foo/bar/baz.txt"},
+		{path: "/foo", elems: []string{"/bar/"}, expected: "/foo/bar/"},
+		{path: "", elems: []string{"foo", "", "bar"}, expected: "foo/bar"},
+		{path: "/foo", elems: []string{"../bar"}, expected: "/foo/../bar"},
+		{path: "C:/", elems: []string{"foo"}, expected: "C:/foo"},
+		{path: "~", elems: []string{"docs"}, expected: "~/docs"},
+		{path: "/foo", elems: nil, expected: "/foo"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(string(tc.expected), func(t *testing.T) {
+			assert.Equal(t, tc.expected, tc.path.Join(tc.elems...))
+		})
+	}
+}
+
+func Test_RemotePath_Split(t *testing.T) {
+	testCases := []struct {
+		path entities.RemotePath
+		dir  entities.RemotePath
+		name string
+	}{
+		{path: "/foo/bar/baz", dir: "/foo/bar", name: "baz"},
+		{path: "/foo/bar/", dir: "/foo", name: "bar"},
+		{path: "/foo", dir: "/", name: "foo"},
+		{path: "/", dir: "/", name: ""},
+		{path: "foo", dir: "", name: "foo"},
+		{path: "foo//bar", dir: "foo", name: "bar"},
+		{path: "C:/foo", dir: "C:/", name: "foo"},
+		{path: "~/docs", dir: "~", name: "docs"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(string(tc.path), func(t *testing.T) {
+			dir, name := tc.path.Split()
+			assert.Equal(t, tc.dir, dir)
+			assert.Equal(t, tc.name, name)
+			assert.Equal(t, tc.dir, tc.path.Dir())
+			assert.Equal(t, tc.name, tc.path.Base())
+		})
+	}
+}
+
+func Test_RemotePath_Clean(t *testing.T) {
+	testCases := []struct {
+		path     entities.RemotePath
+		expected entities.RemotePath
+	}{
+		{path: "/foo//bar/./baz/", expected: "/foo/bar/baz"},
+		{path: "/foo/../../bar", expected: "/bar"},
+		{path: "foo/../../bar", expected: "../bar"},
+		{path: "./", expected: "."},
+		{path: "/..", expected: "/"},
+		{path: "C:/foo/..", expected: "C:/"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(string(tc.path), func(t *testing.T) {
+			assert.Equal(t, tc.expected, tc.path.Clean())
+		})
+	}
+}
+
+func Test_RemotePath_Resolve(t *testing.T) {
+	testCases := []struct {
+		path     entities.RemotePath
+		expected entities.RemotePath
+	}{
+		{path: "/foo", expected: "/foo"},
+		{path: "foo/bar", expected: "/pub/foo/bar"},
+		{path: "../foo", expected: "/pub/../foo"},
+		{path: "~", expected: "/home/user01"},
+		{path: "~/docs/", expected: "/home/user01/docs/"},
+		{path: "~user02/docs", expected: "~user02/docs"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(string(tc.path), func(t *testing.T) {
+			assert.Equal(t, tc.expected, tc.path.Resolve("/pub", "/home/user01"))
+		})
+	}
+}
+
+func Test_RemotePath_Rel(t *testing.T) {
+	testCases := []struct {
+		path     entities.RemotePath
+		base     entities.RemotePath
+		expected string
+		ok       bool
+	}{
+		{path: "/foo/bar/baz", base: "/foo", expected: "bar/baz", ok: true},
+		{path: "/foo/bar/", base: "/foo/", expected: "bar", ok: true},
+		{path: "/foo", base: "/foo", expected: "", ok: true},
+		{path: "/foo/bar", base: "/", expected: "foo/bar", ok: true},
+		{path: "/foobar", base: "/foo", ok: false},
+		{path: "foo/bar", base: "", expected: "foo/bar", ok: true},
+		{path: "/foo/bar", base: "", ok: false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(string(tc.path), func(t *testing.T) {
+			rel, ok := tc.path.Rel(tc.base)
+			assert.Equal(t, tc.ok, ok)
+			if tc.ok {
+				assert.Equal(t, tc.expected, rel)
+			}
+		})
+	}
+}
+
+func Test_RemoteGlob_Escaping(t *testing.T) {
+	escaped := entities.EscapeRemoteGlob(`/data[1]/*.csv?\`)
+	assert.Equal(t, `/data\[1]/\*.csv\?\\`, escaped)
+	assert.False(t, entities.HasRemoteGlobMeta(escaped))
+	assert.Equal(t, `/data[1]/*.csv?\`, entities.UnescapeRemoteGlob(escaped))
+
+	assert.True(t, entities.HasRemoteGlobMeta(`/data\[1]/*.csv`))
+	assert.False(t, entities.HasRemoteGlobMeta("/data/report.csv"))
+}
@@ -0,0 +1,87 @@
+package entities
+
+import "time"
+
+// SyncDirection is the direction in which files are synchronised between local and remote trees.
+type SyncDirection int
+
+const (
+	// SyncDirectionUpload mirrors local tree onto the server.
+	SyncDirectionUpload SyncDirection = iota + 1
+	// SyncDirectionDownload mirrors remote tree onto the local filesystem.
+	SyncDirectionDownload
+)
+
+type SyncActionType int
+
+const (
+	SyncActionCreateDir SyncActionType = iota + 1
+	SyncActionCopy
+	SyncActionDelete
+	SyncActionRename
+)
+
+// SyncReason explains why a synchronisation action is required.
+type SyncReason string
+
+const (
+	SyncReasonNew         SyncReason = "new"
+	SyncReasonSizeChanged SyncReason = "size changed"
+	SyncReasonModified    SyncReason = "modified"
+	SyncReasonExtraneous  SyncReason = "extraneous"
+	SyncReasonDeleted     SyncReason = "deleted"
+	SyncReasonConflict    SyncReason = "conflict"
+)
+
+// SyncActionRoot is the path of actions that apply to the synchronised root directory itself.
+const SyncActionRoot = "."
+
+// SyncAction is a single step required to bring the destination tree in line with the source tree.
+type SyncAction struct {
+	Type SyncActionType
+	// Direction is only set by two-way synchronisation. Actions of upload direction change the remote
+	// tree, and actions of download direction change the local tree.
+	Direction SyncDirection
+	// Path is slash separated and relative to the synchronised root directories.
+	Path string
+	// NewPath is the path an entry is renamed to by rename actions.
+	NewPath     string
+	IsDir       bool
+	SizeInBytes uint64
+	Reason      SyncReason
+}
+
+// SyncConflictPolicy decides how two-way synchronisation resolves entries changed on both sides.
+type SyncConflictPolicy string
+
+const (
+	// SyncConflictPolicyNewest keeps the copy that was modified last.
+	SyncConflictPolicyNewest SyncConflictPolicy = "newest"
+	// SyncConflictPolicyKeepBoth renames the remote copy and synchronises both copies.
+	SyncConflictPolicyKeepBoth SyncConflictPolicy = "keep-both"
+	// SyncConflictPolicyFail aborts synchronisation without changing either tree.
+	SyncConflictPolicyFail SyncConflictPolicy = "fail"
+)
+
+// SyncStateFileName is the name of the file in the local root directory that keeps the state of
+// two-way synchronisation. The file itself is never synchronised.
+const SyncStateFileName = ".gfc-sync-state.json"
+
+// SyncState describes local and remote trees as they were after the last two-way synchronisation.
+type SyncState struct {
+	// Entries are keyed by slash separated path relative to the synchronised root directories.
+	Entries map[string]*SyncStateEntry `json:"entries"`
+}
+
+type SyncStateEntry struct {
+	IsDir  bool          `json:"isDir,omitempty"`
+	Local  SyncFileState `json:"local"`
+	Remote SyncFileState `json:"remote"`
+}
+
+// SyncFileState is the last seen state of an entry on one side. Hash is only known for local files.
+type SyncFileState struct {
+	SizeInBytes uint64    `json:"size"`
+	ModTime     time.Time `json:"modTime"`
+	Hash        string    `json:"hash,omitempty"`
+}
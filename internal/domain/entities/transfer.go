@@ -0,0 +1,120 @@
+package entities
+
+import (
+	"errors"
+	"fmt"
+)
+
+// OverwritePolicy decides what happens to a transferred file whose destination already exists.
+type OverwritePolicy string
+
+const (
+	// OverwritePolicyOverwrite replaces the existing file without checking it.
+	OverwritePolicyOverwrite OverwritePolicy = "overwrite"
+	// OverwritePolicySkip keeps the existing file.
+	OverwritePolicySkip OverwritePolicy = "skip"
+	// OverwritePolicyNewer replaces the existing file only if the source was modified after it.
+	OverwritePolicyNewer OverwritePolicy = "newer"
+	// OverwritePolicySizeDiffers replaces the existing file only if its size differs from the source.
+	OverwritePolicySizeDiffers OverwritePolicy = "size-differs"
+	// OverwritePolicyRename keeps the existing file and writes the source under a free name next to it
+	// (e.g. report-1.csv).
+	OverwritePolicyRename OverwritePolicy = "rename"
+	// OverwritePolicyFail aborts the transfer.
+	OverwritePolicyFail OverwritePolicy = "fail"
+)
+
+// SkippedTransfer is an entry that was not transferred, such as a file whose destination already exists
+// or a symbolic link.
+type SkippedTransfer struct {
+	// Path of the existing destination file, or of the source entry if it was skipped for another reason.
+	Path   string
+	Reason string
+}
+
+// TransferStatus is the outcome of a single entry of a transfer or a removal.
+type TransferStatus string
+
+const (
+	TransferStatusOK      TransferStatus = "ok"
+	TransferStatusSkipped TransferStatus = "skipped"
+	TransferStatusFailed  TransferStatus = "failed"
+)
+
+// TransferResult is the outcome of a single entry, reported for every entry that was processed.
+type TransferResult struct {
+	Path   string         `json:"path" yaml:"path"`
+	Status TransferStatus `json:"status" yaml:"status"`
+	// Reason explains why the entry was skipped or failed.
+	Reason string `json:"reason,omitempty" yaml:"reason,omitempty"`
+	// Err is the error the entry failed with.
+	Err error `json:"-" yaml:"-"`
+}
+
+// NewTransferOK function returns the result of an entry that was transferred or removed.
+func NewTransferOK(path string) *TransferResult {
+	return &TransferResult{Path: path, Status: TransferStatusOK}
+}
+
+// NewTransferSkipped function returns the result of an entry that was skipped.
+func NewTransferSkipped(skipped *SkippedTransfer) *TransferResult {
+	return &TransferResult{Path: skipped.Path, Status: TransferStatusSkipped, Reason: skipped.Reason}
+}
+
+// NewTransferFailed function returns the result of an entry that failed, where the reason includes the
+// cause of the error, as errors returned to the user do not print it.
+func NewTransferFailed(path string, err error) *TransferResult {
+	reason := err.Error()
+	if cause := errors.Unwrap(err); cause != nil {
+		reason = fmt.Sprintf("%s: %s", reason, cause)
+	}
+	return &TransferResult{Path: path, Status: TransferStatusFailed, Reason: reason, Err: err}
+}
+
+// HashAlgorithm is an algorithm used to verify the content of transferred files.
+type HashAlgorithm string
+
+const (
+	HashAlgorithmSHA256 HashAlgorithm = "SHA-256"
+)
+
+// AtomicUploadOptions configure uploads that write the file under a temporary name and rename it into
+// place once it has been verified, so that the file never appears partially written.
+type AtomicUploadOptions struct {
+	// TempPrefix and TempSuffix are added to the file name to compose the temporary name
+	// (e.g. .report.csv.partial).
+	TempPrefix string
+	TempSuffix string
+}
+
+// UnsafeNamePolicy decides what happens to a downloaded entry whose name is illegal or reserved on the
+// local filesystem. Names that would escape the download directory, such as "../.bashrc", are never
+// saved regardless of the policy.
+type UnsafeNamePolicy string
+
+const (
+	// UnsafeNamePolicySkip does not download the entry.
+	UnsafeNamePolicySkip UnsafeNamePolicy = "skip"
+	// UnsafeNamePolicyRename saves the entry under a sanitized name, where illegal characters are replaced
+	// with "_" and reserved names get "_" appended (e.g. CON_.txt).
+	UnsafeNamePolicyRename UnsafeNamePolicy = "rename"
+	// UnsafeNamePolicyFail aborts the download.
+	UnsafeNamePolicyFail UnsafeNamePolicy = "fail"
+)
+
+// LinkPolicy decides what happens to symbolic links found by a recursive transfer. Preserving links only
+// applies to downloads, while failing on links only applies to uploads.
+type LinkPolicy string
+
+const (
+	// LinkPolicySkip does not transfer the link and reports it as skipped.
+	LinkPolicySkip LinkPolicy = "skip"
+	// LinkPolicyFollow transfers the file or the directory the link points to under the name of the link.
+	// Links that point to one of the directories being transferred are skipped to avoid loops.
+	LinkPolicyFollow LinkPolicy = "follow"
+	// LinkPolicyPreserve creates a local symbolic link with the same target. Links whose target is
+	// absolute or escapes the download directory are skipped.
+	LinkPolicyPreserve LinkPolicy = "preserve"
+	// LinkPolicyError aborts the upload before any file is transferred.
+	LinkPolicyError LinkPolicy = "error"
+)
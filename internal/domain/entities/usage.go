@@ -0,0 +1,12 @@
+package entities
+
+// DirUsage is the space taken by files of a remote directory, including files of its subdirectories.
+type DirUsage struct {
+	Path string
+	// Depth is 0 for the directory usage was requested for, 1 for its subdirectories and so on.
+	Depth       int
+	SizeInBytes uint64
+	// Files and Dirs count files and subdirectories at any depth below the directory.
+	Files int
+	Dirs  int
+}
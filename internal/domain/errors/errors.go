@@ -12,6 +12,7 @@ var (
 	InvalidArgumentErrorType = &InvalidArgumentError{}
 	UnknownErrorType         = &UnknownError{}
 	NotFoundErrorType        = &NotFoundError{}
+	ConflictErrorType        = &ConflictError{}
 )
 
 type InternalError struct {
@@ -65,3 +66,16 @@ func NewNotFoundError(msg string, err error) *NotFoundError {
 		),
 	}
 }
+
+type ConflictError struct {
+	baseError
+}
+
+func NewConflictError(msg string, err error) *ConflictError {
+	return &ConflictError{
+		baseError: newBaseError(
+			fmt.Sprintf("a conflict error occurred: %s", msg),
+			err,
+		),
+	}
+}
@@ -36,3 +36,10 @@ func Test_NewNotFoundError_Success(t *testing.T) {
 	assert.IsType(t, ftperrors.NotFoundErrorType, err)
 	assert.EqualError(t, errors.Unwrap(err), "mock error")
 }
+
+func Test_NewConflictError_Success(t *testing.T) {
+	err := ftperrors.NewConflictError("hello world", errors.New("mock error"))
+	assert.EqualError(t, err, "a conflict error occurred: hello world")
+	assert.IsType(t, ftperrors.ConflictErrorType, err)
+	assert.EqualError(t, errors.Unwrap(err), "mock error")
+}
@@ -1,6 +1,15 @@
 package repositories
 
+import "github.com/alexZaicev/go-ftp-client/internal/domain/entities"
+
 type FileStore interface {
 	SaveFile(path string, data []byte) error
+	// Stat returns the entry under the path, or a NotFoundError if it does not exist.
+	Stat(path string) (*entities.Entry, error)
 	CreateDir(path string) error
+	// CreateSymlink creates a symbolic link under the path pointing to the target, replacing an existing
+	// file or link.
+	CreateSymlink(path, target string) error
+	// Remove removes the file or the directory along with its contents.
+	Remove(path string) error
 }
@@ -0,0 +1,102 @@
+package cli
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"github.com/alexZaicev/go-ftp-client/internal/adapters/ftpclient/shell"
+	ftperrors "github.com/alexZaicev/go-ftp-client/internal/domain/errors"
+	"github.com/alexZaicev/go-ftp-client/internal/drivers/cli/models"
+	"github.com/alexZaicev/go-ftp-client/internal/drivers/logging"
+)
+
+// stdinScriptPath reads the script from standard input.
+const stdinScriptPath = "-"
+
+func AddBatchCommand(rootCMD *cobra.Command) error {
+	batchCMD := &cobra.Command{
+		Use:   "batch",
+		Short: "Execute a script of commands over a single connection.",
+		Long: "Execute a script with one shell command per line (e.g. mkdir, put, get, mv, rm) over a single " +
+			"connection to the server. The batch is aborted on the first failed command, unless the command " +
+			"is prefixed with -, in which case its failure is ignored. Lines starting with # are comments.",
+		RunE: func(cmd *cobra.Command, args []string) (err error) {
+			ctx := context.Background()
+
+			input, scriptPath, err := parseBatchFlags(cmd.Flags())
+			if err != nil {
+				return err
+			}
+
+			script := cmd.InOrStdin()
+			if scriptPath != stdinScriptPath {
+				file, openErr := os.Open(scriptPath)
+				if openErr != nil {
+					return ftperrors.NewInvalidArgumentError(models.ArgBatchFile.Long, openErr.Error())
+				}
+				defer func(file io.Closer) {
+					if closeErr := file.Close(); closeErr != nil {
+						err = closeErr
+					}
+				}(file)
+				script = file
+			}
+
+			logger, err := logging.NewZapJSONLogger(
+				getLogLevel(input.Config.Verbose),
+				cmd.OutOrStdout(),
+				cmd.ErrOrStderr(),
+			)
+			if err != nil {
+				return ftperrors.NewInternalError("failed to setup logger", err)
+			}
+
+			lineReader := &scannerLineReader{scanner: bufio.NewScanner(script)}
+			dependencies := newShellDependencies(lineReader, cmd.OutOrStdout())
+
+			err = shell.PerformBatch(ctx, logger, dependencies, input)
+			return
+		},
+	}
+
+	if err := setConnectionFlags(batchCMD); err != nil {
+		return err
+	}
+
+	batchCMD.Flags().StringP(
+		models.ArgBatchFile.Long,
+		models.ArgBatchFile.Short,
+		stdinScriptPath,
+		models.ArgBatchFile.Help,
+	)
+
+	rootCMD.AddCommand(batchCMD)
+	return nil
+}
+
+func parseBatchFlags(flagSet *pflag.FlagSet) (*shell.CmdBatchInput, string, error) {
+	config, err := parseConnectionFlags(flagSet)
+	if err != nil {
+		return nil, "", err
+	}
+
+	scriptPath, err := flagSet.GetString(models.ArgBatchFile.Long)
+	if err != nil {
+		return nil, "", err
+	}
+
+	localDir, err := os.Getwd()
+	if err != nil {
+		return nil, "", ftperrors.NewInternalError("failed to get working directory", err)
+	}
+
+	return &shell.CmdBatchInput{
+		Config:   config,
+		LocalDir: localDir,
+	}, scriptPath, nil
+}
@@ -9,7 +9,9 @@ import (
 	"github.com/alexZaicev/go-ftp-client/internal/adapters/filestore"
 	"github.com/alexZaicev/go-ftp-client/internal/adapters/ftpclient"
 	"github.com/alexZaicev/go-ftp-client/internal/adapters/ftpclient/download"
+	"github.com/alexZaicev/go-ftp-client/internal/domain/entities"
 	ftperrors "github.com/alexZaicev/go-ftp-client/internal/domain/errors"
+	"github.com/alexZaicev/go-ftp-client/internal/drivers/cli/models"
 	"github.com/alexZaicev/go-ftp-client/internal/drivers/logging"
 	"github.com/alexZaicev/go-ftp-client/internal/usecases/ftp"
 )
@@ -18,6 +20,10 @@ func AddDownloadCommand(rootCMD *cobra.Command) error {
 	downloadCMD := &cobra.Command{
 		Use:   "download",
 		Short: "Download file(s) from the server.",
+		Long: "Download a file or a directory from the server. The remote path may be a glob pattern such as " +
+			"'2024-??-*/report.*', in which case matched entries are saved under the download directory. " +
+			"Quote patterns to stop the local shell from expanding them, and escape *, ? and [ with a " +
+			"backslash to match them literally, or pass --literal to take paths as they are.",
 		RunE: func(cmd *cobra.Command, args []string) (err error) {
 			ctx := context.Background()
 
@@ -26,9 +32,14 @@ func AddDownloadCommand(rootCMD *cobra.Command) error {
 				return err
 			}
 
+			progressWriter, err := parseProgressFlag(cmd)
+			if err != nil {
+				return err
+			}
+
 			logger, err := logging.NewZapJSONLogger(
 				getLogLevel(input.Config.Verbose),
-				cmd.OutOrStdout(),
+				logWriter(cmd, input.Output),
 				cmd.ErrOrStderr(),
 			)
 			if err != nil {
@@ -36,10 +47,11 @@ func AddDownloadCommand(rootCMD *cobra.Command) error {
 			}
 
 			dependencies := &download.Dependencies{
-				Connector: ftpclient.NewConnector(),
-				UseCase:   &ftp.Download{},
-				FileStore: &filestore.FileStore{},
-				OutWriter: cmd.OutOrStdout(),
+				Connector:      ftpclient.NewConnector(),
+				UseCase:        &ftp.Download{},
+				FileStore:      &filestore.FileStore{},
+				OutWriter:      cmd.OutOrStdout(),
+				ProgressWriter: progressWriter,
 			}
 
 			err = download.PerformDownload(ctx, logger, dependencies, input)
@@ -51,6 +63,27 @@ func AddDownloadCommand(rootCMD *cobra.Command) error {
 		return err
 	}
 
+	downloadCMD.Flags().Bool(models.ArgLiteral.Long, false, models.ArgLiteral.Help)
+	setFilterFlags(downloadCMD)
+	downloadCMD.Flags().String(
+		models.ArgIfExists.Long,
+		string(entities.OverwritePolicyOverwrite),
+		models.ArgIfExists.Help,
+	)
+	downloadCMD.Flags().String(
+		models.ArgUnsafeNames.Long,
+		string(entities.UnsafeNamePolicySkip),
+		models.ArgUnsafeNames.Help,
+	)
+	downloadCMD.Flags().String(
+		models.ArgLinks.Long,
+		string(entities.LinkPolicySkip),
+		models.ArgLinks.Help,
+	)
+	setReportFlags(downloadCMD)
+	setProgressFlag(downloadCMD)
+	setOutputFlag(downloadCMD)
+
 	rootCMD.AddCommand(downloadCMD)
 	return nil
 }
@@ -71,9 +104,65 @@ func parseDownloadFlags(flagSet *pflag.FlagSet, args []string) (*download.CmdDow
 		return nil, ftperrors.NewInvalidArgumentError("args", err.Error())
 	}
 
+	literal, err := flagSet.GetBool(models.ArgLiteral.Long)
+	if err != nil {
+		return nil, err
+	}
+
+	filter, err := parseFilterFlags(flagSet)
+	if err != nil {
+		return nil, err
+	}
+
+	ifExistsStr, err := flagSet.GetString(models.ArgIfExists.Long)
+	if err != nil {
+		return nil, err
+	}
+	ifExists, err := models.ParseOverwritePolicy(ifExistsStr)
+	if err != nil {
+		return nil, err
+	}
+
+	unsafeNamesStr, err := flagSet.GetString(models.ArgUnsafeNames.Long)
+	if err != nil {
+		return nil, err
+	}
+	unsafeNames, err := models.ParseUnsafeNamePolicy(unsafeNamesStr)
+	if err != nil {
+		return nil, err
+	}
+
+	linksStr, err := flagSet.GetString(models.ArgLinks.Long)
+	if err != nil {
+		return nil, err
+	}
+	links, err := models.ParseLinkPolicy(linksStr)
+	if err != nil {
+		return nil, err
+	}
+
+	continueOnError, reportPath, err := parseReportFlags(flagSet)
+	if err != nil {
+		return nil, err
+	}
+
+	output, err := parseOutputFlag(flagSet)
+	if err != nil {
+		return nil, err
+	}
+
 	return &download.CmdDownloadInput{
-		Config:     config,
-		RemotePath: args[0],
-		Path:       filePath,
+		Config:      config,
+		RemotePath:  args[0],
+		Path:        filePath,
+		Literal:     literal,
+		Filter:      filter,
+		IfExists:    ifExists,
+		UnsafeNames: unsafeNames,
+		Links:       links,
+
+		ContinueOnError: continueOnError,
+		ReportPath:      reportPath,
+		Output:          output,
 	}, nil
 }
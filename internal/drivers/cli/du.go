@@ -0,0 +1,115 @@
+package cli
+
+import (
+	"context"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"github.com/alexZaicev/go-ftp-client/internal/adapters/ftpclient"
+	"github.com/alexZaicev/go-ftp-client/internal/adapters/ftpclient/du"
+	ftperrors "github.com/alexZaicev/go-ftp-client/internal/domain/errors"
+	"github.com/alexZaicev/go-ftp-client/internal/drivers/cli/models"
+	"github.com/alexZaicev/go-ftp-client/internal/drivers/logging"
+	"github.com/alexZaicev/go-ftp-client/internal/usecases/ftp"
+)
+
+func AddDiskUsageCommand(rootCMD *cobra.Command) error {
+	//nolint:dupl // single use case command are very similar
+	duCMD := &cobra.Command{
+		Use:   "du",
+		Short: "Summarize disk usage of directory tree.",
+		Long: "Add up sizes of files below a directory without downloading them, and print the total " +
+			"of every directory down to the maximum depth. Hidden entries are counted and symbolic links " +
+			"are not followed.",
+		RunE: func(cmd *cobra.Command, args []string) (err error) {
+			ctx := context.Background()
+
+			input, err := parseDiskUsageFlags(cmd.Flags(), args)
+			if err != nil {
+				return err
+			}
+
+			logger, err := logging.NewZapJSONLogger(
+				getLogLevel(input.Config.Verbose),
+				logWriter(cmd, input.Output),
+				cmd.ErrOrStderr(),
+			)
+			if err != nil {
+				return ftperrors.NewInternalError("failed to setup logger", err)
+			}
+
+			dependencies := &du.Dependencies{
+				Connector: ftpclient.NewConnector(),
+				UseCase:   &ftp.DiskUsage{},
+				OutWriter: cmd.OutOrStdout(),
+			}
+
+			err = du.PerformDiskUsage(ctx, logger, dependencies, input)
+			return
+		},
+	}
+
+	if err := setConnectionFlags(duCMD); err != nil {
+		return err
+	}
+
+	duCMD.Flags().Bool(models.ArgLiteral.Long, false, models.ArgLiteral.Help)
+
+	duCMD.Flags().IntP(models.ArgMaxDepth.Long, models.ArgMaxDepth.Short, -1, models.ArgMaxDepth.Help)
+
+	duCMD.Flags().BoolP(models.ArgBytes.Long, models.ArgBytes.Short, false, models.ArgBytes.Help)
+
+	setOutputFlag(duCMD)
+
+	rootCMD.AddCommand(duCMD)
+	return nil
+}
+
+func parseDiskUsageFlags(flagSet *pflag.FlagSet, args []string) (*du.CmdDiskUsageInput, error) {
+	config, err := parseConnectionFlags(flagSet)
+	if err != nil {
+		return nil, err
+	}
+
+	literal, err := flagSet.GetBool(models.ArgLiteral.Long)
+	if err != nil {
+		return nil, err
+	}
+
+	maxDepth, err := flagSet.GetInt(models.ArgMaxDepth.Long)
+	if err != nil {
+		return nil, err
+	}
+
+	bytes, err := flagSet.GetBool(models.ArgBytes.Long)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(args) > 1 {
+		return nil, ftperrors.NewInvalidArgumentError(
+			"args",
+			"should be empty or contain exactly one valid path",
+		)
+	}
+
+	// if no args provided, set path to summarize current working directory
+	if len(args) == 0 {
+		args = append(args, "./")
+	}
+
+	output, err := parseOutputFlag(flagSet)
+	if err != nil {
+		return nil, err
+	}
+
+	return &du.CmdDiskUsageInput{
+		Config:   config,
+		Path:     args[0],
+		Literal:  literal,
+		MaxDepth: maxDepth,
+		Bytes:    bytes,
+		Output:   output,
+	}, nil
+}
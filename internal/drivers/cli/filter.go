@@ -0,0 +1,117 @@
+package cli
+
+import (
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"github.com/alexZaicev/go-ftp-client/internal/domain/entities"
+	ftperrors "github.com/alexZaicev/go-ftp-client/internal/domain/errors"
+	"github.com/alexZaicev/go-ftp-client/internal/drivers/cli/models"
+	"github.com/alexZaicev/go-ftp-client/internal/usecases/ftp"
+)
+
+func setFilterFlags(cmd *cobra.Command) {
+	cmd.Flags().StringArray(models.ArgInclude.Long, nil, models.ArgInclude.Help)
+	cmd.Flags().StringArray(models.ArgExclude.Long, nil, models.ArgExclude.Help)
+	cmd.Flags().String(models.ArgExcludeFrom.Long, "", models.ArgExcludeFrom.Help)
+	cmd.Flags().String(models.ArgMinSize.Long, "", models.ArgMinSize.Help)
+	cmd.Flags().String(models.ArgMaxAge.Long, "", models.ArgMaxAge.Help)
+	cmd.Flags().String(models.ArgNewerThan.Long, "", models.ArgNewerThan.Help)
+}
+
+// parseFilterFlags function returns filter options of the command, or nil if no filter flags are set.
+func parseFilterFlags(flagSet *pflag.FlagSet) (*entities.FilterOptions, error) {
+	include, err := flagSet.GetStringArray(models.ArgInclude.Long)
+	if err != nil {
+		return nil, err
+	}
+
+	exclude, err := flagSet.GetStringArray(models.ArgExclude.Long)
+	if err != nil {
+		return nil, err
+	}
+
+	excludeFrom, err := flagSet.GetString(models.ArgExcludeFrom.Long)
+	if err != nil {
+		return nil, err
+	}
+	if excludeFrom != "" {
+		data, readErr := os.ReadFile(excludeFrom)
+		if readErr != nil {
+			return nil, ftperrors.NewInvalidArgumentError(models.ArgExcludeFrom.Long, readErr.Error())
+		}
+		// patterns given on the command line come last, so that they take precedence over the file
+		exclude = append(strings.Split(string(data), "\n"), exclude...)
+	}
+
+	minSizeStr, err := flagSet.GetString(models.ArgMinSize.Long)
+	if err != nil {
+		return nil, err
+	}
+	var minSizeInBytes uint64
+	if minSizeStr != "" {
+		minSizeInBytes, err = models.ParseSize(minSizeStr)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	modifiedAfter, err := parseModifiedAfter(flagSet)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(include) == 0 && len(exclude) == 0 && minSizeInBytes == 0 && modifiedAfter.IsZero() {
+		return nil, nil
+	}
+
+	filter := &entities.FilterOptions{
+		Include:        include,
+		Exclude:        exclude,
+		MinSizeInBytes: minSizeInBytes,
+		ModifiedAfter:  modifiedAfter,
+	}
+	// validate patterns before connecting to the server
+	if _, err = ftp.NewPathFilter(filter); err != nil {
+		return nil, err
+	}
+	return filter, nil
+}
+
+// parseModifiedAfter function combines max-age and newer-than flags into the earliest accepted
+// modification time, taking the later of the two if both are set.
+func parseModifiedAfter(flagSet *pflag.FlagSet) (time.Time, error) {
+	var modifiedAfter time.Time
+
+	maxAgeStr, err := flagSet.GetString(models.ArgMaxAge.Long)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if maxAgeStr != "" {
+		maxAge, parseErr := models.ParseAge(maxAgeStr)
+		if parseErr != nil {
+			return time.Time{}, parseErr
+		}
+		modifiedAfter = time.Now().Add(-maxAge)
+	}
+
+	newerThanStr, err := flagSet.GetString(models.ArgNewerThan.Long)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if newerThanStr != "" {
+		newerThan, parseErr := models.ParseDate(newerThanStr)
+		if parseErr != nil {
+			return time.Time{}, parseErr
+		}
+		if newerThan.After(modifiedAfter) {
+			modifiedAfter = newerThan
+		}
+	}
+
+	return modifiedAfter, nil
+}
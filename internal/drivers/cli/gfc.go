@@ -8,13 +8,15 @@ import (
 	"github.com/spf13/pflag"
 
 	"github.com/alexZaicev/go-ftp-client/internal/adapters/ftpclient"
+	"github.com/alexZaicev/go-ftp-client/internal/adapters/parsers"
 	ftperrors "github.com/alexZaicev/go-ftp-client/internal/domain/errors"
 	"github.com/alexZaicev/go-ftp-client/internal/drivers/cli/models"
 )
 
 const (
-	ArgAll  = "all"
-	ArgSort = "sort"
+	ArgAll     = "all"
+	ArgSort    = "sort"
+	ArgColumns = "columns"
 
 	defaultConnectionTimeout = 5 * time.Second
 	defaultUserAccount       = "anonymous"
@@ -33,6 +35,12 @@ func NewGfcCommand() (*cobra.Command, error) {
 	if err := AddListCommand(rootCMD); err != nil {
 		return nil, ftperrors.NewInternalError("failed to setup list command", err)
 	}
+	if err := AddTreeCommand(rootCMD); err != nil {
+		return nil, ftperrors.NewInternalError("failed to setup tree command", err)
+	}
+	if err := AddDiskUsageCommand(rootCMD); err != nil {
+		return nil, ftperrors.NewInternalError("failed to setup du command", err)
+	}
 	if err := AddUploadCommand(rootCMD); err != nil {
 		return nil, ftperrors.NewInternalError("failed to setup upload command", err)
 	}
@@ -48,6 +56,15 @@ func NewGfcCommand() (*cobra.Command, error) {
 	if err := AddDownloadCommand(rootCMD); err != nil {
 		return nil, ftperrors.NewInternalError("failed to setup download command", err)
 	}
+	if err := AddSyncCommand(rootCMD); err != nil {
+		return nil, ftperrors.NewInternalError("failed to setup sync command", err)
+	}
+	if err := AddShellCommand(rootCMD); err != nil {
+		return nil, ftperrors.NewInternalError("failed to setup shell command", err)
+	}
+	if err := AddBatchCommand(rootCMD); err != nil {
+		return nil, ftperrors.NewInternalError("failed to setup batch command", err)
+	}
 
 	return rootCMD, nil
 }
@@ -85,6 +102,11 @@ func setConnectionFlags(cmd *cobra.Command) error {
 	cmd.Flags().String(models.ArgTLSKeyFilePath.Long, "", models.ArgTLSKeyFilePath.Help)
 	cmd.Flags().Bool(models.ArgTLSInsecure.Long, false, models.ArgTLSInsecure.Help)
 
+	cmd.Flags().Bool(models.ArgStrictListing.Long, false, models.ArgStrictListing.Help)
+	cmd.Flags().String(models.ArgListFormat.Long, string(parsers.ListFormatAuto), models.ArgListFormat.Help)
+	cmd.Flags().String(models.ArgListLanguage.Long, parsers.LanguageAuto, models.ArgListLanguage.Help)
+	cmd.Flags().String(models.ArgServerTimezone.Long, time.UTC.String(), models.ArgServerTimezone.Help)
+
 	return nil
 }
 
@@ -132,6 +154,41 @@ func parseConnectionFlags(flagSet *pflag.FlagSet) (ftpclient.ConnectorConfig, er
 		return ftpclient.ConnectorConfig{}, err
 	}
 
+	strictListing, err := flagSet.GetBool(models.ArgStrictListing.Long)
+	if err != nil {
+		return ftpclient.ConnectorConfig{}, err
+	}
+
+	listFormatStr, err := flagSet.GetString(models.ArgListFormat.Long)
+	if err != nil {
+		return ftpclient.ConnectorConfig{}, err
+	}
+	listFormat, err := parsers.ParseListFormat(listFormatStr)
+	if err != nil {
+		return ftpclient.ConnectorConfig{}, err
+	}
+
+	listLanguage, err := flagSet.GetString(models.ArgListLanguage.Long)
+	if err != nil {
+		return ftpclient.ConnectorConfig{}, err
+	}
+	if err = parsers.ValidateLanguage(listLanguage); err != nil {
+		return ftpclient.ConnectorConfig{}, err
+	}
+
+	serverTimezone, err := flagSet.GetString(models.ArgServerTimezone.Long)
+	if err != nil {
+		return ftpclient.ConnectorConfig{}, err
+	}
+	var location *time.Location
+	detectLocation := serverTimezone == models.ServerTimezoneAuto
+	if !detectLocation {
+		location, err = models.ParseTimezone(serverTimezone)
+		if err != nil {
+			return ftpclient.ConnectorConfig{}, err
+		}
+	}
+
 	return ftpclient.ConnectorConfig{
 		Address:         address,
 		User:            user,
@@ -141,5 +198,10 @@ func parseConnectionFlags(flagSet *pflag.FlagSet) (ftpclient.ConnectorConfig, er
 		TLSCertFilePath: certFilePath,
 		TLSKeyFilePath:  keyFilePath,
 		TLSInsecure:     insecure,
+		ListFormat:      listFormat,
+		LenientListing:  !strictListing,
+		ListLanguage:    listLanguage,
+		Location:        location,
+		DetectLocation:  detectLocation,
 	}, nil
 }
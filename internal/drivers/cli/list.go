@@ -2,6 +2,8 @@ package cli
 
 import (
 	"context"
+	"fmt"
+	"strings"
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
@@ -19,6 +21,9 @@ func AddListCommand(rootCMD *cobra.Command) error {
 	listCMD := &cobra.Command{
 		Use:   "ls",
 		Short: "List files in directory.",
+		Long: "List files in directory, or entries matching a glob pattern such as '*.csv'. " +
+			"Quote patterns to stop the local shell from expanding them, and escape *, ? and [ with a " +
+			"backslash to match them literally, or pass --literal to take paths as they are.",
 		RunE: func(cmd *cobra.Command, args []string) (err error) {
 			ctx := context.Background()
 
@@ -29,7 +34,7 @@ func AddListCommand(rootCMD *cobra.Command) error {
 
 			logger, err := logging.NewZapJSONLogger(
 				getLogLevel(input.Config.Verbose),
-				cmd.OutOrStdout(),
+				logWriter(cmd, input.Output),
 				cmd.ErrOrStderr(),
 			)
 			if err != nil {
@@ -37,9 +42,10 @@ func AddListCommand(rootCMD *cobra.Command) error {
 			}
 
 			dependencies := &list.Dependencies{
-				Connector: ftpclient.NewConnector(),
-				UseCase:   &ftp.ListFiles{},
-				OutWriter: cmd.OutOrStdout(),
+				Connector:   ftpclient.NewConnector(),
+				UseCase:     &ftp.ListFiles{},
+				WalkUseCase: &ftp.Walk{},
+				OutWriter:   cmd.OutOrStdout(),
 			}
 
 			err = list.PerformListFiles(ctx, logger, dependencies, input)
@@ -55,6 +61,23 @@ func AddListCommand(rootCMD *cobra.Command) error {
 
 	listCMD.Flags().Bool(ArgAll, false, "Do not ignore entries starting with '.'")
 
+	listCMD.Flags().Bool(models.ArgLiteral.Long, false, models.ArgLiteral.Help)
+
+	listCMD.Flags().BoolP(
+		models.ArgListRecursive.Long,
+		models.ArgListRecursive.Short,
+		false,
+		models.ArgListRecursive.Help,
+	)
+
+	listCMD.Flags().String(
+		ArgColumns,
+		joinColumns(models.DefaultColumns),
+		fmt.Sprintf("Comma separated list of columns to display (%s)", strings.Join(models.Columns(), ", ")),
+	)
+
+	setOutputFlag(listCMD)
+
 	rootCMD.AddCommand(listCMD)
 	return nil
 }
@@ -70,11 +93,30 @@ func parseListFlags(flagSet *pflag.FlagSet, args []string) (*list.CmdListInput,
 		return nil, err
 	}
 
+	literal, err := flagSet.GetBool(models.ArgLiteral.Long)
+	if err != nil {
+		return nil, err
+	}
+
+	recursive, err := flagSet.GetBool(models.ArgListRecursive.Long)
+	if err != nil {
+		return nil, err
+	}
+
 	sortTypeStr, err := flagSet.GetString(ArgSort)
 	if err != nil {
 		return nil, err
 	}
 
+	columnsStr, err := flagSet.GetString(ArgColumns)
+	if err != nil {
+		return nil, err
+	}
+	columns, err := models.ParseColumns(columnsStr)
+	if err != nil {
+		return nil, err
+	}
+
 	if len(args) > 1 {
 		return nil, ftperrors.NewInvalidArgumentError(
 			"args",
@@ -87,10 +129,27 @@ func parseListFlags(flagSet *pflag.FlagSet, args []string) (*list.CmdListInput,
 		args = append(args, "./")
 	}
 
+	output, err := parseOutputFlag(flagSet)
+	if err != nil {
+		return nil, err
+	}
+
 	return &list.CmdListInput{
-		Config:   config,
-		ShowAll:  showAll,
-		Path:     args[0],
-		SortType: models.SortType(sortTypeStr),
+		Config:    config,
+		ShowAll:   showAll,
+		Recursive: recursive,
+		Path:      args[0],
+		Literal:   literal,
+		SortType:  models.SortType(sortTypeStr),
+		Columns:   columns,
+		Output:    output,
 	}, nil
 }
+
+func joinColumns(columns []models.Column) string {
+	names := make([]string, 0, len(columns))
+	for _, column := range columns {
+		names = append(names, string(column))
+	}
+	return strings.Join(names, ",")
+}
@@ -14,6 +14,49 @@ var (
 	ArgTLSCertFilePath = Argument{Long: "tls-cert", Help: "Path to TLS certificate file"}
 	ArgTLSKeyFilePath  = Argument{Long: "tls-key", Help: "Path to TLS key file"}
 	ArgTLSInsecure     = Argument{Long: "tls-insecure", Help: "Skip TLS certificate verification"}
+	ArgStrictListing   = Argument{Long: "strict-listing", Help: "Fail listings on entries that cannot be parsed instead of skipping them"}
+	ArgListFormat      = Argument{Long: "list-format", Help: "Format of server directory listings (auto, unix, msdos, hosted, rfc3659); auto detects the format from the server system type"}
+	ArgListLanguage    = Argument{Long: "list-language", Help: "Language of month names in listed entries (en, de, fr, es, ru, ja)"}
+	ArgServerTimezone  = Argument{Long: "server-tz", Help: "Time zone of dates listed by the server (auto, a time zone name such as Europe/Berlin or an offset such as +02:00)"}
 
 	ArgRecursive = Argument{Long: "recursive", Short: "r"}
+	ArgReverse   = Argument{Long: "reverse", Help: "Mirror remote tree onto the local filesystem (remote path comes first)"}
+	ArgDelete    = Argument{Long: "delete", Help: "Remove destination entries that do not exist in the source tree"}
+	ArgDryRun    = Argument{Long: "dry-run", Help: "Print planned actions without performing them"}
+	ArgTwoWay    = Argument{Long: "two-way", Help: "Propagate changes made on either side since the last sync to the other side"}
+	ArgConflict  = Argument{Long: "conflict", Help: "How two-way sync resolves entries changed on both sides (newest, keep-both, fail)"}
+
+	ArgInclude     = Argument{Long: "include", Help: "Only process files matching the pattern or located in a matching directory (repeatable)"}
+	ArgExclude     = Argument{Long: "exclude", Help: "Skip entries matching the pattern, or include them again when prefixed with ! (repeatable)"}
+	ArgExcludeFrom = Argument{Long: "exclude-from", Help: "Read exclude patterns from a file with gitignore syntax"}
+	ArgMinSize     = Argument{Long: "min-size", Help: "Skip files smaller than the size (e.g. 512, 10K, 1.5M)"}
+	ArgMaxAge      = Argument{Long: "max-age", Help: "Skip files modified longer ago than the duration (e.g. 12h, 7d, 2w)"}
+	ArgNewerThan   = Argument{Long: "newer-than", Help: "Skip files modified before the date (e.g. 2024-01-02 or 2024-01-02 15:04)"}
+
+	ArgLiteral = Argument{Long: "literal", Help: "Take remote paths as they are, without expanding glob patterns or unescaping backslashes"}
+
+	ArgIfExists    = Argument{Long: "if-exists", Help: "What to do with files that already exist at the destination (overwrite, skip, newer, size-differs, rename, fail)"}
+	ArgUnsafeNames = Argument{Long: "unsafe-names", Help: "What to do with remote entries whose names are illegal or reserved locally (skip, rename, fail), names escaping the destination are never saved"}
+	ArgLinks       = Argument{Long: "links", Help: "What to do with symbolic links of downloaded directories (skip, follow, preserve)"}
+	ArgUploadLinks = Argument{Long: "links", Help: "What to do with symbolic links of uploaded directories (skip, follow, error), other special files are skipped"}
+
+	ArgBatchFile = Argument{Long: "file", Short: "f", Help: "Path to the script with one command per line, - reads the script from standard input"}
+
+	ArgParallel = Argument{Long: "parallel", Help: "Number of files uploaded at the same time, each over its own connection"}
+
+	ArgContinueOnError = Argument{Long: "continue-on-error", Help: "Carry on with the remaining entries when an entry fails, then print a summary of skipped and failed entries"}
+	ArgReport          = Argument{Long: "report", Help: "Path of the JSON report listing the outcome of every entry"}
+
+	ArgOutput = Argument{Long: "output", Short: "o", Help: "Format of the output (table, json, jsonl, csv, yaml), logs are written to standard error unless it is a table"}
+
+	ArgProgress = Argument{Long: "progress", Help: "When progress bars are drawn to standard error (auto, always, never), auto draws them if it is a terminal"}
+
+	ArgListRecursive = Argument{Long: "recursive", Short: "R", Help: "List subdirectories recursively, one directory after another"}
+	ArgTreeDepth     = Argument{Long: "depth", Short: "L", Help: "Number of directory levels to descend, where 1 only lists the given directory and 0 has no limit"}
+	ArgMaxDepth      = Argument{Long: "max-depth", Short: "d", Help: "Print the total of directories only down to the depth, where 0 only prints the given directory and a negative depth prints all of them"}
+	ArgBytes         = Argument{Long: "bytes", Short: "b", Help: "Print sizes in bytes instead of human readable units"}
+
+	ArgAtomic       = Argument{Long: "atomic", Help: "Upload files under a temporary name and rename them into place once their size and checksum are verified"}
+	ArgAtomicPrefix = Argument{Long: "atomic-prefix", Help: "Prefix added to the file name to compose the temporary name of atomic uploads"}
+	ArgAtomicSuffix = Argument{Long: "atomic-suffix", Help: "Suffix added to the file name to compose the temporary name of atomic uploads"}
 )
@@ -0,0 +1,33 @@
+package models
+
+import (
+	"strings"
+
+	"github.com/alexZaicev/go-ftp-client/internal/domain/entities"
+	ftpErrors "github.com/alexZaicev/go-ftp-client/internal/domain/errors"
+)
+
+// Default temporary name of atomic uploads is .name.partial.
+const (
+	DefaultAtomicPrefix = "."
+	DefaultAtomicSuffix = ".partial"
+)
+
+// ParseAtomicUploadOptions function validates the prefix and suffix that compose the temporary name of
+// atomically uploaded files, which has to differ from the file name and stay in the same directory.
+func ParseAtomicUploadOptions(prefix, suffix string) (*entities.AtomicUploadOptions, error) {
+	if prefix == "" && suffix == "" {
+		return nil, ftpErrors.NewInvalidArgumentError(ArgAtomicSuffix.Long, "cannot be empty if prefix is empty")
+	}
+	if strings.ContainsAny(prefix, `/\`) {
+		return nil, ftpErrors.NewInvalidArgumentError(ArgAtomicPrefix.Long, "cannot contain path separators")
+	}
+	if strings.ContainsAny(suffix, `/\`) {
+		return nil, ftpErrors.NewInvalidArgumentError(ArgAtomicSuffix.Long, "cannot contain path separators")
+	}
+
+	return &entities.AtomicUploadOptions{
+		TempPrefix: prefix,
+		TempSuffix: suffix,
+	}, nil
+}
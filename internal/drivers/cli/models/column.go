@@ -0,0 +1,85 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+
+	ftpErrors "github.com/alexZaicev/go-ftp-client/internal/domain/errors"
+)
+
+// Column is an entry attribute that can be displayed in the list output.
+type Column string
+
+const (
+	ColumnType         Column = "type"
+	ColumnPermissions  Column = "permissions"
+	ColumnMode         Column = "mode"
+	ColumnCapabilities Column = "capabilities"
+	ColumnOwners       Column = "owners"
+	ColumnUID          Column = "uid"
+	ColumnGID          Column = "gid"
+	ColumnLinks        Column = "links"
+	ColumnName         Column = "name"
+	ColumnModified     Column = "modified"
+	ColumnCreated      Column = "created"
+	ColumnSize         Column = "size"
+	ColumnUniqueID     Column = "unique-id"
+	ColumnRaw          Column = "raw"
+	ColumnFacts        Column = "facts"
+)
+
+// DefaultColumns are displayed in the list output unless specified otherwise.
+var DefaultColumns = []Column{
+	ColumnType,
+	ColumnPermissions,
+	ColumnOwners,
+	ColumnName,
+	ColumnModified,
+	ColumnSize,
+}
+
+// Columns function returns all supported columns.
+func Columns() []string {
+	return []string{
+		string(ColumnType),
+		string(ColumnPermissions),
+		string(ColumnMode),
+		string(ColumnCapabilities),
+		string(ColumnOwners),
+		string(ColumnUID),
+		string(ColumnGID),
+		string(ColumnLinks),
+		string(ColumnName),
+		string(ColumnModified),
+		string(ColumnCreated),
+		string(ColumnSize),
+		string(ColumnUniqueID),
+		string(ColumnRaw),
+		string(ColumnFacts),
+	}
+}
+
+// ParseColumns function converts comma separated list of column names into columns.
+func ParseColumns(value string) ([]Column, error) {
+	var columns []Column
+	for _, name := range strings.Split(value, ",") {
+		column, err := parseColumn(strings.ToLower(strings.TrimSpace(name)))
+		if err != nil {
+			return nil, err
+		}
+		columns = append(columns, column)
+	}
+	return columns, nil
+}
+
+func parseColumn(name string) (Column, error) {
+	for _, column := range Columns() {
+		if name == column {
+			return Column(name), nil
+		}
+	}
+	return "", ftpErrors.NewInvalidArgumentError(
+		"columns",
+		fmt.Sprintf("must be a comma separated list of %s", strings.Join(Columns(), ", ")),
+	)
+}
@@ -0,0 +1,17 @@
+package models
+
+import (
+	"github.com/alexZaicev/go-ftp-client/internal/domain/entities"
+	ftpErrors "github.com/alexZaicev/go-ftp-client/internal/domain/errors"
+)
+
+// ParseConflictPolicy function validates the conflict policy of two-way sync.
+func ParseConflictPolicy(value string) (entities.SyncConflictPolicy, error) {
+	policy := entities.SyncConflictPolicy(value)
+	switch policy {
+	case entities.SyncConflictPolicyNewest, entities.SyncConflictPolicyKeepBoth, entities.SyncConflictPolicyFail:
+		return policy, nil
+	default:
+		return "", ftpErrors.NewInvalidArgumentError(ArgConflict.Long, "must be one of newest, keep-both, fail")
+	}
+}
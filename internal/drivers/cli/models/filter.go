@@ -0,0 +1,89 @@
+package models
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	ftpErrors "github.com/alexZaicev/go-ftp-client/internal/domain/errors"
+)
+
+const (
+	hoursInDay  = 24
+	daysInWeek  = 7
+	sizeUnitLen = 1024
+)
+
+// sizeUnits are multipliers of size suffixes, which are binary regardless of the "i" in the suffix.
+var sizeUnits = map[string]uint64{
+	"":  1,
+	"K": sizeUnitLen,
+	"M": sizeUnitLen * sizeUnitLen,
+	"G": sizeUnitLen * sizeUnitLen * sizeUnitLen,
+	"T": sizeUnitLen * sizeUnitLen * sizeUnitLen * sizeUnitLen,
+}
+
+// dateFormats are accepted formats of dates and times, interpreted in the local time zone unless
+// the format contains an offset.
+var dateFormats = []string{time.RFC3339, "2006-01-02T15:04:05", "2006-01-02 15:04:05", "2006-01-02 15:04", "2006-01-02"}
+
+// ParseSize function converts a size with an optional unit suffix (e.g. 512, 10K, 1.5MB or 2GiB) into
+// number of bytes.
+func ParseSize(value string) (uint64, error) {
+	value = strings.ToUpper(strings.TrimSpace(value))
+	value = strings.TrimSuffix(strings.TrimSuffix(value, "B"), "I")
+
+	unit := ""
+	if value != "" {
+		if last := value[len(value)-1:]; sizeUnits[last] != 0 {
+			unit = last
+			value = value[:len(value)-1]
+		}
+	}
+
+	size, err := strconv.ParseFloat(value, 64)
+	if err != nil || size < 0 {
+		return 0, ftpErrors.NewInvalidArgumentError("min-size", "must be a size such as 512, 10K or 1.5M")
+	}
+	return uint64(size * float64(sizeUnits[unit])), nil
+}
+
+// ParseAge function converts an age into a duration. Besides units of time.ParseDuration it accepts
+// days (e.g. 7d) and weeks (e.g. 2w).
+func ParseAge(value string) (time.Duration, error) {
+	value = strings.TrimSpace(value)
+
+	var unit time.Duration
+	switch {
+	case strings.HasSuffix(value, "d"):
+		unit = hoursInDay * time.Hour
+	case strings.HasSuffix(value, "w"):
+		unit = daysInWeek * hoursInDay * time.Hour
+	}
+
+	if unit != 0 {
+		count, err := strconv.ParseFloat(value[:len(value)-1], 64)
+		if err == nil && count >= 0 {
+			return time.Duration(count * float64(unit)), nil
+		}
+	} else if age, err := time.ParseDuration(value); err == nil && age >= 0 {
+		return age, nil
+	}
+
+	return 0, ftpErrors.NewInvalidArgumentError("max-age", "must be a duration such as 12h, 7d or 2w")
+}
+
+// ParseDate function converts a date (e.g. 2024-01-02) or a date and time (e.g. 2024-01-02 15:04 or
+// 2024-01-02T15:04:05+02:00) into time.
+func ParseDate(value string) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	for _, format := range dateFormats {
+		if date, err := time.ParseInLocation(format, value, time.Local); err == nil {
+			return date, nil
+		}
+	}
+	return time.Time{}, ftpErrors.NewInvalidArgumentError(
+		"newer-than",
+		"must be a date such as 2024-01-02 or a date and time such as 2024-01-02 15:04",
+	)
+}
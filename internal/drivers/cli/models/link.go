@@ -0,0 +1,32 @@
+package models
+
+import (
+	"github.com/alexZaicev/go-ftp-client/internal/domain/entities"
+	ftpErrors "github.com/alexZaicev/go-ftp-client/internal/domain/errors"
+)
+
+// ParseLinkPolicy function validates the policy applied to symbolic links found by recursive downloads.
+func ParseLinkPolicy(value string) (entities.LinkPolicy, error) {
+	policy := entities.LinkPolicy(value)
+	switch policy {
+	case entities.LinkPolicySkip,
+		entities.LinkPolicyFollow,
+		entities.LinkPolicyPreserve:
+		return policy, nil
+	default:
+		return "", ftpErrors.NewInvalidArgumentError(ArgLinks.Long, "must be one of skip, follow, preserve")
+	}
+}
+
+// ParseUploadLinkPolicy function validates the policy applied to symbolic links found by recursive uploads.
+func ParseUploadLinkPolicy(value string) (entities.LinkPolicy, error) {
+	policy := entities.LinkPolicy(value)
+	switch policy {
+	case entities.LinkPolicySkip,
+		entities.LinkPolicyFollow,
+		entities.LinkPolicyError:
+		return policy, nil
+	default:
+		return "", ftpErrors.NewInvalidArgumentError(ArgUploadLinks.Long, "must be one of skip, follow, error")
+	}
+}
@@ -0,0 +1,40 @@
+package models
+
+import (
+	ftpErrors "github.com/alexZaicev/go-ftp-client/internal/domain/errors"
+)
+
+// OutputFormat decides how results of a command are written to standard output.
+type OutputFormat string
+
+const (
+	// OutputFormatTable renders human readable tables, with shortened dates and humanized sizes.
+	OutputFormatTable OutputFormat = "table"
+	// OutputFormatJSON writes a single JSON document.
+	OutputFormatJSON OutputFormat = "json"
+	// OutputFormatJSONL writes a JSON object per line, one for each record.
+	OutputFormatJSONL OutputFormat = "jsonl"
+	// OutputFormatCSV writes a header followed by a row for each record.
+	OutputFormatCSV  OutputFormat = "csv"
+	OutputFormatYAML OutputFormat = "yaml"
+)
+
+// ParseOutputFormat function validates the output format.
+func ParseOutputFormat(value string) (OutputFormat, error) {
+	format := OutputFormat(value)
+	switch format {
+	case OutputFormatTable,
+		OutputFormatJSON,
+		OutputFormatJSONL,
+		OutputFormatCSV,
+		OutputFormatYAML:
+		return format, nil
+	default:
+		return "", ftpErrors.NewInvalidArgumentError(ArgOutput.Long, "must be one of table, json, jsonl, csv, yaml")
+	}
+}
+
+// IsMachineReadable method reports whether the output is meant to be parsed rather than read.
+func (f OutputFormat) IsMachineReadable() bool {
+	return f != "" && f != OutputFormatTable
+}
@@ -0,0 +1,26 @@
+package models
+
+import (
+	"github.com/alexZaicev/go-ftp-client/internal/domain/entities"
+	ftpErrors "github.com/alexZaicev/go-ftp-client/internal/domain/errors"
+)
+
+// ParseOverwritePolicy function validates the policy applied to files that already exist at the
+// destination of a transfer.
+func ParseOverwritePolicy(value string) (entities.OverwritePolicy, error) {
+	policy := entities.OverwritePolicy(value)
+	switch policy {
+	case entities.OverwritePolicyOverwrite,
+		entities.OverwritePolicySkip,
+		entities.OverwritePolicyNewer,
+		entities.OverwritePolicySizeDiffers,
+		entities.OverwritePolicyRename,
+		entities.OverwritePolicyFail:
+		return policy, nil
+	default:
+		return "", ftpErrors.NewInvalidArgumentError(
+			ArgIfExists.Long,
+			"must be one of overwrite, skip, newer, size-differs, rename, fail",
+		)
+	}
+}
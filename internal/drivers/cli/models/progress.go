@@ -0,0 +1,28 @@
+package models
+
+import (
+	ftpErrors "github.com/alexZaicev/go-ftp-client/internal/domain/errors"
+)
+
+// ProgressMode decides whether progress bars are drawn.
+type ProgressMode string
+
+const (
+	// ProgressModeAuto draws progress bars only if standard error is a terminal.
+	ProgressModeAuto   ProgressMode = "auto"
+	ProgressModeAlways ProgressMode = "always"
+	ProgressModeNever  ProgressMode = "never"
+)
+
+// ParseProgressMode function validates the mode of progress bars.
+func ParseProgressMode(value string) (ProgressMode, error) {
+	mode := ProgressMode(value)
+	switch mode {
+	case ProgressModeAuto,
+		ProgressModeAlways,
+		ProgressModeNever:
+		return mode, nil
+	default:
+		return "", ftpErrors.NewInvalidArgumentError(ArgProgress.Long, "must be one of auto, always, never")
+	}
+}
@@ -0,0 +1,44 @@
+package models
+
+import (
+	"strings"
+	"time"
+
+	ftpErrors "github.com/alexZaicev/go-ftp-client/internal/domain/errors"
+)
+
+// ServerTimezoneAuto detects the server time zone by comparing listed dates with MDTM replies.
+const ServerTimezoneAuto = "auto"
+
+// timezoneOffsetFormats are accepted formats of fixed time zone offsets (e.g. +02:00 or -0530).
+var timezoneOffsetFormats = []string{"-07:00", "-0700", "-07"}
+
+// ParseTimezone function converts either IANA time zone name (e.g. Europe/Berlin) or a fixed offset
+// from UTC (e.g. +02:00) into a location.
+func ParseTimezone(value string) (*time.Location, error) {
+	value = strings.TrimSpace(value)
+	if strings.HasPrefix(value, "+") || strings.HasPrefix(value, "-") {
+		for _, format := range timezoneOffsetFormats {
+			offsetTime, err := time.Parse(format, value)
+			if err != nil {
+				continue
+			}
+			_, offset := offsetTime.Zone()
+			return time.FixedZone(value, offset), nil
+		}
+		return nil, newInvalidTimezoneError()
+	}
+
+	location, err := time.LoadLocation(value)
+	if err != nil || value == "" {
+		return nil, newInvalidTimezoneError()
+	}
+	return location, nil
+}
+
+func newInvalidTimezoneError() error {
+	return ftpErrors.NewInvalidArgumentError(
+		"server-tz",
+		"must be auto, a time zone name (e.g. Europe/Berlin) or an offset from UTC (e.g. +02:00)",
+	)
+}
@@ -0,0 +1,20 @@
+package models
+
+import (
+	"github.com/alexZaicev/go-ftp-client/internal/domain/entities"
+	ftpErrors "github.com/alexZaicev/go-ftp-client/internal/domain/errors"
+)
+
+// ParseUnsafeNamePolicy function validates the policy applied to downloaded entries whose names are
+// illegal or reserved on the local filesystem.
+func ParseUnsafeNamePolicy(value string) (entities.UnsafeNamePolicy, error) {
+	policy := entities.UnsafeNamePolicy(value)
+	switch policy {
+	case entities.UnsafeNamePolicySkip,
+		entities.UnsafeNamePolicyRename,
+		entities.UnsafeNamePolicyFail:
+		return policy, nil
+	default:
+		return "", ftpErrors.NewInvalidArgumentError(ArgUnsafeNames.Long, "must be one of skip, rename, fail")
+	}
+}
@@ -0,0 +1,36 @@
+package cli
+
+import (
+	"io"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"github.com/alexZaicev/go-ftp-client/internal/drivers/cli/models"
+)
+
+func setOutputFlag(cmd *cobra.Command) {
+	cmd.Flags().StringP(
+		models.ArgOutput.Long,
+		models.ArgOutput.Short,
+		string(models.OutputFormatTable),
+		models.ArgOutput.Help,
+	)
+}
+
+func parseOutputFlag(flagSet *pflag.FlagSet) (models.OutputFormat, error) {
+	formatStr, err := flagSet.GetString(models.ArgOutput.Long)
+	if err != nil {
+		return "", err
+	}
+	return models.ParseOutputFormat(formatStr)
+}
+
+// logWriter function returns the writer logs are written to, which is standard error of the command if the
+// output is machine readable, so that logs do not get mixed with it.
+func logWriter(cmd *cobra.Command, format models.OutputFormat) io.Writer {
+	if format.IsMachineReadable() {
+		return cmd.ErrOrStderr()
+	}
+	return cmd.OutOrStdout()
+}
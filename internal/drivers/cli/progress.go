@@ -0,0 +1,40 @@
+package cli
+
+import (
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+
+	"github.com/alexZaicev/go-ftp-client/internal/drivers/cli/models"
+)
+
+func setProgressFlag(cmd *cobra.Command) {
+	cmd.Flags().String(models.ArgProgress.Long, string(models.ProgressModeAuto), models.ArgProgress.Help)
+}
+
+// parseProgressFlag function returns the writer progress bars are drawn to, which is standard error of the
+// command, or nil if progress bars are not drawn.
+func parseProgressFlag(cmd *cobra.Command) (io.Writer, error) {
+	modeStr, err := cmd.Flags().GetString(models.ArgProgress.Long)
+	if err != nil {
+		return nil, err
+	}
+	mode, err := models.ParseProgressMode(modeStr)
+	if err != nil {
+		return nil, err
+	}
+
+	writer := cmd.ErrOrStderr()
+	switch mode {
+	case models.ProgressModeNever:
+		return nil, nil
+	case models.ProgressModeAuto:
+		file, ok := writer.(*os.File)
+		if !ok || !term.IsTerminal(int(file.Fd())) {
+			return nil, nil
+		}
+	}
+	return writer, nil
+}
@@ -6,9 +6,11 @@ import (
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
 
+	"github.com/alexZaicev/go-ftp-client/internal/adapters/filestore"
 	"github.com/alexZaicev/go-ftp-client/internal/adapters/ftpclient"
 	"github.com/alexZaicev/go-ftp-client/internal/adapters/ftpclient/remove"
 	ftperrors "github.com/alexZaicev/go-ftp-client/internal/domain/errors"
+	"github.com/alexZaicev/go-ftp-client/internal/drivers/cli/models"
 	"github.com/alexZaicev/go-ftp-client/internal/drivers/logging"
 	"github.com/alexZaicev/go-ftp-client/internal/usecases/ftp"
 )
@@ -17,7 +19,11 @@ import (
 func AddRemoveCommand(rootCMD *cobra.Command) error {
 	removeCMD := &cobra.Command{
 		Use:   "rm",
-		Short: "Remove file or directory.",
+		Short: "Remove files or directories.",
+		Long: "Remove files or directories matching the given paths. Paths may be glob patterns such as " +
+			"'*.log' or 'logs/**/*.gz', which are matched against server entries. Quote patterns to stop " +
+			"the local shell from expanding them, and escape *, ? and [ with a backslash to match them literally, " +
+			"or pass --literal to take paths as they are.",
 		RunE: func(cmd *cobra.Command, args []string) (err error) {
 			ctx := context.Background()
 
@@ -26,9 +32,14 @@ func AddRemoveCommand(rootCMD *cobra.Command) error {
 				return err
 			}
 
+			progressWriter, err := parseProgressFlag(cmd)
+			if err != nil {
+				return err
+			}
+
 			logger, err := logging.NewZapJSONLogger(
 				getLogLevel(input.Config.Verbose),
-				cmd.OutOrStdout(),
+				logWriter(cmd, input.Output),
 				cmd.ErrOrStderr(),
 			)
 			if err != nil {
@@ -36,9 +47,11 @@ func AddRemoveCommand(rootCMD *cobra.Command) error {
 			}
 
 			dependencies := &remove.Dependencies{
-				Connector: ftpclient.NewConnector(),
-				UseCase:   &ftp.Remove{},
-				OutWriter: cmd.OutOrStdout(),
+				Connector:      ftpclient.NewConnector(),
+				UseCase:        &ftp.Remove{},
+				FileStore:      &filestore.FileStore{},
+				OutWriter:      cmd.OutOrStdout(),
+				ProgressWriter: progressWriter,
 			}
 
 			err = remove.PerformRemove(ctx, logger, dependencies, input)
@@ -50,6 +63,12 @@ func AddRemoveCommand(rootCMD *cobra.Command) error {
 		return err
 	}
 
+	removeCMD.Flags().Bool(models.ArgLiteral.Long, false, models.ArgLiteral.Help)
+	setFilterFlags(removeCMD)
+	setReportFlags(removeCMD)
+	setProgressFlag(removeCMD)
+	setOutputFlag(removeCMD)
+
 	rootCMD.AddCommand(removeCMD)
 	return nil
 }
@@ -60,12 +79,37 @@ func parseRemoveFlags(flagSet *pflag.FlagSet, args []string) (*remove.CmdRemoveI
 		return nil, err
 	}
 
-	if len(args) != 1 {
-		return nil, ftperrors.NewInvalidArgumentError("args", "should contain exactly one valid path")
+	if len(args) == 0 {
+		return nil, ftperrors.NewInvalidArgumentError("args", "should contain at least one valid path")
+	}
+
+	literal, err := flagSet.GetBool(models.ArgLiteral.Long)
+	if err != nil {
+		return nil, err
+	}
+
+	filter, err := parseFilterFlags(flagSet)
+	if err != nil {
+		return nil, err
+	}
+
+	continueOnError, reportPath, err := parseReportFlags(flagSet)
+	if err != nil {
+		return nil, err
+	}
+
+	output, err := parseOutputFlag(flagSet)
+	if err != nil {
+		return nil, err
 	}
 
 	return &remove.CmdRemoveInput{
-		Config: config,
-		Path:   args[0],
+		Config:          config,
+		Paths:           args,
+		Literal:         literal,
+		Filter:          filter,
+		ContinueOnError: continueOnError,
+		ReportPath:      reportPath,
+		Output:          output,
 	}, nil
 }
@@ -0,0 +1,34 @@
+package cli
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	ftperrors "github.com/alexZaicev/go-ftp-client/internal/domain/errors"
+	"github.com/alexZaicev/go-ftp-client/internal/drivers/cli/models"
+)
+
+func setReportFlags(cmd *cobra.Command) {
+	cmd.Flags().Bool(models.ArgContinueOnError.Long, false, models.ArgContinueOnError.Help)
+	cmd.Flags().String(models.ArgReport.Long, "", models.ArgReport.Help)
+}
+
+// parseReportFlags function returns whether the command carries on when an entry fails, along with the
+// absolute path of the JSON report, which is blank if no report is requested.
+func parseReportFlags(flagSet *pflag.FlagSet) (bool, string, error) {
+	continueOnError, err := flagSet.GetBool(models.ArgContinueOnError.Long)
+	if err != nil {
+		return false, "", err
+	}
+
+	reportPath, err := flagSet.GetString(models.ArgReport.Long)
+	if err != nil {
+		return false, "", err
+	}
+	reportPath, err = getFileAbsPath(reportPath)
+	if err != nil {
+		return false, "", ftperrors.NewInvalidArgumentError(models.ArgReport.Long, err.Error())
+	}
+
+	return continueOnError, reportPath, nil
+}
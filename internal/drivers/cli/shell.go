@@ -0,0 +1,149 @@
+package cli
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"golang.org/x/term"
+
+	"github.com/alexZaicev/go-ftp-client/internal/adapters/filestore"
+	"github.com/alexZaicev/go-ftp-client/internal/adapters/ftpclient"
+	"github.com/alexZaicev/go-ftp-client/internal/adapters/ftpclient/shell"
+	ftperrors "github.com/alexZaicev/go-ftp-client/internal/domain/errors"
+	"github.com/alexZaicev/go-ftp-client/internal/drivers/logging"
+	"github.com/alexZaicev/go-ftp-client/internal/usecases/ftp"
+)
+
+func AddShellCommand(rootCMD *cobra.Command) error {
+	shellCMD := &cobra.Command{
+		Use:   "shell",
+		Short: "Start an interactive session with the server.",
+		Long: "Start an interactive session that keeps a single connection to the server open. " +
+			"Type help in the session to list available commands.",
+		RunE: func(cmd *cobra.Command, args []string) (err error) {
+			ctx := context.Background()
+
+			input, err := parseShellFlags(cmd.Flags())
+			if err != nil {
+				return err
+			}
+
+			var (
+				lineReader shell.LineReader
+				outWriter  = cmd.OutOrStdout()
+			)
+			stdinFd := int(os.Stdin.Fd())
+			if term.IsTerminal(stdinFd) {
+				state, rawErr := term.MakeRaw(stdinFd)
+				if rawErr != nil {
+					return ftperrors.NewInternalError("failed to setup terminal", rawErr)
+				}
+				defer func() {
+					if restoreErr := term.Restore(stdinFd, state); restoreErr != nil {
+						err = restoreErr
+					}
+				}()
+
+				terminal := term.NewTerminal(struct {
+					io.Reader
+					io.Writer
+				}{os.Stdin, cmd.OutOrStdout()}, "")
+				if width, height, sizeErr := term.GetSize(stdinFd); sizeErr == nil {
+					if sizeErr = terminal.SetSize(width, height); sizeErr != nil {
+						return ftperrors.NewInternalError("failed to setup terminal", sizeErr)
+					}
+				}
+				// terminal translates line breaks of the output, which is required in raw mode
+				lineReader = &terminalLineReader{terminal: terminal}
+				outWriter = terminal
+			} else {
+				lineReader = &scannerLineReader{scanner: bufio.NewScanner(cmd.InOrStdin())}
+			}
+
+			logger, err := logging.NewZapJSONLogger(
+				getLogLevel(input.Config.Verbose),
+				outWriter,
+				outWriter,
+			)
+			if err != nil {
+				return ftperrors.NewInternalError("failed to setup logger", err)
+			}
+
+			dependencies := newShellDependencies(lineReader, outWriter)
+
+			err = shell.PerformShell(ctx, logger, dependencies, input)
+			return
+		},
+	}
+
+	if err := setConnectionFlags(shellCMD); err != nil {
+		return err
+	}
+
+	rootCMD.AddCommand(shellCMD)
+	return nil
+}
+
+func parseShellFlags(flagSet *pflag.FlagSet) (*shell.CmdShellInput, error) {
+	config, err := parseConnectionFlags(flagSet)
+	if err != nil {
+		return nil, err
+	}
+
+	localDir, err := os.Getwd()
+	if err != nil {
+		return nil, ftperrors.NewInternalError("failed to get working directory", err)
+	}
+
+	return &shell.CmdShellInput{
+		Config:   config,
+		LocalDir: localDir,
+	}, nil
+}
+
+// newShellDependencies function returns dependencies of a session that reads command lines with the
+// line reader.
+func newShellDependencies(lineReader shell.LineReader, outWriter io.Writer) *shell.Dependencies {
+	return &shell.Dependencies{
+		Connector:       ftpclient.NewConnector(),
+		ListUseCase:     &ftp.ListFiles{},
+		DownloadUseCase: &ftp.Download{},
+		UploadUseCase:   &ftp.UploadFile{},
+		MkdirUseCase:    &ftp.Mkdir{},
+		RemoveUseCase:   &ftp.Remove{},
+		MoveUseCase:     &ftp.Move{},
+		FileStore:       &filestore.FileStore{},
+		Filesystem:      os.DirFS("/"),
+		LineReader:      lineReader,
+		OutWriter:       outWriter,
+	}
+}
+
+// terminalLineReader reads lines from the terminal, which provides line editing and history.
+type terminalLineReader struct {
+	terminal *term.Terminal
+}
+
+func (r *terminalLineReader) ReadLine(prompt string) (string, error) {
+	r.terminal.SetPrompt(prompt)
+	return r.terminal.ReadLine()
+}
+
+// scannerLineReader reads lines from the input that is not a terminal, such as a pipe, without prompts.
+type scannerLineReader struct {
+	scanner *bufio.Scanner
+}
+
+func (r *scannerLineReader) ReadLine(_ string) (string, error) {
+	if !r.scanner.Scan() {
+		if err := r.scanner.Err(); err != nil {
+			return "", err
+		}
+		return "", io.EOF
+	}
+	return r.scanner.Text(), nil
+}
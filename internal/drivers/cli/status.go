@@ -29,7 +29,7 @@ func AddStatusCommand(rootCMD *cobra.Command) error {
 
 			logger, err := logging.NewZapJSONLogger(
 				getLogLevel(input.Config.Verbose),
-				cmd.OutOrStdout(),
+				logWriter(cmd, input.Output),
 				cmd.ErrOrStderr(),
 			)
 			if err != nil {
@@ -51,6 +51,8 @@ func AddStatusCommand(rootCMD *cobra.Command) error {
 		return err
 	}
 
+	setOutputFlag(statusCMD)
+
 	rootCMD.AddCommand(statusCMD)
 	return nil
 }
@@ -61,7 +63,13 @@ func parseStatusFlags(flagSet *pflag.FlagSet, _ []string) (*status.CmdStatusInpu
 		return nil, err
 	}
 
+	output, err := parseOutputFlag(flagSet)
+	if err != nil {
+		return nil, err
+	}
+
 	return &status.CmdStatusInput{
 		Config: config,
+		Output: output,
 	}, nil
 }
@@ -0,0 +1,145 @@
+package cli
+
+import (
+	"context"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"github.com/alexZaicev/go-ftp-client/internal/adapters/filestore"
+	"github.com/alexZaicev/go-ftp-client/internal/adapters/ftpclient"
+	"github.com/alexZaicev/go-ftp-client/internal/adapters/ftpclient/sync"
+	"github.com/alexZaicev/go-ftp-client/internal/domain/entities"
+	ftperrors "github.com/alexZaicev/go-ftp-client/internal/domain/errors"
+	"github.com/alexZaicev/go-ftp-client/internal/drivers/cli/models"
+	"github.com/alexZaicev/go-ftp-client/internal/drivers/logging"
+	"github.com/alexZaicev/go-ftp-client/internal/usecases/ftp"
+)
+
+func AddSyncCommand(rootCMD *cobra.Command) error {
+	syncCMD := &cobra.Command{
+		Use:   "sync",
+		Short: "Mirror local directory tree onto the server, the other way around with --reverse, or both ways with --two-way.",
+		RunE: func(cmd *cobra.Command, args []string) (err error) {
+			ctx := context.Background()
+
+			input, err := parseSyncFlags(cmd.Flags(), args)
+			if err != nil {
+				return err
+			}
+
+			logger, err := logging.NewZapJSONLogger(
+				getLogLevel(input.Config.Verbose),
+				logWriter(cmd, input.Output),
+				cmd.ErrOrStderr(),
+			)
+			if err != nil {
+				return ftperrors.NewInternalError("failed to setup logger", err)
+			}
+
+			dependencies := &sync.Dependencies{
+				Connector:         ftpclient.NewConnector(),
+				Filesystem:        os.DirFS("/"),
+				FileStore:         &filestore.FileStore{},
+				PlanUseCase:       &ftp.SyncPlan{},
+				TwoWayPlanUseCase: &ftp.TwoWaySyncPlan{},
+				SnapshotUseCase:   &ftp.SyncSnapshot{},
+				UploadUseCase:     &ftp.UploadFile{},
+				DownloadUseCase:   &ftp.Download{},
+				MkdirUseCase:      &ftp.Mkdir{},
+				RemoveUseCase:     &ftp.Remove{},
+				MoveUseCase:       &ftp.Move{},
+				OutWriter:         cmd.OutOrStdout(),
+			}
+
+			err = sync.PerformSync(ctx, logger, dependencies, input)
+			return
+		},
+	}
+
+	if err := setConnectionFlags(syncCMD); err != nil {
+		return err
+	}
+
+	syncCMD.Flags().Bool(models.ArgReverse.Long, false, models.ArgReverse.Help)
+	syncCMD.Flags().Bool(models.ArgDelete.Long, false, models.ArgDelete.Help)
+	syncCMD.Flags().Bool(models.ArgDryRun.Long, false, models.ArgDryRun.Help)
+	syncCMD.Flags().Bool(models.ArgTwoWay.Long, false, models.ArgTwoWay.Help)
+	syncCMD.Flags().String(models.ArgConflict.Long, string(entities.SyncConflictPolicyFail), models.ArgConflict.Help)
+	setOutputFlag(syncCMD)
+
+	rootCMD.AddCommand(syncCMD)
+	return nil
+}
+
+func parseSyncFlags(flagSet *pflag.FlagSet, args []string) (*sync.CmdSyncInput, error) {
+	config, err := parseConnectionFlags(flagSet)
+	if err != nil {
+		return nil, err
+	}
+
+	reverse, err := flagSet.GetBool(models.ArgReverse.Long)
+	if err != nil {
+		return nil, err
+	}
+
+	deleteExtraneous, err := flagSet.GetBool(models.ArgDelete.Long)
+	if err != nil {
+		return nil, err
+	}
+
+	dryRun, err := flagSet.GetBool(models.ArgDryRun.Long)
+	if err != nil {
+		return nil, err
+	}
+
+	twoWay, err := flagSet.GetBool(models.ArgTwoWay.Long)
+	if err != nil {
+		return nil, err
+	}
+	if twoWay && (reverse || deleteExtraneous) {
+		return nil, ftperrors.NewInvalidArgumentError(models.ArgTwoWay.Long, "cannot be combined with reverse or delete")
+	}
+
+	conflictStr, err := flagSet.GetString(models.ArgConflict.Long)
+	if err != nil {
+		return nil, err
+	}
+	conflictPolicy, err := models.ParseConflictPolicy(conflictStr)
+	if err != nil {
+		return nil, err
+	}
+
+	output, err := parseOutputFlag(flagSet)
+	if err != nil {
+		return nil, err
+	}
+
+	//nolint:gomnd // expecting 2 args for command
+	if len(args) != 2 {
+		return nil, ftperrors.NewInvalidArgumentError("args", "should contain valid source and destination paths")
+	}
+
+	localArg, remotePath := args[0], args[1]
+	if reverse {
+		remotePath, localArg = args[0], args[1]
+	}
+
+	localPath, err := getFileAbsPath(localArg)
+	if err != nil {
+		return nil, ftperrors.NewInvalidArgumentError("args", err.Error())
+	}
+
+	return &sync.CmdSyncInput{
+		Config:         config,
+		Path:           localPath,
+		RemotePath:     remotePath,
+		Reverse:        reverse,
+		Delete:         deleteExtraneous,
+		DryRun:         dryRun,
+		TwoWay:         twoWay,
+		ConflictPolicy: conflictPolicy,
+		Output:         output,
+	}, nil
+}
@@ -0,0 +1,109 @@
+package cli
+
+import (
+	"context"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"github.com/alexZaicev/go-ftp-client/internal/adapters/ftpclient"
+	"github.com/alexZaicev/go-ftp-client/internal/adapters/ftpclient/tree"
+	ftperrors "github.com/alexZaicev/go-ftp-client/internal/domain/errors"
+	"github.com/alexZaicev/go-ftp-client/internal/drivers/cli/models"
+	"github.com/alexZaicev/go-ftp-client/internal/drivers/logging"
+	"github.com/alexZaicev/go-ftp-client/internal/usecases/ftp"
+)
+
+func AddTreeCommand(rootCMD *cobra.Command) error {
+	//nolint:dupl // single use case command are very similar
+	treeCMD := &cobra.Command{
+		Use:   "tree",
+		Short: "Print directory tree.",
+		Long: "Print the hierarchy of directories and files below a directory, followed by the number of " +
+			"directories and files in it. Symbolic links are printed with their targets, but not followed.",
+		RunE: func(cmd *cobra.Command, args []string) (err error) {
+			ctx := context.Background()
+
+			input, err := parseTreeFlags(cmd.Flags(), args)
+			if err != nil {
+				return err
+			}
+
+			logger, err := logging.NewZapJSONLogger(
+				getLogLevel(input.Config.Verbose),
+				cmd.OutOrStdout(),
+				cmd.ErrOrStderr(),
+			)
+			if err != nil {
+				return ftperrors.NewInternalError("failed to setup logger", err)
+			}
+
+			dependencies := &tree.Dependencies{
+				Connector: ftpclient.NewConnector(),
+				UseCase:   &ftp.Walk{},
+				OutWriter: cmd.OutOrStdout(),
+			}
+
+			err = tree.PerformTree(ctx, logger, dependencies, input)
+			return
+		},
+	}
+
+	if err := setConnectionFlags(treeCMD); err != nil {
+		return err
+	}
+
+	treeCMD.Flags().Bool(ArgAll, false, "Do not ignore entries starting with '.'")
+
+	treeCMD.Flags().Bool(models.ArgLiteral.Long, false, models.ArgLiteral.Help)
+
+	treeCMD.Flags().IntP(models.ArgTreeDepth.Long, models.ArgTreeDepth.Short, 0, models.ArgTreeDepth.Help)
+
+	rootCMD.AddCommand(treeCMD)
+	return nil
+}
+
+func parseTreeFlags(flagSet *pflag.FlagSet, args []string) (*tree.CmdTreeInput, error) {
+	config, err := parseConnectionFlags(flagSet)
+	if err != nil {
+		return nil, err
+	}
+
+	showAll, err := flagSet.GetBool(ArgAll)
+	if err != nil {
+		return nil, err
+	}
+
+	literal, err := flagSet.GetBool(models.ArgLiteral.Long)
+	if err != nil {
+		return nil, err
+	}
+
+	depth, err := flagSet.GetInt(models.ArgTreeDepth.Long)
+	if err != nil {
+		return nil, err
+	}
+	if depth < 0 {
+		return nil, ftperrors.NewInvalidArgumentError(models.ArgTreeDepth.Long, "should not be negative")
+	}
+
+	if len(args) > 1 {
+		return nil, ftperrors.NewInvalidArgumentError(
+			"args",
+			"should be empty or contain exactly one valid path",
+		)
+	}
+
+	// if no args provided, set path to print tree of current working directory
+	if len(args) == 0 {
+		args = append(args, "./")
+	}
+
+	return &tree.CmdTreeInput{
+		Config:  config,
+		Path:    args[0],
+		Literal: literal,
+		ShowAll: showAll,
+		Depth:   depth,
+	}, nil
+}
@@ -7,8 +7,10 @@ import (
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
 
+	"github.com/alexZaicev/go-ftp-client/internal/adapters/filestore"
 	"github.com/alexZaicev/go-ftp-client/internal/adapters/ftpclient"
 	"github.com/alexZaicev/go-ftp-client/internal/adapters/ftpclient/upload"
+	"github.com/alexZaicev/go-ftp-client/internal/domain/entities"
 	ftperrors "github.com/alexZaicev/go-ftp-client/internal/domain/errors"
 	"github.com/alexZaicev/go-ftp-client/internal/drivers/cli/models"
 	"github.com/alexZaicev/go-ftp-client/internal/drivers/logging"
@@ -27,9 +29,14 @@ func AddUploadCommand(rootCMD *cobra.Command) error {
 				return err
 			}
 
+			progressWriter, err := parseProgressFlag(cmd)
+			if err != nil {
+				return err
+			}
+
 			logger, err := logging.NewZapJSONLogger(
 				getLogLevel(input.Config.Verbose),
-				cmd.OutOrStdout(),
+				logWriter(cmd, input.Output),
 				cmd.ErrOrStderr(),
 			)
 			if err != nil {
@@ -39,10 +46,13 @@ func AddUploadCommand(rootCMD *cobra.Command) error {
 			filesystem := os.DirFS("/")
 
 			dependencies := &upload.Dependencies{
-				MkdirUseCase:  &ftp.Mkdir{},
-				UploadUseCase: &ftp.UploadFile{},
-				Connector:     ftpclient.NewConnector(),
-				Filesystem:    filesystem,
+				MkdirUseCase:   &ftp.Mkdir{},
+				UploadUseCase:  &ftp.UploadFile{},
+				Connector:      ftpclient.NewConnector(),
+				Filesystem:     filesystem,
+				FileStore:      &filestore.FileStore{},
+				OutWriter:      cmd.OutOrStdout(),
+				ProgressWriter: progressWriter,
 			}
 
 			err = upload.PerformUploadFile(ctx, logger, dependencies, input)
@@ -61,6 +71,25 @@ func AddUploadCommand(rootCMD *cobra.Command) error {
 		"Recursively upload directory tree",
 	)
 
+	setFilterFlags(uploadCMD)
+	uploadCMD.Flags().String(
+		models.ArgIfExists.Long,
+		string(entities.OverwritePolicyOverwrite),
+		models.ArgIfExists.Help,
+	)
+	uploadCMD.Flags().String(
+		models.ArgUploadLinks.Long,
+		string(entities.LinkPolicySkip),
+		models.ArgUploadLinks.Help,
+	)
+	uploadCMD.Flags().Int(models.ArgParallel.Long, 1, models.ArgParallel.Help)
+	uploadCMD.Flags().Bool(models.ArgAtomic.Long, false, models.ArgAtomic.Help)
+	uploadCMD.Flags().String(models.ArgAtomicPrefix.Long, models.DefaultAtomicPrefix, models.ArgAtomicPrefix.Help)
+	uploadCMD.Flags().String(models.ArgAtomicSuffix.Long, models.DefaultAtomicSuffix, models.ArgAtomicSuffix.Help)
+	setReportFlags(uploadCMD)
+	setProgressFlag(uploadCMD)
+	setOutputFlag(uploadCMD)
+
 	rootCMD.AddCommand(uploadCMD)
 	return nil
 }
@@ -86,10 +115,84 @@ func parseUploadFlags(flagSet *pflag.FlagSet, args []string) (*upload.CmdUploadI
 		return nil, ftperrors.NewInvalidArgumentError("args", err.Error())
 	}
 
+	filter, err := parseFilterFlags(flagSet)
+	if err != nil {
+		return nil, err
+	}
+
+	ifExistsStr, err := flagSet.GetString(models.ArgIfExists.Long)
+	if err != nil {
+		return nil, err
+	}
+	ifExists, err := models.ParseOverwritePolicy(ifExistsStr)
+	if err != nil {
+		return nil, err
+	}
+
+	linksStr, err := flagSet.GetString(models.ArgUploadLinks.Long)
+	if err != nil {
+		return nil, err
+	}
+	links, err := models.ParseUploadLinkPolicy(linksStr)
+	if err != nil {
+		return nil, err
+	}
+
+	parallel, err := flagSet.GetInt(models.ArgParallel.Long)
+	if err != nil {
+		return nil, err
+	}
+	if parallel < 1 {
+		return nil, ftperrors.NewInvalidArgumentError(models.ArgParallel.Long, "must be greater than zero")
+	}
+
+	atomic, err := parseAtomicFlags(flagSet)
+	if err != nil {
+		return nil, err
+	}
+
+	continueOnError, reportPath, err := parseReportFlags(flagSet)
+	if err != nil {
+		return nil, err
+	}
+
+	output, err := parseOutputFlag(flagSet)
+	if err != nil {
+		return nil, err
+	}
+
 	return &upload.CmdUploadInput{
 		Config:         config,
 		FilePath:       filePath,
 		Recursive:      recursive,
 		RemoteFilePath: args[1],
+		Filter:         filter,
+		IfExists:       ifExists,
+		Atomic:         atomic,
+		Links:          links,
+		Parallel:       parallel,
+
+		ContinueOnError: continueOnError,
+		ReportPath:      reportPath,
+		Output:          output,
 	}, nil
 }
+
+// parseAtomicFlags function returns options of atomic uploads, or nil if files are uploaded directly.
+func parseAtomicFlags(flagSet *pflag.FlagSet) (*entities.AtomicUploadOptions, error) {
+	atomic, err := flagSet.GetBool(models.ArgAtomic.Long)
+	if err != nil || !atomic {
+		return nil, err
+	}
+
+	prefix, err := flagSet.GetString(models.ArgAtomicPrefix.Long)
+	if err != nil {
+		return nil, err
+	}
+	suffix, err := flagSet.GetString(models.ArgAtomicSuffix.Long)
+	if err != nil {
+		return nil, err
+	}
+
+	return models.ParseAtomicUploadOptions(prefix, suffix)
+}
@@ -1,5 +1,21 @@
 package ftp
 
+import (
+	"github.com/alexZaicev/go-ftp-client/internal/drivers/logging"
+)
+
 func isRootDir(name string) bool {
 	return name == "." || name == ".."
 }
+
+// logSkippedLines function reports list entries that were skipped by the connection in lenient listing mode.
+func logSkippedLines(logger logging.Logger, remotePath string, skippedLines []string) {
+	for _, line := range skippedLines {
+		logger.
+			WithFields(logging.Fields{
+				"remote-path": remotePath,
+				"line":        line,
+			}).
+			Warn("skipped list entry that could not be parsed")
+	}
+}
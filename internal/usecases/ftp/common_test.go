@@ -14,6 +14,7 @@ import (
 const (
 	dirPath              = "/foo/bar/baz"
 	remoteDirPath        = "/doo/dee/daa"
+	homeDirPath          = "/home/user01"
 	fileName             = "foobarbaz.txt"
 	sizeInBytes   uint64 = 587
 
@@ -22,6 +23,7 @@ const (
 
 var (
 	remotePathNoDir   = fileName
+	tempFileName      = "." + fileName + ".partial"
 	remotePathWithDir = fmt.Sprintf("%s/%s", remoteDirPath, fileName)
 
 	localPathWithDir = fmt.Sprintf("%s/%s", dirPath, fileName)
@@ -97,3 +99,21 @@ func newEntry(
 		LastModificationDate: date,
 	}
 }
+
+// newRecentEntry function returns an entry modified the duration ago, as listed by UNIX servers for
+// recently modified entries, with time of the day but no year.
+func newRecentEntry(entryType entities.EntryType, name string, sizeInBytes uint64, age time.Duration) *entities.Entry {
+	date := time.Now().UTC().Add(-age)
+
+	return &entities.Entry{
+		Type:                 entryType,
+		Permissions:          "rwxrwxrwx",
+		Name:                 name,
+		OwnerUser:            "user01",
+		OwnerGroup:           "group01",
+		SizeInBytes:          sizeInBytes,
+		NumHardLinks:         2,
+		LastModificationDate: time.Date(0, date.Month(), date.Day(), date.Hour(), date.Minute(), 0, 0, time.UTC),
+		YearUnknown:          true,
+	}
+}
@@ -0,0 +1,101 @@
+package ftp
+
+import (
+	"context"
+
+	"github.com/alexZaicev/go-ftp-client/internal/domain/connection"
+	"github.com/alexZaicev/go-ftp-client/internal/domain/entities"
+	"github.com/alexZaicev/go-ftp-client/internal/drivers/logging"
+)
+
+type DiskUsageUseCase interface {
+	Execute(ctx context.Context, repos *DiskUsageRepos, input *DiskUsageInput) ([]*entities.DirUsage, error)
+}
+
+type DiskUsageInput struct {
+	// Path is the remote directory whose usage is added up. Glob meta characters escaped with a backslash
+	// are listed as is.
+	Path string
+	// Literal takes Path as is, without unescaping backslashes.
+	Literal bool
+	// MaxDepth limits which directories are reported, where 0 only reports the directory at Path. Deeper
+	// directories are still walked and added to their parents. All directories are reported if it is
+	// negative.
+	MaxDepth int
+}
+
+type DiskUsageRepos struct {
+	Logger     logging.Logger
+	Connection connection.Connection
+}
+
+type DiskUsage struct {
+}
+
+// dirUsageNode is the usage of a walked directory along with usages of its subdirectories.
+type dirUsageNode struct {
+	usage    *entities.DirUsage
+	children []*dirUsageNode
+}
+
+// Execute method walks the whole tree, including hidden entries, and returns usages of directories with
+// subdirectories coming before their parents, so that the usage of the directory at Path is the last one.
+// Symbolic links are neither followed nor counted.
+func (u *DiskUsage) Execute(
+	ctx context.Context,
+	repos *DiskUsageRepos,
+	input *DiskUsageInput,
+) ([]*entities.DirUsage, error) {
+	var root *dirUsageNode
+	nodes := make(map[string]*dirUsageNode)
+
+	walker := &remoteWalker{
+		logger:   repos.Logger,
+		conn:     repos.Connection,
+		showAll:  true,
+		sortType: entities.SortTypeName,
+		visit: func(dir *WalkedDir) error {
+			node := nodes[dir.Path]
+			if node == nil {
+				node = &dirUsageNode{usage: &entities.DirUsage{Path: dir.Path}}
+				root = node
+			}
+
+			for _, entry := range dir.Entries {
+				switch entry.Type {
+				case entities.EntryTypeFile:
+					node.usage.SizeInBytes += entry.SizeInBytes
+					node.usage.Files++
+				case entities.EntryTypeDir:
+					childPath := entities.RemotePath(dir.Path).Join(entry.Name).String()
+					child := &dirUsageNode{usage: &entities.DirUsage{Path: childPath, Depth: dir.Depth + 1}}
+					nodes[childPath] = child
+					node.children = append(node.children, child)
+					node.usage.Dirs++
+				}
+			}
+			return nil
+		},
+	}
+	if err := walker.walk(ctx, entities.RemotePath(remoteLiteralPath(input.Path, input.Literal)), 0); err != nil {
+		return nil, err
+	}
+
+	var usages []*entities.DirUsage
+	addUpUsage(root, input.MaxDepth, &usages)
+	return usages, nil
+}
+
+// addUpUsage function adds usages of subdirectories to the usage of the directory, appending those within
+// the depth limit to usages after their subdirectories.
+func addUpUsage(node *dirUsageNode, maxDepth int, usages *[]*entities.DirUsage) {
+	for _, child := range node.children {
+		addUpUsage(child, maxDepth, usages)
+		node.usage.SizeInBytes += child.usage.SizeInBytes
+		node.usage.Files += child.usage.Files
+		node.usage.Dirs += child.usage.Dirs
+	}
+	if maxDepth < 0 || node.usage.Depth <= maxDepth {
+		*usages = append(*usages, node.usage)
+	}
+}
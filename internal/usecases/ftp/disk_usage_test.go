@@ -0,0 +1,141 @@
+package ftp_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/alexZaicev/go-ftp-client/internal/domain/connection"
+	"github.com/alexZaicev/go-ftp-client/internal/domain/entities"
+	ftperrors "github.com/alexZaicev/go-ftp-client/internal/domain/errors"
+	"github.com/alexZaicev/go-ftp-client/internal/drivers/logging/assertlogging"
+	"github.com/alexZaicev/go-ftp-client/internal/usecases/ftp"
+	connectionMocks "github.com/alexZaicev/go-ftp-client/mocks/domain/connection"
+)
+
+func Test_DiskUsage_Execute_Success(t *testing.T) {
+	testCases := []struct {
+		name           string
+		maxDepth       int
+		expectedUsages []*entities.DirUsage
+	}{
+		{
+			name:     "usage of all directories",
+			maxDepth: -1,
+			expectedUsages: []*entities.DirUsage{
+				{Path: dirPath + "/dir-1/dir-2", Depth: 2, SizeInBytes: 300, Files: 2},
+				{Path: dirPath + "/dir-1", Depth: 1, SizeInBytes: 300, Files: 2, Dirs: 1},
+				{Path: dirPath + "/dir-3", Depth: 1},
+				{Path: dirPath, Depth: 0, SizeInBytes: 400, Files: 3, Dirs: 3},
+			},
+		},
+		{
+			name:     "usage of directories down to depth",
+			maxDepth: 1,
+			expectedUsages: []*entities.DirUsage{
+				{Path: dirPath + "/dir-1", Depth: 1, SizeInBytes: 300, Files: 2, Dirs: 1},
+				{Path: dirPath + "/dir-3", Depth: 1},
+				{Path: dirPath, Depth: 0, SizeInBytes: 400, Files: 3, Dirs: 3},
+			},
+		},
+		{
+			name:     "usage of root directory",
+			maxDepth: 0,
+			expectedUsages: []*entities.DirUsage{
+				{Path: dirPath, Depth: 0, SizeInBytes: 400, Files: 3, Dirs: 3},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			logger := assertlogging.NewLogger(t)
+
+			connMock := connectionMocks.NewConnection(t)
+			connMock.
+				On("List", ctx, &connection.ListOptions{Path: dirPath, ShowAll: true}).
+				Return(&connection.ListResult{
+					Entries: []*entities.Entry{
+						rootDir1,
+						rootDir2,
+						newEntry(t, entities.EntryTypeDir, "dir-3", 4096, "2022-01-12 16:23"),
+						newEntry(t, entities.EntryTypeLink, "link-1", 6, "2022-01-12 16:23"),
+						newEntry(t, entities.EntryTypeFile, ".file-1", 100, "2022-01-12 16:23"),
+						newEntry(t, entities.EntryTypeDir, "dir-1", 4096, "2022-01-12 16:23"),
+					},
+				}, nil).
+				Once()
+			connMock.
+				On("List", ctx, &connection.ListOptions{Path: dirPath + "/dir-1", ShowAll: true}).
+				Return(&connection.ListResult{
+					Entries: []*entities.Entry{
+						newEntry(t, entities.EntryTypeDir, "dir-2", 4096, "2022-01-12 16:23"),
+					},
+				}, nil).
+				Once()
+			connMock.
+				On("List", ctx, &connection.ListOptions{Path: dirPath + "/dir-1/dir-2", ShowAll: true}).
+				Return(&connection.ListResult{
+					Entries: []*entities.Entry{
+						newEntry(t, entities.EntryTypeFile, "file-2", 200, "2022-01-12 16:23"),
+						newEntry(t, entities.EntryTypeFile, "file-3", 100, "2022-01-12 16:23"),
+					},
+				}, nil).
+				Once()
+			connMock.
+				On("List", ctx, &connection.ListOptions{Path: dirPath + "/dir-3", ShowAll: true}).
+				Return(&connection.ListResult{}, nil).
+				Once()
+
+			useCaseRepos := &ftp.DiskUsageRepos{
+				Logger:     logger,
+				Connection: connMock,
+			}
+			useCaseInput := &ftp.DiskUsageInput{
+				Path:     dirPath,
+				MaxDepth: tc.maxDepth,
+			}
+
+			useCase := &ftp.DiskUsage{}
+			usages, err := useCase.Execute(ctx, useCaseRepos, useCaseInput)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedUsages, usages)
+		})
+	}
+}
+
+func Test_DiskUsage_Execute_ListError(t *testing.T) {
+	ctx := context.Background()
+
+	logger := assertlogging.NewLogger(t)
+	logger.
+		ExpectError("failed to list directory").
+		WithError(assertlogging.EqualError("mock error")).
+		WithField("remote-path", assertlogging.Equal(dirPath))
+
+	connMock := connectionMocks.NewConnection(t)
+	connMock.
+		On("List", ctx, &connection.ListOptions{Path: dirPath, ShowAll: true}).
+		Return(nil, errors.New("mock error")).
+		Once()
+
+	useCaseRepos := &ftp.DiskUsageRepos{
+		Logger:     logger,
+		Connection: connMock,
+	}
+	useCaseInput := &ftp.DiskUsageInput{
+		Path: dirPath,
+	}
+
+	useCase := &ftp.DiskUsage{}
+	usages, err := useCase.Execute(ctx, useCaseRepos, useCaseInput)
+	assert.Nil(t, usages)
+	require.EqualError(t, err, "an internal error occurred: failed to list directory")
+	assert.IsType(t, ftperrors.InternalErrorType, err)
+	assert.NoError(t, errors.Unwrap(err))
+}
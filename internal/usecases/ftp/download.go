@@ -3,7 +3,10 @@ package ftp
 import (
 	"context"
 	"fmt"
+	"io"
 	"path/filepath"
+	"runtime"
+	"strings"
 
 	"github.com/alexZaicev/go-ftp-client/internal/domain/connection"
 	"github.com/alexZaicev/go-ftp-client/internal/domain/entities"
@@ -13,49 +16,251 @@ import (
 )
 
 type DownloadUseCase interface {
-	Execute(context.Context, *DownloadRepos, *DownloadInput) error
+	Execute(context.Context, *DownloadRepos, *DownloadInput) (*DownloadOutput, error)
 }
 
 type DownloadInput struct {
+	// RemotePath is either a literal path or a glob pattern. Entries matched by a pattern are saved
+	// under Path directory, keeping their paths relative to the leading directories of the pattern.
+	// A pattern matching nothing is downloaded as a literal path if it exists.
 	RemotePath string
 	Path       string
+	// Literal takes RemotePath as is, without expanding glob patterns or unescaping backslashes.
+	Literal bool
+	// Filter selects entries of downloaded directories, if provided.
+	Filter *entities.FilterOptions
+	// IfExists decides what happens to files that already exist locally, they are overwritten by default.
+	IfExists entities.OverwritePolicy
+	// UnsafeNames decides what happens to entries whose names are illegal or reserved on the local
+	// filesystem, they are skipped by default.
+	UnsafeNames entities.UnsafeNamePolicy
+	// Links decides what happens to symbolic links of downloaded directories, they are skipped by default.
+	Links entities.LinkPolicy
+	// ContinueOnError records entries of downloaded directories that fail as failed results and carries on
+	// with the rest, instead of aborting the download.
+	ContinueOnError bool
+}
+
+type DownloadOutput struct {
+	// Skipped are files that were not downloaded, as local files already exist, and symbolic links that
+	// were not downloaded.
+	Skipped []*entities.SkippedTransfer
+	// Results are outcomes of every downloaded, skipped and failed entry, in the order they were processed.
+	Results []*entities.TransferResult
 }
 
 type DownloadRepos struct {
 	Logger     logging.Logger
 	Connection connection.Connection
 	FileStore  repositories.FileStore
+	// Observer is notified of downloaded files and listed directories, if provided.
+	Observer TransferObserver
 }
 
 type Download struct {
 }
 
-func (d *Download) Execute(ctx context.Context, repos *DownloadRepos, input *DownloadInput) error {
-	isDir, err := repos.Connection.IsDir(ctx, input.RemotePath)
+const (
+	reasonLinkSkipped        = "symbolic link"
+	reasonLinkLoop           = "link loop"
+	reasonLinkTargetNotFound = "link target not found"
+	reasonLinkTargetUnknown  = "link target unknown"
+	reasonLinkTargetUnsafe   = "unsafe link target"
+	reasonThroughLink        = "path goes through a symbolic link"
+
+	// maxLinkHops limits the chain of links pointing to other links, same as the limit of Linux.
+	maxLinkHops = 40
+)
+
+// downloadRun holds options and results of a single download.
+type downloadRun struct {
+	filter      *PathFilter
+	ifExists    entities.OverwritePolicy
+	unsafeNames entities.UnsafeNamePolicy
+	links       entities.LinkPolicy
+	names       *localNameChecker
+	observer    TransferObserver
+	// continueOnError records failed entries instead of aborting the download
+	continueOnError bool
+	skipped         []*entities.SkippedTransfer
+	results         []*entities.TransferResult
+	// dirs are remote directories being downloaded, from the root of the download to the current one.
+	dirs []entities.RemotePath
+	// preserved are local paths of symbolic links created by the download.
+	preserved map[string]bool
+}
+
+// throughLink method reports whether the local path is, or goes through, a symbolic link created by the
+// download, so that saving to it would follow the link.
+func (r *downloadRun) throughLink(path string) bool {
+	for path = filepath.Clean(path); ; path = filepath.Dir(path) {
+		if r.preserved[path] {
+			return true
+		}
+		if parent := filepath.Dir(path); parent == path {
+			return false
+		}
+	}
+}
+
+// skipLink method reports the symbolic link as not downloaded.
+func (r *downloadRun) skipLink(linkPath, reason string) {
+	r.skip(&entities.SkippedTransfer{Path: linkPath, Reason: reason})
+}
+
+func (r *downloadRun) skip(skipped *entities.SkippedTransfer) {
+	r.skipped = append(r.skipped, skipped)
+	r.results = append(r.results, entities.NewTransferSkipped(skipped))
+	notifyFileSkipped(r.observer, skipped)
+}
+
+func (r *downloadRun) ok(remotePath string) {
+	r.results = append(r.results, entities.NewTransferOK(remotePath))
+}
+
+// fail method records the entry as failed and returns nil if the download continues on errors, otherwise
+// it returns the error to abort the download.
+func (r *downloadRun) fail(remotePath string, err error) error {
+	if !r.continueOnError {
+		return err
+	}
+	r.results = append(r.results, entities.NewTransferFailed(remotePath, err))
+	return nil
+}
+
+// isDownloading method reports whether the directory is being downloaded already, either itself or as a
+// parent of the current directory, so that following a link to it would loop.
+func (r *downloadRun) isDownloading(dirPath entities.RemotePath) bool {
+	for _, dir := range r.dirs {
+		if _, ok := dir.Rel(dirPath); ok {
+			return true
+		}
+	}
+	return false
+}
+
+func (d *Download) Execute(ctx context.Context, repos *DownloadRepos, input *DownloadInput) (*DownloadOutput, error) {
+	filter, err := NewPathFilter(input.Filter)
+	if err != nil {
+		return nil, err
+	}
+
+	run := &downloadRun{
+		filter:      filter,
+		ifExists:    input.IfExists,
+		unsafeNames: input.UnsafeNames,
+		links:       input.Links,
+		names:       &localNameChecker{windows: runtime.GOOS == "windows"},
+		observer:    repos.Observer,
+		preserved:   make(map[string]bool),
+
+		continueOnError: input.ContinueOnError,
+	}
+	if downloadErr := d.download(ctx, repos, run, input); downloadErr != nil {
+		return nil, downloadErr
+	}
+
+	return &DownloadOutput{Skipped: run.skipped, Results: run.results}, nil
+}
+
+func (d *Download) download(ctx context.Context, repos *DownloadRepos, run *downloadRun, input *DownloadInput) error {
+	remotePath := remoteLiteralPath(input.RemotePath, input.Literal)
+	if isRemoteGlob(input.RemotePath, input.Literal) {
+		matches, err := expandGlobOrLiteral(ctx, repos.Logger, repos.Connection, input.RemotePath)
+		if err != nil {
+			return err
+		}
+		if matches != nil {
+			return d.downloadGlob(ctx, repos, run, input, matches)
+		}
+		remotePath = input.RemotePath
+	}
+
+	isDir, err := repos.Connection.IsDir(ctx, remotePath)
 	if err != nil {
 		repos.Logger.
 			WithError(err).
-			WithField("remote-path", input.RemotePath).
+			WithField("remote-path", remotePath).
 			Error("failed to check if entry is a directory")
 		return ftperrors.NewInternalError("failed to check if entry is a directory", nil)
 	}
 
 	if !isDir {
-		if downloadErr := d.downloadAndSaveFile(ctx, repos, input.RemotePath, input.Path); downloadErr != nil {
+		if downloadErr := d.downloadAndSaveFile(ctx, repos, run, remotePath, input.Path); downloadErr != nil {
 			return downloadErr
 		}
 
 		return nil
 	}
 
-	if downloadErr := d.downloadAndSaveFileRecursively(ctx, repos, input.RemotePath, input.Path); downloadErr != nil {
+	if downloadErr := d.downloadAndSaveFileRecursively(
+		ctx, repos, run, remotePath, "", input.Path,
+	); downloadErr != nil {
 		return downloadErr
 	}
 
 	return nil
 }
 
-func (d *Download) downloadAndSaveFile(ctx context.Context, repos *DownloadRepos, remotePath, path string) error {
+func (d *Download) downloadGlob(
+	ctx context.Context,
+	repos *DownloadRepos,
+	run *downloadRun,
+	input *DownloadInput,
+	matches []*globMatch,
+) error {
+	base := globBase(input.RemotePath)
+	for _, match := range topmostGlobMatches(matches) {
+		relPath := strings.TrimPrefix(strings.TrimPrefix(match.path, base), "/")
+		if matchErr := d.downloadMatch(ctx, repos, run, match, relPath, input.Path); matchErr != nil {
+			if failErr := run.fail(match.path, matchErr); failErr != nil {
+				return failErr
+			}
+		}
+	}
+
+	return nil
+}
+
+// downloadMatch method downloads the entry matched by a glob pattern into the download directory under
+// path, keeping its path relative to the leading directories of the pattern.
+func (d *Download) downloadMatch(
+	ctx context.Context,
+	repos *DownloadRepos,
+	run *downloadRun,
+	match *globMatch,
+	relPath, path string,
+) error {
+	localPath, ok, err := d.localPath(repos.Logger, run, match.path, path, relPath)
+	if err != nil || !ok {
+		return err
+	}
+	if d.skipThroughLink(repos.Logger, run, match.path, localPath) {
+		return nil
+	}
+
+	switch match.entry.Type {
+	case entities.EntryTypeLink:
+		return d.downloadLink(ctx, repos, run, match.path, relPath, localPath, match.entry)
+	case entities.EntryTypeDir:
+		if !run.filter.MatchDir(relPath) {
+			return nil
+		}
+		return d.downloadAndSaveFileRecursively(ctx, repos, run, match.path, relPath, localPath)
+	default:
+		if !run.filter.MatchEntry(relPath, match.entry) {
+			return nil
+		}
+		return d.downloadAndSaveFile(ctx, repos, run, match.path, localPath)
+	}
+}
+
+func (d *Download) downloadAndSaveFile(
+	ctx context.Context,
+	repos *DownloadRepos,
+	run *downloadRun,
+	remotePath, path string,
+) error {
 	logger := repos.Logger.WithField("remote-path", remotePath)
 
 	sizeInBytes, err := repos.Connection.Size(remotePath)
@@ -64,7 +269,43 @@ func (d *Download) downloadAndSaveFile(ctx context.Context, repos *DownloadRepos
 		return ftperrors.NewInternalError("failed to retrieve file size", nil)
 	}
 
-	data, err := repos.Connection.Download(ctx, remotePath)
+	if checksOverwrite(run.ifExists) {
+		var skipped *entities.SkippedTransfer
+		path, skipped, err = d.resolveLocalPath(ctx, repos, run, remotePath, sizeInBytes, path)
+		if err != nil {
+			return err
+		}
+		if skipped != nil {
+			run.skip(skipped)
+			return nil
+		}
+	}
+
+	transfer := startFileTransfer(run.observer, remotePath, sizeInBytes)
+	err = d.downloadFile(ctx, repos, logger, remotePath, path, sizeInBytes, transfer.writer())
+	transfer.end(err)
+	if err != nil {
+		return err
+	}
+
+	run.ok(remotePath)
+	return nil
+}
+
+// downloadFile method downloads the remote file of the expected size and saves it under path, writing
+// the downloaded bytes to the progress writer, if any, as they arrive.
+func (d *Download) downloadFile(
+	ctx context.Context,
+	repos *DownloadRepos,
+	logger logging.Logger,
+	remotePath, path string,
+	sizeInBytes uint64,
+	progressWriter io.Writer,
+) error {
+	data, err := repos.Connection.Download(ctx, &connection.DownloadOptions{
+		Path:           remotePath,
+		ProgressWriter: progressWriter,
+	})
 	if err != nil {
 		logger.WithError(err).Error("failed to download file")
 		return ftperrors.NewInternalError("failed to download file", nil)
@@ -91,13 +332,25 @@ func (d *Download) downloadAndSaveFile(ctx context.Context, repos *DownloadRepos
 	return nil
 }
 
-func (d *Download) downloadAndSaveFileRecursively(ctx context.Context, repos *DownloadRepos, remotePath, path string) error {
+// downloadAndSaveFileRecursively method downloads the remote directory, where relPath is the path of the
+// directory relative to the root of the download that is matched against the filter.
+func (d *Download) downloadAndSaveFileRecursively(
+	ctx context.Context,
+	repos *DownloadRepos,
+	run *downloadRun,
+	remotePath, relPath, path string,
+) error {
+	run.dirs = append(run.dirs, entities.RemotePath(remotePath).Clean())
+	defer func() {
+		run.dirs = run.dirs[:len(run.dirs)-1]
+	}()
+
 	if createDirErr := repos.FileStore.CreateDir(path); createDirErr != nil {
 		repos.Logger.WithError(createDirErr).WithField("path", path).Error("failed to create directory")
 		return ftperrors.NewInternalError("failed to create directory", nil)
 	}
 
-	entries, listErr := repos.Connection.List(ctx, &connection.ListOptions{
+	result, listErr := repos.Connection.List(ctx, &connection.ListOptions{
 		Path:    remotePath,
 		ShowAll: true,
 	})
@@ -109,32 +362,333 @@ func (d *Download) downloadAndSaveFileRecursively(ctx context.Context, repos *Do
 		return ftperrors.NewInternalError("failed to list directory", nil)
 	}
 
-	for _, entry := range entries {
+	logSkippedLines(repos.Logger, remotePath, result.SkippedLines)
+	if run.observer != nil {
+		run.observer.DirListed(remotePath)
+	}
+
+	for _, entry := range result.Entries {
 		if isRootDir(entry.Name) {
 			continue
 		}
 
-		entryPath := filepath.Join(remotePath, entry.Name)
-		localPath := filepath.Join(path, entry.Name)
-
-		switch entry.Type {
-		// ignore links as they not downloadable
-		case entities.EntryTypeLink:
-		case entities.EntryTypeFile:
-			if downloadErr := d.downloadAndSaveFile(ctx, repos, entryPath, localPath); downloadErr != nil {
-				return downloadErr
-			}
-		case entities.EntryTypeDir:
-			if downloadErr := d.downloadAndSaveFileRecursively(ctx, repos, entryPath, localPath); downloadErr != nil {
-				return downloadErr
+		entryPath := entities.RemotePath(remotePath).Join(entry.Name).String()
+		entryRelPath := entities.RemotePath(relPath).Join(entry.Name).String()
+		if entryErr := d.downloadEntry(ctx, repos, run, entryPath, entryRelPath, path, entry); entryErr != nil {
+			if failErr := run.fail(entryPath, entryErr); failErr != nil {
+				return failErr
 			}
-		default:
-			return ftperrors.NewUnknownError(
-				fmt.Sprintf("unexpected entry type: %d", entry.Type),
-				nil,
-			)
 		}
 	}
 
 	return nil
 }
+
+// downloadEntry method downloads the entry of the remote directory into the local directory under path.
+func (d *Download) downloadEntry(
+	ctx context.Context,
+	repos *DownloadRepos,
+	run *downloadRun,
+	entryPath, entryRelPath, path string,
+	entry *entities.Entry,
+) error {
+	localName, ok, err := d.localName(repos.Logger, run, entryPath, entry.Name)
+	if err != nil || !ok {
+		return err
+	}
+	localPath := filepath.Join(path, localName)
+	if d.skipThroughLink(repos.Logger, run, entryPath, localPath) {
+		return nil
+	}
+
+	switch entry.Type {
+	case entities.EntryTypeLink:
+		return d.downloadLink(ctx, repos, run, entryPath, entryRelPath, localPath, entry)
+	case entities.EntryTypeFile:
+		if !run.filter.MatchEntry(entryRelPath, entry) {
+			return nil
+		}
+		return d.downloadAndSaveFile(ctx, repos, run, entryPath, localPath)
+	case entities.EntryTypeDir:
+		if !run.filter.MatchDir(entryRelPath) {
+			return nil
+		}
+		return d.downloadAndSaveFileRecursively(ctx, repos, run, entryPath, entryRelPath, localPath)
+	default:
+		return ftperrors.NewUnknownError(
+			fmt.Sprintf("unexpected entry type: %d", entry.Type),
+			nil,
+		)
+	}
+}
+
+// downloadLink method applies the link policy to the symbolic link, where relPath is the path of the link
+// relative to the root of the download that is matched against the filter.
+func (d *Download) downloadLink(
+	ctx context.Context,
+	repos *DownloadRepos,
+	run *downloadRun,
+	linkPath, relPath, path string,
+	entry *entities.Entry,
+) error {
+	switch run.links {
+	case entities.LinkPolicyFollow:
+		return d.followLink(ctx, repos, run, linkPath, relPath, path, entry)
+	case entities.LinkPolicyPreserve:
+		return d.preserveLink(ctx, repos, run, linkPath, relPath, path, entry)
+	default:
+		if run.filter.MatchEntry(relPath, entry) {
+			run.skipLink(linkPath, reasonLinkSkipped)
+		}
+		return nil
+	}
+}
+
+// followLink method downloads the file or the directory the symbolic link points to under the name of
+// the link.
+func (d *Download) followLink(
+	ctx context.Context,
+	repos *DownloadRepos,
+	run *downloadRun,
+	linkPath, relPath, path string,
+	entry *entities.Entry,
+) error {
+	targetPath, target, reason, err := d.resolveLink(ctx, repos, linkPath, entry)
+	if err != nil {
+		return err
+	}
+	if reason != "" {
+		run.skipLink(linkPath, reason)
+		return nil
+	}
+
+	if target.Type == entities.EntryTypeDir {
+		if !run.filter.MatchDir(relPath) {
+			return nil
+		}
+		if run.isDownloading(targetPath) {
+			run.skipLink(linkPath, reasonLinkLoop)
+			return nil
+		}
+		return d.downloadAndSaveFileRecursively(ctx, repos, run, targetPath.String(), relPath, path)
+	}
+
+	if !run.filter.MatchEntry(relPath, target) {
+		return nil
+	}
+	return d.downloadAndSaveFile(ctx, repos, run, targetPath.String(), path)
+}
+
+// resolveLink method follows the symbolic link, along with any links it points to, until it reaches an
+// entry that is not a link. Targets are resolved using the link name reported by the listing and checked
+// with a stat call. The reason is returned instead if the target cannot be resolved.
+func (d *Download) resolveLink(
+	ctx context.Context,
+	repos *DownloadRepos,
+	linkPath string,
+	entry *entities.Entry,
+) (entities.RemotePath, *entities.Entry, string, error) {
+	path := entities.RemotePath(linkPath).Clean()
+	visited := map[entities.RemotePath]bool{path: true}
+
+	for entry.Type == entities.EntryTypeLink {
+		if entry.LinkName == "" {
+			return "", nil, reasonLinkTargetUnknown, nil
+		}
+
+		target := entities.RemotePath(entry.LinkName)
+		if !target.IsAbs() {
+			target = path.Dir().Join(entry.LinkName)
+		}
+		path = target.Clean()
+		if visited[path] || len(visited) > maxLinkHops {
+			return "", nil, reasonLinkLoop, nil
+		}
+		visited[path] = true
+
+		var err error
+		entry, err = remoteEntry(ctx, repos.Logger, repos.Connection, path.String())
+		if err != nil {
+			return "", nil, "", err
+		}
+		if entry == nil {
+			return "", nil, reasonLinkTargetNotFound, nil
+		}
+	}
+
+	return path, entry, "", nil
+}
+
+// preserveLink method creates a local symbolic link with the same target as the remote one.
+func (d *Download) preserveLink(
+	ctx context.Context,
+	repos *DownloadRepos,
+	run *downloadRun,
+	linkPath, relPath, path string,
+	entry *entities.Entry,
+) error {
+	if !run.filter.MatchEntry(relPath, entry) {
+		return nil
+	}
+
+	if entry.LinkName == "" {
+		run.skipLink(linkPath, reasonLinkTargetUnknown)
+		return nil
+	}
+	target, ok := run.names.linkTarget(relPath, path, entry.LinkName, run.preserved)
+	if !ok {
+		repos.Logger.
+			WithFields(logging.Fields{
+				"remote-path": linkPath,
+				"link-name":   entry.LinkName,
+			}).
+			Warn("security warning: skipped link with unsafe target")
+		run.skipLink(linkPath, reasonLinkTargetUnsafe)
+		return nil
+	}
+
+	if checksOverwrite(run.ifExists) {
+		var skipped *entities.SkippedTransfer
+		var err error
+		path, skipped, err = d.resolveLocalPath(ctx, repos, run, linkPath, entry.SizeInBytes, path)
+		if err != nil {
+			return err
+		}
+		if skipped != nil {
+			run.skip(skipped)
+			return nil
+		}
+	}
+
+	if err := repos.FileStore.CreateSymlink(path, target); err != nil {
+		repos.Logger.WithField("path", path).WithError(err).Error("failed to create symbolic link")
+		return ftperrors.NewInternalError("failed to create symbolic link", nil)
+	}
+	run.preserved[filepath.Clean(path)] = true
+
+	run.ok(linkPath)
+	return nil
+}
+
+// skipThroughLink method skips the entry if its local path is, or goes through, a symbolic link created
+// by the download. The server may list an entry under the name of a link preserved earlier, and saving it
+// would follow the link instead of staying within the download directory.
+func (d *Download) skipThroughLink(logger logging.Logger, run *downloadRun, remotePath, path string) bool {
+	if !run.throughLink(path) {
+		return false
+	}
+
+	logger.
+		WithFields(logging.Fields{
+			"remote-path": remotePath,
+			"path":        path,
+		}).
+		Warn("security warning: skipped entry that would be saved through a symbolic link")
+	run.skip(&entities.SkippedTransfer{Path: remotePath, Reason: reasonThroughLink})
+	return true
+}
+
+// localPath method joins the slash separated path, relative to the download directory, onto the
+// directory after validating each of its names, reporting false if the entry is skipped.
+func (d *Download) localPath(
+	logger logging.Logger,
+	run *downloadRun,
+	remotePath, dirPath, relPath string,
+) (string, bool, error) {
+	localPath := dirPath
+	for _, name := range strings.Split(relPath, "/") {
+		localName, ok, err := d.localName(logger, run, remotePath, name)
+		if err != nil || !ok {
+			return "", ok, err
+		}
+		localPath = filepath.Join(localPath, localName)
+	}
+	return localPath, true, nil
+}
+
+// localName method validates the name of a remote entry, which is listed by the server and cannot be
+// trusted, before it is joined onto a local path. Names that would escape the download directory are
+// never saved, while the unsafe name policy applies to names that are illegal or reserved locally.
+// Rejected names are logged as security warnings and reported false, unless the download is aborted.
+func (d *Download) localName(
+	logger logging.Logger,
+	run *downloadRun,
+	remotePath, name string,
+) (string, bool, error) {
+	escapes, reason := run.names.check(name)
+	if reason == "" {
+		return name, true, nil
+	}
+
+	logger = logger.WithFields(logging.Fields{
+		"remote-path": remotePath,
+		"reason":      reason,
+	})
+	switch {
+	case run.unsafeNames == entities.UnsafeNamePolicyFail:
+		logger.Warn("security warning: unsafe entry name aborted download")
+		return "", false, ftperrors.NewInternalError(
+			fmt.Sprintf("name of remote entry %s %s", remotePath, reason),
+			nil,
+		)
+	case run.unsafeNames == entities.UnsafeNamePolicyRename && !escapes:
+		localName := run.names.sanitize(name)
+		logger.WithField("local-name", localName).Warn("security warning: unsafe entry name was sanitized")
+		return localName, true, nil
+	default:
+		logger.Warn("security warning: skipped entry with unsafe name")
+		return "", false, nil
+	}
+}
+
+// resolveLocalPath method applies the overwrite policy to the local file, returning either the path to
+// save the file to or the reason the download is skipped.
+func (d *Download) resolveLocalPath(
+	ctx context.Context,
+	repos *DownloadRepos,
+	run *downloadRun,
+	remotePath string,
+	sizeInBytes uint64,
+	path string,
+) (string, *entities.SkippedTransfer, error) {
+	existing, err := localEntry(repos.Logger, repos.FileStore, path)
+	if err != nil || existing == nil {
+		return path, nil, err
+	}
+
+	source := &entities.Entry{SizeInBytes: sizeInBytes}
+	if run.ifExists == entities.OverwritePolicyNewer {
+		remote, statErr := remoteEntry(ctx, repos.Logger, repos.Connection, remotePath)
+		if statErr != nil {
+			return "", nil, statErr
+		}
+		if remote != nil {
+			source = remote
+		}
+	}
+
+	decision, reason, err := resolveOverwrite(run.ifExists, path, source, existing)
+	if err != nil {
+		return "", nil, err
+	}
+
+	switch decision {
+	case overwriteDecisionSkip:
+		return "", &entities.SkippedTransfer{Path: path, Reason: reason}, nil
+	case overwriteDecisionRename:
+		statLocal := func(localPath string) (*entities.Entry, error) {
+			return localEntry(repos.Logger, repos.FileStore, localPath)
+		}
+		localPath, renameErr := freePath(path, filepath.Ext(path), statLocal)
+		if renameErr != nil {
+			return "", nil, renameErr
+		}
+		repos.Logger.
+			WithField("path", path).
+			WithField("new-path", localPath).
+			Info("local file already exists, saving under a new name")
+		return localPath, nil, nil
+	default:
+		return path, nil, nil
+	}
+}
@@ -6,8 +6,10 @@ import (
 	"fmt"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 
 	"github.com/alexZaicev/go-ftp-client/internal/domain/connection"
@@ -17,6 +19,7 @@ import (
 	"github.com/alexZaicev/go-ftp-client/internal/usecases/ftp"
 	connectionMocks "github.com/alexZaicev/go-ftp-client/mocks/domain/connection"
 	repositoryMocks "github.com/alexZaicev/go-ftp-client/mocks/domain/repositories"
+	useCaseMocks "github.com/alexZaicev/go-ftp-client/mocks/usecases/ftp"
 )
 
 func Test_Download_Execute_File_Success(t *testing.T) {
@@ -35,7 +38,7 @@ func Test_Download_Execute_File_Success(t *testing.T) {
 		Return(sizeInBytes, nil).
 		Once()
 	connMock.
-		On("Download", ctx, remotePathNoDir).
+		On("Download", ctx, &connection.DownloadOptions{Path: remotePathNoDir}).
 		Return(fileContent, nil).
 		Once()
 
@@ -59,10 +62,15 @@ func Test_Download_Execute_File_Success(t *testing.T) {
 	useCase := &ftp.Download{}
 
 	// act
-	err := useCase.Execute(ctx, useCaseRepos, useCaseInput)
+	output, err := useCase.Execute(ctx, useCaseRepos, useCaseInput)
 
 	// assert
 	assert.NoError(t, err)
+	assert.Equal(t, &ftp.DownloadOutput{
+		Results: []*entities.TransferResult{
+			{Path: remotePathNoDir, Status: entities.TransferStatusOK},
+		},
+	}, output)
 }
 
 func Test_Download_Execute_Directory_Success(t *testing.T) {
@@ -81,12 +89,14 @@ func Test_Download_Execute_Directory_Success(t *testing.T) {
 			Path:    remoteDirPath,
 			ShowAll: true,
 		}).
-		Return([]*entities.Entry{
-			rootDir1,
-			rootDir2,
-			newEntry(t, entities.EntryTypeFile, "file-1", sizeInBytes, "2022-01-12 16:23"),
-			newEntry(t, entities.EntryTypeLink, "link-1", sizeInBytes, "2022-01-12 16:23"),
-			newEntry(t, entities.EntryTypeDir, "dir-1", sizeInBytes, "2022-01-12 16:23"),
+		Return(&connection.ListResult{
+			Entries: []*entities.Entry{
+				rootDir1,
+				rootDir2,
+				newEntry(t, entities.EntryTypeFile, "file-1", sizeInBytes, "2022-01-12 16:23"),
+				newEntry(t, entities.EntryTypeLink, "link-1", sizeInBytes, "2022-01-12 16:23"),
+				newEntry(t, entities.EntryTypeDir, "dir-1", sizeInBytes, "2022-01-12 16:23"),
+			},
 		}, nil).
 		Once()
 	connMock.
@@ -94,10 +104,12 @@ func Test_Download_Execute_Directory_Success(t *testing.T) {
 			Path:    filepath.Join(remoteDirPath, "dir-1"),
 			ShowAll: true,
 		}).
-		Return([]*entities.Entry{
-			rootDir1,
-			rootDir2,
-			newEntry(t, entities.EntryTypeFile, "file-2", sizeInBytes, "2022-01-12 16:23"),
+		Return(&connection.ListResult{
+			Entries: []*entities.Entry{
+				rootDir1,
+				rootDir2,
+				newEntry(t, entities.EntryTypeFile, "file-2", sizeInBytes, "2022-01-12 16:23"),
+			},
 		}, nil).
 		Once()
 
@@ -110,11 +122,11 @@ func Test_Download_Execute_Directory_Success(t *testing.T) {
 		Return(sizeInBytes, nil).
 		Once()
 	connMock.
-		On("Download", ctx, filepath.Join(remoteDirPath, "file-1")).
+		On("Download", ctx, &connection.DownloadOptions{Path: filepath.Join(remoteDirPath, "file-1")}).
 		Return(fileContent, nil).
 		Once()
 	connMock.
-		On("Download", ctx, filepath.Join(remoteDirPath, "dir-1", "file-2")).
+		On("Download", ctx, &connection.DownloadOptions{Path: filepath.Join(remoteDirPath, "dir-1", "file-2")}).
 		Return(fileContent, nil).
 		Once()
 
@@ -150,10 +162,203 @@ func Test_Download_Execute_Directory_Success(t *testing.T) {
 	useCase := &ftp.Download{}
 
 	// act
-	err := useCase.Execute(ctx, useCaseRepos, useCaseInput)
+	output, err := useCase.Execute(ctx, useCaseRepos, useCaseInput)
 
 	// assert
 	assert.NoError(t, err)
+	assert.Equal(t, &ftp.DownloadOutput{
+		Skipped: []*entities.SkippedTransfer{
+			{Path: remoteDirPath + "/link-1", Reason: "symbolic link"},
+		},
+		Results: []*entities.TransferResult{
+			{Path: remoteDirPath + "/file-1", Status: entities.TransferStatusOK},
+			{Path: remoteDirPath + "/link-1", Status: entities.TransferStatusSkipped, Reason: "symbolic link"},
+			{Path: remoteDirPath + "/dir-1/file-2", Status: entities.TransferStatusOK},
+		},
+	}, output)
+}
+
+//nolint:funlen // test case can get a bit large
+func Test_Download_Execute_Directory_Filter_Success(t *testing.T) {
+	// arrange
+	ctx := context.Background()
+
+	logger := assertlogging.NewLogger(t)
+
+	connMock := connectionMocks.NewConnection(t)
+	connMock.
+		On("IsDir", ctx, remoteDirPath).
+		Return(true, nil).
+		Once()
+	connMock.
+		On("List", ctx, &connection.ListOptions{
+			Path:    remoteDirPath,
+			ShowAll: true,
+		}).
+		Return(&connection.ListResult{
+			Entries: []*entities.Entry{
+				rootDir1,
+				rootDir2,
+				newEntry(t, entities.EntryTypeFile, "file-1.txt", sizeInBytes, "2022-01-12 16:23"),
+				newEntry(t, entities.EntryTypeFile, "file-2.tmp", sizeInBytes, "2022-01-12 16:23"),
+				newEntry(t, entities.EntryTypeFile, "small.txt", 10, "2022-01-12 16:23"),
+				newEntry(t, entities.EntryTypeFile, "old.txt", sizeInBytes, "2020-01-12 16:23"),
+				newEntry(t, entities.EntryTypeDir, "node_modules", sizeInBytes, "2022-01-12 16:23"),
+				newEntry(t, entities.EntryTypeDir, "dir-1", sizeInBytes, "2022-01-12 16:23"),
+			},
+		}, nil).
+		Once()
+	connMock.
+		On("List", ctx, &connection.ListOptions{
+			Path:    filepath.Join(remoteDirPath, "dir-1"),
+			ShowAll: true,
+		}).
+		Return(&connection.ListResult{
+			Entries: []*entities.Entry{
+				rootDir1,
+				rootDir2,
+				newEntry(t, entities.EntryTypeFile, "file-2.txt", sizeInBytes, "2022-01-12 16:23"),
+				newEntry(t, entities.EntryTypeFile, "file-3.txt", sizeInBytes, "2022-01-12 16:23"),
+			},
+		}, nil).
+		Once()
+
+	connMock.
+		On("Size", filepath.Join(remoteDirPath, "file-1.txt")).
+		Return(sizeInBytes, nil).
+		Once()
+	connMock.
+		On("Size", filepath.Join(remoteDirPath, "dir-1", "file-3.txt")).
+		Return(sizeInBytes, nil).
+		Once()
+	connMock.
+		On("Download", ctx, &connection.DownloadOptions{Path: filepath.Join(remoteDirPath, "file-1.txt")}).
+		Return(fileContent, nil).
+		Once()
+	connMock.
+		On("Download", ctx, &connection.DownloadOptions{Path: filepath.Join(remoteDirPath, "dir-1", "file-3.txt")}).
+		Return(fileContent, nil).
+		Once()
+
+	fileStoreMock := repositoryMocks.NewFileStore(t)
+	fileStoreMock.
+		On("CreateDir", dirPath).
+		Return(nil).
+		Once()
+	fileStoreMock.
+		On("CreateDir", filepath.Join(dirPath, "dir-1")).
+		Return(nil).
+		Once()
+	fileStoreMock.
+		On("SaveFile", filepath.Join(dirPath, "file-1.txt"), fileContent).
+		Return(nil).
+		Once()
+	fileStoreMock.
+		On("SaveFile", filepath.Join(dirPath, "dir-1", "file-3.txt"), fileContent).
+		Return(nil).
+		Once()
+
+	useCaseRepos := &ftp.DownloadRepos{
+		Logger:     logger,
+		Connection: connMock,
+		FileStore:  fileStoreMock,
+	}
+
+	useCaseInput := &ftp.DownloadInput{
+		RemotePath: remoteDirPath,
+		Path:       dirPath,
+		Filter: &entities.FilterOptions{
+			Exclude:        []string{"*.tmp", "node_modules/", "dir-1/file-2.txt"},
+			MinSizeInBytes: 100,
+			ModifiedAfter:  time.Date(2021, time.January, 1, 0, 0, 0, 0, time.UTC),
+		},
+	}
+
+	useCase := &ftp.Download{}
+
+	// act
+	output, err := useCase.Execute(ctx, useCaseRepos, useCaseInput)
+
+	// assert
+	assert.NoError(t, err)
+	assert.Equal(t, &ftp.DownloadOutput{
+		Results: []*entities.TransferResult{
+			{Path: remoteDirPath + "/file-1.txt", Status: entities.TransferStatusOK},
+			{Path: remoteDirPath + "/dir-1/file-3.txt", Status: entities.TransferStatusOK},
+		},
+	}, output)
+}
+
+func Test_Download_Execute_Directory_Filter_RecentEntries_Success(t *testing.T) {
+	// arrange
+	ctx := context.Background()
+
+	logger := assertlogging.NewLogger(t)
+
+	connMock := connectionMocks.NewConnection(t)
+	connMock.
+		On("IsDir", ctx, remoteDirPath).
+		Return(true, nil).
+		Once()
+	connMock.
+		On("List", ctx, &connection.ListOptions{
+			Path:    remoteDirPath,
+			ShowAll: true,
+		}).
+		Return(&connection.ListResult{
+			Entries: []*entities.Entry{
+				rootDir1,
+				rootDir2,
+				newRecentEntry(entities.EntryTypeFile, "file-1.txt", sizeInBytes, time.Hour),
+				newRecentEntry(entities.EntryTypeFile, "file-2.txt", sizeInBytes, 30*24*time.Hour),
+			},
+		}, nil).
+		Once()
+	connMock.
+		On("Size", filepath.Join(remoteDirPath, "file-1.txt")).
+		Return(sizeInBytes, nil).
+		Once()
+	connMock.
+		On("Download", ctx, &connection.DownloadOptions{Path: filepath.Join(remoteDirPath, "file-1.txt")}).
+		Return(fileContent, nil).
+		Once()
+
+	fileStoreMock := repositoryMocks.NewFileStore(t)
+	fileStoreMock.
+		On("CreateDir", dirPath).
+		Return(nil).
+		Once()
+	fileStoreMock.
+		On("SaveFile", filepath.Join(dirPath, "file-1.txt"), fileContent).
+		Return(nil).
+		Once()
+
+	useCaseRepos := &ftp.DownloadRepos{
+		Logger:     logger,
+		Connection: connMock,
+		FileStore:  fileStoreMock,
+	}
+
+	useCaseInput := &ftp.DownloadInput{
+		RemotePath: remoteDirPath,
+		Path:       dirPath,
+		Filter: &entities.FilterOptions{
+			ModifiedAfter: time.Now().Add(-7 * 24 * time.Hour),
+		},
+	}
+
+	useCase := &ftp.Download{}
+
+	// act
+	output, err := useCase.Execute(ctx, useCaseRepos, useCaseInput)
+
+	// assert
+	assert.NoError(t, err)
+	assert.Equal(t, &ftp.DownloadOutput{
+		Results: []*entities.TransferResult{
+			{Path: remoteDirPath + "/file-1.txt", Status: entities.TransferStatusOK},
+		},
+	}, output)
 }
 
 func Test_Download_Execute_IsDirError(t *testing.T) {
@@ -188,9 +393,10 @@ func Test_Download_Execute_IsDirError(t *testing.T) {
 	useCase := &ftp.Download{}
 
 	// act
-	err := useCase.Execute(ctx, useCaseRepos, useCaseInput)
+	output, err := useCase.Execute(ctx, useCaseRepos, useCaseInput)
 
 	// assert
+	assert.Nil(t, output)
 	require.EqualError(t, err, "an internal error occurred: failed to check if entry is a directory")
 	assert.IsType(t, ftperrors.InternalErrorType, err)
 	assert.NoError(t, errors.Unwrap(err))
@@ -232,9 +438,10 @@ func Test_Download_Execute_SizeError(t *testing.T) {
 	useCase := &ftp.Download{}
 
 	// act
-	err := useCase.Execute(ctx, useCaseRepos, useCaseInput)
+	output, err := useCase.Execute(ctx, useCaseRepos, useCaseInput)
 
 	// assert
+	assert.Nil(t, output)
 	require.EqualError(t, err, "an internal error occurred: failed to retrieve file size")
 	assert.IsType(t, ftperrors.InternalErrorType, err)
 	assert.NoError(t, errors.Unwrap(err))
@@ -260,7 +467,7 @@ func Test_Download_Execute_DownloadError(t *testing.T) {
 		Return(sizeInBytes, nil).
 		Once()
 	connMock.
-		On("Download", ctx, remotePathNoDir).
+		On("Download", ctx, &connection.DownloadOptions{Path: remotePathNoDir}).
 		Return(fileContent, errors.New("mock error")).
 		Once()
 
@@ -280,9 +487,10 @@ func Test_Download_Execute_DownloadError(t *testing.T) {
 	useCase := &ftp.Download{}
 
 	// act
-	err := useCase.Execute(ctx, useCaseRepos, useCaseInput)
+	output, err := useCase.Execute(ctx, useCaseRepos, useCaseInput)
 
 	// assert
+	assert.Nil(t, output)
 	require.EqualError(t, err, "an internal error occurred: failed to download file")
 	assert.IsType(t, ftperrors.InternalErrorType, err)
 	assert.NoError(t, errors.Unwrap(err))
@@ -311,7 +519,7 @@ func Test_Download_Execute_SizeMismatchError(t *testing.T) {
 		Return(uint64(1024), nil).
 		Once()
 	connMock.
-		On("Download", ctx, remotePathNoDir).
+		On("Download", ctx, &connection.DownloadOptions{Path: remotePathNoDir}).
 		Return(fileContent, nil).
 		Once()
 
@@ -331,9 +539,10 @@ func Test_Download_Execute_SizeMismatchError(t *testing.T) {
 	useCase := &ftp.Download{}
 
 	// act
-	err := useCase.Execute(ctx, useCaseRepos, useCaseInput)
+	output, err := useCase.Execute(ctx, useCaseRepos, useCaseInput)
 
 	// assert
+	assert.Nil(t, output)
 	require.EqualError(
 		t,
 		err,
@@ -366,7 +575,7 @@ func Test_Download_Execute_SaveError(t *testing.T) {
 		Return(sizeInBytes, nil).
 		Once()
 	connMock.
-		On("Download", ctx, remotePathNoDir).
+		On("Download", ctx, &connection.DownloadOptions{Path: remotePathNoDir}).
 		Return(fileContent, nil).
 		Once()
 
@@ -390,9 +599,10 @@ func Test_Download_Execute_SaveError(t *testing.T) {
 	useCase := &ftp.Download{}
 
 	// act
-	err := useCase.Execute(ctx, useCaseRepos, useCaseInput)
+	output, err := useCase.Execute(ctx, useCaseRepos, useCaseInput)
 
 	// assert
+	assert.Nil(t, output)
 	require.EqualError(t, err, "an internal error occurred: failed to save file")
 	assert.IsType(t, ftperrors.InternalErrorType, err)
 	assert.NoError(t, errors.Unwrap(err))
@@ -434,9 +644,10 @@ func Test_Download_Execute_CreateDirError(t *testing.T) {
 	useCase := &ftp.Download{}
 
 	// act
-	err := useCase.Execute(ctx, useCaseRepos, useCaseInput)
+	output, err := useCase.Execute(ctx, useCaseRepos, useCaseInput)
 
 	// assert
+	assert.Nil(t, output)
 	require.EqualError(t, err, "an internal error occurred: failed to create directory")
 	assert.IsType(t, ftperrors.InternalErrorType, err)
 	assert.NoError(t, errors.Unwrap(err))
@@ -462,12 +673,14 @@ func Test_Download_Execute_ListError(t *testing.T) {
 			Path:    remoteDirPath,
 			ShowAll: true,
 		}).
-		Return([]*entities.Entry{
-			rootDir1,
-			rootDir2,
-			newEntry(t, entities.EntryTypeFile, "file-1", sizeInBytes, "2022-01-12 16:23"),
-			newEntry(t, entities.EntryTypeLink, "link-1", sizeInBytes, "2022-01-12 16:23"),
-			newEntry(t, entities.EntryTypeDir, "dir-1", sizeInBytes, "2022-01-12 16:23"),
+		Return(&connection.ListResult{
+			Entries: []*entities.Entry{
+				rootDir1,
+				rootDir2,
+				newEntry(t, entities.EntryTypeFile, "file-1", sizeInBytes, "2022-01-12 16:23"),
+				newEntry(t, entities.EntryTypeLink, "link-1", sizeInBytes, "2022-01-12 16:23"),
+				newEntry(t, entities.EntryTypeDir, "dir-1", sizeInBytes, "2022-01-12 16:23"),
+			},
 		}, nil).
 		Once()
 	connMock.
@@ -483,7 +696,7 @@ func Test_Download_Execute_ListError(t *testing.T) {
 		Return(sizeInBytes, nil).
 		Once()
 	connMock.
-		On("Download", ctx, filepath.Join(remoteDirPath, "file-1")).
+		On("Download", ctx, &connection.DownloadOptions{Path: filepath.Join(remoteDirPath, "file-1")}).
 		Return(fileContent, nil).
 		Once()
 
@@ -515,23 +728,29 @@ func Test_Download_Execute_ListError(t *testing.T) {
 	useCase := &ftp.Download{}
 
 	// act
-	err := useCase.Execute(ctx, useCaseRepos, useCaseInput)
+	output, err := useCase.Execute(ctx, useCaseRepos, useCaseInput)
 
 	// assert
+	assert.Nil(t, output)
 	require.EqualError(t, err, "an internal error occurred: failed to list directory")
 	assert.IsType(t, ftperrors.InternalErrorType, err)
 	assert.NoError(t, errors.Unwrap(err))
 }
 
-func Test_Download_Execute_Directory_SizeError(t *testing.T) {
+//nolint:funlen // test case can get a bit large
+func Test_Download_Execute_ContinueOnError_Success(t *testing.T) {
 	// arrange
 	ctx := context.Background()
 
 	logger := assertlogging.NewLogger(t)
 	logger.
-		ExpectError("failed to retrieve file size").
+		ExpectError("failed to download file").
 		WithError(assertlogging.EqualError("mock error")).
-		WithField("remote-path", assertlogging.Equal(filepath.Join(remoteDirPath, "dir-1", "file-2")))
+		WithField("remote-path", assertlogging.Equal(filepath.Join(remoteDirPath, "file-1")))
+	logger.
+		ExpectError("failed to list directory").
+		WithError(assertlogging.EqualError("mock error")).
+		WithField("remote-path", assertlogging.Equal(filepath.Join(remoteDirPath, "dir-1")))
 
 	connMock := connectionMocks.NewConnection(t)
 	connMock.
@@ -543,12 +762,12 @@ func Test_Download_Execute_Directory_SizeError(t *testing.T) {
 			Path:    remoteDirPath,
 			ShowAll: true,
 		}).
-		Return([]*entities.Entry{
-			rootDir1,
-			rootDir2,
-			newEntry(t, entities.EntryTypeFile, "file-1", sizeInBytes, "2022-01-12 16:23"),
-			newEntry(t, entities.EntryTypeLink, "link-1", sizeInBytes, "2022-01-12 16:23"),
-			newEntry(t, entities.EntryTypeDir, "dir-1", sizeInBytes, "2022-01-12 16:23"),
+		Return(&connection.ListResult{
+			Entries: []*entities.Entry{
+				newEntry(t, entities.EntryTypeFile, "file-1", sizeInBytes, "2022-01-12 16:23"),
+				newEntry(t, entities.EntryTypeDir, "dir-1", sizeInBytes, "2022-01-12 16:23"),
+				newEntry(t, entities.EntryTypeFile, "file-2", sizeInBytes, "2022-01-12 16:23"),
+			},
 		}, nil).
 		Once()
 	connMock.
@@ -556,23 +775,22 @@ func Test_Download_Execute_Directory_SizeError(t *testing.T) {
 			Path:    filepath.Join(remoteDirPath, "dir-1"),
 			ShowAll: true,
 		}).
-		Return([]*entities.Entry{
-			rootDir1,
-			rootDir2,
-			newEntry(t, entities.EntryTypeFile, "file-2", sizeInBytes, "2022-01-12 16:23"),
-		}, nil).
+		Return(nil, errors.New("mock error")).
 		Once()
-
 	connMock.
 		On("Size", filepath.Join(remoteDirPath, "file-1")).
 		Return(sizeInBytes, nil).
 		Once()
 	connMock.
-		On("Size", filepath.Join(remoteDirPath, "dir-1", "file-2")).
-		Return(uint64(0), errors.New("mock error")).
+		On("Download", ctx, &connection.DownloadOptions{Path: filepath.Join(remoteDirPath, "file-1")}).
+		Return(nil, errors.New("mock error")).
+		Once()
+	connMock.
+		On("Size", filepath.Join(remoteDirPath, "file-2")).
+		Return(sizeInBytes, nil).
 		Once()
 	connMock.
-		On("Download", ctx, filepath.Join(remoteDirPath, "file-1")).
+		On("Download", ctx, &connection.DownloadOptions{Path: filepath.Join(remoteDirPath, "file-2")}).
 		Return(fileContent, nil).
 		Once()
 
@@ -586,7 +804,7 @@ func Test_Download_Execute_Directory_SizeError(t *testing.T) {
 		Return(nil).
 		Once()
 	fileStoreMock.
-		On("SaveFile", filepath.Join(dirPath, "file-1"), fileContent).
+		On("SaveFile", filepath.Join(dirPath, "file-2"), fileContent).
 		Return(nil).
 		Once()
 
@@ -597,26 +815,166 @@ func Test_Download_Execute_Directory_SizeError(t *testing.T) {
 	}
 
 	useCaseInput := &ftp.DownloadInput{
-		RemotePath: remoteDirPath,
-		Path:       dirPath,
+		RemotePath:      remoteDirPath,
+		Path:            dirPath,
+		ContinueOnError: true,
 	}
 
 	useCase := &ftp.Download{}
 
 	// act
-	err := useCase.Execute(ctx, useCaseRepos, useCaseInput)
+	output, err := useCase.Execute(ctx, useCaseRepos, useCaseInput)
 
 	// assert
-	require.EqualError(t, err, "an internal error occurred: failed to retrieve file size")
-	assert.IsType(t, ftperrors.InternalErrorType, err)
-	assert.NoError(t, errors.Unwrap(err))
+	require.NoError(t, err)
+	require.Len(t, output.Results, 3)
+	for idx, expected := range []struct {
+		path   string
+		reason string
+	}{
+		{path: remoteDirPath + "/file-1", reason: "an internal error occurred: failed to download file"},
+		{path: remoteDirPath + "/dir-1", reason: "an internal error occurred: failed to list directory"},
+	} {
+		assert.Equal(t, expected.path, output.Results[idx].Path)
+		assert.Equal(t, entities.TransferStatusFailed, output.Results[idx].Status)
+		assert.Equal(t, expected.reason, output.Results[idx].Reason)
+		assert.IsType(t, ftperrors.InternalErrorType, output.Results[idx].Err)
+	}
+	assert.Equal(t, &entities.TransferResult{
+		Path:   remoteDirPath + "/file-2",
+		Status: entities.TransferStatusOK,
+	}, output.Results[2])
 }
 
-func Test_Download_Execute_UnknownError(t *testing.T) {
+func Test_Download_Execute_Observer_Success(t *testing.T) {
+	// arrange
+	ctx := context.Background()
+
+	logger := assertlogging.NewLogger(t)
+	logger.
+		ExpectError("failed to download file").
+		WithError(assertlogging.EqualError("mock error")).
+		WithField("remote-path", assertlogging.Equal(filepath.Join(remoteDirPath, "file-1")))
+
+	file1Path := filepath.Join(remoteDirPath, "file-1")
+	file2Path := filepath.Join(remoteDirPath, "file-2")
+	matchEvent := func(path string, transferredBytes uint64) interface{} {
+		return mock.MatchedBy(func(event ftp.TransferEvent) bool {
+			return event.Path == path &&
+				event.SizeInBytes == sizeInBytes &&
+				event.TransferredBytes == transferredBytes &&
+				!event.StartedAt.IsZero()
+		})
+	}
+
+	observerMock := useCaseMocks.NewTransferObserver(t)
+	observerMock.
+		On("DirListed", remoteDirPath).
+		Once()
+	observerMock.
+		On("FileStarted", matchEvent(file1Path, 0)).
+		Once()
+	observerMock.
+		On("FileFailed", matchEvent(file1Path, 0), mock.MatchedBy(func(err error) bool {
+			return err != nil && err.Error() == "an internal error occurred: failed to download file"
+		})).
+		Once()
+	observerMock.
+		On("FileStarted", matchEvent(file2Path, 0)).
+		Once()
+	observerMock.
+		On("BytesTransferred", matchEvent(file2Path, sizeInBytes), int(sizeInBytes)).
+		Once()
+	observerMock.
+		On("FileCompleted", matchEvent(file2Path, sizeInBytes)).
+		Once()
+
+	connMock := connectionMocks.NewConnection(t)
+	connMock.
+		On("IsDir", ctx, remoteDirPath).
+		Return(true, nil).
+		Once()
+	connMock.
+		On("List", ctx, &connection.ListOptions{
+			Path:    remoteDirPath,
+			ShowAll: true,
+		}).
+		Return(&connection.ListResult{
+			Entries: []*entities.Entry{
+				newEntry(t, entities.EntryTypeFile, "file-1", sizeInBytes, "2022-01-12 16:23"),
+				newEntry(t, entities.EntryTypeFile, "file-2", sizeInBytes, "2022-01-12 16:23"),
+			},
+		}, nil).
+		Once()
+	connMock.
+		On("Size", file1Path).
+		Return(sizeInBytes, nil).
+		Once()
+	connMock.
+		On("Download", ctx, mock.MatchedBy(func(options *connection.DownloadOptions) bool {
+			return options.Path == file1Path && options.ProgressWriter != nil
+		})).
+		Return(nil, errors.New("mock error")).
+		Once()
+	connMock.
+		On("Size", file2Path).
+		Return(sizeInBytes, nil).
+		Once()
+	connMock.
+		On("Download", ctx, mock.MatchedBy(func(options *connection.DownloadOptions) bool {
+			return options.Path == file2Path && options.ProgressWriter != nil
+		})).
+		Run(func(args mock.Arguments) {
+			_, writeErr := args.Get(1).(*connection.DownloadOptions).ProgressWriter.Write(fileContent)
+			require.NoError(t, writeErr)
+		}).
+		Return(fileContent, nil).
+		Once()
+
+	fileStoreMock := repositoryMocks.NewFileStore(t)
+	fileStoreMock.
+		On("CreateDir", dirPath).
+		Return(nil).
+		Once()
+	fileStoreMock.
+		On("SaveFile", filepath.Join(dirPath, "file-2"), fileContent).
+		Return(nil).
+		Once()
+
+	useCaseRepos := &ftp.DownloadRepos{
+		Logger:     logger,
+		Connection: connMock,
+		FileStore:  fileStoreMock,
+		Observer:   observerMock,
+	}
+
+	useCaseInput := &ftp.DownloadInput{
+		RemotePath:      remoteDirPath,
+		Path:            dirPath,
+		ContinueOnError: true,
+	}
+
+	useCase := &ftp.Download{}
+
+	// act
+	output, err := useCase.Execute(ctx, useCaseRepos, useCaseInput)
+
+	// assert
+	require.NoError(t, err)
+	require.Len(t, output.Results, 2)
+	assert.Equal(t, entities.TransferStatusFailed, output.Results[0].Status)
+	assert.Equal(t, entities.TransferStatusOK, output.Results[1].Status)
+}
+
+func Test_Download_Execute_Directory_SizeError(t *testing.T) {
 	// arrange
 	ctx := context.Background()
 
 	logger := assertlogging.NewLogger(t)
+	logger.
+		ExpectError("failed to retrieve file size").
+		WithError(assertlogging.EqualError("mock error")).
+		WithField("remote-path", assertlogging.Equal(filepath.Join(remoteDirPath, "dir-1", "file-2")))
 
 	connMock := connectionMocks.NewConnection(t)
 	connMock.
@@ -628,12 +986,14 @@ func Test_Download_Execute_UnknownError(t *testing.T) {
 			Path:    remoteDirPath,
 			ShowAll: true,
 		}).
-		Return([]*entities.Entry{
-			rootDir1,
-			rootDir2,
-			newEntry(t, entities.EntryTypeFile, "file-1", sizeInBytes, "2022-01-12 16:23"),
-			newEntry(t, entities.EntryTypeLink, "link-1", sizeInBytes, "2022-01-12 16:23"),
-			newEntry(t, entities.EntryTypeDir, "dir-1", sizeInBytes, "2022-01-12 16:23"),
+		Return(&connection.ListResult{
+			Entries: []*entities.Entry{
+				rootDir1,
+				rootDir2,
+				newEntry(t, entities.EntryTypeFile, "file-1", sizeInBytes, "2022-01-12 16:23"),
+				newEntry(t, entities.EntryTypeLink, "link-1", sizeInBytes, "2022-01-12 16:23"),
+				newEntry(t, entities.EntryTypeDir, "dir-1", sizeInBytes, "2022-01-12 16:23"),
+			},
 		}, nil).
 		Once()
 	connMock.
@@ -641,10 +1001,12 @@ func Test_Download_Execute_UnknownError(t *testing.T) {
 			Path:    filepath.Join(remoteDirPath, "dir-1"),
 			ShowAll: true,
 		}).
-		Return([]*entities.Entry{
-			rootDir1,
-			rootDir2,
-			newEntry(t, entities.EntryType(0), "file-2", sizeInBytes, "2022-01-12 16:23"),
+		Return(&connection.ListResult{
+			Entries: []*entities.Entry{
+				rootDir1,
+				rootDir2,
+				newEntry(t, entities.EntryTypeFile, "file-2", sizeInBytes, "2022-01-12 16:23"),
+			},
 		}, nil).
 		Once()
 
@@ -653,7 +1015,11 @@ func Test_Download_Execute_UnknownError(t *testing.T) {
 		Return(sizeInBytes, nil).
 		Once()
 	connMock.
-		On("Download", ctx, filepath.Join(remoteDirPath, "file-1")).
+		On("Size", filepath.Join(remoteDirPath, "dir-1", "file-2")).
+		Return(uint64(0), errors.New("mock error")).
+		Once()
+	connMock.
+		On("Download", ctx, &connection.DownloadOptions{Path: filepath.Join(remoteDirPath, "file-1")}).
 		Return(fileContent, nil).
 		Once()
 
@@ -685,10 +1051,901 @@ func Test_Download_Execute_UnknownError(t *testing.T) {
 	useCase := &ftp.Download{}
 
 	// act
-	err := useCase.Execute(ctx, useCaseRepos, useCaseInput)
+	output, err := useCase.Execute(ctx, useCaseRepos, useCaseInput)
 
 	// assert
-	require.EqualError(t, err, "an unknown error occurred: unexpected entry type: 0")
-	assert.IsType(t, ftperrors.UnknownErrorType, err)
+	assert.Nil(t, output)
+	require.EqualError(t, err, "an internal error occurred: failed to retrieve file size")
+	assert.IsType(t, ftperrors.InternalErrorType, err)
 	assert.NoError(t, errors.Unwrap(err))
 }
+
+func Test_Download_Execute_UnknownError(t *testing.T) {
+	// arrange
+	ctx := context.Background()
+
+	logger := assertlogging.NewLogger(t)
+
+	connMock := connectionMocks.NewConnection(t)
+	connMock.
+		On("IsDir", ctx, remoteDirPath).
+		Return(true, nil).
+		Once()
+	connMock.
+		On("List", ctx, &connection.ListOptions{
+			Path:    remoteDirPath,
+			ShowAll: true,
+		}).
+		Return(&connection.ListResult{
+			Entries: []*entities.Entry{
+				rootDir1,
+				rootDir2,
+				newEntry(t, entities.EntryTypeFile, "file-1", sizeInBytes, "2022-01-12 16:23"),
+				newEntry(t, entities.EntryTypeLink, "link-1", sizeInBytes, "2022-01-12 16:23"),
+				newEntry(t, entities.EntryTypeDir, "dir-1", sizeInBytes, "2022-01-12 16:23"),
+			},
+		}, nil).
+		Once()
+	connMock.
+		On("List", ctx, &connection.ListOptions{
+			Path:    filepath.Join(remoteDirPath, "dir-1"),
+			ShowAll: true,
+		}).
+		Return(&connection.ListResult{
+			Entries: []*entities.Entry{
+				rootDir1,
+				rootDir2,
+				newEntry(t, entities.EntryType(0), "file-2", sizeInBytes, "2022-01-12 16:23"),
+			},
+		}, nil).
+		Once()
+
+	connMock.
+		On("Size", filepath.Join(remoteDirPath, "file-1")).
+		Return(sizeInBytes, nil).
+		Once()
+	connMock.
+		On("Download", ctx, &connection.DownloadOptions{Path: filepath.Join(remoteDirPath, "file-1")}).
+		Return(fileContent, nil).
+		Once()
+
+	fileStoreMock := repositoryMocks.NewFileStore(t)
+	fileStoreMock.
+		On("CreateDir", dirPath).
+		Return(nil).
+		Once()
+	fileStoreMock.
+		On("CreateDir", filepath.Join(dirPath, "dir-1")).
+		Return(nil).
+		Once()
+	fileStoreMock.
+		On("SaveFile", filepath.Join(dirPath, "file-1"), fileContent).
+		Return(nil).
+		Once()
+
+	useCaseRepos := &ftp.DownloadRepos{
+		Logger:     logger,
+		Connection: connMock,
+		FileStore:  fileStoreMock,
+	}
+
+	useCaseInput := &ftp.DownloadInput{
+		RemotePath: remoteDirPath,
+		Path:       dirPath,
+	}
+
+	useCase := &ftp.Download{}
+
+	// act
+	output, err := useCase.Execute(ctx, useCaseRepos, useCaseInput)
+
+	// assert
+	assert.Nil(t, output)
+	require.EqualError(t, err, "an unknown error occurred: unexpected entry type: 0")
+	assert.IsType(t, ftperrors.UnknownErrorType, err)
+	assert.NoError(t, errors.Unwrap(err))
+}
+
+//nolint:funlen // test case can get a bit large
+func Test_Download_Execute_IfExists_Success(t *testing.T) {
+	existingDate := time.Date(2022, time.January, 12, 16, 23, 0, 0, time.UTC)
+	existing := &entities.Entry{
+		Type:                 entities.EntryTypeFile,
+		Name:                 fileName,
+		SizeInBytes:          sizeInBytes,
+		LastModificationDate: existingDate,
+	}
+	renamedPath := fmt.Sprintf("%s/foobarbaz-1.txt", dirPath)
+	recent := &entities.Entry{
+		Type:                 entities.EntryTypeFile,
+		Name:                 fileName,
+		SizeInBytes:          sizeInBytes,
+		LastModificationDate: time.Now().Add(-2 * time.Hour),
+	}
+
+	testCases := []struct {
+		name           string
+		ifExists       entities.OverwritePolicy
+		existing       *entities.Entry
+		remoteDate     time.Time
+		remote         *entities.Entry
+		expectedPath   string
+		expectedOutput *ftp.DownloadOutput
+	}{
+		{
+			name:         "missing file",
+			ifExists:     entities.OverwritePolicyFail,
+			expectedPath: localPathWithDir,
+			expectedOutput: &ftp.DownloadOutput{
+				Results: []*entities.TransferResult{{Path: remotePathWithDir, Status: entities.TransferStatusOK}},
+			},
+		},
+		{
+			name:     "skip",
+			ifExists: entities.OverwritePolicySkip,
+			existing: existing,
+			expectedOutput: &ftp.DownloadOutput{
+				Skipped: []*entities.SkippedTransfer{{Path: localPathWithDir, Reason: "already exists"}},
+				Results: []*entities.TransferResult{
+					{Path: localPathWithDir, Status: entities.TransferStatusSkipped, Reason: "already exists"},
+				},
+			},
+		},
+		{
+			name:       "newer with older remote file",
+			ifExists:   entities.OverwritePolicyNewer,
+			existing:   existing,
+			remoteDate: existingDate.Add(-time.Minute),
+			expectedOutput: &ftp.DownloadOutput{
+				Skipped: []*entities.SkippedTransfer{{Path: localPathWithDir, Reason: "is not older than the source"}},
+				Results: []*entities.TransferResult{
+					{Path: localPathWithDir, Status: entities.TransferStatusSkipped, Reason: "is not older than the source"},
+				},
+			},
+		},
+		{
+			name:         "newer with newer remote file",
+			ifExists:     entities.OverwritePolicyNewer,
+			existing:     existing,
+			remoteDate:   existingDate.Add(time.Minute),
+			expectedPath: localPathWithDir,
+			expectedOutput: &ftp.DownloadOutput{
+				Results: []*entities.TransferResult{{Path: remotePathWithDir, Status: entities.TransferStatusOK}},
+			},
+		},
+		{
+			name:     "newer with older listed remote file",
+			ifExists: entities.OverwritePolicyNewer,
+			existing: recent,
+			remote:   newRecentEntry(entities.EntryTypeFile, fileName, sizeInBytes, 3*time.Hour),
+			expectedOutput: &ftp.DownloadOutput{
+				Skipped: []*entities.SkippedTransfer{{Path: localPathWithDir, Reason: "is not older than the source"}},
+				Results: []*entities.TransferResult{
+					{Path: localPathWithDir, Status: entities.TransferStatusSkipped, Reason: "is not older than the source"},
+				},
+			},
+		},
+		{
+			name:         "newer with newer listed remote file",
+			ifExists:     entities.OverwritePolicyNewer,
+			existing:     recent,
+			remote:       newRecentEntry(entities.EntryTypeFile, fileName, sizeInBytes, time.Hour),
+			expectedPath: localPathWithDir,
+			expectedOutput: &ftp.DownloadOutput{
+				Results: []*entities.TransferResult{{Path: remotePathWithDir, Status: entities.TransferStatusOK}},
+			},
+		},
+		{
+			name:     "newer with older modified remote file",
+			ifExists: entities.OverwritePolicyNewer,
+			existing: recent,
+			remote: &entities.Entry{
+				SizeInBytes:          sizeInBytes,
+				LastModificationDate: time.Now().UTC().Add(-3 * time.Hour),
+			},
+			expectedOutput: &ftp.DownloadOutput{
+				Skipped: []*entities.SkippedTransfer{{Path: localPathWithDir, Reason: "is not older than the source"}},
+				Results: []*entities.TransferResult{
+					{Path: localPathWithDir, Status: entities.TransferStatusSkipped, Reason: "is not older than the source"},
+				},
+			},
+		},
+		{
+			name:     "newer with newer modified remote file",
+			ifExists: entities.OverwritePolicyNewer,
+			existing: recent,
+			remote: &entities.Entry{
+				SizeInBytes:          sizeInBytes,
+				LastModificationDate: time.Now().UTC().Add(-time.Hour),
+			},
+			expectedPath: localPathWithDir,
+			expectedOutput: &ftp.DownloadOutput{
+				Results: []*entities.TransferResult{{Path: remotePathWithDir, Status: entities.TransferStatusOK}},
+			},
+		},
+		{
+			name:     "size differs with same size",
+			ifExists: entities.OverwritePolicySizeDiffers,
+			existing: existing,
+			expectedOutput: &ftp.DownloadOutput{
+				Skipped: []*entities.SkippedTransfer{{Path: localPathWithDir, Reason: "has the same size"}},
+				Results: []*entities.TransferResult{
+					{Path: localPathWithDir, Status: entities.TransferStatusSkipped, Reason: "has the same size"},
+				},
+			},
+		},
+		{
+			name:         "rename",
+			ifExists:     entities.OverwritePolicyRename,
+			existing:     existing,
+			expectedPath: renamedPath,
+			expectedOutput: &ftp.DownloadOutput{
+				Results: []*entities.TransferResult{{Path: remotePathWithDir, Status: entities.TransferStatusOK}},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// arrange
+			ctx := context.Background()
+
+			logger := assertlogging.NewLogger(t)
+
+			connMock := connectionMocks.NewConnection(t)
+			connMock.
+				On("IsDir", ctx, remotePathWithDir).
+				Return(false, nil).
+				Once()
+			connMock.
+				On("Size", remotePathWithDir).
+				Return(sizeInBytes, nil).
+				Once()
+			if tc.ifExists == entities.OverwritePolicyNewer {
+				remote := tc.remote
+				if remote == nil {
+					remote = &entities.Entry{SizeInBytes: sizeInBytes, LastModificationDate: tc.remoteDate}
+				}
+				connMock.
+					On("Stat", ctx, remotePathWithDir).
+					Return(remote, nil).
+					Once()
+			}
+
+			fileStoreMock := repositoryMocks.NewFileStore(t)
+			if tc.existing == nil {
+				fileStoreMock.
+					On("Stat", localPathWithDir).
+					Return(nil, ftperrors.NewNotFoundError("mock error", nil)).
+					Once()
+			} else {
+				fileStoreMock.
+					On("Stat", localPathWithDir).
+					Return(tc.existing, nil).
+					Once()
+			}
+			if tc.ifExists == entities.OverwritePolicyRename {
+				logger.
+					ExpectInfo("local file already exists, saving under a new name").
+					WithField("path", assertlogging.Equal(localPathWithDir)).
+					WithField("new-path", assertlogging.Equal(renamedPath))
+				fileStoreMock.
+					On("Stat", renamedPath).
+					Return(nil, ftperrors.NewNotFoundError("mock error", nil)).
+					Once()
+			}
+			if tc.expectedPath != "" {
+				connMock.
+					On("Download", ctx, &connection.DownloadOptions{Path: remotePathWithDir}).
+					Return(fileContent, nil).
+					Once()
+				fileStoreMock.
+					On("SaveFile", tc.expectedPath, fileContent).
+					Return(nil).
+					Once()
+			}
+
+			useCaseRepos := &ftp.DownloadRepos{
+				Logger:     logger,
+				Connection: connMock,
+				FileStore:  fileStoreMock,
+			}
+
+			useCaseInput := &ftp.DownloadInput{
+				RemotePath: remotePathWithDir,
+				Path:       localPathWithDir,
+				IfExists:   tc.ifExists,
+			}
+
+			useCase := &ftp.Download{}
+
+			// act
+			output, err := useCase.Execute(ctx, useCaseRepos, useCaseInput)
+
+			// assert
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedOutput, output)
+		})
+	}
+}
+
+func Test_Download_Execute_IfExists_FailError(t *testing.T) {
+	// arrange
+	ctx := context.Background()
+
+	logger := assertlogging.NewLogger(t)
+
+	connMock := connectionMocks.NewConnection(t)
+	connMock.
+		On("IsDir", ctx, remotePathWithDir).
+		Return(false, nil).
+		Once()
+	connMock.
+		On("Size", remotePathWithDir).
+		Return(sizeInBytes, nil).
+		Once()
+
+	fileStoreMock := repositoryMocks.NewFileStore(t)
+	fileStoreMock.
+		On("Stat", localPathWithDir).
+		Return(&entities.Entry{Type: entities.EntryTypeFile, Name: fileName, SizeInBytes: sizeInBytes}, nil).
+		Once()
+
+	useCaseRepos := &ftp.DownloadRepos{
+		Logger:     logger,
+		Connection: connMock,
+		FileStore:  fileStoreMock,
+	}
+
+	useCaseInput := &ftp.DownloadInput{
+		RemotePath: remotePathWithDir,
+		Path:       localPathWithDir,
+		IfExists:   entities.OverwritePolicyFail,
+	}
+
+	useCase := &ftp.Download{}
+
+	// act
+	output, err := useCase.Execute(ctx, useCaseRepos, useCaseInput)
+
+	// assert
+	assert.Nil(t, output)
+	require.EqualError(t, err, fmt.Sprintf("a conflict error occurred: %s already exists", localPathWithDir))
+	assert.IsType(t, ftperrors.ConflictErrorType, err)
+}
+
+//nolint:funlen // test case can get a bit large
+func Test_Download_Execute_UnsafeNames_Success(t *testing.T) {
+	const controlCharName = "report\x1b[2J.csv"
+
+	testCases := []struct {
+		name            string
+		unsafeNames     entities.UnsafeNamePolicy
+		expectedLogMsg  string
+		sanitizedName   string
+		expectSanitized bool
+	}{
+		{
+			name:           "skip by default",
+			expectedLogMsg: "security warning: skipped entry with unsafe name",
+		},
+		{
+			name:           "skip",
+			unsafeNames:    entities.UnsafeNamePolicySkip,
+			expectedLogMsg: "security warning: skipped entry with unsafe name",
+		},
+		{
+			name:            "rename",
+			unsafeNames:     entities.UnsafeNamePolicyRename,
+			expectedLogMsg:  "security warning: unsafe entry name was sanitized",
+			sanitizedName:   "report_[2J.csv",
+			expectSanitized: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			logger := assertlogging.NewLogger(t)
+			// names escaping the download directory are skipped regardless of the policy
+			logger.
+				ExpectWarn("security warning: skipped entry with unsafe name").
+				WithField("remote-path", assertlogging.Equal(remoteDirPath+"/../../.bashrc")).
+				WithField("reason", assertlogging.Equal("escapes the download directory"))
+			warnLog := logger.
+				ExpectWarn(tc.expectedLogMsg).
+				WithField("remote-path", assertlogging.Equal(remoteDirPath+"/"+controlCharName)).
+				WithField("reason", assertlogging.Equal("contains characters that are illegal on the local filesystem"))
+			if tc.expectSanitized {
+				warnLog.WithField("local-name", assertlogging.Equal(tc.sanitizedName))
+			}
+
+			connMock := connectionMocks.NewConnection(t)
+			connMock.
+				On("IsDir", ctx, remoteDirPath).
+				Return(true, nil).
+				Once()
+			connMock.
+				On("List", ctx, &connection.ListOptions{
+					Path:    remoteDirPath,
+					ShowAll: true,
+				}).
+				Return(&connection.ListResult{
+					Entries: []*entities.Entry{
+						newEntry(t, entities.EntryTypeFile, "../../.bashrc", sizeInBytes, "2022-01-12 16:23"),
+						newEntry(t, entities.EntryTypeFile, controlCharName, sizeInBytes, "2022-01-12 16:23"),
+						newEntry(t, entities.EntryTypeFile, "file-1", sizeInBytes, "2022-01-12 16:23"),
+					},
+				}, nil).
+				Once()
+
+			fileStoreMock := repositoryMocks.NewFileStore(t)
+			fileStoreMock.
+				On("CreateDir", dirPath).
+				Return(nil).
+				Once()
+
+			downloadedNames := []string{"file-1"}
+			if tc.expectSanitized {
+				downloadedNames = append(downloadedNames, controlCharName)
+				fileStoreMock.
+					On("SaveFile", filepath.Join(dirPath, tc.sanitizedName), fileContent).
+					Return(nil).
+					Once()
+			}
+			fileStoreMock.
+				On("SaveFile", filepath.Join(dirPath, "file-1"), fileContent).
+				Return(nil).
+				Once()
+			for _, name := range downloadedNames {
+				connMock.
+					On("Size", remoteDirPath+"/"+name).
+					Return(sizeInBytes, nil).
+					Once()
+				connMock.
+					On("Download", ctx, &connection.DownloadOptions{Path: remoteDirPath + "/" + name}).
+					Return(fileContent, nil).
+					Once()
+			}
+
+			useCaseRepos := &ftp.DownloadRepos{
+				Logger:     logger,
+				Connection: connMock,
+				FileStore:  fileStoreMock,
+			}
+			useCaseInput := &ftp.DownloadInput{
+				RemotePath:  remoteDirPath,
+				Path:        dirPath,
+				UnsafeNames: tc.unsafeNames,
+			}
+
+			var expectedResults []*entities.TransferResult
+			if tc.expectSanitized {
+				expectedResults = append(expectedResults, &entities.TransferResult{
+					Path: remoteDirPath + "/" + controlCharName, Status: entities.TransferStatusOK,
+				})
+			}
+			expectedResults = append(expectedResults, &entities.TransferResult{
+				Path: remoteDirPath + "/file-1", Status: entities.TransferStatusOK,
+			})
+
+			useCase := &ftp.Download{}
+			output, err := useCase.Execute(ctx, useCaseRepos, useCaseInput)
+			assert.NoError(t, err)
+			assert.Equal(t, &ftp.DownloadOutput{Results: expectedResults}, output)
+		})
+	}
+}
+
+func Test_Download_Execute_UnsafeNames_FailError(t *testing.T) {
+	ctx := context.Background()
+
+	logger := assertlogging.NewLogger(t)
+	logger.
+		ExpectWarn("security warning: unsafe entry name aborted download").
+		WithField("remote-path", assertlogging.Equal(remoteDirPath+"/dir-1/../../../.ssh")).
+		WithField("reason", assertlogging.Equal("escapes the download directory"))
+
+	connMock := connectionMocks.NewConnection(t)
+	connMock.
+		On("List", ctx, &connection.ListOptions{
+			Path:    remoteDirPath,
+			ShowAll: true,
+		}).
+		Return(&connection.ListResult{
+			Entries: []*entities.Entry{
+				newEntry(t, entities.EntryTypeDir, "dir-1", sizeInBytes, "2022-01-12 16:23"),
+			},
+		}, nil).
+		Once()
+	connMock.
+		On("List", ctx, &connection.ListOptions{
+			Path:    remoteDirPath + "/dir-1",
+			ShowAll: true,
+		}).
+		Return(&connection.ListResult{
+			Entries: []*entities.Entry{
+				newEntry(t, entities.EntryTypeDir, "../../../.ssh", sizeInBytes, "2022-01-12 16:23"),
+			},
+		}, nil).
+		Once()
+
+	fileStoreMock := repositoryMocks.NewFileStore(t)
+	fileStoreMock.
+		On("CreateDir", filepath.Join(dirPath, "dir-1")).
+		Return(nil).
+		Once()
+
+	useCaseRepos := &ftp.DownloadRepos{
+		Logger:     logger,
+		Connection: connMock,
+		FileStore:  fileStoreMock,
+	}
+	useCaseInput := &ftp.DownloadInput{
+		RemotePath:  remoteDirPath + "/*",
+		Path:        dirPath,
+		UnsafeNames: entities.UnsafeNamePolicyFail,
+	}
+
+	useCase := &ftp.Download{}
+	output, err := useCase.Execute(ctx, useCaseRepos, useCaseInput)
+	assert.Nil(t, output)
+	require.EqualError(
+		t,
+		err,
+		"an internal error occurred: name of remote entry "+remoteDirPath+"/dir-1/../../../.ssh escapes the download directory",
+	)
+	assert.IsType(t, ftperrors.InternalErrorType, err)
+}
+
+func newLink(t *testing.T, name, linkName string) *entities.Entry {
+	entry := newEntry(t, entities.EntryTypeLink, name, uint64(len(linkName)), "2022-01-12 16:23")
+	entry.LinkName = linkName
+	return entry
+}
+
+//nolint:funlen // test case can get a bit large
+func Test_Download_Execute_Links_Follow_Success(t *testing.T) {
+	ctx := context.Background()
+
+	logger := assertlogging.NewLogger(t)
+
+	connMock := connectionMocks.NewConnection(t)
+	connMock.
+		On("IsDir", ctx, remoteDirPath).
+		Return(true, nil).
+		Once()
+	connMock.
+		On("List", ctx, &connection.ListOptions{
+			Path:    remoteDirPath,
+			ShowAll: true,
+		}).
+		Return(&connection.ListResult{
+			Entries: []*entities.Entry{
+				newLink(t, "latest", "/releases/v1.2.3"),
+				newLink(t, "current.txt", "../files/report.txt"),
+				newLink(t, "up", ".."),
+				newLink(t, "self", "self"),
+				newLink(t, "broken", "missing"),
+				newLink(t, "unknown", ""),
+			},
+		}, nil).
+		Once()
+	connMock.
+		On("Stat", ctx, "/releases/v1.2.3").
+		Return(newEntry(t, entities.EntryTypeDir, "v1.2.3", sizeInBytes, "2022-01-12 16:23"), nil).
+		Once()
+	connMock.
+		On("Stat", ctx, "/doo/dee/files/report.txt").
+		Return(newEntry(t, entities.EntryTypeFile, "report.txt", sizeInBytes, "2022-01-12 16:23"), nil).
+		Once()
+	connMock.
+		On("Stat", ctx, "/doo/dee").
+		Return(newEntry(t, entities.EntryTypeDir, "dee", sizeInBytes, "2022-01-12 16:23"), nil).
+		Once()
+	connMock.
+		On("Stat", ctx, remoteDirPath+"/missing").
+		Return(nil, ftperrors.NewNotFoundError("entry not found", nil)).
+		Once()
+	connMock.
+		On("List", ctx, &connection.ListOptions{
+			Path:    "/releases/v1.2.3",
+			ShowAll: true,
+		}).
+		Return(&connection.ListResult{
+			Entries: []*entities.Entry{
+				newEntry(t, entities.EntryTypeFile, "file-1", sizeInBytes, "2022-01-12 16:23"),
+			},
+		}, nil).
+		Once()
+	for _, remotePath := range []string{"/releases/v1.2.3/file-1", "/doo/dee/files/report.txt"} {
+		connMock.
+			On("Size", remotePath).
+			Return(sizeInBytes, nil).
+			Once()
+		connMock.
+			On("Download", ctx, &connection.DownloadOptions{Path: remotePath}).
+			Return(fileContent, nil).
+			Once()
+	}
+
+	fileStoreMock := repositoryMocks.NewFileStore(t)
+	fileStoreMock.
+		On("CreateDir", dirPath).
+		Return(nil).
+		Once()
+	fileStoreMock.
+		On("CreateDir", filepath.Join(dirPath, "latest")).
+		Return(nil).
+		Once()
+	fileStoreMock.
+		On("SaveFile", filepath.Join(dirPath, "latest", "file-1"), fileContent).
+		Return(nil).
+		Once()
+	fileStoreMock.
+		On("SaveFile", filepath.Join(dirPath, "current.txt"), fileContent).
+		Return(nil).
+		Once()
+
+	useCaseRepos := &ftp.DownloadRepos{
+		Logger:     logger,
+		Connection: connMock,
+		FileStore:  fileStoreMock,
+	}
+	useCaseInput := &ftp.DownloadInput{
+		RemotePath: remoteDirPath,
+		Path:       dirPath,
+		Links:      entities.LinkPolicyFollow,
+	}
+
+	useCase := &ftp.Download{}
+	output, err := useCase.Execute(ctx, useCaseRepos, useCaseInput)
+	assert.NoError(t, err)
+	assert.Equal(t, &ftp.DownloadOutput{
+		Skipped: []*entities.SkippedTransfer{
+			{Path: remoteDirPath + "/up", Reason: "link loop"},
+			{Path: remoteDirPath + "/self", Reason: "link loop"},
+			{Path: remoteDirPath + "/broken", Reason: "link target not found"},
+			{Path: remoteDirPath + "/unknown", Reason: "link target unknown"},
+		},
+		Results: []*entities.TransferResult{
+			{Path: "/releases/v1.2.3/file-1", Status: entities.TransferStatusOK},
+			{Path: "/doo/dee/files/report.txt", Status: entities.TransferStatusOK},
+			{Path: remoteDirPath + "/up", Status: entities.TransferStatusSkipped, Reason: "link loop"},
+			{Path: remoteDirPath + "/self", Status: entities.TransferStatusSkipped, Reason: "link loop"},
+			{Path: remoteDirPath + "/broken", Status: entities.TransferStatusSkipped, Reason: "link target not found"},
+			{Path: remoteDirPath + "/unknown", Status: entities.TransferStatusSkipped, Reason: "link target unknown"},
+		},
+	}, output)
+}
+
+func Test_Download_Execute_Links_Follow_StatError(t *testing.T) {
+	ctx := context.Background()
+
+	logger := assertlogging.NewLogger(t)
+	logger.
+		ExpectError("failed to check if entry exists").
+		WithError(assertlogging.EqualError("mock error")).
+		WithField("remote-path", assertlogging.Equal("/releases/v1.2.3"))
+
+	connMock := connectionMocks.NewConnection(t)
+	connMock.
+		On("IsDir", ctx, remoteDirPath).
+		Return(true, nil).
+		Once()
+	connMock.
+		On("List", ctx, &connection.ListOptions{
+			Path:    remoteDirPath,
+			ShowAll: true,
+		}).
+		Return(&connection.ListResult{
+			Entries: []*entities.Entry{
+				newLink(t, "latest", "/releases/v1.2.3"),
+			},
+		}, nil).
+		Once()
+	connMock.
+		On("Stat", ctx, "/releases/v1.2.3").
+		Return(nil, errors.New("mock error")).
+		Once()
+
+	fileStoreMock := repositoryMocks.NewFileStore(t)
+	fileStoreMock.
+		On("CreateDir", dirPath).
+		Return(nil).
+		Once()
+
+	useCaseRepos := &ftp.DownloadRepos{
+		Logger:     logger,
+		Connection: connMock,
+		FileStore:  fileStoreMock,
+	}
+	useCaseInput := &ftp.DownloadInput{
+		RemotePath: remoteDirPath,
+		Path:       dirPath,
+		Links:      entities.LinkPolicyFollow,
+	}
+
+	useCase := &ftp.Download{}
+	output, err := useCase.Execute(ctx, useCaseRepos, useCaseInput)
+	assert.Nil(t, output)
+	require.EqualError(t, err, "an internal error occurred: failed to check if entry exists")
+	assert.IsType(t, ftperrors.InternalErrorType, err)
+}
+
+//nolint:funlen // test case can get a bit large
+func Test_Download_Execute_Links_Preserve_Success(t *testing.T) {
+	ctx := context.Background()
+
+	logger := assertlogging.NewLogger(t)
+	for _, link := range [][2]string{{"passwd", "/etc/passwd"}, {"escape", "../../.bashrc"}} {
+		logger.
+			ExpectWarn("security warning: skipped link with unsafe target").
+			WithField("remote-path", assertlogging.Equal(remoteDirPath+"/"+link[0])).
+			WithField("link-name", assertlogging.Equal(link[1]))
+	}
+
+	connMock := connectionMocks.NewConnection(t)
+	connMock.
+		On("IsDir", ctx, remoteDirPath).
+		Return(true, nil).
+		Once()
+	connMock.
+		On("List", ctx, &connection.ListOptions{
+			Path:    remoteDirPath,
+			ShowAll: true,
+		}).
+		Return(&connection.ListResult{
+			Entries: []*entities.Entry{
+				newLink(t, "latest", "v1.2.3"),
+				newLink(t, "passwd", "/etc/passwd"),
+				newLink(t, "escape", "../../.bashrc"),
+				newEntry(t, entities.EntryTypeDir, "dir-1", sizeInBytes, "2022-01-12 16:23"),
+			},
+		}, nil).
+		Once()
+	connMock.
+		On("List", ctx, &connection.ListOptions{
+			Path:    remoteDirPath + "/dir-1",
+			ShowAll: true,
+		}).
+		Return(&connection.ListResult{
+			Entries: []*entities.Entry{
+				newLink(t, "sibling", "../latest"),
+			},
+		}, nil).
+		Once()
+
+	fileStoreMock := repositoryMocks.NewFileStore(t)
+	fileStoreMock.
+		On("CreateDir", dirPath).
+		Return(nil).
+		Once()
+	fileStoreMock.
+		On("CreateDir", filepath.Join(dirPath, "dir-1")).
+		Return(nil).
+		Once()
+	fileStoreMock.
+		On("CreateSymlink", filepath.Join(dirPath, "latest"), "v1.2.3").
+		Return(nil).
+		Once()
+	fileStoreMock.
+		On("CreateSymlink", filepath.Join(dirPath, "dir-1", "sibling"), filepath.FromSlash("../latest")).
+		Return(nil).
+		Once()
+
+	useCaseRepos := &ftp.DownloadRepos{
+		Logger:     logger,
+		Connection: connMock,
+		FileStore:  fileStoreMock,
+	}
+	useCaseInput := &ftp.DownloadInput{
+		RemotePath: remoteDirPath,
+		Path:       dirPath,
+		Links:      entities.LinkPolicyPreserve,
+	}
+
+	useCase := &ftp.Download{}
+	output, err := useCase.Execute(ctx, useCaseRepos, useCaseInput)
+	assert.NoError(t, err)
+	assert.Equal(t, &ftp.DownloadOutput{
+		Skipped: []*entities.SkippedTransfer{
+			{Path: remoteDirPath + "/passwd", Reason: "unsafe link target"},
+			{Path: remoteDirPath + "/escape", Reason: "unsafe link target"},
+		},
+		Results: []*entities.TransferResult{
+			{Path: remoteDirPath + "/latest", Status: entities.TransferStatusOK},
+			{Path: remoteDirPath + "/passwd", Status: entities.TransferStatusSkipped, Reason: "unsafe link target"},
+			{Path: remoteDirPath + "/escape", Status: entities.TransferStatusSkipped, Reason: "unsafe link target"},
+			{Path: remoteDirPath + "/dir-1/sibling", Status: entities.TransferStatusOK},
+		},
+	}, output)
+}
+
+//nolint:funlen // test case can get a bit large
+func Test_Download_Execute_Links_Preserve_ChainedEscape(t *testing.T) {
+	ctx := context.Background()
+
+	subDirPath := remoteDirPath + "/sub"
+
+	logger := assertlogging.NewLogger(t)
+	logger.
+		ExpectWarn("security warning: skipped link with unsafe target").
+		WithField("remote-path", assertlogging.Equal(subDirPath+"/l3")).
+		WithField("link-name", assertlogging.Equal("l2/.."))
+	logger.
+		ExpectWarn("security warning: skipped entry that would be saved through a symbolic link").
+		WithField("remote-path", assertlogging.Equal(subDirPath+"/l2")).
+		WithField("path", assertlogging.Equal(filepath.Join(dirPath, "sub", "l2")))
+
+	connMock := connectionMocks.NewConnection(t)
+	connMock.
+		On("IsDir", ctx, remoteDirPath).
+		Return(true, nil).
+		Once()
+	connMock.
+		On("List", ctx, &connection.ListOptions{
+			Path:    remoteDirPath,
+			ShowAll: true,
+		}).
+		Return(&connection.ListResult{
+			Entries: []*entities.Entry{
+				newEntry(t, entities.EntryTypeDir, "sub", sizeInBytes, "2022-01-12 16:23"),
+			},
+		}, nil).
+		Once()
+	connMock.
+		On("List", ctx, &connection.ListOptions{
+			Path:    subDirPath,
+			ShowAll: true,
+		}).
+		Return(&connection.ListResult{
+			Entries: []*entities.Entry{
+				newLink(t, "l2", ".."),
+				newLink(t, "l3", "l2/.."),
+				newEntry(t, entities.EntryTypeDir, "l2", sizeInBytes, "2022-01-12 16:23"),
+			},
+		}, nil).
+		Once()
+
+	fileStoreMock := repositoryMocks.NewFileStore(t)
+	fileStoreMock.
+		On("CreateDir", dirPath).
+		Return(nil).
+		Once()
+	fileStoreMock.
+		On("CreateDir", filepath.Join(dirPath, "sub")).
+		Return(nil).
+		Once()
+	fileStoreMock.
+		On("CreateSymlink", filepath.Join(dirPath, "sub", "l2"), "..").
+		Return(nil).
+		Once()
+
+	useCaseRepos := &ftp.DownloadRepos{
+		Logger:     logger,
+		Connection: connMock,
+		FileStore:  fileStoreMock,
+	}
+	useCaseInput := &ftp.DownloadInput{
+		RemotePath: remoteDirPath,
+		Path:       dirPath,
+		Links:      entities.LinkPolicyPreserve,
+	}
+
+	useCase := &ftp.Download{}
+	output, err := useCase.Execute(ctx, useCaseRepos, useCaseInput)
+	assert.NoError(t, err)
+	assert.Equal(t, &ftp.DownloadOutput{
+		Skipped: []*entities.SkippedTransfer{
+			{Path: subDirPath + "/l3", Reason: "unsafe link target"},
+			{Path: subDirPath + "/l2", Reason: "path goes through a symbolic link"},
+		},
+		Results: []*entities.TransferResult{
+			{Path: subDirPath + "/l2", Status: entities.TransferStatusOK},
+			{Path: subDirPath + "/l3", Status: entities.TransferStatusSkipped, Reason: "unsafe link target"},
+			{Path: subDirPath + "/l2", Status: entities.TransferStatusSkipped, Reason: "path goes through a symbolic link"},
+		},
+	}, output)
+}
@@ -0,0 +1,219 @@
+package ftp
+
+import (
+	"fmt"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/alexZaicev/go-ftp-client/internal/domain/entities"
+	ftperrors "github.com/alexZaicev/go-ftp-client/internal/domain/errors"
+)
+
+// PathFilter selects entries of a directory tree according to the filter options. Paths passed to its
+// methods are slash separated and relative to the root of the tree. A nil filter matches everything.
+type PathFilter struct {
+	include        []*filterPattern
+	exclude        []*filterPattern
+	minSizeInBytes uint64
+	modifiedAfter  time.Time
+}
+
+// filterPattern is a single gitignore pattern:
+//   - a pattern without a slash matches the name of an entry at any depth, otherwise it is matched
+//     against the whole path relative to the root;
+//   - a trailing slash matches directories only;
+//   - "**" as a whole segment matches any number of nested directories, including none;
+//   - a leading "!" negates the pattern, including entries excluded by an earlier pattern.
+type filterPattern struct {
+	segments []string
+	negate   bool
+	dirOnly  bool
+	anchored bool
+}
+
+// NewPathFilter function compiles filter options, returning nil if no options are provided.
+func NewPathFilter(options *entities.FilterOptions) (*PathFilter, error) {
+	if options == nil {
+		return nil, nil
+	}
+
+	include, err := parseFilterPatterns("include", options.Include)
+	if err != nil {
+		return nil, err
+	}
+	for _, pattern := range include {
+		if pattern.negate {
+			return nil, ftperrors.NewInvalidArgumentError("include", "cannot contain negated patterns")
+		}
+	}
+
+	exclude, err := parseFilterPatterns("exclude", options.Exclude)
+	if err != nil {
+		return nil, err
+	}
+
+	return &PathFilter{
+		include:        include,
+		exclude:        exclude,
+		minSizeInBytes: options.MinSizeInBytes,
+		modifiedAfter:  options.ModifiedAfter,
+	}, nil
+}
+
+// MatchDir method checks whether the directory should be traversed.
+func (f *PathFilter) MatchDir(relPath string) bool {
+	if f == nil {
+		return true
+	}
+	return !f.excluded(relPath, true)
+}
+
+// MatchFile method checks whether the file should be processed.
+func (f *PathFilter) MatchFile(relPath string, sizeInBytes uint64, modTime time.Time) bool {
+	if f == nil {
+		return true
+	}
+	if sizeInBytes < f.minSizeInBytes {
+		return false
+	}
+	if !f.modifiedAfter.IsZero() && modTime.Before(f.modifiedAfter) {
+		return false
+	}
+	return f.included(relPath) && !f.excluded(relPath, false)
+}
+
+// MatchEntry method checks whether the listed remote file should be processed. The year of recently
+// modified entries, which are listed without one, is resolved before their age is checked.
+func (f *PathFilter) MatchEntry(relPath string, entry *entities.Entry) bool {
+	if f == nil {
+		return true
+	}
+	return f.MatchFile(relPath, entry.SizeInBytes, entry.ResolvedModificationDate(time.Now()))
+}
+
+func (f *PathFilter) included(relPath string) bool {
+	if len(f.include) == 0 {
+		return true
+	}
+
+	for _, pattern := range f.include {
+		if pattern.match(relPath, false) {
+			return true
+		}
+		for dirPath := path.Dir(relPath); dirPath != "."; dirPath = path.Dir(dirPath) {
+			if pattern.match(dirPath, true) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (f *PathFilter) excluded(relPath string, isDir bool) bool {
+	// entries of an excluded directory cannot be included again, as in gitignore
+	for dirPath := path.Dir(relPath); dirPath != "."; dirPath = path.Dir(dirPath) {
+		if f.excludedEntry(dirPath, true) {
+			return true
+		}
+	}
+	return f.excludedEntry(relPath, isDir)
+}
+
+func (f *PathFilter) excludedEntry(relPath string, isDir bool) bool {
+	excluded := false
+	for _, pattern := range f.exclude {
+		if pattern.match(relPath, isDir) {
+			excluded = !pattern.negate
+		}
+	}
+	return excluded
+}
+
+func (p *filterPattern) match(relPath string, isDir bool) bool {
+	if p.dirOnly && !isDir {
+		return false
+	}
+
+	names := strings.Split(relPath, "/")
+	if !p.anchored {
+		names = names[len(names)-1:]
+	}
+	return matchFilterSegments(p.segments, names)
+}
+
+func matchFilterSegments(segments, names []string) bool {
+	if len(segments) == 0 {
+		return len(names) == 0
+	}
+
+	if segments[0] == globAnyDirs {
+		for idx := 0; idx <= len(names); idx++ {
+			if matchFilterSegments(segments[1:], names[idx:]) {
+				return true
+			}
+		}
+		return false
+	}
+
+	if len(names) == 0 {
+		return false
+	}
+	// patterns were validated beforehand
+	if ok, _ := path.Match(segments[0], names[0]); !ok {
+		return false
+	}
+	return matchFilterSegments(segments[1:], names[1:])
+}
+
+// parseFilterPatterns function parses lines of gitignore patterns, skipping blank lines and comments.
+func parseFilterPatterns(argument string, lines []string) ([]*filterPattern, error) {
+	var patterns []*filterPattern
+	for _, line := range lines {
+		pattern, err := parseFilterPattern(argument, line)
+		if err != nil {
+			return nil, err
+		}
+		if pattern != nil {
+			patterns = append(patterns, pattern)
+		}
+	}
+	return patterns, nil
+}
+
+func parseFilterPattern(argument, line string) (*filterPattern, error) {
+	line = strings.TrimRight(line, " \r")
+	if line == "" || strings.HasPrefix(line, "#") {
+		return nil, nil
+	}
+
+	pattern := &filterPattern{}
+	switch {
+	case strings.HasPrefix(line, "!"):
+		pattern.negate = true
+		line = line[1:]
+	case strings.HasPrefix(line, `\!`), strings.HasPrefix(line, `\#`):
+		line = line[1:]
+	}
+
+	if strings.HasSuffix(line, "/") {
+		pattern.dirOnly = true
+		line = strings.TrimRight(line, "/")
+	}
+	pattern.anchored = strings.Contains(line, "/")
+
+	for _, segment := range strings.Split(line, "/") {
+		if segment == "" {
+			continue
+		}
+		if _, err := path.Match(segment, ""); err != nil {
+			return nil, ftperrors.NewInvalidArgumentError(argument, fmt.Sprintf("contains malformed pattern %s", line))
+		}
+		pattern.segments = append(pattern.segments, segment)
+	}
+	if len(pattern.segments) == 0 {
+		return nil, nil
+	}
+
+	return pattern, nil
+}
@@ -0,0 +1,182 @@
+package ftp_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/alexZaicev/go-ftp-client/internal/domain/entities"
+	ftperrors "github.com/alexZaicev/go-ftp-client/internal/domain/errors"
+	"github.com/alexZaicev/go-ftp-client/internal/usecases/ftp"
+)
+
+//nolint:funlen // test case can get a bit large
+func Test_PathFilter_Match(t *testing.T) {
+	modTime := time.Date(2024, time.January, 2, 10, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name        string
+		options     *entities.FilterOptions
+		relPath     string
+		isDir       bool
+		sizeInBytes uint64
+		expected    bool
+	}{
+		{
+			name:     "no filter",
+			relPath:  "src/main.go",
+			expected: true,
+		},
+		{
+			name:     "name pattern matches at any depth",
+			options:  &entities.FilterOptions{Exclude: []string{"*.tmp"}},
+			relPath:  "src/cache/data.tmp",
+			expected: false,
+		},
+		{
+			name:     "excluded directory",
+			options:  &entities.FilterOptions{Exclude: []string{"node_modules/"}},
+			relPath:  "web/node_modules",
+			isDir:    true,
+			expected: false,
+		},
+		{
+			name:     "directory pattern does not match files",
+			options:  &entities.FilterOptions{Exclude: []string{"build/"}},
+			relPath:  "docs/build",
+			expected: true,
+		},
+		{
+			name:     "contents of excluded directory",
+			options:  &entities.FilterOptions{Exclude: []string{".git", "!*.go"}},
+			relPath:  ".git/hooks/main.go",
+			expected: false,
+		},
+		{
+			name:     "anchored pattern",
+			options:  &entities.FilterOptions{Exclude: []string{"/vendor"}},
+			relPath:  "pkg/vendor",
+			isDir:    true,
+			expected: true,
+		},
+		{
+			name:     "anchored nested pattern",
+			options:  &entities.FilterOptions{Exclude: []string{"docs/*.md"}},
+			relPath:  "docs/README.md",
+			expected: false,
+		},
+		{
+			name:     "any directories pattern",
+			options:  &entities.FilterOptions{Exclude: []string{"**/testdata/**"}},
+			relPath:  "pkg/parsers/testdata/list.txt",
+			expected: false,
+		},
+		{
+			name:     "negated pattern",
+			options:  &entities.FilterOptions{Exclude: []string{"*.log", "!keep.log"}},
+			relPath:  "logs/keep.log",
+			expected: true,
+		},
+		{
+			name:     "last matching pattern wins",
+			options:  &entities.FilterOptions{Exclude: []string{"!keep.log", "*.log"}},
+			relPath:  "logs/keep.log",
+			expected: false,
+		},
+		{
+			name:     "comments and blank lines",
+			options:  &entities.FilterOptions{Exclude: []string{"# *.go", "", `\#notes.txt`}},
+			relPath:  "#notes.txt",
+			expected: false,
+		},
+		{
+			name:     "include pattern",
+			options:  &entities.FilterOptions{Include: []string{"*.go"}},
+			relPath:  "src/main.txt",
+			expected: false,
+		},
+		{
+			name:     "include directory",
+			options:  &entities.FilterOptions{Include: []string{"src/"}},
+			relPath:  "src/util/main.txt",
+			expected: true,
+		},
+		{
+			name:     "include does not prune directories",
+			options:  &entities.FilterOptions{Include: []string{"*.go"}},
+			relPath:  "src",
+			isDir:    true,
+			expected: true,
+		},
+		{
+			name:        "min size",
+			options:     &entities.FilterOptions{MinSizeInBytes: 1024},
+			relPath:     "small.txt",
+			sizeInBytes: 1023,
+			expected:    false,
+		},
+		{
+			name:     "modified after",
+			options:  &entities.FilterOptions{ModifiedAfter: modTime.Add(time.Minute)},
+			relPath:  "old.txt",
+			expected: false,
+		},
+		{
+			name:     "modified after does not apply to directories",
+			options:  &entities.FilterOptions{ModifiedAfter: modTime.Add(time.Minute)},
+			relPath:  "old",
+			isDir:    true,
+			expected: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			filter, err := ftp.NewPathFilter(tc.options)
+			require.NoError(t, err)
+
+			if tc.isDir {
+				assert.Equal(t, tc.expected, filter.MatchDir(tc.relPath))
+			} else {
+				assert.Equal(t, tc.expected, filter.MatchFile(tc.relPath, tc.sizeInBytes, modTime))
+			}
+		})
+	}
+}
+
+func Test_NewPathFilter_Error(t *testing.T) {
+	testCases := []struct {
+		name           string
+		options        *entities.FilterOptions
+		expectedErrMsg string
+	}{
+		{
+			name:    "malformed include pattern",
+			options: &entities.FilterOptions{Include: []string{"[a-"}},
+			expectedErrMsg: "an invalid argument error occurred: argument include contains " +
+				"malformed pattern [a-",
+		},
+		{
+			name:           "negated include pattern",
+			options:        &entities.FilterOptions{Include: []string{"!*.go"}},
+			expectedErrMsg: "an invalid argument error occurred: argument include cannot contain negated patterns",
+		},
+		{
+			name:    "malformed exclude pattern",
+			options: &entities.FilterOptions{Exclude: []string{"logs/[a-"}},
+			expectedErrMsg: "an invalid argument error occurred: argument exclude contains " +
+				"malformed pattern logs/[a-",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			filter, err := ftp.NewPathFilter(tc.options)
+			assert.Nil(t, filter)
+			require.EqualError(t, err, tc.expectedErrMsg)
+			assert.IsType(t, ftperrors.InvalidArgumentErrorType, err)
+		})
+	}
+}
@@ -0,0 +1,266 @@
+package ftp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/alexZaicev/go-ftp-client/internal/domain/connection"
+	"github.com/alexZaicev/go-ftp-client/internal/domain/entities"
+	ftperrors "github.com/alexZaicev/go-ftp-client/internal/domain/errors"
+	"github.com/alexZaicev/go-ftp-client/internal/drivers/logging"
+)
+
+// globAnyDirs is a pattern segment that matches any number of nested directories, including none.
+const globAnyDirs = "**"
+
+// globMatch is a remote entry matched by a glob pattern.
+type globMatch struct {
+	path  string
+	entry *entities.Entry
+}
+
+// globBase function returns the leading directories of the pattern that contain no glob meta characters.
+func globBase(pattern string) string {
+	base, _ := splitGlob(pattern)
+	return base
+}
+
+func splitGlob(pattern string) (string, []string) {
+	root := entities.RemotePath(pattern).Root()
+
+	var segments []string
+	for _, segment := range strings.Split(pattern[len(root):], entities.RemoteSeparator) {
+		if segment != "" && segment != "." {
+			segments = append(segments, segment)
+		}
+	}
+
+	base := entities.RemotePath(root)
+	idx := 0
+	for ; idx < len(segments) && !entities.HasRemoteGlobMeta(segments[idx]); idx++ {
+		base = base.Join(entities.UnescapeRemoteGlob(segments[idx]))
+	}
+	return base.String(), segments[idx:]
+}
+
+// isRemoteGlob function reports whether the path is expanded as a glob pattern, which it is not if the
+// path is taken literally.
+func isRemoteGlob(p string, literal bool) bool {
+	return !literal && entities.HasRemoteGlobMeta(p)
+}
+
+// remoteLiteralPath function returns the remote path that is not expanded as a glob pattern. Glob meta
+// characters escaped with a backslash are unescaped, unless the path is taken literally.
+func remoteLiteralPath(p string, literal bool) string {
+	if literal {
+		return p
+	}
+	return entities.UnescapeRemoteGlob(p)
+}
+
+// globExpander matches remote entries against shell-style glob patterns. Each segment of the pattern is
+// matched against names of entries listed in the directories matched so far, with the following rules:
+//   - "*" matches any sequence of characters except "/", "?" matches a single character and "[a-z]"
+//     matches a character class, as in path.Match;
+//   - "**" as a whole segment matches any number of nested directories, including none;
+//   - entries starting with "." are only matched by segments that start with "." as well;
+//   - a backslash escapes the following character, so that it is matched literally.
+type globExpander struct {
+	logger   logging.Logger
+	conn     connection.Connection
+	listings map[string][]*entities.Entry
+}
+
+// expandGlob function returns remote entries matching the pattern sorted by path.
+func expandGlob(
+	ctx context.Context,
+	logger logging.Logger,
+	conn connection.Connection,
+	pattern string,
+) ([]*globMatch, error) {
+	expander := &globExpander{
+		logger:   logger,
+		conn:     conn,
+		listings: make(map[string][]*entities.Entry),
+	}
+
+	base, segments := splitGlob(pattern)
+	for _, segment := range segments {
+		if _, err := path.Match(segment, ""); err != nil {
+			return nil, ftperrors.NewInvalidArgumentError("pattern", "is malformed")
+		}
+	}
+
+	unique := make(map[string]*globMatch)
+	if err := expander.match(ctx, base, segments, unique); err != nil {
+		return nil, err
+	}
+	if len(unique) == 0 {
+		return nil, ftperrors.NewNotFoundError(fmt.Sprintf("no entries match %s pattern", pattern), nil)
+	}
+
+	matches := make([]*globMatch, 0, len(unique))
+	for _, match := range unique {
+		matches = append(matches, match)
+	}
+	sort.Slice(matches, func(i, j int) bool {
+		return matches[i].path < matches[j].path
+	})
+	return matches, nil
+}
+
+// expandGlobOrLiteral function returns remote entries matching the pattern like expandGlob, unless the
+// pattern matches nothing or is malformed while an entry exists under the pattern taken as a literal path.
+// No matches are returned in that case, so that names containing glob meta characters (e.g. "report [1].pdf")
+// are accessed as before.
+func expandGlobOrLiteral(
+	ctx context.Context,
+	logger logging.Logger,
+	conn connection.Connection,
+	pattern string,
+) ([]*globMatch, error) {
+	matches, err := expandGlob(ctx, logger, conn, pattern)
+	if err == nil {
+		return matches, nil
+	}
+
+	var notFoundErr *ftperrors.NotFoundError
+	var invalidArgErr *ftperrors.InvalidArgumentError
+	if !errors.As(err, &notFoundErr) && !errors.As(err, &invalidArgErr) {
+		return nil, err
+	}
+
+	entry, statErr := remoteEntry(ctx, logger, conn, pattern)
+	if statErr != nil {
+		return nil, statErr
+	}
+	if entry == nil {
+		return nil, err
+	}
+	return nil, nil
+}
+
+func (e *globExpander) match(
+	ctx context.Context,
+	dirPath string,
+	segments []string,
+	matches map[string]*globMatch,
+) error {
+	entries, err := e.list(ctx, dirPath)
+	if err != nil {
+		return err
+	}
+
+	segment, rest := segments[0], segments[1:]
+	if segment == globAnyDirs {
+		if len(rest) > 0 {
+			if matchErr := e.match(ctx, dirPath, rest, matches); matchErr != nil {
+				return matchErr
+			}
+		}
+
+		for _, entry := range entries {
+			if strings.HasPrefix(entry.Name, ".") {
+				continue
+			}
+
+			entryPath := entities.RemotePath(dirPath).Join(entry.Name).String()
+			if len(rest) == 0 {
+				matches[entryPath] = &globMatch{path: entryPath, entry: entry}
+			}
+			if entry.Type == entities.EntryTypeDir {
+				if matchErr := e.match(ctx, entryPath, segments, matches); matchErr != nil {
+					return matchErr
+				}
+			}
+		}
+		return nil
+	}
+
+	for _, entry := range entries {
+		if strings.HasPrefix(entry.Name, ".") && !strings.HasPrefix(segment, ".") {
+			continue
+		}
+		// pattern was validated beforehand
+		if ok, _ := path.Match(segment, entry.Name); !ok {
+			continue
+		}
+
+		entryPath := entities.RemotePath(dirPath).Join(entry.Name).String()
+		if len(rest) == 0 {
+			matches[entryPath] = &globMatch{path: entryPath, entry: entry}
+			continue
+		}
+		if entry.Type == entities.EntryTypeDir {
+			if matchErr := e.match(ctx, entryPath, rest, matches); matchErr != nil {
+				return matchErr
+			}
+		}
+	}
+	return nil
+}
+
+// list method lists directory entries once per expansion, as "**" segments visit directories repeatedly.
+func (e *globExpander) list(ctx context.Context, dirPath string) ([]*entities.Entry, error) {
+	if entries, ok := e.listings[dirPath]; ok {
+		return entries, nil
+	}
+
+	listPath := dirPath
+	if listPath == "" {
+		listPath = "."
+	}
+	result, err := e.conn.List(ctx, &connection.ListOptions{
+		Path:    listPath,
+		ShowAll: true,
+	})
+	if err != nil {
+		e.logger.
+			WithError(err).
+			WithField("remote-path", listPath).
+			Error("failed to list directory")
+		return nil, ftperrors.NewInternalError("failed to list directory", nil)
+	}
+
+	logSkippedLines(e.logger, listPath, result.SkippedLines)
+
+	entries := make([]*entities.Entry, 0, len(result.Entries))
+	for _, entry := range result.Entries {
+		if !isRootDir(entry.Name) {
+			entries = append(entries, entry)
+		}
+	}
+	e.listings[dirPath] = entries
+	return entries, nil
+}
+
+// topmostGlobMatches function drops matches nested in other matched directories, as those are processed
+// along with the directory.
+func topmostGlobMatches(matches []*globMatch) []*globMatch {
+	var topmost []*globMatch
+	matchedDirs := make(map[string]bool)
+	for _, match := range matches {
+		// matches are sorted, so parent directories precede their contents
+		if hasMatchedParent(matchedDirs, match.path) {
+			continue
+		}
+		if match.entry.Type == entities.EntryTypeDir {
+			matchedDirs[match.path] = true
+		}
+		topmost = append(topmost, match)
+	}
+	return topmost
+}
+
+func hasMatchedParent(matchedDirs map[string]bool, matchPath string) bool {
+	for dirPath := entities.RemotePath(matchPath).Dir(); dirPath != "" && !dirPath.IsRoot(); dirPath = dirPath.Dir() {
+		if matchedDirs[dirPath.String()] {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,395 @@
+package ftp_test
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/alexZaicev/go-ftp-client/internal/domain/connection"
+	"github.com/alexZaicev/go-ftp-client/internal/domain/entities"
+	ftperrors "github.com/alexZaicev/go-ftp-client/internal/domain/errors"
+	"github.com/alexZaicev/go-ftp-client/internal/drivers/logging/assertlogging"
+	"github.com/alexZaicev/go-ftp-client/internal/usecases/ftp"
+	connectionMocks "github.com/alexZaicev/go-ftp-client/mocks/domain/connection"
+	repositoryMocks "github.com/alexZaicev/go-ftp-client/mocks/domain/repositories"
+)
+
+//nolint:funlen // test case can get a bit large
+func Test_ListFiles_Execute_Glob_Success(t *testing.T) {
+	testCases := []struct {
+		name          string
+		pattern       string
+		listedPaths   []string
+		expectedNames []string
+	}{
+		{
+			name:          "wildcard",
+			pattern:       "*.csv",
+			listedPaths:   []string{"."},
+			expectedNames: []string{"a.csv", "b.csv"},
+		},
+		{
+			name:          "hidden entries",
+			pattern:       ".*.csv",
+			listedPaths:   []string{"."},
+			expectedNames: []string{".hidden.csv"},
+		},
+		{
+			name:          "nested wildcards",
+			pattern:       "2024-??-*/report.*",
+			listedPaths:   []string{".", "2024-01-02", "2024-01-03"},
+			expectedNames: []string{"2024-01-02/report.pdf", "2024-01-03/report.csv"},
+		},
+		{
+			name:        "any directories",
+			pattern:     "**/*.csv",
+			listedPaths: []string{".", "2024-01-02", "2024-01-03", "2024-01-03/sub"},
+			expectedNames: []string{
+				"2024-01-03/report.csv",
+				"2024-01-03/sub/deep.csv",
+				"a.csv",
+				"b.csv",
+			},
+		},
+		{
+			name:          "literal base directory",
+			pattern:       "2024-01-03/sub/*",
+			listedPaths:   []string{"2024-01-03/sub"},
+			expectedNames: []string{"2024-01-03/sub/deep.csv"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			logger := assertlogging.NewLogger(t)
+
+			connMock := connectionMocks.NewConnection(t)
+			listings := getGlobTree(t)
+			expectedListings := make(map[string][]*entities.Entry)
+			for _, listedPath := range tc.listedPaths {
+				expectedListings[listedPath] = listings[listedPath]
+			}
+			setMocksForRemoteListing(ctx, connMock, expectedListings)
+
+			useCaseRepos := &ftp.ListFilesRepos{
+				Logger:     logger,
+				Connection: connMock,
+			}
+			useCaseInput := &ftp.ListFilesInput{
+				Path:     tc.pattern,
+				SortType: entities.SortTypeName,
+			}
+
+			useCase := &ftp.ListFiles{}
+			entries, err := useCase.Execute(ctx, useCaseRepos, useCaseInput)
+			assert.NoError(t, err)
+
+			names := make([]string, 0, len(entries))
+			for _, entry := range entries {
+				names = append(names, entry.Name)
+			}
+			assert.Equal(t, tc.expectedNames, names)
+		})
+	}
+}
+
+func Test_ListFiles_Execute_Glob_Errors(t *testing.T) {
+	testCases := []struct {
+		name           string
+		pattern        string
+		listErr        error
+		expectedErrMsg string
+		expectedErr    error
+	}{
+		{
+			name:           "malformed pattern",
+			pattern:        "logs/[a-",
+			expectedErrMsg: "an invalid argument error occurred: argument pattern is malformed",
+			expectedErr:    ftperrors.InvalidArgumentErrorType,
+		},
+		{
+			name:           "no matches",
+			pattern:        "*.gz",
+			expectedErrMsg: "not found error occurred: no entries match *.gz pattern",
+			expectedErr:    ftperrors.NotFoundErrorType,
+		},
+		{
+			name:           "list error",
+			pattern:        "*.gz",
+			listErr:        errors.New("mock error"),
+			expectedErrMsg: "an internal error occurred: failed to list directory",
+			expectedErr:    ftperrors.InternalErrorType,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			logger := assertlogging.NewLogger(t)
+
+			connMock := connectionMocks.NewConnection(t)
+			switch {
+			case tc.listErr != nil:
+				logger.
+					ExpectError("failed to list directory").
+					WithError(assertlogging.EqualError("mock error")).
+					WithField("remote-path", assertlogging.Equal("."))
+				connMock.
+					On("List", ctx, &connection.ListOptions{Path: ".", ShowAll: true}).
+					Return(nil, tc.listErr).
+					Once()
+			case tc.expectedErr == ftperrors.NotFoundErrorType:
+				setMocksForRemoteListing(ctx, connMock, map[string][]*entities.Entry{
+					".": getGlobTree(t)["."],
+				})
+			}
+			if tc.listErr == nil {
+				// pattern is looked up as a literal path before giving up
+				connMock.
+					On("Stat", ctx, tc.pattern).
+					Return(nil, ftperrors.NewNotFoundError("mock error", nil)).
+					Once()
+			}
+
+			useCaseRepos := &ftp.ListFilesRepos{
+				Logger:     logger,
+				Connection: connMock,
+			}
+			useCaseInput := &ftp.ListFilesInput{
+				Path:     tc.pattern,
+				SortType: entities.SortTypeName,
+			}
+
+			useCase := &ftp.ListFiles{}
+			entries, err := useCase.Execute(ctx, useCaseRepos, useCaseInput)
+			assert.Nil(t, entries)
+			require.EqualError(t, err, tc.expectedErrMsg)
+			assert.IsType(t, tc.expectedErr, err)
+		})
+	}
+}
+
+func Test_Remove_Execute_Glob_Success(t *testing.T) {
+	ctx := context.Background()
+
+	logger := assertlogging.NewLogger(t)
+
+	connMock := connectionMocks.NewConnection(t)
+	setMocksForRemoteListing(ctx, connMock, map[string][]*entities.Entry{
+		".":          getGlobTree(t)["."],
+		"2024-01-02": getGlobTree(t)["2024-01-02"],
+		"2024-01-03": getGlobTree(t)["2024-01-03"],
+	})
+	// matched directory is removed recursively
+	connMock.
+		On("List", ctx, &connection.ListOptions{Path: "2024-01-03/sub", ShowAll: true}).
+		Return(&connection.ListResult{Entries: getGlobTree(t)["2024-01-03/sub"]}, nil).
+		Once()
+	connMock.On("RemoveFile", "2024-01-03/sub/deep.csv").Return(nil).Once()
+	connMock.On("RemoveDir", "2024-01-03/sub").Return(nil).Once()
+	connMock.On("RemoveFile", "2024-01-02/summary.txt").Return(nil).Once()
+
+	useCaseRepos := &ftp.RemoveRepos{
+		Logger:     logger,
+		Connection: connMock,
+	}
+	useCaseInput := &ftp.RemoveInput{
+		Path: "2024-*/s*",
+	}
+
+	useCase := &ftp.Remove{}
+	output, err := useCase.Execute(ctx, useCaseRepos, useCaseInput)
+	assert.NoError(t, err)
+	assert.Equal(t, &ftp.RemoveOutput{
+		Results: []*entities.TransferResult{
+			{Path: "2024-01-02/summary.txt", Status: entities.TransferStatusOK},
+			{Path: "2024-01-03/sub/deep.csv", Status: entities.TransferStatusOK},
+			{Path: "2024-01-03/sub", Status: entities.TransferStatusOK},
+		},
+	}, output)
+}
+
+func Test_Download_Execute_Glob_Success(t *testing.T) {
+	ctx := context.Background()
+
+	logger := assertlogging.NewLogger(t)
+
+	connMock := connectionMocks.NewConnection(t)
+	setMocksForRemoteListing(ctx, connMock, map[string][]*entities.Entry{
+		"/data":            getGlobTree(t)["."],
+		"/data/2024-01-02": getGlobTree(t)["2024-01-02"],
+		"/data/2024-01-03": getGlobTree(t)["2024-01-03"],
+	})
+	for _, remotePath := range []string{"/data/2024-01-02/report.pdf", "/data/2024-01-03/report.csv"} {
+		connMock.On("Size", remotePath).Return(uint64(len(fileContent)), nil).Once()
+		connMock.On("Download", ctx, &connection.DownloadOptions{Path: remotePath}).Return(fileContent, nil).Once()
+	}
+
+	fileStoreMock := repositoryMocks.NewFileStore(t)
+	fileStoreMock.
+		On("SaveFile", filepath.Join(dirPath, "2024-01-02", "report.pdf"), fileContent).
+		Return(nil).
+		Once()
+	fileStoreMock.
+		On("SaveFile", filepath.Join(dirPath, "2024-01-03", "report.csv"), fileContent).
+		Return(nil).
+		Once()
+
+	useCaseRepos := &ftp.DownloadRepos{
+		Logger:     logger,
+		Connection: connMock,
+		FileStore:  fileStoreMock,
+	}
+	useCaseInput := &ftp.DownloadInput{
+		RemotePath: "/data/2024-??-*/report.*",
+		Path:       dirPath,
+	}
+
+	useCase := &ftp.Download{}
+	output, err := useCase.Execute(ctx, useCaseRepos, useCaseInput)
+	assert.NoError(t, err)
+	assert.Equal(t, &ftp.DownloadOutput{
+		Results: []*entities.TransferResult{
+			{Path: "/data/2024-01-02/report.pdf", Status: entities.TransferStatusOK},
+			{Path: "/data/2024-01-03/report.csv", Status: entities.TransferStatusOK},
+		},
+	}, output)
+}
+
+func Test_Download_Execute_Glob_LiteralFallback_Success(t *testing.T) {
+	ctx := context.Background()
+
+	logger := assertlogging.NewLogger(t)
+
+	remotePath := "/data/report [1].pdf"
+	entry := newEntry(t, entities.EntryTypeFile, "report [1].pdf", uint64(len(fileContent)), "2022-01-12 10:00")
+
+	connMock := connectionMocks.NewConnection(t)
+	// "[1]" only matches "report 1.pdf" as a pattern
+	setMocksForRemoteListing(ctx, connMock, map[string][]*entities.Entry{
+		"/data": {entry},
+	})
+	connMock.On("Stat", ctx, remotePath).Return(entry, nil).Once()
+	connMock.On("IsDir", ctx, remotePath).Return(false, nil).Once()
+	connMock.On("Size", remotePath).Return(uint64(len(fileContent)), nil).Once()
+	connMock.On("Download", ctx, &connection.DownloadOptions{Path: remotePath}).Return(fileContent, nil).Once()
+
+	fileStoreMock := repositoryMocks.NewFileStore(t)
+	fileStoreMock.
+		On("SaveFile", filepath.Join(dirPath, "report [1].pdf"), fileContent).
+		Return(nil).
+		Once()
+
+	useCaseRepos := &ftp.DownloadRepos{
+		Logger:     logger,
+		Connection: connMock,
+		FileStore:  fileStoreMock,
+	}
+	useCaseInput := &ftp.DownloadInput{
+		RemotePath: remotePath,
+		Path:       filepath.Join(dirPath, "report [1].pdf"),
+	}
+
+	useCase := &ftp.Download{}
+	output, err := useCase.Execute(ctx, useCaseRepos, useCaseInput)
+	assert.NoError(t, err)
+	assert.Equal(t, &ftp.DownloadOutput{
+		Results: []*entities.TransferResult{
+			{Path: remotePath, Status: entities.TransferStatusOK},
+		},
+	}, output)
+}
+
+func Test_Remove_Execute_Literal_Success(t *testing.T) {
+	ctx := context.Background()
+
+	logger := assertlogging.NewLogger(t)
+
+	// backslashes and glob meta characters are part of the name
+	remotePath := `logs4\*.log`
+
+	connMock := connectionMocks.NewConnection(t)
+	connMock.On("IsDir", ctx, remotePath).Return(false, nil).Once()
+	connMock.On("RemoveFile", remotePath).Return(nil).Once()
+
+	useCaseRepos := &ftp.RemoveRepos{
+		Logger:     logger,
+		Connection: connMock,
+	}
+	useCaseInput := &ftp.RemoveInput{
+		Path:    remotePath,
+		Literal: true,
+	}
+
+	useCase := &ftp.Remove{}
+	output, err := useCase.Execute(ctx, useCaseRepos, useCaseInput)
+	assert.NoError(t, err)
+	assert.Equal(t, &ftp.RemoveOutput{
+		Results: []*entities.TransferResult{
+			{Path: remotePath, Status: entities.TransferStatusOK},
+		},
+	}, output)
+}
+
+func Test_ListFiles_Execute_Literal_Success(t *testing.T) {
+	ctx := context.Background()
+
+	logger := assertlogging.NewLogger(t)
+
+	remotePath := `C:\data\[archive]`
+	expectedEntries := getGlobTree(t)["2024-01-02"]
+
+	connMock := connectionMocks.NewConnection(t)
+	connMock.
+		On("List", ctx, &connection.ListOptions{Path: remotePath}).
+		Return(&connection.ListResult{Entries: expectedEntries}, nil).
+		Once()
+
+	useCaseRepos := &ftp.ListFilesRepos{
+		Logger:     logger,
+		Connection: connMock,
+	}
+	useCaseInput := &ftp.ListFilesInput{
+		Path:     remotePath,
+		SortType: entities.SortTypeName,
+		Literal:  true,
+	}
+
+	useCase := &ftp.ListFiles{}
+	entries, err := useCase.Execute(ctx, useCaseRepos, useCaseInput)
+	assert.NoError(t, err)
+	assert.Equal(t, expectedEntries, entries)
+}
+
+func getGlobTree(t *testing.T) map[string][]*entities.Entry {
+	return map[string][]*entities.Entry{
+		".": {
+			rootDir1,
+			rootDir2,
+			newEntry(t, entities.EntryTypeFile, ".hidden.csv", 1, "2022-01-12 10:00"),
+			newEntry(t, entities.EntryTypeDir, "2024-01-02", 0, "2022-01-12 10:00"),
+			newEntry(t, entities.EntryTypeDir, "2024-01-03", 0, "2022-01-12 10:00"),
+			newEntry(t, entities.EntryTypeFile, "a.csv", 2, "2022-01-12 10:00"),
+			newEntry(t, entities.EntryTypeFile, "b.csv", 3, "2022-01-12 10:00"),
+			newEntry(t, entities.EntryTypeFile, "notes.txt", 4, "2022-01-12 10:00"),
+		},
+		"2024-01-02": {
+			newEntry(t, entities.EntryTypeFile, "report.pdf", 5, "2022-01-12 10:00"),
+			newEntry(t, entities.EntryTypeFile, "summary.txt", 5, "2022-01-12 10:00"),
+		},
+		"2024-01-03": {
+			newEntry(t, entities.EntryTypeFile, "report.csv", 5, "2022-01-12 10:00"),
+			newEntry(t, entities.EntryTypeDir, "sub", 0, "2022-01-12 10:00"),
+		},
+		"2024-01-03/sub": {
+			newEntry(t, entities.EntryTypeFile, "deep.csv", 6, "2022-01-12 10:00"),
+		},
+	}
+}
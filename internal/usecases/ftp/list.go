@@ -16,9 +16,13 @@ type ListFilesUseCase interface {
 }
 
 type ListFilesInput struct {
-	ShowAll  bool
+	ShowAll bool
+	// Path is either a literal path or a glob pattern. Entries matched by a pattern are named after
+	// their matched paths. A pattern matching nothing is listed as a literal path if it exists.
 	Path     string
 	SortType entities.SortType
+	// Literal takes Path as is, without expanding glob patterns or unescaping backslashes.
+	Literal bool
 }
 
 type ListFilesRepos struct {
@@ -30,14 +34,9 @@ type ListFiles struct {
 }
 
 func (u *ListFiles) Execute(ctx context.Context, repos *ListFilesRepos, input *ListFilesInput) ([]*entities.Entry, error) {
-	listOptions := &connection.ListOptions{
-		Path:    input.Path,
-		ShowAll: input.ShowAll,
-	}
-	entries, err := repos.Connection.List(ctx, listOptions)
+	entries, err := u.list(ctx, repos, input)
 	if err != nil {
-		repos.Logger.WithError(err).Error("failed to list files")
-		return nil, errors.NewInternalError("failed to list files", nil)
+		return nil, err
 	}
 
 	if len(entries) == 0 {
@@ -47,8 +46,15 @@ func (u *ListFiles) Execute(ctx context.Context, repos *ListFilesRepos, input *L
 		)
 	}
 
+	sortEntries(entries, input.SortType)
+
+	return entries, nil
+}
+
+// sortEntries function sorts entries in place by the sort type, keeping their order if it is not set.
+func sortEntries(entries []*entities.Entry, sortType entities.SortType) {
 	sort.Slice(entries, func(i, j int) bool {
-		switch input.SortType {
+		switch sortType {
 		case entities.SortTypeName:
 			return entries[i].Name < entries[j].Name
 		case entities.SortTypeSize:
@@ -59,6 +65,39 @@ func (u *ListFiles) Execute(ctx context.Context, repos *ListFilesRepos, input *L
 			return false
 		}
 	})
+}
 
-	return entries, nil
+func (u *ListFiles) list(ctx context.Context, repos *ListFilesRepos, input *ListFilesInput) ([]*entities.Entry, error) {
+	// glob meta characters escaped in a literal path are listed as is
+	listPath := remoteLiteralPath(input.Path, input.Literal)
+	if isRemoteGlob(input.Path, input.Literal) {
+		matches, err := expandGlobOrLiteral(ctx, repos.Logger, repos.Connection, input.Path)
+		if err != nil {
+			return nil, err
+		}
+		if matches != nil {
+			entries := make([]*entities.Entry, 0, len(matches))
+			for _, match := range matches {
+				entry := *match.entry
+				entry.Name = match.path
+				entries = append(entries, &entry)
+			}
+			return entries, nil
+		}
+		listPath = input.Path
+	}
+
+	listOptions := &connection.ListOptions{
+		Path:    listPath,
+		ShowAll: input.ShowAll,
+	}
+	result, err := repos.Connection.List(ctx, listOptions)
+	if err != nil {
+		repos.Logger.WithError(err).Error("failed to list files")
+		return nil, errors.NewInternalError("failed to list files", nil)
+	}
+
+	logSkippedLines(repos.Logger, listPath, result.SkippedLines)
+
+	return result.Entries, nil
 }
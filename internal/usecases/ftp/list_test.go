@@ -96,7 +96,7 @@ func Test_ListFiles_Execute_Success(t *testing.T) {
 						Path:    dirPath,
 						ShowAll: true,
 					}).
-				Return(tc.entries, nil).
+				Return(&connection.ListResult{Entries: tc.entries}, nil).
 				Once()
 
 			useCaseRepos := &ftp.ListFilesRepos{
@@ -121,6 +121,81 @@ func Test_ListFiles_Execute_Success(t *testing.T) {
 	}
 }
 
+func Test_ListFiles_Execute_SkippedLines_Success(t *testing.T) {
+	ctx := context.Background()
+
+	logger := assertlogging.NewLogger(t)
+	logger.
+		ExpectWarn("skipped list entry that could not be parsed").
+		WithField("remote-path", assertlogging.Equal(dirPath)).
+		WithField("line", assertlogging.Equal("total 8"))
+
+	expectedEntries := getEntries(t)[:1]
+
+	connMock := connectionMocks.NewConnection(t)
+	connMock.
+		On(
+			"List",
+			ctx,
+			&connection.ListOptions{
+				Path:    dirPath,
+				ShowAll: true,
+			}).
+		Return(&connection.ListResult{
+			Entries:      expectedEntries,
+			SkippedLines: []string{"total 8"},
+		}, nil).
+		Once()
+
+	useCaseRepos := &ftp.ListFilesRepos{
+		Logger:     logger,
+		Connection: connMock,
+	}
+	useCaseInput := &ftp.ListFilesInput{
+		Path:     dirPath,
+		ShowAll:  true,
+		SortType: entities.SortTypeName,
+	}
+
+	useCase := &ftp.ListFiles{}
+	entries, err := useCase.Execute(ctx, useCaseRepos, useCaseInput)
+	assert.NoError(t, err)
+	assert.Equal(t, expectedEntries, entries)
+}
+
+func Test_ListFiles_Execute_EscapedPath_Success(t *testing.T) {
+	ctx := context.Background()
+
+	logger := assertlogging.NewLogger(t)
+
+	expectedEntries := getEntries(t)[:1]
+
+	connMock := connectionMocks.NewConnection(t)
+	connMock.
+		On(
+			"List",
+			ctx,
+			&connection.ListOptions{
+				Path: "/data[1]/*.csv",
+			}).
+		Return(&connection.ListResult{Entries: expectedEntries}, nil).
+		Once()
+
+	useCaseRepos := &ftp.ListFilesRepos{
+		Logger:     logger,
+		Connection: connMock,
+	}
+	useCaseInput := &ftp.ListFilesInput{
+		Path:     `/data\[1]/\*.csv`,
+		SortType: entities.SortTypeName,
+	}
+
+	useCase := &ftp.ListFiles{}
+	entries, err := useCase.Execute(ctx, useCaseRepos, useCaseInput)
+	assert.NoError(t, err)
+	assert.Equal(t, expectedEntries, entries)
+}
+
 func Test_ListFiles_Execute_ListError(t *testing.T) {
 	ctx := context.Background()
 
@@ -173,7 +248,7 @@ func Test_ListFiles_Execute_NotFoundError(t *testing.T) {
 				Path:    dirPath,
 				ShowAll: true,
 			}).
-		Return(nil, nil).
+		Return(&connection.ListResult{}, nil).
 		Once()
 
 	useCaseRepos := &ftp.ListFilesRepos{
@@ -0,0 +1,133 @@
+package ftp
+
+import (
+	"path/filepath"
+	"strings"
+	"unicode"
+
+	"github.com/alexZaicev/go-ftp-client/internal/domain/entities"
+)
+
+const (
+	reasonNameEscapes  = "escapes the download directory"
+	reasonNameIllegal  = "contains characters that are illegal on the local filesystem"
+	reasonNameReserved = "is reserved on the local filesystem"
+
+	// windowsIllegalChars may not appear in file names on Windows, along with path separators.
+	windowsIllegalChars = `<>:"|?*`
+	sanitizedChar       = '_'
+)
+
+// windowsReservedNames are device names that may not be used as file names on Windows, even with an
+// extension.
+var windowsReservedNames = map[string]bool{
+	"CON": true, "PRN": true, "AUX": true, "NUL": true,
+	"COM1": true, "COM2": true, "COM3": true, "COM4": true, "COM5": true,
+	"COM6": true, "COM7": true, "COM8": true, "COM9": true,
+	"LPT1": true, "LPT2": true, "LPT3": true, "LPT4": true, "LPT5": true,
+	"LPT6": true, "LPT7": true, "LPT8": true, "LPT9": true,
+}
+
+// localNameChecker validates names of remote entries before they are joined onto a local path. Names
+// are checked against the rules of the local filesystem, where Windows is more restrictive than others.
+type localNameChecker struct {
+	windows bool
+}
+
+// check method returns why the name cannot be saved locally as is, or an empty string if it can. Names
+// that would escape the download directory are reported separately, as they must never be sanitized.
+func (c *localNameChecker) check(name string) (escapes bool, reason string) {
+	if name == "" || name == "." || name == ".." ||
+		strings.ContainsAny(name, "/\x00") || filepath.IsAbs(name) || filepath.VolumeName(name) != "" ||
+		(c.windows && strings.Contains(name, `\`)) {
+		return true, reasonNameEscapes
+	}
+
+	// control characters are legal on most filesystems, but may be used to tamper with terminal output
+	if strings.IndexFunc(name, unicode.IsControl) >= 0 {
+		return false, reasonNameIllegal
+	}
+	if !c.windows {
+		return false, ""
+	}
+
+	if strings.ContainsAny(name, windowsIllegalChars) || strings.HasSuffix(name, ".") || strings.HasSuffix(name, " ") {
+		return false, reasonNameIllegal
+	}
+	if windowsReservedNames[strings.ToUpper(reservedStem(name))] {
+		return false, reasonNameReserved
+	}
+	return false, ""
+}
+
+// sanitize method returns a name that is legal on the local filesystem. It must not be called with names
+// that escape the download directory.
+func (c *localNameChecker) sanitize(name string) string {
+	sanitized := []rune(name)
+	for i, r := range sanitized {
+		if unicode.IsControl(r) || (c.windows && strings.ContainsRune(windowsIllegalChars, r)) {
+			sanitized[i] = sanitizedChar
+		}
+	}
+	if c.windows {
+		// trailing dots and spaces are stripped by Windows
+		for i := len(sanitized) - 1; i >= 0 && (sanitized[i] == '.' || sanitized[i] == ' '); i-- {
+			sanitized[i] = sanitizedChar
+		}
+	}
+	name = string(sanitized)
+
+	if c.windows {
+		if stem := reservedStem(name); windowsReservedNames[strings.ToUpper(stem)] {
+			name = stem + string(sanitizedChar) + name[len(stem):]
+		}
+	}
+	return name
+}
+
+// linkTarget method validates the target of a remote symbolic link before it is preserved locally, where
+// relPath is the slash separated path of the link relative to the download directory and localPath is
+// where the link is created. Only relative targets made of legal names that stay within the download
+// directory are accepted, so that the link cannot be used to read or write outside of it. As cleaning the
+// target does not account for links, a ".." following a link preserved earlier is rejected as well.
+func (c *localNameChecker) linkTarget(relPath, localPath, linkName string, preserved map[string]bool) (string, bool) {
+	target := entities.RemotePath(linkName)
+	if linkName == "" || target.Root() != "" {
+		return "", false
+	}
+
+	current := filepath.Dir(localPath)
+	throughLink := false
+	for _, name := range strings.Split(linkName, entities.RemoteSeparator) {
+		switch name {
+		case "", ".":
+			continue
+		case "..":
+			if throughLink {
+				return "", false
+			}
+			current = filepath.Dir(current)
+			continue
+		}
+		if _, reason := c.check(name); reason != "" {
+			return "", false
+		}
+		current = filepath.Join(current, name)
+		throughLink = throughLink || preserved[current]
+	}
+
+	resolved := entities.RemotePath(relPath).Dir().Join(linkName).Clean()
+	if resolved == ".." || strings.HasPrefix(resolved.String(), "../") {
+		return "", false
+	}
+	return filepath.FromSlash(linkName), true
+}
+
+// reservedStem function returns the part of the name that Windows compares with reserved names, which
+// ignores the extension (e.g. CON.tar.gz is reserved as well).
+func reservedStem(name string) string {
+	if idx := strings.Index(name, "."); idx >= 0 {
+		return name[:idx]
+	}
+	return name
+}
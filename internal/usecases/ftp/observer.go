@@ -0,0 +1,155 @@
+package ftp
+
+import (
+	"io"
+	"time"
+
+	"github.com/alexZaicev/go-ftp-client/internal/domain/entities"
+)
+
+// TransferEvent describes the file being transferred when the observer is notified.
+type TransferEvent struct {
+	// Path is the remote path of the file, both for downloads and uploads.
+	Path        string
+	SizeInBytes uint64
+	// TransferredBytes is the number of bytes transferred so far.
+	TransferredBytes uint64
+	// StartedAt is when the transfer started, it is zero for skipped files.
+	StartedAt time.Time
+	// Elapsed is the time since the transfer started.
+	Elapsed time.Duration
+}
+
+// TransferObserver is notified of files transferred and entries processed by use cases, such as progress
+// bars, logs or machine readable output. It is optional for use cases that accept it. Transfers may run
+// in parallel, in which case the observer is notified by several goroutines at once.
+type TransferObserver interface {
+	// FileStarted is called before the file is transferred.
+	FileStarted(event TransferEvent)
+	// BytesTransferred is called as bytes of the file are transferred, where n is the number of bytes
+	// transferred since the previous call.
+	BytesTransferred(event TransferEvent, n int)
+	// FileCompleted is called once the file is transferred.
+	FileCompleted(event TransferEvent)
+	// FileFailed is called once the transfer of the file fails.
+	FileFailed(event TransferEvent, err error)
+	// FileSkipped is called if the file is not transferred, such as when it already exists.
+	FileSkipped(event TransferEvent, reason string)
+	// DirListed is called once the directory is listed while walking the remote tree.
+	DirListed(path string)
+	// EntryRemoved is called once the file or the directory is removed.
+	EntryRemoved(path string)
+}
+
+// TransferObservers notifies every observer in the given order.
+type TransferObservers []TransferObserver
+
+func (o TransferObservers) FileStarted(event TransferEvent) {
+	for _, observer := range o {
+		observer.FileStarted(event)
+	}
+}
+
+func (o TransferObservers) BytesTransferred(event TransferEvent, n int) {
+	for _, observer := range o {
+		observer.BytesTransferred(event, n)
+	}
+}
+
+func (o TransferObservers) FileCompleted(event TransferEvent) {
+	for _, observer := range o {
+		observer.FileCompleted(event)
+	}
+}
+
+func (o TransferObservers) FileFailed(event TransferEvent, err error) {
+	for _, observer := range o {
+		observer.FileFailed(event, err)
+	}
+}
+
+func (o TransferObservers) FileSkipped(event TransferEvent, reason string) {
+	for _, observer := range o {
+		observer.FileSkipped(event, reason)
+	}
+}
+
+func (o TransferObservers) DirListed(path string) {
+	for _, observer := range o {
+		observer.DirListed(path)
+	}
+}
+
+func (o TransferObservers) EntryRemoved(path string) {
+	for _, observer := range o {
+		observer.EntryRemoved(path)
+	}
+}
+
+// fileTransfer notifies the observer of the file being transferred, bytes written to it are reported as
+// transferred. It is nil if there is no observer, in which case nothing is reported.
+type fileTransfer struct {
+	observer TransferObserver
+	event    TransferEvent
+}
+
+func startFileTransfer(observer TransferObserver, path string, sizeInBytes uint64) *fileTransfer {
+	if observer == nil {
+		return nil
+	}
+
+	transfer := &fileTransfer{
+		observer: observer,
+		event: TransferEvent{
+			Path:        path,
+			SizeInBytes: sizeInBytes,
+			StartedAt:   time.Now(),
+		},
+	}
+	observer.FileStarted(transfer.event)
+	return transfer
+}
+
+func (t *fileTransfer) Write(p []byte) (int, error) {
+	t.event.TransferredBytes += uint64(len(p))
+	t.event.Elapsed = time.Since(t.event.StartedAt)
+	t.observer.BytesTransferred(t.event, len(p))
+	return len(p), nil
+}
+
+// writer method returns the writer that bytes of the file are written to, or nil if there is no observer.
+func (t *fileTransfer) writer() io.Writer {
+	if t == nil {
+		return nil
+	}
+	return t
+}
+
+// reader method returns the reader that reports bytes of the file as they are read from it.
+func (t *fileTransfer) reader(reader io.Reader) io.Reader {
+	if t == nil {
+		return reader
+	}
+	return io.TeeReader(reader, t)
+}
+
+// end method notifies the observer that the file was transferred, or that it failed if err is not nil.
+func (t *fileTransfer) end(err error) {
+	if t == nil {
+		return
+	}
+
+	t.event.Elapsed = time.Since(t.event.StartedAt)
+	if err != nil {
+		t.observer.FileFailed(t.event, err)
+		return
+	}
+	t.observer.FileCompleted(t.event)
+}
+
+// notifyFileSkipped function notifies the observer, if any, that the file is not transferred.
+func notifyFileSkipped(observer TransferObserver, skipped *entities.SkippedTransfer) {
+	if observer != nil {
+		observer.FileSkipped(TransferEvent{Path: skipped.Path}, skipped.Reason)
+	}
+}
@@ -0,0 +1,129 @@
+package ftp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/alexZaicev/go-ftp-client/internal/domain/connection"
+	"github.com/alexZaicev/go-ftp-client/internal/domain/entities"
+	ftperrors "github.com/alexZaicev/go-ftp-client/internal/domain/errors"
+	"github.com/alexZaicev/go-ftp-client/internal/domain/repositories"
+	"github.com/alexZaicev/go-ftp-client/internal/drivers/logging"
+)
+
+// maxRenameAttempts limits the search for a free name of a file transferred with the rename policy.
+const maxRenameAttempts = 1000
+
+// overwriteDecision is the outcome of applying an overwrite policy to an existing destination.
+type overwriteDecision int
+
+const (
+	overwriteDecisionWrite overwriteDecision = iota + 1
+	overwriteDecisionSkip
+	overwriteDecisionRename
+)
+
+// checksOverwrite function reports whether the policy requires checking the destination, as the
+// default policy overwrites it without a check.
+func checksOverwrite(policy entities.OverwritePolicy) bool {
+	return policy != "" && policy != entities.OverwritePolicyOverwrite
+}
+
+// resolveOverwrite function applies the policy to the source file whose destination already exists,
+// returning the reason when the file is skipped. Remote entries listed without a year are compared
+// with the year resolved.
+func resolveOverwrite(
+	policy entities.OverwritePolicy,
+	path string,
+	source, existing *entities.Entry,
+) (overwriteDecision, string, error) {
+	switch policy {
+	case entities.OverwritePolicySkip:
+		return overwriteDecisionSkip, "already exists", nil
+	case entities.OverwritePolicyNewer:
+		now := time.Now()
+		if source.ResolvedModificationDate(now).After(existing.ResolvedModificationDate(now)) {
+			return overwriteDecisionWrite, "", nil
+		}
+		return overwriteDecisionSkip, "is not older than the source", nil
+	case entities.OverwritePolicySizeDiffers:
+		if source.SizeInBytes != existing.SizeInBytes {
+			return overwriteDecisionWrite, "", nil
+		}
+		return overwriteDecisionSkip, "has the same size", nil
+	case entities.OverwritePolicyRename:
+		return overwriteDecisionRename, "", nil
+	case entities.OverwritePolicyFail:
+		return 0, "", ftperrors.NewConflictError(fmt.Sprintf("%s already exists", path), nil)
+	default:
+		return overwriteDecisionWrite, "", nil
+	}
+}
+
+// renamedPath function adds a numeric suffix to the file name, keeping its extension (e.g. report-1.csv).
+// Names that consist of the extension only, such as .env, get the suffix at the end.
+func renamedPath(filePath, ext string, suffix int) string {
+	if name := filePath[strings.LastIndexAny(filePath, `/\`)+1:]; name == ext {
+		ext = ""
+	}
+	return fmt.Sprintf("%s-%d%s", strings.TrimSuffix(filePath, ext), suffix, ext)
+}
+
+// freePath function returns the first renamed path under which no entry exists.
+func freePath(filePath, ext string, stat func(string) (*entities.Entry, error)) (string, error) {
+	for suffix := 1; suffix <= maxRenameAttempts; suffix++ {
+		candidate := renamedPath(filePath, ext, suffix)
+		entry, err := stat(candidate)
+		if err != nil {
+			return "", err
+		}
+		if entry == nil {
+			return candidate, nil
+		}
+	}
+	return "", ftperrors.NewConflictError(fmt.Sprintf("no free name found for %s", filePath), nil)
+}
+
+// remoteEntry function returns the remote entry under the path, or nil if it does not exist.
+func remoteEntry(
+	ctx context.Context,
+	logger logging.Logger,
+	conn connection.Connection,
+	remotePath string,
+) (*entities.Entry, error) {
+	entry, err := conn.Stat(ctx, remotePath)
+	if err != nil {
+		var notFoundErr *ftperrors.NotFoundError
+		if errors.As(err, &notFoundErr) {
+			return nil, nil
+		}
+
+		logger.
+			WithError(err).
+			WithField("remote-path", remotePath).
+			Error("failed to check if entry exists")
+		return nil, ftperrors.NewInternalError("failed to check if entry exists", nil)
+	}
+	return entry, nil
+}
+
+// localEntry function returns the local entry under the path, or nil if it does not exist.
+func localEntry(logger logging.Logger, fileStore repositories.FileStore, path string) (*entities.Entry, error) {
+	entry, err := fileStore.Stat(path)
+	if err != nil {
+		var notFoundErr *ftperrors.NotFoundError
+		if errors.As(err, &notFoundErr) {
+			return nil, nil
+		}
+
+		logger.
+			WithError(err).
+			WithField("path", path).
+			Error("failed to check if entry exists")
+		return nil, ftperrors.NewInternalError("failed to check if entry exists", nil)
+	}
+	return entry, nil
+}
@@ -3,7 +3,7 @@ package ftp
 import (
 	"context"
 	"fmt"
-	"path/filepath"
+	"strings"
 
 	"github.com/alexZaicev/go-ftp-client/internal/domain/connection"
 	"github.com/alexZaicev/go-ftp-client/internal/domain/entities"
@@ -12,54 +12,169 @@ import (
 )
 
 type RemoveUseCase interface {
-	Execute(context.Context, *RemoveRepos, *RemoveInput) error
+	Execute(context.Context, *RemoveRepos, *RemoveInput) (*RemoveOutput, error)
 }
 
 type RemoveInput struct {
+	// Path is either a literal path or a glob pattern matching entries to remove. A pattern matching
+	// nothing is removed as a literal path if it exists.
 	Path string
+	// Literal takes Path as is, without expanding glob patterns or unescaping backslashes.
+	Literal bool
+	// Filter selects entries of removed directories, if provided. Directories are only removed when
+	// none of their entries are left behind by the filter.
+	Filter *entities.FilterOptions
+	// ContinueOnError records entries of removed directories that fail as failed results and carries on
+	// with the rest, instead of aborting the removal.
+	ContinueOnError bool
+}
+
+type RemoveOutput struct {
+	// Results are outcomes of every removed and failed entry, in the order they were processed.
+	Results []*entities.TransferResult
 }
 
 type RemoveRepos struct {
 	Logger     logging.Logger
 	Connection connection.Connection
+	// Observer is notified of removed entries and listed directories, if provided.
+	Observer TransferObserver
 }
 
 type Remove struct {
 }
 
-func (u *Remove) Execute(ctx context.Context, repos *RemoveRepos, input *RemoveInput) error {
-	isDir, err := repos.Connection.IsDir(ctx, input.Path)
+// removeRun holds options and results of a single removal.
+type removeRun struct {
+	filter   *PathFilter
+	observer TransferObserver
+	// continueOnError records failed entries instead of aborting the removal
+	continueOnError bool
+	results         []*entities.TransferResult
+}
+
+func (r *removeRun) ok(path string) {
+	r.results = append(r.results, entities.NewTransferOK(path))
+	if r.observer != nil {
+		r.observer.EntryRemoved(path)
+	}
+}
+
+// fail method records the entry as failed and returns nil if the removal continues on errors, otherwise
+// it returns the error to abort the removal.
+func (r *removeRun) fail(path string, err error) error {
+	if !r.continueOnError {
+		return err
+	}
+	r.results = append(r.results, entities.NewTransferFailed(path, err))
+	return nil
+}
+
+func (u *Remove) Execute(ctx context.Context, repos *RemoveRepos, input *RemoveInput) (*RemoveOutput, error) {
+	filter, err := NewPathFilter(input.Filter)
+	if err != nil {
+		return nil, err
+	}
+
+	run := &removeRun{
+		filter:          filter,
+		observer:        repos.Observer,
+		continueOnError: input.ContinueOnError,
+	}
+	if removeErr := u.removeAll(ctx, repos, run, input.Path, input.Literal); removeErr != nil {
+		return nil, removeErr
+	}
+
+	return &RemoveOutput{Results: run.results}, nil
+}
+
+func (u *Remove) removeAll(
+	ctx context.Context,
+	repos *RemoveRepos,
+	run *removeRun,
+	pattern string,
+	literal bool,
+) error {
+	if !isRemoteGlob(pattern, literal) {
+		return u.remove(ctx, repos, run, remoteLiteralPath(pattern, literal))
+	}
+
+	matches, err := expandGlobOrLiteral(ctx, repos.Logger, repos.Connection, pattern)
+	if err != nil {
+		return err
+	}
+	if matches == nil {
+		return u.remove(ctx, repos, run, pattern)
+	}
+
+	base := globBase(pattern)
+	for _, match := range topmostGlobMatches(matches) {
+		relPath := strings.TrimPrefix(strings.TrimPrefix(match.path, base), "/")
+		var matchErr error
+		switch {
+		case match.entry.Type == entities.EntryTypeDir:
+			if run.filter.MatchDir(relPath) {
+				_, matchErr = u.removeRecursive(ctx, repos, run, match.path, relPath)
+			}
+		case run.filter.MatchEntry(relPath, match.entry):
+			matchErr = u.removeFile(repos, run, match.path)
+		}
+		if matchErr != nil {
+			if failErr := run.fail(match.path, matchErr); failErr != nil {
+				return failErr
+			}
+		}
+	}
+
+	return nil
+}
+
+func (u *Remove) remove(ctx context.Context, repos *RemoveRepos, run *removeRun, path string) error {
+	isDir, err := repos.Connection.IsDir(ctx, path)
 	if err != nil {
 		repos.Logger.
 			WithError(err).
-			WithField("remote-path", input.Path).
+			WithField("remote-path", path).
 			Error("failed to check if entry is a directory")
 		return ftperrors.NewInternalError("failed to check if entry is a directory", nil)
 	}
 
 	if !isDir {
-		if removeErr := repos.Connection.RemoveFile(input.Path); removeErr != nil {
-			repos.Logger.
-				WithError(removeErr).
-				WithField("remote-path", input.Path).
-				Error("failed to remove file")
-			return ftperrors.NewInternalError("failed to remove file", nil)
-		}
-
-		return nil
+		return u.removeFile(repos, run, path)
 	}
 
 	// recursively remove contents of the provided directory; the directory itself
 	// will be removed in the later connection call.
-	if removeErr := u.removeRecursive(ctx, repos, input.Path); removeErr != nil {
+	if _, removeErr := u.removeRecursive(ctx, repos, run, path, ""); removeErr != nil {
 		return removeErr
 	}
 
 	return nil
 }
 
-func (u *Remove) removeRecursive(ctx context.Context, repos *RemoveRepos, path string) error {
-	entries, listErr := repos.Connection.List(ctx, &connection.ListOptions{
+func (u *Remove) removeFile(repos *RemoveRepos, run *removeRun, path string) error {
+	if removeErr := repos.Connection.RemoveFile(path); removeErr != nil {
+		repos.Logger.
+			WithError(removeErr).
+			WithField("remote-path", path).
+			Error("failed to remove file")
+		return ftperrors.NewInternalError("failed to remove file", nil)
+	}
+
+	run.ok(path)
+	return nil
+}
+
+// removeRecursive method removes the directory along with its contents, where relPath is the path of the
+// directory relative to the root of the removal that is matched against the filter. It reports whether
+// the directory was kept, as some of its entries were left behind by the filter or failed to be removed.
+func (u *Remove) removeRecursive(
+	ctx context.Context,
+	repos *RemoveRepos,
+	run *removeRun,
+	path, relPath string,
+) (bool, error) {
+	result, listErr := repos.Connection.List(ctx, &connection.ListOptions{
 		Path:    path,
 		ShowAll: true,
 	})
@@ -68,33 +183,36 @@ func (u *Remove) removeRecursive(ctx context.Context, repos *RemoveRepos, path s
 			WithError(listErr).
 			WithField("remote-path", path).
 			Error("failed to list directory")
-		return ftperrors.NewInternalError("failed to list directory", nil)
+		return false, ftperrors.NewInternalError("failed to list directory", nil)
 	}
 
-	for _, entry := range entries {
+	logSkippedLines(repos.Logger, path, result.SkippedLines)
+	if run.observer != nil {
+		run.observer.DirListed(path)
+	}
+
+	kept := false
+	for _, entry := range result.Entries {
 		if isRootDir(entry.Name) {
 			continue
 		}
 
-		entryPath := filepath.Join(path, entry.Name)
-		logger := repos.Logger.WithField("remote-path", entryPath)
+		entryPath := entities.RemotePath(path).Join(entry.Name).String()
+		entryRelPath := entities.RemotePath(relPath).Join(entry.Name).String()
 
-		switch entry.Type {
-		case entities.EntryTypeFile, entities.EntryTypeLink:
-			if removeErr := repos.Connection.RemoveFile(entryPath); removeErr != nil {
-				logger.WithError(removeErr).Error("failed to remove file")
-				return ftperrors.NewInternalError("failed to remove file", nil)
-			}
-		case entities.EntryTypeDir:
-			if removeErr := u.removeRecursive(ctx, repos, entryPath); removeErr != nil {
-				return removeErr
+		entryKept, removeErr := u.removeEntry(ctx, repos, run, entryPath, entryRelPath, entry)
+		if removeErr != nil {
+			if failErr := run.fail(entryPath, removeErr); failErr != nil {
+				return false, failErr
 			}
-		default:
-			return ftperrors.NewUnknownError(
-				fmt.Sprintf("unexpected entry type: %d", entry.Type),
-				nil,
-			)
+			// the failed entry is left behind, so the directory cannot be removed
+			entryKept = true
 		}
+		kept = kept || entryKept
+	}
+
+	if kept {
+		return true, nil
 	}
 
 	if removeErr := repos.Connection.RemoveDir(path); removeErr != nil {
@@ -102,8 +220,36 @@ func (u *Remove) removeRecursive(ctx context.Context, repos *RemoveRepos, path s
 			WithError(removeErr).
 			WithField("remote-path", path).
 			Error("failed to remove directory")
-		return ftperrors.NewInternalError("failed to remove directory", nil)
+		return false, ftperrors.NewInternalError("failed to remove directory", nil)
 	}
 
-	return nil
+	run.ok(path)
+	return false, nil
+}
+
+// removeEntry method removes the entry of the directory, reporting whether it was kept by the filter.
+func (u *Remove) removeEntry(
+	ctx context.Context,
+	repos *RemoveRepos,
+	run *removeRun,
+	entryPath, entryRelPath string,
+	entry *entities.Entry,
+) (bool, error) {
+	switch entry.Type {
+	case entities.EntryTypeFile, entities.EntryTypeLink:
+		if !run.filter.MatchEntry(entryRelPath, entry) {
+			return true, nil
+		}
+		return false, u.removeFile(repos, run, entryPath)
+	case entities.EntryTypeDir:
+		if !run.filter.MatchDir(entryRelPath) {
+			return true, nil
+		}
+		return u.removeRecursive(ctx, repos, run, entryPath, entryRelPath)
+	default:
+		return false, ftperrors.NewUnknownError(
+			fmt.Sprintf("unexpected entry type: %d", entry.Type),
+			nil,
+		)
+	}
 }
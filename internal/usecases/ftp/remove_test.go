@@ -5,6 +5,7 @@ import (
 	"errors"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -15,6 +16,7 @@ import (
 	"github.com/alexZaicev/go-ftp-client/internal/drivers/logging/assertlogging"
 	"github.com/alexZaicev/go-ftp-client/internal/usecases/ftp"
 	connectionMocks "github.com/alexZaicev/go-ftp-client/mocks/domain/connection"
+	useCaseMocks "github.com/alexZaicev/go-ftp-client/mocks/usecases/ftp"
 )
 
 func Test_Remove_Execute_File_Success(t *testing.T) {
@@ -45,10 +47,15 @@ func Test_Remove_Execute_File_Success(t *testing.T) {
 	useCase := ftp.Remove{}
 
 	// act
-	err := useCase.Execute(ctx, useCaseRepos, useCaseInput)
+	output, err := useCase.Execute(ctx, useCaseRepos, useCaseInput)
 
 	// assert
 	assert.NoError(t, err)
+	assert.Equal(t, &ftp.RemoveOutput{
+		Results: []*entities.TransferResult{
+			{Path: remotePathNoDir, Status: entities.TransferStatusOK},
+		},
+	}, output)
 }
 
 func Test_Remove_Execute_Directory_Success(t *testing.T) {
@@ -67,12 +74,14 @@ func Test_Remove_Execute_Directory_Success(t *testing.T) {
 			Path:    remoteDirPath,
 			ShowAll: true,
 		}).
-		Return([]*entities.Entry{
-			rootDir1,
-			rootDir2,
-			newEntry(t, entities.EntryTypeFile, "file-1", sizeInBytes, "2022-01-12 16:23"),
-			newEntry(t, entities.EntryTypeLink, "link-1", sizeInBytes, "2022-01-12 16:23"),
-			newEntry(t, entities.EntryTypeDir, "dir-1", sizeInBytes, "2022-01-12 16:23"),
+		Return(&connection.ListResult{
+			Entries: []*entities.Entry{
+				rootDir1,
+				rootDir2,
+				newEntry(t, entities.EntryTypeFile, "file-1", sizeInBytes, "2022-01-12 16:23"),
+				newEntry(t, entities.EntryTypeLink, "link-1", sizeInBytes, "2022-01-12 16:23"),
+				newEntry(t, entities.EntryTypeDir, "dir-1", sizeInBytes, "2022-01-12 16:23"),
+			},
 		}, nil).
 		Once()
 	connMock.
@@ -80,10 +89,12 @@ func Test_Remove_Execute_Directory_Success(t *testing.T) {
 			Path:    filepath.Join(remoteDirPath, "dir-1"),
 			ShowAll: true,
 		}).
-		Return([]*entities.Entry{
-			rootDir1,
-			rootDir2,
-			newEntry(t, entities.EntryTypeFile, "file-2", sizeInBytes, "2022-01-12 16:23"),
+		Return(&connection.ListResult{
+			Entries: []*entities.Entry{
+				rootDir1,
+				rootDir2,
+				newEntry(t, entities.EntryTypeFile, "file-2", sizeInBytes, "2022-01-12 16:23"),
+			},
 		}, nil).
 		Once()
 	connMock.
@@ -119,10 +130,158 @@ func Test_Remove_Execute_Directory_Success(t *testing.T) {
 	useCase := ftp.Remove{}
 
 	// act
-	err := useCase.Execute(ctx, useCaseRepos, useCaseInput)
+	output, err := useCase.Execute(ctx, useCaseRepos, useCaseInput)
 
 	// assert
 	assert.NoError(t, err)
+	assert.Equal(t, &ftp.RemoveOutput{
+		Results: []*entities.TransferResult{
+			{Path: remoteDirPath + "/file-1", Status: entities.TransferStatusOK},
+			{Path: remoteDirPath + "/link-1", Status: entities.TransferStatusOK},
+			{Path: remoteDirPath + "/dir-1/file-2", Status: entities.TransferStatusOK},
+			{Path: remoteDirPath + "/dir-1", Status: entities.TransferStatusOK},
+			{Path: remoteDirPath, Status: entities.TransferStatusOK},
+		},
+	}, output)
+}
+
+func Test_Remove_Execute_Directory_Filter_Success(t *testing.T) {
+	// arrange
+	ctx := context.Background()
+
+	logger := assertlogging.NewLogger(t)
+
+	connMock := connectionMocks.NewConnection(t)
+	connMock.
+		On("IsDir", ctx, remoteDirPath).
+		Return(true, nil).
+		Once()
+	connMock.
+		On("List", ctx, &connection.ListOptions{
+			Path:    remoteDirPath,
+			ShowAll: true,
+		}).
+		Return(&connection.ListResult{
+			Entries: []*entities.Entry{
+				rootDir1,
+				rootDir2,
+				newEntry(t, entities.EntryTypeFile, "file-1.log", sizeInBytes, "2022-01-12 16:23"),
+				newEntry(t, entities.EntryTypeFile, "file-2.txt", sizeInBytes, "2022-01-12 16:23"),
+				newEntry(t, entities.EntryTypeDir, ".git", sizeInBytes, "2022-01-12 16:23"),
+				newEntry(t, entities.EntryTypeDir, "dir-1", sizeInBytes, "2022-01-12 16:23"),
+			},
+		}, nil).
+		Once()
+	connMock.
+		On("List", ctx, &connection.ListOptions{
+			Path:    filepath.Join(remoteDirPath, "dir-1"),
+			ShowAll: true,
+		}).
+		Return(&connection.ListResult{
+			Entries: []*entities.Entry{
+				rootDir1,
+				rootDir2,
+				newEntry(t, entities.EntryTypeFile, "file-3.log", sizeInBytes, "2022-01-12 16:23"),
+			},
+		}, nil).
+		Once()
+	// directories with entries left behind by the filter are kept
+	connMock.
+		On("RemoveDir", filepath.Join(remoteDirPath, "dir-1")).
+		Return(nil).
+		Once()
+	connMock.
+		On("RemoveFile", filepath.Join(remoteDirPath, "file-1.log")).
+		Return(nil).
+		Once()
+	connMock.
+		On("RemoveFile", filepath.Join(remoteDirPath, "dir-1", "file-3.log")).
+		Return(nil).
+		Once()
+
+	useCaseRepos := &ftp.RemoveRepos{
+		Logger:     logger,
+		Connection: connMock,
+	}
+
+	useCaseInput := &ftp.RemoveInput{
+		Path: remoteDirPath,
+		Filter: &entities.FilterOptions{
+			Include: []string{"*.log"},
+			Exclude: []string{".git/"},
+		},
+	}
+
+	useCase := ftp.Remove{}
+
+	// act
+	output, err := useCase.Execute(ctx, useCaseRepos, useCaseInput)
+
+	// assert
+	assert.NoError(t, err)
+	assert.Equal(t, &ftp.RemoveOutput{
+		Results: []*entities.TransferResult{
+			{Path: remoteDirPath + "/file-1.log", Status: entities.TransferStatusOK},
+			{Path: remoteDirPath + "/dir-1/file-3.log", Status: entities.TransferStatusOK},
+			{Path: remoteDirPath + "/dir-1", Status: entities.TransferStatusOK},
+		},
+	}, output)
+}
+
+func Test_Remove_Execute_Directory_Filter_RecentEntries_Success(t *testing.T) {
+	// arrange
+	ctx := context.Background()
+
+	logger := assertlogging.NewLogger(t)
+
+	connMock := connectionMocks.NewConnection(t)
+	connMock.
+		On("IsDir", ctx, remoteDirPath).
+		Return(true, nil).
+		Once()
+	connMock.
+		On("List", ctx, &connection.ListOptions{
+			Path:    remoteDirPath,
+			ShowAll: true,
+		}).
+		Return(&connection.ListResult{
+			Entries: []*entities.Entry{
+				rootDir1,
+				rootDir2,
+				newRecentEntry(entities.EntryTypeFile, "file-1.log", sizeInBytes, time.Hour),
+				newRecentEntry(entities.EntryTypeFile, "file-2.log", sizeInBytes, 30*24*time.Hour),
+			},
+		}, nil).
+		Once()
+	connMock.
+		On("RemoveFile", filepath.Join(remoteDirPath, "file-1.log")).
+		Return(nil).
+		Once()
+
+	useCaseRepos := &ftp.RemoveRepos{
+		Logger:     logger,
+		Connection: connMock,
+	}
+
+	useCaseInput := &ftp.RemoveInput{
+		Path: remoteDirPath,
+		Filter: &entities.FilterOptions{
+			ModifiedAfter: time.Now().Add(-7 * 24 * time.Hour),
+		},
+	}
+
+	useCase := ftp.Remove{}
+
+	// act
+	output, err := useCase.Execute(ctx, useCaseRepos, useCaseInput)
+
+	// assert
+	assert.NoError(t, err)
+	assert.Equal(t, &ftp.RemoveOutput{
+		Results: []*entities.TransferResult{
+			{Path: remoteDirPath + "/file-1.log", Status: entities.TransferStatusOK},
+		},
+	}, output)
 }
 
 func Test_Remove_Execute_IsDirError(t *testing.T) {
@@ -153,9 +312,10 @@ func Test_Remove_Execute_IsDirError(t *testing.T) {
 	useCase := ftp.Remove{}
 
 	// act
-	err := useCase.Execute(ctx, useCaseRepos, useCaseInput)
+	output, err := useCase.Execute(ctx, useCaseRepos, useCaseInput)
 
 	// assert
+	assert.Nil(t, output)
 	require.EqualError(t, err, "an internal error occurred: failed to check if entry is a directory")
 	assert.IsType(t, ftperrors.InternalErrorType, err)
 	assert.NoError(t, errors.Unwrap(err))
@@ -193,9 +353,10 @@ func Test_Remove_Execute_RemoveFileError(t *testing.T) {
 	useCase := ftp.Remove{}
 
 	// act
-	err := useCase.Execute(ctx, useCaseRepos, useCaseInput)
+	output, err := useCase.Execute(ctx, useCaseRepos, useCaseInput)
 
 	// assert
+	assert.Nil(t, output)
 	require.EqualError(t, err, "an internal error occurred: failed to remove file")
 	assert.IsType(t, ftperrors.InternalErrorType, err)
 	assert.NoError(t, errors.Unwrap(err))
@@ -221,12 +382,14 @@ func Test_Remove_Execute_ListError(t *testing.T) {
 			Path:    remoteDirPath,
 			ShowAll: true,
 		}).
-		Return([]*entities.Entry{
-			rootDir1,
-			rootDir2,
-			newEntry(t, entities.EntryTypeFile, "file-1", sizeInBytes, "2022-01-12 16:23"),
-			newEntry(t, entities.EntryTypeLink, "link-1", sizeInBytes, "2022-01-12 16:23"),
-			newEntry(t, entities.EntryTypeDir, "dir-1", sizeInBytes, "2022-01-12 16:23"),
+		Return(&connection.ListResult{
+			Entries: []*entities.Entry{
+				rootDir1,
+				rootDir2,
+				newEntry(t, entities.EntryTypeFile, "file-1", sizeInBytes, "2022-01-12 16:23"),
+				newEntry(t, entities.EntryTypeLink, "link-1", sizeInBytes, "2022-01-12 16:23"),
+				newEntry(t, entities.EntryTypeDir, "dir-1", sizeInBytes, "2022-01-12 16:23"),
+			},
 		}, nil).
 		Once()
 	connMock.
@@ -257,9 +420,10 @@ func Test_Remove_Execute_ListError(t *testing.T) {
 	useCase := ftp.Remove{}
 
 	// act
-	err := useCase.Execute(ctx, useCaseRepos, useCaseInput)
+	output, err := useCase.Execute(ctx, useCaseRepos, useCaseInput)
 
 	// assert
+	assert.Nil(t, output)
 	require.EqualError(t, err, "an internal error occurred: failed to list directory")
 	assert.IsType(t, ftperrors.InternalErrorType, err)
 	assert.NoError(t, errors.Unwrap(err))
@@ -285,12 +449,14 @@ func Test_Remove_Execute_Directory_RemoveFileError(t *testing.T) {
 			Path:    remoteDirPath,
 			ShowAll: true,
 		}).
-		Return([]*entities.Entry{
-			rootDir1,
-			rootDir2,
-			newEntry(t, entities.EntryTypeFile, "file-1", sizeInBytes, "2022-01-12 16:23"),
-			newEntry(t, entities.EntryTypeLink, "link-1", sizeInBytes, "2022-01-12 16:23"),
-			newEntry(t, entities.EntryTypeDir, "dir-1", sizeInBytes, "2022-01-12 16:23"),
+		Return(&connection.ListResult{
+			Entries: []*entities.Entry{
+				rootDir1,
+				rootDir2,
+				newEntry(t, entities.EntryTypeFile, "file-1", sizeInBytes, "2022-01-12 16:23"),
+				newEntry(t, entities.EntryTypeLink, "link-1", sizeInBytes, "2022-01-12 16:23"),
+				newEntry(t, entities.EntryTypeDir, "dir-1", sizeInBytes, "2022-01-12 16:23"),
+			},
 		}, nil).
 		Once()
 	connMock.
@@ -298,10 +464,12 @@ func Test_Remove_Execute_Directory_RemoveFileError(t *testing.T) {
 			Path:    filepath.Join(remoteDirPath, "dir-1"),
 			ShowAll: true,
 		}).
-		Return([]*entities.Entry{
-			rootDir1,
-			rootDir2,
-			newEntry(t, entities.EntryTypeFile, "file-2", sizeInBytes, "2022-01-12 16:23"),
+		Return(&connection.ListResult{
+			Entries: []*entities.Entry{
+				rootDir1,
+				rootDir2,
+				newEntry(t, entities.EntryTypeFile, "file-2", sizeInBytes, "2022-01-12 16:23"),
+			},
 		}, nil).
 		Once()
 	connMock.
@@ -329,14 +497,142 @@ func Test_Remove_Execute_Directory_RemoveFileError(t *testing.T) {
 	useCase := ftp.Remove{}
 
 	// act
-	err := useCase.Execute(ctx, useCaseRepos, useCaseInput)
+	output, err := useCase.Execute(ctx, useCaseRepos, useCaseInput)
 
 	// assert
+	assert.Nil(t, output)
 	require.EqualError(t, err, "an internal error occurred: failed to remove file")
 	assert.IsType(t, ftperrors.InternalErrorType, err)
 	assert.NoError(t, errors.Unwrap(err))
 }
 
+func Test_Remove_Execute_ContinueOnError_Success(t *testing.T) {
+	// arrange
+	ctx := context.Background()
+
+	logger := assertlogging.NewLogger(t)
+	logger.
+		ExpectError("failed to remove file").
+		WithError(assertlogging.EqualError("mock error")).
+		WithField("remote-path", assertlogging.Equal(filepath.Join(remoteDirPath, "file-1")))
+
+	connMock := connectionMocks.NewConnection(t)
+	connMock.
+		On("IsDir", ctx, remoteDirPath).
+		Return(true, nil).
+		Once()
+	connMock.
+		On("List", ctx, &connection.ListOptions{
+			Path:    remoteDirPath,
+			ShowAll: true,
+		}).
+		Return(&connection.ListResult{
+			Entries: []*entities.Entry{
+				newEntry(t, entities.EntryTypeFile, "file-1", sizeInBytes, "2022-01-12 16:23"),
+				newEntry(t, entities.EntryTypeFile, "file-2", sizeInBytes, "2022-01-12 16:23"),
+			},
+		}, nil).
+		Once()
+	connMock.
+		On("RemoveFile", filepath.Join(remoteDirPath, "file-1")).
+		Return(errors.New("mock error")).
+		Once()
+	connMock.
+		On("RemoveFile", filepath.Join(remoteDirPath, "file-2")).
+		Return(nil).
+		Once()
+
+	useCaseRepos := &ftp.RemoveRepos{
+		Logger:     logger,
+		Connection: connMock,
+	}
+
+	useCaseInput := &ftp.RemoveInput{
+		Path:            remoteDirPath,
+		ContinueOnError: true,
+	}
+
+	useCase := ftp.Remove{}
+
+	// act
+	output, err := useCase.Execute(ctx, useCaseRepos, useCaseInput)
+
+	// assert
+	require.NoError(t, err)
+	require.Len(t, output.Results, 2)
+	// directory is kept, as the file that failed to be removed is left behind
+	assert.Equal(t, remoteDirPath+"/file-1", output.Results[0].Path)
+	assert.Equal(t, entities.TransferStatusFailed, output.Results[0].Status)
+	assert.Equal(t, "an internal error occurred: failed to remove file", output.Results[0].Reason)
+	assert.IsType(t, ftperrors.InternalErrorType, output.Results[0].Err)
+	assert.Equal(t, &entities.TransferResult{
+		Path:   remoteDirPath + "/file-2",
+		Status: entities.TransferStatusOK,
+	}, output.Results[1])
+}
+
+func Test_Remove_Execute_Observer_Success(t *testing.T) {
+	// arrange
+	ctx := context.Background()
+
+	logger := assertlogging.NewLogger(t)
+
+	observerMock := useCaseMocks.NewTransferObserver(t)
+	observerMock.
+		On("DirListed", remoteDirPath).
+		Once()
+	observerMock.
+		On("EntryRemoved", filepath.Join(remoteDirPath, "file-1")).
+		Once()
+	observerMock.
+		On("EntryRemoved", remoteDirPath).
+		Once()
+
+	connMock := connectionMocks.NewConnection(t)
+	connMock.
+		On("IsDir", ctx, remoteDirPath).
+		Return(true, nil).
+		Once()
+	connMock.
+		On("List", ctx, &connection.ListOptions{
+			Path:    remoteDirPath,
+			ShowAll: true,
+		}).
+		Return(&connection.ListResult{
+			Entries: []*entities.Entry{
+				newEntry(t, entities.EntryTypeFile, "file-1", sizeInBytes, "2022-01-12 16:23"),
+			},
+		}, nil).
+		Once()
+	connMock.
+		On("RemoveFile", filepath.Join(remoteDirPath, "file-1")).
+		Return(nil).
+		Once()
+	connMock.
+		On("RemoveDir", remoteDirPath).
+		Return(nil).
+		Once()
+
+	useCaseRepos := &ftp.RemoveRepos{
+		Logger:     logger,
+		Connection: connMock,
+		Observer:   observerMock,
+	}
+
+	useCaseInput := &ftp.RemoveInput{
+		Path: remoteDirPath,
+	}
+
+	useCase := ftp.Remove{}
+
+	// act
+	output, err := useCase.Execute(ctx, useCaseRepos, useCaseInput)
+
+	// assert
+	require.NoError(t, err)
+	assert.Len(t, output.Results, 2)
+}
+
 func Test_Remove_Execute_RemoveDirError(t *testing.T) {
 	// arrange
 	ctx := context.Background()
@@ -357,12 +653,14 @@ func Test_Remove_Execute_RemoveDirError(t *testing.T) {
 			Path:    remoteDirPath,
 			ShowAll: true,
 		}).
-		Return([]*entities.Entry{
-			rootDir1,
-			rootDir2,
-			newEntry(t, entities.EntryTypeFile, "file-1", sizeInBytes, "2022-01-12 16:23"),
-			newEntry(t, entities.EntryTypeLink, "link-1", sizeInBytes, "2022-01-12 16:23"),
-			newEntry(t, entities.EntryTypeDir, "dir-1", sizeInBytes, "2022-01-12 16:23"),
+		Return(&connection.ListResult{
+			Entries: []*entities.Entry{
+				rootDir1,
+				rootDir2,
+				newEntry(t, entities.EntryTypeFile, "file-1", sizeInBytes, "2022-01-12 16:23"),
+				newEntry(t, entities.EntryTypeLink, "link-1", sizeInBytes, "2022-01-12 16:23"),
+				newEntry(t, entities.EntryTypeDir, "dir-1", sizeInBytes, "2022-01-12 16:23"),
+			},
 		}, nil).
 		Once()
 	connMock.
@@ -370,10 +668,12 @@ func Test_Remove_Execute_RemoveDirError(t *testing.T) {
 			Path:    filepath.Join(remoteDirPath, "dir-1"),
 			ShowAll: true,
 		}).
-		Return([]*entities.Entry{
-			rootDir1,
-			rootDir2,
-			newEntry(t, entities.EntryTypeFile, "file-2", sizeInBytes, "2022-01-12 16:23"),
+		Return(&connection.ListResult{
+			Entries: []*entities.Entry{
+				rootDir1,
+				rootDir2,
+				newEntry(t, entities.EntryTypeFile, "file-2", sizeInBytes, "2022-01-12 16:23"),
+			},
 		}, nil).
 		Once()
 	connMock.
@@ -409,9 +709,10 @@ func Test_Remove_Execute_RemoveDirError(t *testing.T) {
 	useCase := ftp.Remove{}
 
 	// act
-	err := useCase.Execute(ctx, useCaseRepos, useCaseInput)
+	output, err := useCase.Execute(ctx, useCaseRepos, useCaseInput)
 
 	// assert
+	assert.Nil(t, output)
 	require.EqualError(t, err, "an internal error occurred: failed to remove directory")
 	assert.IsType(t, ftperrors.InternalErrorType, err)
 	assert.NoError(t, errors.Unwrap(err))
@@ -433,12 +734,14 @@ func Test_Remove_Execute_UnknownError(t *testing.T) {
 			Path:    remoteDirPath,
 			ShowAll: true,
 		}).
-		Return([]*entities.Entry{
-			rootDir1,
-			rootDir2,
-			newEntry(t, entities.EntryTypeFile, "file-1", sizeInBytes, "2022-01-12 16:23"),
-			newEntry(t, entities.EntryTypeLink, "link-1", sizeInBytes, "2022-01-12 16:23"),
-			newEntry(t, entities.EntryTypeDir, "dir-1", sizeInBytes, "2022-01-12 16:23"),
+		Return(&connection.ListResult{
+			Entries: []*entities.Entry{
+				rootDir1,
+				rootDir2,
+				newEntry(t, entities.EntryTypeFile, "file-1", sizeInBytes, "2022-01-12 16:23"),
+				newEntry(t, entities.EntryTypeLink, "link-1", sizeInBytes, "2022-01-12 16:23"),
+				newEntry(t, entities.EntryTypeDir, "dir-1", sizeInBytes, "2022-01-12 16:23"),
+			},
 		}, nil).
 		Once()
 	connMock.
@@ -446,10 +749,12 @@ func Test_Remove_Execute_UnknownError(t *testing.T) {
 			Path:    filepath.Join(remoteDirPath, "dir-1"),
 			ShowAll: true,
 		}).
-		Return([]*entities.Entry{
-			rootDir1,
-			rootDir2,
-			newEntry(t, entities.EntryType(0), "file-2", sizeInBytes, "2022-01-12 16:23"),
+		Return(&connection.ListResult{
+			Entries: []*entities.Entry{
+				rootDir1,
+				rootDir2,
+				newEntry(t, entities.EntryType(0), "file-2", sizeInBytes, "2022-01-12 16:23"),
+			},
 		}, nil).
 		Once()
 	connMock.
@@ -473,9 +778,10 @@ func Test_Remove_Execute_UnknownError(t *testing.T) {
 	useCase := ftp.Remove{}
 
 	// act
-	err := useCase.Execute(ctx, useCaseRepos, useCaseInput)
+	output, err := useCase.Execute(ctx, useCaseRepos, useCaseInput)
 
 	// assert
+	assert.Nil(t, output)
 	require.EqualError(t, err, "an unknown error occurred: unexpected entry type: 0")
 	assert.IsType(t, ftperrors.UnknownErrorType, err)
 	assert.NoError(t, errors.Unwrap(err))
@@ -0,0 +1,153 @@
+package ftp
+
+import (
+	"context"
+	"io/fs"
+	"path"
+	"time"
+
+	"github.com/alexZaicev/go-ftp-client/internal/domain/connection"
+	"github.com/alexZaicev/go-ftp-client/internal/domain/entities"
+	ftperrors "github.com/alexZaicev/go-ftp-client/internal/domain/errors"
+	"github.com/alexZaicev/go-ftp-client/internal/drivers/logging"
+)
+
+type SyncPlanUseCase interface {
+	Execute(context.Context, *SyncPlanRepos, *SyncPlanInput) ([]*entities.SyncAction, error)
+}
+
+type SyncPlanInput struct {
+	// Path of the local tree within the repos filesystem (slash separated, without leading slash).
+	Path       string
+	RemotePath string
+	Direction  entities.SyncDirection
+	// Delete plans removal of destination entries that do not exist in the source tree.
+	Delete bool
+}
+
+type SyncPlanRepos struct {
+	Logger     logging.Logger
+	Connection connection.Connection
+	Filesystem fs.FS
+}
+
+// SyncPlan use case compares local and remote trees by size and last modification date, and plans
+// actions required to mirror the source tree onto the destination tree. Files are considered modified
+// if the source copy was changed after the destination copy, as transfers do not preserve modification
+// dates.
+type SyncPlan struct {
+}
+
+func (u *SyncPlan) Execute(
+	ctx context.Context,
+	repos *SyncPlanRepos,
+	input *SyncPlanInput,
+) ([]*entities.SyncAction, error) {
+	localFiles, localExists, err := localTree(repos.Logger, repos.Filesystem, input.Path)
+	if err != nil {
+		return nil, err
+	}
+	remoteFiles, remoteExists, err := remoteTree(ctx, repos.Logger, repos.Connection, input.RemotePath)
+	if err != nil {
+		return nil, err
+	}
+
+	src, dst, dstExists := localFiles, remoteFiles, remoteExists
+	switch input.Direction {
+	case entities.SyncDirectionUpload:
+		if !localExists {
+			return nil, ftperrors.NewNotFoundError("local directory not found", nil)
+		}
+	case entities.SyncDirectionDownload:
+		if !remoteExists {
+			return nil, ftperrors.NewNotFoundError("remote directory not found", nil)
+		}
+		src, dst, dstExists = remoteFiles, localFiles, localExists
+	default:
+		return nil, ftperrors.NewInvalidArgumentError("direction", "must be either upload or download")
+	}
+
+	var actions []*entities.SyncAction
+	if !dstExists {
+		actions = append(actions, &entities.SyncAction{
+			Type:   entities.SyncActionCreateDir,
+			Path:   entities.SyncActionRoot,
+			IsDir:  true,
+			Reason: entities.SyncReasonNew,
+		})
+	}
+
+	// sorted paths ensure that parent directories are created before their contents
+	for _, filePath := range sortedPaths(src) {
+		srcFile := src[filePath]
+		dstFile, ok := dst[filePath]
+
+		var reason entities.SyncReason
+		switch {
+		case !ok:
+			reason = entities.SyncReasonNew
+		case srcFile.isDir != dstFile.isDir:
+			repos.Logger.
+				WithField("path", filePath).
+				Warn("skipped entry that is a file on one side and a directory on the other")
+			continue
+		case srcFile.isDir:
+			continue
+		case srcFile.sizeInBytes != dstFile.sizeInBytes:
+			reason = entities.SyncReasonSizeChanged
+		case srcFile.modTime.Truncate(time.Minute).After(dstFile.modTime.Truncate(time.Minute)):
+			// remote dates are listed with a minute precision at best
+			reason = entities.SyncReasonModified
+		default:
+			continue
+		}
+
+		actionType := entities.SyncActionCopy
+		if srcFile.isDir {
+			actionType = entities.SyncActionCreateDir
+		}
+		actions = append(actions, &entities.SyncAction{
+			Type:        actionType,
+			Path:        filePath,
+			IsDir:       srcFile.isDir,
+			SizeInBytes: srcFile.sizeInBytes,
+			Reason:      reason,
+		})
+	}
+
+	if input.Delete {
+		actions = append(actions, u.extraneous(src, dst)...)
+	}
+
+	return actions, nil
+}
+
+// extraneous method plans removal of destination entries that do not exist in the source tree. Contents
+// of extraneous directories are not listed separately, as they are removed along with the directory.
+func (u *SyncPlan) extraneous(src, dst map[string]*syncFile) []*entities.SyncAction {
+	var actions []*entities.SyncAction
+	removedDirs := make(map[string]bool)
+	for _, filePath := range sortedPaths(dst) {
+		if _, ok := src[filePath]; ok {
+			continue
+		}
+		if removedDirs[path.Dir(filePath)] {
+			// mark nested directories as removed to skip their contents as well
+			removedDirs[filePath] = true
+			continue
+		}
+
+		dstFile := dst[filePath]
+		if dstFile.isDir {
+			removedDirs[filePath] = true
+		}
+		actions = append(actions, &entities.SyncAction{
+			Type:        entities.SyncActionDelete,
+			Path:        filePath,
+			IsDir:       dstFile.isDir,
+			SizeInBytes: dstFile.sizeInBytes,
+			Reason:      entities.SyncReasonExtraneous,
+		})
+	}
+	return actions
+}
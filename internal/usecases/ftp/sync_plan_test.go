@@ -0,0 +1,315 @@
+package ftp_test
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/alexZaicev/go-ftp-client/internal/domain/connection"
+	"github.com/alexZaicev/go-ftp-client/internal/domain/entities"
+	ftperrors "github.com/alexZaicev/go-ftp-client/internal/domain/errors"
+	"github.com/alexZaicev/go-ftp-client/internal/drivers/logging/assertlogging"
+	"github.com/alexZaicev/go-ftp-client/internal/usecases/ftp"
+	connectionMocks "github.com/alexZaicev/go-ftp-client/mocks/domain/connection"
+)
+
+const (
+	syncLocalPath = "local"
+)
+
+//nolint:funlen // test case can get a bit large
+func Test_SyncPlan_Execute_Success(t *testing.T) {
+	testCases := []struct {
+		name            string
+		direction       entities.SyncDirection
+		deleteEntries   bool
+		expectedActions []*entities.SyncAction
+	}{
+		{
+			name:      "upload",
+			direction: entities.SyncDirectionUpload,
+			expectedActions: []*entities.SyncAction{
+				{Type: entities.SyncActionCopy, Path: "b.txt", SizeInBytes: 3, Reason: entities.SyncReasonSizeChanged},
+				{Type: entities.SyncActionCopy, Path: "c.txt", SizeInBytes: 2, Reason: entities.SyncReasonModified},
+				{Type: entities.SyncActionCreateDir, Path: "new", IsDir: true, Reason: entities.SyncReasonNew},
+				{Type: entities.SyncActionCopy, Path: "new/d.txt", SizeInBytes: 1, Reason: entities.SyncReasonNew},
+			},
+		},
+		{
+			name:          "upload with delete",
+			direction:     entities.SyncDirectionUpload,
+			deleteEntries: true,
+			expectedActions: []*entities.SyncAction{
+				{Type: entities.SyncActionCopy, Path: "b.txt", SizeInBytes: 3, Reason: entities.SyncReasonSizeChanged},
+				{Type: entities.SyncActionCopy, Path: "c.txt", SizeInBytes: 2, Reason: entities.SyncReasonModified},
+				{Type: entities.SyncActionCreateDir, Path: "new", IsDir: true, Reason: entities.SyncReasonNew},
+				{Type: entities.SyncActionCopy, Path: "new/d.txt", SizeInBytes: 1, Reason: entities.SyncReasonNew},
+				{Type: entities.SyncActionDelete, Path: "old", IsDir: true, Reason: entities.SyncReasonExtraneous},
+				{Type: entities.SyncActionDelete, Path: "z.txt", SizeInBytes: 7, Reason: entities.SyncReasonExtraneous},
+			},
+		},
+		{
+			name:          "download with delete",
+			direction:     entities.SyncDirectionDownload,
+			deleteEntries: true,
+			expectedActions: []*entities.SyncAction{
+				{Type: entities.SyncActionCopy, Path: "a.txt", SizeInBytes: 5, Reason: entities.SyncReasonModified},
+				{Type: entities.SyncActionCopy, Path: "b.txt", SizeInBytes: 4, Reason: entities.SyncReasonSizeChanged},
+				{Type: entities.SyncActionCreateDir, Path: "old", IsDir: true, Reason: entities.SyncReasonNew},
+				{Type: entities.SyncActionCopy, Path: "old/e.txt", SizeInBytes: 6, Reason: entities.SyncReasonNew},
+				{Type: entities.SyncActionCopy, Path: "z.txt", SizeInBytes: 7, Reason: entities.SyncReasonNew},
+				{Type: entities.SyncActionDelete, Path: "new", IsDir: true, Reason: entities.SyncReasonExtraneous},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			logger := assertlogging.NewLogger(t)
+
+			connMock := connectionMocks.NewConnection(t)
+			connMock.
+				On("IsDir", ctx, remoteDirPath).
+				Return(true, nil).
+				Once()
+			setMocksForRemoteTree(ctx, t, connMock)
+
+			useCaseRepos := &ftp.SyncPlanRepos{
+				Logger:     logger,
+				Connection: connMock,
+				Filesystem: getLocalTree(),
+			}
+			useCaseInput := &ftp.SyncPlanInput{
+				Path:       syncLocalPath,
+				RemotePath: remoteDirPath,
+				Direction:  tc.direction,
+				Delete:     tc.deleteEntries,
+			}
+
+			useCase := &ftp.SyncPlan{}
+			actions, err := useCase.Execute(ctx, useCaseRepos, useCaseInput)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedActions, actions)
+		})
+	}
+}
+
+func Test_SyncPlan_Execute_RemoteRootNotFound_Success(t *testing.T) {
+	ctx := context.Background()
+
+	logger := assertlogging.NewLogger(t)
+
+	connMock := connectionMocks.NewConnection(t)
+	connMock.
+		On("IsDir", ctx, remoteDirPath).
+		Return(false, ftperrors.NewNotFoundError("mock error", nil)).
+		Once()
+
+	useCaseRepos := &ftp.SyncPlanRepos{
+		Logger:     logger,
+		Connection: connMock,
+		Filesystem: fstest.MapFS{
+			"local/a.txt": {Data: []byte("aaaaa")},
+		},
+	}
+	useCaseInput := &ftp.SyncPlanInput{
+		Path:       syncLocalPath,
+		RemotePath: remoteDirPath + "/",
+		Direction:  entities.SyncDirectionUpload,
+	}
+
+	useCase := &ftp.SyncPlan{}
+	actions, err := useCase.Execute(ctx, useCaseRepos, useCaseInput)
+	assert.NoError(t, err)
+	assert.Equal(t, []*entities.SyncAction{
+		{Type: entities.SyncActionCreateDir, Path: entities.SyncActionRoot, IsDir: true, Reason: entities.SyncReasonNew},
+		{Type: entities.SyncActionCopy, Path: "a.txt", SizeInBytes: 5, Reason: entities.SyncReasonNew},
+	}, actions)
+}
+
+func Test_SyncPlan_Execute_UnsafeRemoteName_Success(t *testing.T) {
+	ctx := context.Background()
+
+	logger := assertlogging.NewLogger(t)
+	logger.
+		ExpectWarn("security warning: skipped entry with unsafe name").
+		WithField("remote-path", assertlogging.Equal(remoteDirPath+"/../../.bashrc")).
+		WithField("reason", assertlogging.Equal("escapes the download directory"))
+
+	connMock := connectionMocks.NewConnection(t)
+	connMock.
+		On("IsDir", ctx, remoteDirPath).
+		Return(true, nil).
+		Once()
+	connMock.
+		On("List", ctx, &connection.ListOptions{Path: remoteDirPath, ShowAll: true}).
+		Return(&connection.ListResult{
+			Entries: []*entities.Entry{
+				newEntry(t, entities.EntryTypeFile, "a.txt", 5, "2022-01-12 10:00"),
+				newEntry(t, entities.EntryTypeFile, "../../.bashrc", 7, "2022-01-12 10:00"),
+			},
+		}, nil).
+		Once()
+
+	useCaseRepos := &ftp.SyncPlanRepos{
+		Logger:     logger,
+		Connection: connMock,
+		Filesystem: fstest.MapFS{
+			"local": {Mode: fs.ModeDir},
+		},
+	}
+	useCaseInput := &ftp.SyncPlanInput{
+		Path:       syncLocalPath,
+		RemotePath: remoteDirPath,
+		Direction:  entities.SyncDirectionDownload,
+		Delete:     true,
+	}
+
+	useCase := &ftp.SyncPlan{}
+	actions, err := useCase.Execute(ctx, useCaseRepos, useCaseInput)
+	assert.NoError(t, err)
+	assert.Equal(t, []*entities.SyncAction{
+		{Type: entities.SyncActionCopy, Path: "a.txt", SizeInBytes: 5, Reason: entities.SyncReasonNew},
+	}, actions)
+}
+
+func Test_SyncPlan_Execute_LocalRootNotFoundError(t *testing.T) {
+	ctx := context.Background()
+
+	logger := assertlogging.NewLogger(t)
+
+	connMock := connectionMocks.NewConnection(t)
+	connMock.
+		On("IsDir", ctx, remoteDirPath).
+		Return(true, nil).
+		Once()
+	connMock.
+		On("List", ctx, &connection.ListOptions{Path: remoteDirPath, ShowAll: true}).
+		Return(&connection.ListResult{}, nil).
+		Once()
+
+	useCaseRepos := &ftp.SyncPlanRepos{
+		Logger:     logger,
+		Connection: connMock,
+		Filesystem: fstest.MapFS{},
+	}
+	useCaseInput := &ftp.SyncPlanInput{
+		Path:       syncLocalPath,
+		RemotePath: remoteDirPath,
+		Direction:  entities.SyncDirectionUpload,
+	}
+
+	useCase := &ftp.SyncPlan{}
+	actions, err := useCase.Execute(ctx, useCaseRepos, useCaseInput)
+	assert.Nil(t, actions)
+	require.EqualError(t, err, "not found error occurred: local directory not found")
+	assert.IsType(t, ftperrors.NotFoundErrorType, err)
+}
+
+func Test_SyncPlan_Execute_RemoteRootNotDirError(t *testing.T) {
+	ctx := context.Background()
+
+	logger := assertlogging.NewLogger(t)
+
+	connMock := connectionMocks.NewConnection(t)
+	connMock.
+		On("IsDir", ctx, remoteDirPath).
+		Return(false, nil).
+		Once()
+
+	useCaseRepos := &ftp.SyncPlanRepos{
+		Logger:     logger,
+		Connection: connMock,
+		Filesystem: getLocalTree(),
+	}
+	useCaseInput := &ftp.SyncPlanInput{
+		Path:       syncLocalPath,
+		RemotePath: remoteDirPath,
+		Direction:  entities.SyncDirectionUpload,
+	}
+
+	useCase := &ftp.SyncPlan{}
+	actions, err := useCase.Execute(ctx, useCaseRepos, useCaseInput)
+	assert.Nil(t, actions)
+	require.EqualError(t, err, "an invalid argument error occurred: argument remote-path must be a directory")
+	assert.IsType(t, ftperrors.InvalidArgumentErrorType, err)
+}
+
+func Test_SyncPlan_Execute_ListError(t *testing.T) {
+	ctx := context.Background()
+
+	logger := assertlogging.NewLogger(t)
+	logger.
+		ExpectError("failed to list directory").
+		WithError(assertlogging.EqualError("mock error")).
+		WithField("remote-path", assertlogging.Equal(remoteDirPath))
+
+	connMock := connectionMocks.NewConnection(t)
+	connMock.
+		On("IsDir", ctx, remoteDirPath).
+		Return(true, nil).
+		Once()
+	connMock.
+		On("List", ctx, &connection.ListOptions{Path: remoteDirPath, ShowAll: true}).
+		Return(nil, errors.New("mock error")).
+		Once()
+
+	useCaseRepos := &ftp.SyncPlanRepos{
+		Logger:     logger,
+		Connection: connMock,
+		Filesystem: getLocalTree(),
+	}
+	useCaseInput := &ftp.SyncPlanInput{
+		Path:       syncLocalPath,
+		RemotePath: remoteDirPath,
+		Direction:  entities.SyncDirectionDownload,
+	}
+
+	useCase := &ftp.SyncPlan{}
+	actions, err := useCase.Execute(ctx, useCaseRepos, useCaseInput)
+	assert.Nil(t, actions)
+	require.EqualError(t, err, "an internal error occurred: failed to list directory")
+	assert.IsType(t, ftperrors.InternalErrorType, err)
+}
+
+func getLocalTree() fstest.MapFS {
+	return fstest.MapFS{
+		"local/a.txt":     {Data: []byte("aaaaa"), ModTime: time.Date(2022, 1, 10, 10, 0, 0, 0, time.UTC)},
+		"local/b.txt":     {Data: []byte("bbb"), ModTime: time.Date(2022, 1, 10, 10, 0, 0, 0, time.UTC)},
+		"local/c.txt":     {Data: []byte("cc"), ModTime: time.Date(2022, 1, 20, 10, 0, 0, 0, time.UTC)},
+		"local/new/d.txt": {Data: []byte("d"), ModTime: time.Date(2022, 1, 20, 10, 0, 0, 0, time.UTC)},
+	}
+}
+
+func setMocksForRemoteTree(ctx context.Context, t *testing.T, connMock *connectionMocks.Connection) {
+	connMock.
+		On("List", ctx, &connection.ListOptions{Path: remoteDirPath, ShowAll: true}).
+		Return(&connection.ListResult{
+			Entries: []*entities.Entry{
+				rootDir1,
+				rootDir2,
+				newEntry(t, entities.EntryTypeFile, "a.txt", 5, "2022-01-12 10:00"),
+				newEntry(t, entities.EntryTypeFile, "b.txt", 4, "2022-01-12 10:00"),
+				newEntry(t, entities.EntryTypeFile, "c.txt", 2, "2022-01-12 10:00"),
+				newEntry(t, entities.EntryTypeDir, "old", 0, "2022-01-12 10:00"),
+				newEntry(t, entities.EntryTypeFile, "z.txt", 7, "2022-01-12 10:00"),
+			},
+		}, nil).
+		Once()
+	connMock.
+		On("List", ctx, &connection.ListOptions{Path: remoteDirPath + "/old", ShowAll: true}).
+		Return(&connection.ListResult{
+			Entries: []*entities.Entry{
+				newEntry(t, entities.EntryTypeFile, "e.txt", 6, "2022-01-12 10:00"),
+			},
+		}, nil).
+		Once()
+}
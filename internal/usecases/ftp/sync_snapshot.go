@@ -0,0 +1,122 @@
+package ftp
+
+import (
+	"context"
+	"io/fs"
+	"path"
+
+	"github.com/alexZaicev/go-ftp-client/internal/domain/connection"
+	"github.com/alexZaicev/go-ftp-client/internal/domain/entities"
+	"github.com/alexZaicev/go-ftp-client/internal/drivers/logging"
+)
+
+type SyncSnapshotUseCase interface {
+	Execute(context.Context, *SyncSnapshotRepos, *SyncSnapshotInput) (*entities.SyncState, error)
+}
+
+type SyncSnapshotInput struct {
+	// Path of the local tree within the repos filesystem (slash separated, without leading slash).
+	Path       string
+	RemotePath string
+	// Previous state is used to avoid hashing local files that did not change since.
+	Previous *entities.SyncState
+	// Pending are paths of planned actions that were not performed. Their previous state, along with
+	// the state of their contents, is kept so that the changes are planned again on the next run.
+	Pending []string
+}
+
+type SyncSnapshotRepos struct {
+	Logger     logging.Logger
+	Connection connection.Connection
+	Filesystem fs.FS
+}
+
+// SyncSnapshot use case records the state of entries that exist in both local and remote trees after
+// two-way synchronisation.
+type SyncSnapshot struct {
+}
+
+func (u *SyncSnapshot) Execute(
+	ctx context.Context,
+	repos *SyncSnapshotRepos,
+	input *SyncSnapshotInput,
+) (*entities.SyncState, error) {
+	var previousEntries map[string]*entities.SyncStateEntry
+	if input.Previous != nil {
+		previousEntries = input.Previous.Entries
+	}
+
+	localFiles, _, err := localTree(repos.Logger, repos.Filesystem, input.Path)
+	if err != nil {
+		return nil, err
+	}
+	remoteFiles, _, err := remoteTree(ctx, repos.Logger, repos.Connection, input.RemotePath)
+	if err != nil {
+		return nil, err
+	}
+
+	pending := make(map[string]bool, len(input.Pending))
+	for _, filePath := range input.Pending {
+		pending[filePath] = true
+	}
+
+	state := &entities.SyncState{Entries: make(map[string]*entities.SyncStateEntry)}
+	for _, filePath := range unionPaths(localFiles, remoteFiles, previousEntries) {
+		previous := previousEntries[filePath]
+		if isPending(pending, filePath) {
+			if previous != nil {
+				state.Entries[filePath] = previous
+			}
+			continue
+		}
+
+		localFile, remoteFile := localFiles[filePath], remoteFiles[filePath]
+		if localFile == nil || remoteFile == nil || localFile.isDir != remoteFile.isDir {
+			continue
+		}
+
+		entry := &entities.SyncStateEntry{
+			IsDir: localFile.isDir,
+			Local: entities.SyncFileState{
+				SizeInBytes: localFile.sizeInBytes,
+				ModTime:     localFile.modTime,
+			},
+			Remote: entities.SyncFileState{
+				SizeInBytes: remoteFile.sizeInBytes,
+				ModTime:     remoteFile.modTime,
+			},
+		}
+		if !localFile.isDir {
+			entry.Local.Hash, err = u.localHash(repos, input.Path, filePath, localFile, previous)
+			if err != nil {
+				return nil, err
+			}
+		}
+		state.Entries[filePath] = entry
+	}
+
+	return state, nil
+}
+
+func (u *SyncSnapshot) localHash(
+	repos *SyncSnapshotRepos,
+	root, filePath string,
+	file *syncFile,
+	previous *entities.SyncStateEntry,
+) (string, error) {
+	if previous != nil && !previous.IsDir &&
+		previous.Local.SizeInBytes == file.sizeInBytes && previous.Local.ModTime.Equal(file.modTime) {
+		return previous.Local.Hash, nil
+	}
+	return localFileHash(repos.Logger, repos.Filesystem, root, filePath)
+}
+
+// isPending function checks whether the path or any of its parent directories is pending.
+func isPending(pending map[string]bool, filePath string) bool {
+	for ; filePath != "." && filePath != "/" && filePath != ""; filePath = path.Dir(filePath) {
+		if pending[filePath] {
+			return true
+		}
+	}
+	return false
+}
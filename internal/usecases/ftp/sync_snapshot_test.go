@@ -0,0 +1,81 @@
+package ftp_test
+
+import (
+	"context"
+	"io/fs"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/alexZaicev/go-ftp-client/internal/domain/entities"
+	"github.com/alexZaicev/go-ftp-client/internal/drivers/logging/assertlogging"
+	"github.com/alexZaicev/go-ftp-client/internal/usecases/ftp"
+	connectionMocks "github.com/alexZaicev/go-ftp-client/mocks/domain/connection"
+)
+
+//nolint:funlen // test case can get a bit large
+func Test_SyncSnapshot_Execute_Success(t *testing.T) {
+	ctx := context.Background()
+
+	logger := assertlogging.NewLogger(t)
+
+	connMock := connectionMocks.NewConnection(t)
+	connMock.
+		On("IsDir", ctx, remoteDirPath).
+		Return(true, nil).
+		Once()
+	setMocksForRemoteListing(ctx, connMock, map[string][]*entities.Entry{
+		remoteDirPath: {
+			rootDir1,
+			rootDir2,
+			newEntry(t, entities.EntryTypeDir, "docs", 0, "2022-01-10 10:00"),
+			newEntry(t, entities.EntryTypeFile, "pending.txt", 3, "2022-01-15 10:00"),
+			newEntry(t, entities.EntryTypeFile, "same.txt", 3, "2022-01-10 10:00"),
+			newEntry(t, entities.EntryTypeFile, "uploaded.txt", 4, "2022-01-20 10:00"),
+		},
+		remoteDirPath + "/docs": {},
+	})
+
+	previousSame := newFileStateEntry("aaa")
+	previousSame.Local.Hash = "cached"
+	previousPending := newFileStateEntry("ppp")
+
+	useCaseRepos := &ftp.SyncSnapshotRepos{
+		Logger:     logger,
+		Connection: connMock,
+		Filesystem: fstest.MapFS{
+			"local/docs":         {Mode: fs.ModeDir, ModTime: syncedDate},
+			"local/local.txt":    {Data: []byte("l"), ModTime: modifiedDate},
+			"local/pending.txt":  {Data: []byte("ppp"), ModTime: syncedDate},
+			"local/same.txt":     {Data: []byte("aaa"), ModTime: syncedDate},
+			"local/uploaded.txt": {Data: []byte("cccc"), ModTime: syncedDate},
+		},
+	}
+	useCaseInput := &ftp.SyncSnapshotInput{
+		Path:       syncLocalPath,
+		RemotePath: remoteDirPath,
+		Previous: &entities.SyncState{
+			Entries: map[string]*entities.SyncStateEntry{
+				"pending.txt": previousPending,
+				"same.txt":    previousSame,
+			},
+		},
+		Pending: []string{"pending.txt"},
+	}
+
+	uploaded := newFileStateEntry("cccc")
+	uploaded.Remote.ModTime = modifiedDate
+
+	useCase := &ftp.SyncSnapshot{}
+	state, err := useCase.Execute(ctx, useCaseRepos, useCaseInput)
+	assert.NoError(t, err)
+	assert.Equal(t, &entities.SyncState{
+		Entries: map[string]*entities.SyncStateEntry{
+			"docs":         newDirStateEntry(),
+			"pending.txt":  previousPending,
+			"same.txt":     previousSame,
+			"uploaded.txt": uploaded,
+		},
+	}, state)
+}
@@ -0,0 +1,220 @@
+package ftp
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"io/fs"
+	"path"
+	"runtime"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/alexZaicev/go-ftp-client/internal/domain/connection"
+	"github.com/alexZaicev/go-ftp-client/internal/domain/entities"
+	ftperrors "github.com/alexZaicev/go-ftp-client/internal/domain/errors"
+	"github.com/alexZaicev/go-ftp-client/internal/drivers/logging"
+)
+
+// syncFile describes an entry of either local or remote tree.
+type syncFile struct {
+	isDir       bool
+	sizeInBytes uint64
+	modTime     time.Time
+}
+
+// localTree function walks local tree and returns its entries keyed by path relative to the root. Entries
+// other than regular files and directories are skipped, as well as the two-way synchronisation state file.
+func localTree(logger logging.Logger, filesystem fs.FS, root string) (map[string]*syncFile, bool, error) {
+	if root == "" {
+		root = "."
+	}
+
+	files := make(map[string]*syncFile)
+	err := fs.WalkDir(filesystem, root, func(filePath string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if filePath == root {
+			if !d.IsDir() {
+				return ftperrors.NewInvalidArgumentError("path", "must be a directory")
+			}
+			return nil
+		}
+
+		relPath := relativePath(root, filePath)
+		if relPath == entities.SyncStateFileName {
+			return nil
+		}
+		if !d.IsDir() && !d.Type().IsRegular() {
+			logger.WithField("path", filePath).Warn("skipped entry that is not a regular file")
+			return nil
+		}
+
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		files[relPath] = &syncFile{
+			isDir:       d.IsDir(),
+			sizeInBytes: uint64(info.Size()),
+			modTime:     info.ModTime(),
+		}
+		return nil
+	})
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) && len(files) == 0 {
+			return files, false, nil
+		}
+
+		var invalidArgErr *ftperrors.InvalidArgumentError
+		if errors.As(err, &invalidArgErr) {
+			return nil, false, invalidArgErr
+		}
+
+		logger.WithError(err).WithField("path", root).Error("failed to walk local directory")
+		return nil, false, ftperrors.NewInternalError("failed to walk local directory", nil)
+	}
+	return files, true, nil
+}
+
+// remoteTree function walks remote tree and returns its entries keyed by path relative to the root. Entries
+// whose names cannot be joined onto a local path as is are skipped, as the names are listed by the server
+// and cannot be trusted.
+func remoteTree(
+	ctx context.Context,
+	logger logging.Logger,
+	conn connection.Connection,
+	root string,
+) (map[string]*syncFile, bool, error) {
+	files := make(map[string]*syncFile)
+
+	trimmedRoot := strings.TrimSuffix(root, "/")
+	if trimmedRoot != "" && trimmedRoot != "." {
+		isDir, err := conn.IsDir(ctx, trimmedRoot)
+		if err != nil {
+			var notFoundErr *ftperrors.NotFoundError
+			if errors.As(err, &notFoundErr) {
+				return files, false, nil
+			}
+
+			logger.
+				WithError(err).
+				WithField("remote-path", root).
+				Error("failed to check if entry is a directory")
+			return nil, false, ftperrors.NewInternalError("failed to check if entry is a directory", nil)
+		}
+		if !isDir {
+			return nil, false, ftperrors.NewInvalidArgumentError("remote-path", "must be a directory")
+		}
+	}
+
+	names := &localNameChecker{windows: runtime.GOOS == "windows"}
+	if err := walkRemote(ctx, logger, conn, names, root, "", time.Now(), files); err != nil {
+		return nil, false, err
+	}
+	return files, true, nil
+}
+
+func walkRemote(
+	ctx context.Context,
+	logger logging.Logger,
+	conn connection.Connection,
+	names *localNameChecker,
+	root, dirPath string,
+	now time.Time,
+	files map[string]*syncFile,
+) error {
+	remotePath := entities.RemotePath(root).Join(dirPath).String()
+	result, err := conn.List(ctx, &connection.ListOptions{
+		Path:    remotePath,
+		ShowAll: true,
+	})
+	if err != nil {
+		logger.
+			WithError(err).
+			WithField("remote-path", remotePath).
+			Error("failed to list directory")
+		return ftperrors.NewInternalError("failed to list directory", nil)
+	}
+
+	logSkippedLines(logger, remotePath, result.SkippedLines)
+
+	for _, entry := range result.Entries {
+		if isRootDir(entry.Name) {
+			continue
+		}
+		if _, reason := names.check(entry.Name); reason != "" {
+			logger.
+				WithFields(logging.Fields{
+					"remote-path": entities.RemotePath(remotePath).Join(entry.Name).String(),
+					"reason":      reason,
+				}).
+				Warn("security warning: skipped entry with unsafe name")
+			continue
+		}
+
+		filePath := path.Join(dirPath, entry.Name)
+		switch entry.Type {
+		case entities.EntryTypeFile:
+			files[filePath] = &syncFile{
+				sizeInBytes: entry.SizeInBytes,
+				modTime:     entry.ResolvedModificationDate(now),
+			}
+		case entities.EntryTypeDir:
+			files[filePath] = &syncFile{
+				isDir:   true,
+				modTime: entry.ResolvedModificationDate(now),
+			}
+			if walkErr := walkRemote(ctx, logger, conn, names, root, filePath, now, files); walkErr != nil {
+				return walkErr
+			}
+		default:
+			logger.WithField("remote-path", entities.RemotePath(root).Join(filePath).String()).Warn("skipped entry that is not a regular file")
+		}
+	}
+
+	return nil
+}
+
+// localFileHash function calculates SHA-256 checksum of a local file.
+func localFileHash(logger logging.Logger, filesystem fs.FS, root, filePath string) (string, error) {
+	fullPath := path.Join(root, filePath)
+
+	file, err := filesystem.Open(fullPath)
+	if err != nil {
+		logger.WithError(err).WithField("path", fullPath).Error("failed to open file")
+		return "", ftperrors.NewInternalError("failed to open file", nil)
+	}
+	defer func(file fs.File) {
+		if closeErr := file.Close(); closeErr != nil {
+			logger.WithError(closeErr).WithField("path", fullPath).Warn("failed to close file")
+		}
+	}(file)
+
+	hash := sha256.New()
+	if _, err = io.Copy(hash, file); err != nil {
+		logger.WithError(err).WithField("path", fullPath).Error("failed to read file")
+		return "", ftperrors.NewInternalError("failed to read file", nil)
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+func relativePath(root, filePath string) string {
+	if root == "." {
+		return filePath
+	}
+	return strings.TrimPrefix(filePath, root+"/")
+}
+
+func sortedPaths(files map[string]*syncFile) []string {
+	paths := make([]string, 0, len(files))
+	for filePath := range files {
+		paths = append(paths, filePath)
+	}
+	sort.Strings(paths)
+	return paths
+}
@@ -0,0 +1,400 @@
+package ftp
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/alexZaicev/go-ftp-client/internal/domain/connection"
+	"github.com/alexZaicev/go-ftp-client/internal/domain/entities"
+	ftperrors "github.com/alexZaicev/go-ftp-client/internal/domain/errors"
+	"github.com/alexZaicev/go-ftp-client/internal/drivers/logging"
+)
+
+type TwoWaySyncPlanUseCase interface {
+	Execute(context.Context, *TwoWaySyncPlanRepos, *TwoWaySyncPlanInput) ([]*entities.SyncAction, error)
+}
+
+type TwoWaySyncPlanInput struct {
+	// Path of the local tree within the repos filesystem (slash separated, without leading slash).
+	Path       string
+	RemotePath string
+	// State of both trees after the last synchronisation, empty on the first run.
+	State          *entities.SyncState
+	ConflictPolicy entities.SyncConflictPolicy
+}
+
+type TwoWaySyncPlanRepos struct {
+	Logger     logging.Logger
+	Connection connection.Connection
+	Filesystem fs.FS
+}
+
+// TwoWaySyncPlan use case compares local and remote trees with the state recorded after the last
+// synchronisation to find entries created, modified or deleted on either side, and plans actions that
+// propagate the changes to the other side. Entries changed on both sides are resolved by conflict policy.
+type TwoWaySyncPlan struct {
+}
+
+// syncChange is a change of an entry on one side since the last synchronisation.
+type syncChange int
+
+const (
+	syncChangeNone syncChange = iota
+	syncChangeCreated
+	syncChangeModified
+	syncChangeDeleted
+)
+
+// conflictCopyDateFormat is used in names of conflict copies to tell copies of the same entry apart.
+const conflictCopyDateFormat = "20060102-1504"
+
+//nolint:funlen // planning is easier to follow in one place
+func (u *TwoWaySyncPlan) Execute(
+	ctx context.Context,
+	repos *TwoWaySyncPlanRepos,
+	input *TwoWaySyncPlanInput,
+) ([]*entities.SyncAction, error) {
+	switch input.ConflictPolicy {
+	case entities.SyncConflictPolicyNewest, entities.SyncConflictPolicyKeepBoth, entities.SyncConflictPolicyFail:
+	default:
+		return nil, ftperrors.NewInvalidArgumentError("conflict", "must be one of newest, keep-both, fail")
+	}
+
+	var stateEntries map[string]*entities.SyncStateEntry
+	if input.State != nil {
+		stateEntries = input.State.Entries
+	}
+
+	localFiles, localExists, err := localTree(repos.Logger, repos.Filesystem, input.Path)
+	if err != nil {
+		return nil, err
+	}
+	if !localExists {
+		return nil, ftperrors.NewNotFoundError("local directory not found", nil)
+	}
+	remoteFiles, remoteExists, err := remoteTree(ctx, repos.Logger, repos.Connection, input.RemotePath)
+	if err != nil {
+		return nil, err
+	}
+	// a missing remote tree that was synchronised before is more likely a wrong path than a deletion
+	// of everything, which would otherwise be propagated to the local tree
+	if !remoteExists && len(stateEntries) > 0 {
+		return nil, ftperrors.NewNotFoundError("remote directory not found", nil)
+	}
+
+	paths := unionPaths(localFiles, remoteFiles, stateEntries)
+
+	planned := make(map[string][]*entities.SyncAction)
+	var conflicts []string
+	for _, filePath := range paths {
+		localFile, remoteFile, entry := localFiles[filePath], remoteFiles[filePath], stateEntries[filePath]
+		if localFile != nil && remoteFile != nil && localFile.isDir != remoteFile.isDir {
+			repos.Logger.
+				WithField("path", filePath).
+				Warn("skipped entry that is a file on one side and a directory on the other")
+			continue
+		}
+
+		localChange, changeErr := u.localChange(repos, input.Path, filePath, localFile, entry)
+		if changeErr != nil {
+			return nil, changeErr
+		}
+		remoteChange := u.remoteChange(remoteFile, entry)
+
+		actions, conflict := u.planEntry(filePath, localFile, remoteFile, localChange, remoteChange, input.ConflictPolicy)
+		if conflict {
+			repos.Logger.WithField("path", filePath).Warn("entry was changed on both sides")
+			conflicts = append(conflicts, filePath)
+		}
+		if len(actions) > 0 {
+			planned[filePath] = actions
+		}
+	}
+
+	if len(conflicts) > 0 && input.ConflictPolicy == entities.SyncConflictPolicyFail {
+		return nil, ftperrors.NewConflictError(
+			fmt.Sprintf("entries were changed on both sides: %s", strings.Join(conflicts, ", ")),
+			nil,
+		)
+	}
+
+	u.restoreDirs(paths, planned)
+
+	var actions []*entities.SyncAction
+	if !remoteExists {
+		actions = append(actions, &entities.SyncAction{
+			Type:      entities.SyncActionCreateDir,
+			Direction: entities.SyncDirectionUpload,
+			Path:      entities.SyncActionRoot,
+			IsDir:     true,
+			Reason:    entities.SyncReasonNew,
+		})
+	}
+
+	// sorted paths ensure that parent directories are created before their contents, and contents of
+	// deleted directories are not listed separately, as they are removed along with the directory
+	removedDirs := make(map[string]bool)
+	for _, filePath := range paths {
+		for _, action := range planned[filePath] {
+			if action.Type == entities.SyncActionDelete {
+				if removedDirs[path.Dir(filePath)] {
+					removedDirs[filePath] = true
+					continue
+				}
+				if action.IsDir {
+					removedDirs[filePath] = true
+				}
+			}
+			actions = append(actions, action)
+		}
+	}
+
+	return actions, nil
+}
+
+// localChange method compares local entry with its last synchronised state. Files with a new modification
+// date but the same content (e.g. after they were copied or checked out again) are not considered modified.
+func (u *TwoWaySyncPlan) localChange(
+	repos *TwoWaySyncPlanRepos,
+	root, filePath string,
+	file *syncFile,
+	entry *entities.SyncStateEntry,
+) (syncChange, error) {
+	switch {
+	case file == nil && entry == nil:
+		return syncChangeNone, nil
+	case file == nil:
+		return syncChangeDeleted, nil
+	case entry == nil:
+		return syncChangeCreated, nil
+	case file.isDir != entry.IsDir:
+		return syncChangeModified, nil
+	case file.isDir:
+		return syncChangeNone, nil
+	case file.sizeInBytes != entry.Local.SizeInBytes:
+		return syncChangeModified, nil
+	case file.modTime.Equal(entry.Local.ModTime):
+		return syncChangeNone, nil
+	}
+
+	hash, err := localFileHash(repos.Logger, repos.Filesystem, root, filePath)
+	if err != nil {
+		return syncChangeNone, err
+	}
+	if hash == entry.Local.Hash {
+		return syncChangeNone, nil
+	}
+	return syncChangeModified, nil
+}
+
+// remoteChange method compares remote entry with its last synchronised state. Only dates newer than the
+// recorded ones count as modifications, as older entries are listed with a date but no time of the day.
+func (u *TwoWaySyncPlan) remoteChange(file *syncFile, entry *entities.SyncStateEntry) syncChange {
+	switch {
+	case file == nil && entry == nil:
+		return syncChangeNone
+	case file == nil:
+		return syncChangeDeleted
+	case entry == nil:
+		return syncChangeCreated
+	case file.isDir != entry.IsDir:
+		return syncChangeModified
+	case file.isDir:
+		return syncChangeNone
+	case file.sizeInBytes != entry.Remote.SizeInBytes:
+		return syncChangeModified
+	case file.modTime.Truncate(time.Minute).After(entry.Remote.ModTime.Truncate(time.Minute)):
+		return syncChangeModified
+	default:
+		return syncChangeNone
+	}
+}
+
+// planEntry method plans actions for a single entry and reports whether the entry was changed on both sides.
+func (u *TwoWaySyncPlan) planEntry(
+	filePath string,
+	localFile, remoteFile *syncFile,
+	localChange, remoteChange syncChange,
+	policy entities.SyncConflictPolicy,
+) ([]*entities.SyncAction, bool) {
+	switch {
+	case localChange == syncChangeNone && remoteChange == syncChangeNone,
+		localChange == syncChangeDeleted && remoteChange == syncChangeDeleted:
+		return nil, false
+	case localChange == syncChangeDeleted && remoteChange == syncChangeNone:
+		return []*entities.SyncAction{newDeleteAction(filePath, entities.SyncDirectionUpload, remoteFile)}, false
+	case remoteChange == syncChangeDeleted && localChange == syncChangeNone:
+		return []*entities.SyncAction{newDeleteAction(filePath, entities.SyncDirectionDownload, localFile)}, false
+	case localChange == syncChangeDeleted:
+		// copy modified on one side wins over deletion on the other
+		return []*entities.SyncAction{
+			newCopyAction(filePath, entities.SyncDirectionDownload, remoteFile, entities.SyncReasonConflict),
+		}, true
+	case remoteChange == syncChangeDeleted:
+		return []*entities.SyncAction{
+			newCopyAction(filePath, entities.SyncDirectionUpload, localFile, entities.SyncReasonConflict),
+		}, true
+	case remoteChange == syncChangeNone:
+		return []*entities.SyncAction{
+			newCopyAction(filePath, entities.SyncDirectionUpload, localFile, changeReason(localChange)),
+		}, false
+	case localChange == syncChangeNone:
+		return []*entities.SyncAction{
+			newCopyAction(filePath, entities.SyncDirectionDownload, remoteFile, changeReason(remoteChange)),
+		}, false
+	case localFile.isDir:
+		return nil, false
+	case localChange == syncChangeCreated && remoteChange == syncChangeCreated &&
+		localFile.sizeInBytes == remoteFile.sizeInBytes:
+		// files created on both sides before the first synchronisation are most likely the same,
+		// as remote content cannot be compared without downloading it
+		return nil, false
+	}
+
+	switch policy {
+	case entities.SyncConflictPolicyNewest:
+		if remoteFile.modTime.Truncate(time.Minute).After(localFile.modTime.Truncate(time.Minute)) {
+			return []*entities.SyncAction{
+				newCopyAction(filePath, entities.SyncDirectionDownload, remoteFile, entities.SyncReasonConflict),
+			}, true
+		}
+		return []*entities.SyncAction{
+			newCopyAction(filePath, entities.SyncDirectionUpload, localFile, entities.SyncReasonConflict),
+		}, true
+	case entities.SyncConflictPolicyKeepBoth:
+		copyPath := conflictCopyPath(filePath, remoteFile.modTime)
+		return []*entities.SyncAction{
+			{
+				Type:        entities.SyncActionRename,
+				Direction:   entities.SyncDirectionUpload,
+				Path:        filePath,
+				NewPath:     copyPath,
+				SizeInBytes: remoteFile.sizeInBytes,
+				Reason:      entities.SyncReasonConflict,
+			},
+			newCopyAction(copyPath, entities.SyncDirectionDownload, remoteFile, entities.SyncReasonConflict),
+			newCopyAction(filePath, entities.SyncDirectionUpload, localFile, entities.SyncReasonConflict),
+		}, true
+	default:
+		return nil, true
+	}
+}
+
+// restoreDirs method replaces deletion of directories that still have contents to synchronise with
+// creation of the directory on the side it was deleted from.
+func (u *TwoWaySyncPlan) restoreDirs(paths []string, planned map[string][]*entities.SyncAction) {
+	for idx := len(paths) - 1; idx >= 0; idx-- {
+		dirPath := paths[idx]
+		actions := planned[dirPath]
+		if len(actions) != 1 || actions[0].Type != entities.SyncActionDelete || !actions[0].IsDir {
+			continue
+		}
+
+		for _, filePath := range paths {
+			if !strings.HasPrefix(filePath, dirPath+"/") || !hasNonDeleteAction(planned[filePath]) {
+				continue
+			}
+
+			direction := entities.SyncDirectionUpload
+			if actions[0].Direction == entities.SyncDirectionUpload {
+				direction = entities.SyncDirectionDownload
+			}
+			planned[dirPath] = []*entities.SyncAction{{
+				Type:      entities.SyncActionCreateDir,
+				Direction: direction,
+				Path:      dirPath,
+				IsDir:     true,
+				Reason:    entities.SyncReasonConflict,
+			}}
+			break
+		}
+	}
+}
+
+func hasNonDeleteAction(actions []*entities.SyncAction) bool {
+	for _, action := range actions {
+		if action.Type != entities.SyncActionDelete {
+			return true
+		}
+	}
+	return false
+}
+
+func newCopyAction(
+	filePath string,
+	direction entities.SyncDirection,
+	file *syncFile,
+	reason entities.SyncReason,
+) *entities.SyncAction {
+	actionType := entities.SyncActionCopy
+	if file.isDir {
+		actionType = entities.SyncActionCreateDir
+	}
+	return &entities.SyncAction{
+		Type:        actionType,
+		Direction:   direction,
+		Path:        filePath,
+		IsDir:       file.isDir,
+		SizeInBytes: file.sizeInBytes,
+		Reason:      reason,
+	}
+}
+
+func newDeleteAction(filePath string, direction entities.SyncDirection, file *syncFile) *entities.SyncAction {
+	return &entities.SyncAction{
+		Type:        entities.SyncActionDelete,
+		Direction:   direction,
+		Path:        filePath,
+		IsDir:       file.isDir,
+		SizeInBytes: file.sizeInBytes,
+		Reason:      entities.SyncReasonDeleted,
+	}
+}
+
+func changeReason(change syncChange) entities.SyncReason {
+	if change == syncChangeCreated {
+		return entities.SyncReasonNew
+	}
+	return entities.SyncReasonModified
+}
+
+// conflictCopyPath function names the copy of a conflicting entry after its modification date, e.g.
+// docs/report.conflict-20220112-1000.txt.
+func conflictCopyPath(filePath string, modTime time.Time) string {
+	dir, name := path.Split(filePath)
+	ext := path.Ext(name)
+	stem := strings.TrimSuffix(name, ext)
+	if stem == "" {
+		// hidden files such as .env have no extension
+		stem, ext = name, ""
+	}
+	return dir + fmt.Sprintf("%s.conflict-%s%s", stem, modTime.Format(conflictCopyDateFormat), ext)
+}
+
+func unionPaths(
+	localFiles, remoteFiles map[string]*syncFile,
+	stateEntries map[string]*entities.SyncStateEntry,
+) []string {
+	unique := make(map[string]bool)
+	for filePath := range localFiles {
+		unique[filePath] = true
+	}
+	for filePath := range remoteFiles {
+		unique[filePath] = true
+	}
+	for filePath := range stateEntries {
+		unique[filePath] = true
+	}
+
+	paths := make([]string, 0, len(unique))
+	for filePath := range unique {
+		paths = append(paths, filePath)
+	}
+	sort.Strings(paths)
+	return paths
+}
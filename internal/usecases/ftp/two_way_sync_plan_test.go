@@ -0,0 +1,444 @@
+package ftp_test
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/alexZaicev/go-ftp-client/internal/domain/connection"
+	"github.com/alexZaicev/go-ftp-client/internal/domain/entities"
+	ftperrors "github.com/alexZaicev/go-ftp-client/internal/domain/errors"
+	"github.com/alexZaicev/go-ftp-client/internal/drivers/logging/assertlogging"
+	"github.com/alexZaicev/go-ftp-client/internal/usecases/ftp"
+	connectionMocks "github.com/alexZaicev/go-ftp-client/mocks/domain/connection"
+)
+
+var (
+	syncedDate   = time.Date(2022, 1, 10, 10, 0, 0, 0, time.UTC)
+	modifiedDate = time.Date(2022, 1, 20, 10, 0, 0, 0, time.UTC)
+)
+
+//nolint:funlen // test case can get a bit large
+func Test_TwoWaySyncPlan_Execute_Success(t *testing.T) {
+	ctx := context.Background()
+
+	logger := assertlogging.NewLogger(t)
+
+	connMock := connectionMocks.NewConnection(t)
+	connMock.
+		On("IsDir", ctx, remoteDirPath).
+		Return(true, nil).
+		Once()
+	setMocksForRemoteListing(ctx, connMock, map[string][]*entities.Entry{
+		remoteDirPath: {
+			rootDir1,
+			rootDir2,
+			newEntry(t, entities.EntryTypeDir, "gone", 0, "2022-01-10 10:00"),
+			newEntry(t, entities.EntryTypeFile, "local-del.txt", 3, "2022-01-10 10:00"),
+			newEntry(t, entities.EntryTypeFile, "local-mod.txt", 3, "2022-01-10 10:00"),
+			newEntry(t, entities.EntryTypeFile, "remote-mod.txt", 3, "2022-01-15 10:00"),
+			newEntry(t, entities.EntryTypeFile, "remote-new.txt", 4, "2022-01-15 10:00"),
+			newEntry(t, entities.EntryTypeFile, "same.txt", 3, "2022-01-10 10:00"),
+			newEntry(t, entities.EntryTypeFile, "touched.txt", 3, "2022-01-10 10:00"),
+		},
+		remoteDirPath + "/gone": {
+			newEntry(t, entities.EntryTypeFile, "x.txt", 3, "2022-01-10 10:00"),
+		},
+	})
+
+	useCaseRepos := &ftp.TwoWaySyncPlanRepos{
+		Logger:     logger,
+		Connection: connMock,
+		Filesystem: fstest.MapFS{
+			"local/local-mod.txt":                 {Data: []byte("cccc"), ModTime: modifiedDate},
+			"local/local-new.txt":                 {Data: []byte("e"), ModTime: modifiedDate},
+			"local/remote-del.txt":                {Data: []byte("fff"), ModTime: syncedDate},
+			"local/remote-mod.txt":                {Data: []byte("ddd"), ModTime: syncedDate},
+			"local/same.txt":                      {Data: []byte("aaa"), ModTime: syncedDate},
+			"local/touched.txt":                   {Data: []byte("bbb"), ModTime: modifiedDate},
+			"local/" + entities.SyncStateFileName: {Data: []byte("{}"), ModTime: modifiedDate},
+		},
+	}
+	useCaseInput := &ftp.TwoWaySyncPlanInput{
+		Path:       syncLocalPath,
+		RemotePath: remoteDirPath,
+		State: &entities.SyncState{
+			Entries: map[string]*entities.SyncStateEntry{
+				"gone":           newDirStateEntry(),
+				"gone/x.txt":     newFileStateEntry("xxx"),
+				"local-del.txt":  newFileStateEntry("ggg"),
+				"local-mod.txt":  newFileStateEntry("ccc"),
+				"remote-del.txt": newFileStateEntry("fff"),
+				"remote-mod.txt": newFileStateEntry("ddd"),
+				"same.txt":       newFileStateEntry("aaa"),
+				"touched.txt":    newFileStateEntry("bbb"),
+			},
+		},
+		ConflictPolicy: entities.SyncConflictPolicyFail,
+	}
+
+	useCase := &ftp.TwoWaySyncPlan{}
+	actions, err := useCase.Execute(ctx, useCaseRepos, useCaseInput)
+	assert.NoError(t, err)
+	assert.Equal(t, []*entities.SyncAction{
+		{
+			Type:      entities.SyncActionDelete,
+			Direction: entities.SyncDirectionUpload,
+			Path:      "gone",
+			IsDir:     true,
+			Reason:    entities.SyncReasonDeleted,
+		},
+		{
+			Type:        entities.SyncActionDelete,
+			Direction:   entities.SyncDirectionUpload,
+			Path:        "local-del.txt",
+			SizeInBytes: 3,
+			Reason:      entities.SyncReasonDeleted,
+		},
+		{
+			Type:        entities.SyncActionCopy,
+			Direction:   entities.SyncDirectionUpload,
+			Path:        "local-mod.txt",
+			SizeInBytes: 4,
+			Reason:      entities.SyncReasonModified,
+		},
+		{
+			Type:        entities.SyncActionCopy,
+			Direction:   entities.SyncDirectionUpload,
+			Path:        "local-new.txt",
+			SizeInBytes: 1,
+			Reason:      entities.SyncReasonNew,
+		},
+		{
+			Type:        entities.SyncActionDelete,
+			Direction:   entities.SyncDirectionDownload,
+			Path:        "remote-del.txt",
+			SizeInBytes: 3,
+			Reason:      entities.SyncReasonDeleted,
+		},
+		{
+			Type:        entities.SyncActionCopy,
+			Direction:   entities.SyncDirectionDownload,
+			Path:        "remote-mod.txt",
+			SizeInBytes: 3,
+			Reason:      entities.SyncReasonModified,
+		},
+		{
+			Type:        entities.SyncActionCopy,
+			Direction:   entities.SyncDirectionDownload,
+			Path:        "remote-new.txt",
+			SizeInBytes: 4,
+			Reason:      entities.SyncReasonNew,
+		},
+	}, actions)
+}
+
+//nolint:funlen // test case can get a bit large
+func Test_TwoWaySyncPlan_Execute_Conflict_Success(t *testing.T) {
+	testCases := []struct {
+		name            string
+		policy          entities.SyncConflictPolicy
+		expectedActions []*entities.SyncAction
+	}{
+		{
+			name:   "newest",
+			policy: entities.SyncConflictPolicyNewest,
+			expectedActions: []*entities.SyncAction{
+				{
+					Type:        entities.SyncActionCopy,
+					Direction:   entities.SyncDirectionUpload,
+					Path:        "docs/report.txt",
+					SizeInBytes: 4,
+					Reason:      entities.SyncReasonConflict,
+				},
+			},
+		},
+		{
+			name:   "keep both",
+			policy: entities.SyncConflictPolicyKeepBoth,
+			expectedActions: []*entities.SyncAction{
+				{
+					Type:        entities.SyncActionRename,
+					Direction:   entities.SyncDirectionUpload,
+					Path:        "docs/report.txt",
+					NewPath:     "docs/report.conflict-20220115-1000.txt",
+					SizeInBytes: 5,
+					Reason:      entities.SyncReasonConflict,
+				},
+				{
+					Type:        entities.SyncActionCopy,
+					Direction:   entities.SyncDirectionDownload,
+					Path:        "docs/report.conflict-20220115-1000.txt",
+					SizeInBytes: 5,
+					Reason:      entities.SyncReasonConflict,
+				},
+				{
+					Type:        entities.SyncActionCopy,
+					Direction:   entities.SyncDirectionUpload,
+					Path:        "docs/report.txt",
+					SizeInBytes: 4,
+					Reason:      entities.SyncReasonConflict,
+				},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			logger := assertlogging.NewLogger(t)
+			logger.
+				ExpectWarn("entry was changed on both sides").
+				WithField("path", assertlogging.Equal("docs/report.txt"))
+
+			connMock := connectionMocks.NewConnection(t)
+			connMock.
+				On("IsDir", ctx, remoteDirPath).
+				Return(true, nil).
+				Once()
+			setMocksForConflictingTree(ctx, t, connMock)
+
+			useCaseRepos := &ftp.TwoWaySyncPlanRepos{
+				Logger:     logger,
+				Connection: connMock,
+				Filesystem: getConflictingLocalTree(),
+			}
+			useCaseInput := &ftp.TwoWaySyncPlanInput{
+				Path:           syncLocalPath,
+				RemotePath:     remoteDirPath,
+				State:          getConflictingState(),
+				ConflictPolicy: tc.policy,
+			}
+
+			useCase := &ftp.TwoWaySyncPlan{}
+			actions, err := useCase.Execute(ctx, useCaseRepos, useCaseInput)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedActions, actions)
+		})
+	}
+}
+
+func Test_TwoWaySyncPlan_Execute_RestoreDir_Success(t *testing.T) {
+	ctx := context.Background()
+
+	logger := assertlogging.NewLogger(t)
+	logger.
+		ExpectWarn("entry was changed on both sides").
+		WithField("path", assertlogging.Equal("docs/b.txt"))
+
+	connMock := connectionMocks.NewConnection(t)
+	connMock.
+		On("IsDir", ctx, remoteDirPath).
+		Return(true, nil).
+		Once()
+	setMocksForRemoteListing(ctx, connMock, map[string][]*entities.Entry{
+		remoteDirPath: {rootDir1, rootDir2},
+	})
+
+	useCaseRepos := &ftp.TwoWaySyncPlanRepos{
+		Logger:     logger,
+		Connection: connMock,
+		Filesystem: fstest.MapFS{
+			"local/docs/a.txt": {Data: []byte("aaa"), ModTime: syncedDate},
+			"local/docs/b.txt": {Data: []byte("bbbb"), ModTime: modifiedDate},
+		},
+	}
+	useCaseInput := &ftp.TwoWaySyncPlanInput{
+		Path:       syncLocalPath,
+		RemotePath: remoteDirPath,
+		State: &entities.SyncState{
+			Entries: map[string]*entities.SyncStateEntry{
+				"docs":       newDirStateEntry(),
+				"docs/a.txt": newFileStateEntry("aaa"),
+				"docs/b.txt": newFileStateEntry("bbb"),
+			},
+		},
+		ConflictPolicy: entities.SyncConflictPolicyNewest,
+	}
+
+	useCase := &ftp.TwoWaySyncPlan{}
+	actions, err := useCase.Execute(ctx, useCaseRepos, useCaseInput)
+	assert.NoError(t, err)
+	assert.Equal(t, []*entities.SyncAction{
+		{
+			Type:      entities.SyncActionCreateDir,
+			Direction: entities.SyncDirectionUpload,
+			Path:      "docs",
+			IsDir:     true,
+			Reason:    entities.SyncReasonConflict,
+		},
+		{
+			Type:        entities.SyncActionDelete,
+			Direction:   entities.SyncDirectionDownload,
+			Path:        "docs/a.txt",
+			SizeInBytes: 3,
+			Reason:      entities.SyncReasonDeleted,
+		},
+		{
+			Type:        entities.SyncActionCopy,
+			Direction:   entities.SyncDirectionUpload,
+			Path:        "docs/b.txt",
+			SizeInBytes: 4,
+			Reason:      entities.SyncReasonConflict,
+		},
+	}, actions)
+}
+
+func Test_TwoWaySyncPlan_Execute_ConflictError(t *testing.T) {
+	ctx := context.Background()
+
+	logger := assertlogging.NewLogger(t)
+	logger.
+		ExpectWarn("entry was changed on both sides").
+		WithField("path", assertlogging.Equal("docs/report.txt"))
+
+	connMock := connectionMocks.NewConnection(t)
+	connMock.
+		On("IsDir", ctx, remoteDirPath).
+		Return(true, nil).
+		Once()
+	setMocksForConflictingTree(ctx, t, connMock)
+
+	useCaseRepos := &ftp.TwoWaySyncPlanRepos{
+		Logger:     logger,
+		Connection: connMock,
+		Filesystem: getConflictingLocalTree(),
+	}
+	useCaseInput := &ftp.TwoWaySyncPlanInput{
+		Path:           syncLocalPath,
+		RemotePath:     remoteDirPath,
+		State:          getConflictingState(),
+		ConflictPolicy: entities.SyncConflictPolicyFail,
+	}
+
+	useCase := &ftp.TwoWaySyncPlan{}
+	actions, err := useCase.Execute(ctx, useCaseRepos, useCaseInput)
+	assert.Nil(t, actions)
+	require.EqualError(t, err, "a conflict error occurred: entries were changed on both sides: docs/report.txt")
+	assert.IsType(t, ftperrors.ConflictErrorType, err)
+}
+
+func Test_TwoWaySyncPlan_Execute_Errors(t *testing.T) {
+	testCases := []struct {
+		name           string
+		policy         entities.SyncConflictPolicy
+		expectedErrMsg string
+		expectedErr    error
+	}{
+		{
+			name:           "invalid conflict policy",
+			policy:         "not-valid",
+			expectedErrMsg: "an invalid argument error occurred: argument conflict must be one of newest, keep-both, fail",
+			expectedErr:    ftperrors.InvalidArgumentErrorType,
+		},
+		{
+			name:           "remote root not found",
+			policy:         entities.SyncConflictPolicyFail,
+			expectedErrMsg: "not found error occurred: remote directory not found",
+			expectedErr:    ftperrors.NotFoundErrorType,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			logger := assertlogging.NewLogger(t)
+
+			connMock := connectionMocks.NewConnection(t)
+			if tc.policy == entities.SyncConflictPolicyFail {
+				connMock.
+					On("IsDir", ctx, remoteDirPath).
+					Return(false, ftperrors.NewNotFoundError("mock error", nil)).
+					Once()
+			}
+
+			useCaseRepos := &ftp.TwoWaySyncPlanRepos{
+				Logger:     logger,
+				Connection: connMock,
+				Filesystem: getConflictingLocalTree(),
+			}
+			useCaseInput := &ftp.TwoWaySyncPlanInput{
+				Path:           syncLocalPath,
+				RemotePath:     remoteDirPath,
+				State:          getConflictingState(),
+				ConflictPolicy: tc.policy,
+			}
+
+			useCase := &ftp.TwoWaySyncPlan{}
+			actions, err := useCase.Execute(ctx, useCaseRepos, useCaseInput)
+			assert.Nil(t, actions)
+			require.EqualError(t, err, tc.expectedErrMsg)
+			assert.IsType(t, tc.expectedErr, err)
+		})
+	}
+}
+
+func getConflictingLocalTree() fstest.MapFS {
+	return fstest.MapFS{
+		"local/docs/report.txt": {Data: []byte("xxxx"), ModTime: modifiedDate},
+	}
+}
+
+func getConflictingState() *entities.SyncState {
+	return &entities.SyncState{
+		Entries: map[string]*entities.SyncStateEntry{
+			"docs":            newDirStateEntry(),
+			"docs/report.txt": newFileStateEntry("xxx"),
+		},
+	}
+}
+
+func setMocksForConflictingTree(ctx context.Context, t *testing.T, connMock *connectionMocks.Connection) {
+	setMocksForRemoteListing(ctx, connMock, map[string][]*entities.Entry{
+		remoteDirPath: {
+			newEntry(t, entities.EntryTypeDir, "docs", 0, "2022-01-10 10:00"),
+		},
+		remoteDirPath + "/docs": {
+			newEntry(t, entities.EntryTypeFile, "report.txt", 5, "2022-01-15 10:00"),
+		},
+	})
+}
+
+func setMocksForRemoteListing(
+	ctx context.Context,
+	connMock *connectionMocks.Connection,
+	listings map[string][]*entities.Entry,
+) {
+	for dirPath, entries := range listings {
+		connMock.
+			On("List", ctx, &connection.ListOptions{Path: dirPath, ShowAll: true}).
+			Return(&connection.ListResult{Entries: entries}, nil).
+			Once()
+	}
+}
+
+func newFileStateEntry(content string) *entities.SyncStateEntry {
+	hash := sha256.Sum256([]byte(content))
+	return &entities.SyncStateEntry{
+		Local: entities.SyncFileState{
+			SizeInBytes: uint64(len(content)),
+			ModTime:     syncedDate,
+			Hash:        hex.EncodeToString(hash[:]),
+		},
+		Remote: entities.SyncFileState{
+			SizeInBytes: uint64(len(content)),
+			ModTime:     syncedDate,
+		},
+	}
+}
+
+func newDirStateEntry() *entities.SyncStateEntry {
+	return &entities.SyncStateEntry{
+		IsDir:  true,
+		Local:  entities.SyncFileState{ModTime: syncedDate},
+		Remote: entities.SyncFileState{ModTime: syncedDate},
+	}
+}
@@ -2,56 +2,206 @@ package ftp
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"io"
-	"path/filepath"
-	"strings"
+	"path"
+	"time"
 
 	"github.com/alexZaicev/go-ftp-client/internal/domain/connection"
+	"github.com/alexZaicev/go-ftp-client/internal/domain/entities"
 	ftperrors "github.com/alexZaicev/go-ftp-client/internal/domain/errors"
 	"github.com/alexZaicev/go-ftp-client/internal/drivers/logging"
 )
 
 type UploadFileUseCase interface {
-	Execute(context.Context, *UploadFileRepos, *UploadFileInput) error
+	Execute(context.Context, *UploadFileRepos, *UploadFileInput) (*UploadFileOutput, error)
 }
 
 type UploadFileInput struct {
 	FileReader  io.Reader
 	RemotePath  string
 	SizeInBytes uint64
+	// ModTime of the local file, compared with the remote file by the newer overwrite policy.
+	ModTime time.Time
+	// IfExists decides what happens if the remote file already exists, it is overwritten by default.
+	IfExists entities.OverwritePolicy
+	// Atomic uploads the file under a temporary name and renames it into place once verified, if provided.
+	Atomic *entities.AtomicUploadOptions
+}
+
+type UploadFileOutput struct {
+	// RemotePath the file was uploaded to, which differs from the input path if the file was renamed.
+	RemotePath string
+	// Skipped is set if the file was not uploaded, as the remote file already exists.
+	Skipped *entities.SkippedTransfer
 }
 
 type UploadFileRepos struct {
 	Logger     logging.Logger
 	Connection connection.Connection
+	// Observer is notified of the uploaded file, if provided.
+	Observer TransferObserver
 }
 
 type UploadFile struct {
 }
 
-func (u *UploadFile) Execute(ctx context.Context, repos *UploadFileRepos, input *UploadFileInput) error {
-	dirPath, fileName := filepath.Split(input.RemotePath)
-	if strings.HasSuffix(dirPath, string(filepath.Separator)) {
-		dirPath = dirPath[:len(dirPath)-1]
+func (u *UploadFile) Execute(
+	ctx context.Context,
+	repos *UploadFileRepos,
+	input *UploadFileInput,
+) (output *UploadFileOutput, err error) {
+	remotePath := input.RemotePath
+	if checksOverwrite(input.IfExists) {
+		target, skipped, err := u.resolveRemotePath(ctx, repos, input)
+		if err != nil {
+			return nil, err
+		}
+		if skipped != nil {
+			notifyFileSkipped(repos.Observer, skipped)
+			return &UploadFileOutput{RemotePath: remotePath, Skipped: skipped}, nil
+		}
+		remotePath = target
 	}
 
+	remoteDir, fileName := entities.RemotePath(remotePath).Split()
+	dirPath := remoteDir.String()
+
 	if dirPath != "" {
-		if err := repos.Connection.Cd(dirPath); err != nil {
+		prevDir, pwdErr := repos.Connection.Pwd()
+		if pwdErr != nil {
+			repos.Logger.WithError(pwdErr).Error("failed to get working directory")
+			return nil, ftperrors.NewInternalError("failed to get working directory", nil)
+		}
+
+		if err = repos.Connection.Cd(dirPath); err != nil {
 			var notFoundErr *ftperrors.NotFoundError
 			if errors.As(err, &notFoundErr) {
 				repos.Logger.WithError(notFoundErr).Error(fmt.Sprintf("directory %s not found", dirPath))
-				return notFoundErr
+				return nil, notFoundErr
 			}
 
 			repos.Logger.WithError(err).Error("failed to change directory")
-			return ftperrors.NewInternalError("failed to change directory", nil)
+			return nil, ftperrors.NewInternalError("failed to change directory", nil)
+		}
+
+		// file is uploaded from within its directory, after which the working directory is restored
+		defer func() {
+			if cdErr := repos.Connection.Cd(prevDir); cdErr != nil {
+				repos.Logger.WithError(cdErr).Error("failed to restore working directory")
+				if err == nil {
+					output, err = nil, ftperrors.NewInternalError("failed to restore working directory", nil)
+				}
+			}
+		}()
+	}
+
+	transfer := startFileTransfer(repos.Observer, remotePath, input.SizeInBytes)
+	fileReader := transfer.reader(input.FileReader)
+	if input.Atomic != nil {
+		err = u.uploadAtomically(ctx, repos, input, fileReader, fileName)
+	} else {
+		err = u.uploadAndVerify(ctx, repos, fileReader, fileName, input.SizeInBytes)
+	}
+	transfer.end(err)
+	if err != nil {
+		return nil, err
+	}
+
+	return &UploadFileOutput{RemotePath: remotePath}, nil
+}
+
+// resolveRemotePath method applies the overwrite policy to the remote file, returning either the path
+// to upload the file to or the reason the upload is skipped.
+func (u *UploadFile) resolveRemotePath(
+	ctx context.Context,
+	repos *UploadFileRepos,
+	input *UploadFileInput,
+) (string, *entities.SkippedTransfer, error) {
+	existing, err := remoteEntry(ctx, repos.Logger, repos.Connection, input.RemotePath)
+	if err != nil || existing == nil {
+		return input.RemotePath, nil, err
+	}
+
+	source := &entities.Entry{
+		SizeInBytes:          input.SizeInBytes,
+		LastModificationDate: input.ModTime,
+	}
+	decision, reason, err := resolveOverwrite(input.IfExists, input.RemotePath, source, existing)
+	if err != nil {
+		return "", nil, err
+	}
+
+	switch decision {
+	case overwriteDecisionSkip:
+		return "", &entities.SkippedTransfer{Path: input.RemotePath, Reason: reason}, nil
+	case overwriteDecisionRename:
+		statRemote := func(remotePath string) (*entities.Entry, error) {
+			return remoteEntry(ctx, repos.Logger, repos.Connection, remotePath)
+		}
+		remotePath, renameErr := freePath(input.RemotePath, path.Ext(input.RemotePath), statRemote)
+		if renameErr != nil {
+			return "", nil, renameErr
+		}
+		repos.Logger.
+			WithField("remote-path", input.RemotePath).
+			WithField("new-remote-path", remotePath).
+			Info("remote file already exists, uploading under a new name")
+		return remotePath, nil, nil
+	default:
+		return input.RemotePath, nil, nil
+	}
+}
+
+// uploadAtomically method uploads the file under a temporary name, verifies its size and checksum, and
+// renames it into place. The temporary file is removed if any of the steps fail.
+func (u *UploadFile) uploadAtomically(
+	ctx context.Context,
+	repos *UploadFileRepos,
+	input *UploadFileInput,
+	fileReader io.Reader,
+	fileName string,
+) error {
+	tempName := input.Atomic.TempPrefix + fileName + input.Atomic.TempSuffix
+	hasher := sha256.New()
+
+	err := u.uploadAndVerify(ctx, repos, io.TeeReader(fileReader, hasher), tempName, input.SizeInBytes)
+	if err == nil {
+		err = u.verifyChecksum(repos, tempName, hex.EncodeToString(hasher.Sum(nil)))
+	}
+	if err == nil {
+		if moveErr := repos.Connection.Move(tempName, fileName); moveErr != nil {
+			repos.Logger.WithError(moveErr).Error("failed to move uploaded file into place")
+			err = ftperrors.NewInternalError("failed to move uploaded file into place", nil)
 		}
 	}
 
+	if err != nil {
+		if removeErr := repos.Connection.RemoveFile(tempName); removeErr != nil {
+			repos.Logger.
+				WithError(removeErr).
+				WithField("remote-path", tempName).
+				Warn("failed to remove partially uploaded file")
+		}
+		return err
+	}
+	return nil
+}
+
+// uploadAndVerify method uploads the file to the current directory and checks that its size on the
+// server matches the expected one.
+func (u *UploadFile) uploadAndVerify(
+	ctx context.Context,
+	repos *UploadFileRepos,
+	fileReader io.Reader,
+	fileName string,
+	expectedSizeInBytes uint64,
+) error {
 	options := &connection.UploadOptions{
-		FileReader: input.FileReader,
+		FileReader: fileReader,
 		Path:       fileName,
 	}
 
@@ -66,11 +216,11 @@ func (u *UploadFile) Execute(ctx context.Context, repos *UploadFileRepos, input
 		return ftperrors.NewInternalError("failed to check file size", nil)
 	}
 
-	if sizeInBytes != input.SizeInBytes {
-		msg := fmt.Sprintf("uploaded file size %d does not match the actual %d", sizeInBytes, input.SizeInBytes)
+	if sizeInBytes != expectedSizeInBytes {
+		msg := fmt.Sprintf("uploaded file size %d does not match the actual %d", sizeInBytes, expectedSizeInBytes)
 		repos.Logger.WithFields(
 			logging.Fields{
-				"actual-size-in-bytes":   input.SizeInBytes,
+				"actual-size-in-bytes":   expectedSizeInBytes,
 				"uploaded-size-in-bytes": sizeInBytes,
 			},
 		).Error(msg)
@@ -79,3 +229,31 @@ func (u *UploadFile) Execute(ctx context.Context, repos *UploadFileRepos, input
 
 	return nil
 }
+
+// verifyChecksum method compares the checksum of the uploaded file computed by the server with the
+// checksum of the uploaded content. Verification is skipped if the server cannot compute checksums.
+func (u *UploadFile) verifyChecksum(repos *UploadFileRepos, fileName, expectedChecksum string) error {
+	checksum, err := repos.Connection.Checksum(fileName, entities.HashAlgorithmSHA256)
+	if err != nil {
+		repos.Logger.WithError(err).Error("failed to check file checksum")
+		return ftperrors.NewInternalError("failed to check file checksum", nil)
+	}
+
+	if checksum == "" {
+		repos.Logger.Debug("server does not support checksums, skipping checksum verification")
+		return nil
+	}
+
+	if checksum != expectedChecksum {
+		msg := "uploaded file checksum does not match the actual"
+		repos.Logger.WithFields(
+			logging.Fields{
+				"actual-checksum":   expectedChecksum,
+				"uploaded-checksum": checksum,
+			},
+		).Error(msg)
+		return ftperrors.NewInternalError(msg, nil)
+	}
+
+	return nil
+}
@@ -3,18 +3,25 @@ package ftp_test
 import (
 	"bytes"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"errors"
 	"fmt"
+	"io"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 
 	"github.com/alexZaicev/go-ftp-client/internal/domain/connection"
+	"github.com/alexZaicev/go-ftp-client/internal/domain/entities"
 	ftperrors "github.com/alexZaicev/go-ftp-client/internal/domain/errors"
 	"github.com/alexZaicev/go-ftp-client/internal/drivers/logging/assertlogging"
 	"github.com/alexZaicev/go-ftp-client/internal/usecases/ftp"
 	connectionMocks "github.com/alexZaicev/go-ftp-client/mocks/domain/connection"
+	useCaseMocks "github.com/alexZaicev/go-ftp-client/mocks/usecases/ftp"
 )
 
 func Test_UploadFile_Execute_NoDirSuccess(t *testing.T) {
@@ -51,8 +58,9 @@ func Test_UploadFile_Execute_NoDirSuccess(t *testing.T) {
 	}
 
 	useCase := &ftp.UploadFile{}
-	err := useCase.Execute(ctx, useCaseRepos, useCaseInput)
+	output, err := useCase.Execute(ctx, useCaseRepos, useCaseInput)
 	assert.NoError(t, err)
+	assert.Equal(t, &ftp.UploadFileOutput{RemotePath: useCaseInput.RemotePath}, output)
 }
 
 func Test_UploadFile_Execute_WithDirSuccess(t *testing.T) {
@@ -63,10 +71,7 @@ func Test_UploadFile_Execute_WithDirSuccess(t *testing.T) {
 	logger := assertlogging.NewLogger(t)
 
 	connMock := connectionMocks.NewConnection(t)
-	connMock.
-		On("Cd", remoteDirPath).
-		Return(nil).
-		Once()
+	setMocksForCd(connMock, nil)
 	connMock.
 		On(
 			"Upload",
@@ -93,8 +98,70 @@ func Test_UploadFile_Execute_WithDirSuccess(t *testing.T) {
 	}
 
 	useCase := &ftp.UploadFile{}
-	err := useCase.Execute(ctx, useCaseRepos, useCaseInput)
+	output, err := useCase.Execute(ctx, useCaseRepos, useCaseInput)
+	assert.NoError(t, err)
+	assert.Equal(t, &ftp.UploadFileOutput{RemotePath: useCaseInput.RemotePath}, output)
+}
+
+func Test_UploadFile_Execute_Observer_Success(t *testing.T) {
+	ctx := context.Background()
+
+	content := "this is content of awesome file"
+	buffer := bytes.NewBufferString(content)
+
+	logger := assertlogging.NewLogger(t)
+
+	matchEvent := func(transferredBytes uint64) interface{} {
+		return mock.MatchedBy(func(event ftp.TransferEvent) bool {
+			return event.Path == remotePathNoDir &&
+				event.SizeInBytes == sizeInBytes &&
+				event.TransferredBytes == transferredBytes
+		})
+	}
+
+	observerMock := useCaseMocks.NewTransferObserver(t)
+	observerMock.
+		On("FileStarted", matchEvent(0)).
+		Once()
+	observerMock.
+		On("BytesTransferred", matchEvent(uint64(len(content))), len(content)).
+		Once()
+	observerMock.
+		On("FileCompleted", matchEvent(uint64(len(content)))).
+		Once()
+
+	connMock := connectionMocks.NewConnection(t)
+	connMock.
+		On("Upload", ctx, mock.AnythingOfType("*connection.UploadOptions")).
+		Run(func(args mock.Arguments) {
+			options := args.Get(1).(*connection.UploadOptions)
+			assert.Equal(t, fileName, options.Path)
+			data, readErr := io.ReadAll(options.FileReader)
+			require.NoError(t, readErr)
+			assert.Equal(t, content, string(data))
+		}).
+		Return(nil).
+		Once()
+	connMock.
+		On("Size", remotePathNoDir).
+		Return(sizeInBytes, nil).
+		Once()
+
+	useCaseRepos := &ftp.UploadFileRepos{
+		Logger:     logger,
+		Connection: connMock,
+		Observer:   observerMock,
+	}
+	useCaseInput := &ftp.UploadFileInput{
+		FileReader:  buffer,
+		RemotePath:  remotePathNoDir,
+		SizeInBytes: sizeInBytes,
+	}
+
+	useCase := &ftp.UploadFile{}
+	output, err := useCase.Execute(ctx, useCaseRepos, useCaseInput)
 	assert.NoError(t, err)
+	assert.Equal(t, &ftp.UploadFileOutput{RemotePath: useCaseInput.RemotePath}, output)
 }
 
 func Test_UploadFile_Execute_DirNotFoundError(t *testing.T) {
@@ -108,10 +175,7 @@ func Test_UploadFile_Execute_DirNotFoundError(t *testing.T) {
 		WithError(assertlogging.EqualError(fmt.Sprintf("not found error occurred: directory %s not found", remoteDirPath)))
 
 	connMock := connectionMocks.NewConnection(t)
-	connMock.
-		On("Cd", remoteDirPath).
-		Return(ftperrors.NewNotFoundError(fmt.Sprintf("directory %s not found", remoteDirPath), nil)).
-		Once()
+	setMocksForCd(connMock, ftperrors.NewNotFoundError(fmt.Sprintf("directory %s not found", remoteDirPath), nil))
 
 	useCaseRepos := &ftp.UploadFileRepos{
 		Logger:     logger,
@@ -124,7 +188,8 @@ func Test_UploadFile_Execute_DirNotFoundError(t *testing.T) {
 	}
 
 	useCase := &ftp.UploadFile{}
-	err := useCase.Execute(ctx, useCaseRepos, useCaseInput)
+	output, err := useCase.Execute(ctx, useCaseRepos, useCaseInput)
+	assert.Nil(t, output)
 	require.EqualError(t, err, fmt.Sprintf("not found error occurred: directory %s not found", remoteDirPath))
 	assert.IsType(t, ftperrors.NotFoundErrorType, err)
 	assert.NoError(t, errors.Unwrap(err))
@@ -140,9 +205,94 @@ func Test_UploadFile_Execute_CdError(t *testing.T) {
 		ExpectError("failed to change directory").
 		WithError(assertlogging.EqualError("mock error"))
 
+	connMock := connectionMocks.NewConnection(t)
+	setMocksForCd(connMock, errors.New("mock error"))
+
+	useCaseRepos := &ftp.UploadFileRepos{
+		Logger:     logger,
+		Connection: connMock,
+	}
+	useCaseInput := &ftp.UploadFileInput{
+		FileReader:  buffer,
+		RemotePath:  remotePathWithDir,
+		SizeInBytes: sizeInBytes,
+	}
+
+	useCase := &ftp.UploadFile{}
+	output, err := useCase.Execute(ctx, useCaseRepos, useCaseInput)
+	assert.Nil(t, output)
+	require.EqualError(t, err, "an internal error occurred: failed to change directory")
+	assert.IsType(t, ftperrors.InternalErrorType, err)
+	assert.NoError(t, errors.Unwrap(err))
+}
+
+func Test_UploadFile_Execute_PwdError(t *testing.T) {
+	ctx := context.Background()
+
+	logger := assertlogging.NewLogger(t)
+	logger.
+		ExpectError("failed to get working directory").
+		WithError(assertlogging.EqualError("mock error"))
+
+	connMock := connectionMocks.NewConnection(t)
+	connMock.
+		On("Pwd").
+		Return("", errors.New("mock error")).
+		Once()
+
+	useCaseRepos := &ftp.UploadFileRepos{
+		Logger:     logger,
+		Connection: connMock,
+	}
+	useCaseInput := &ftp.UploadFileInput{
+		FileReader:  bytes.NewBufferString("this is content of awesome file"),
+		RemotePath:  remotePathWithDir,
+		SizeInBytes: sizeInBytes,
+	}
+
+	useCase := &ftp.UploadFile{}
+	output, err := useCase.Execute(ctx, useCaseRepos, useCaseInput)
+	assert.Nil(t, output)
+	require.EqualError(t, err, "an internal error occurred: failed to get working directory")
+	assert.IsType(t, ftperrors.InternalErrorType, err)
+	assert.NoError(t, errors.Unwrap(err))
+}
+
+func Test_UploadFile_Execute_RestoreDirError(t *testing.T) {
+	ctx := context.Background()
+
+	buffer := bytes.NewBufferString("this is content of awesome file")
+
+	logger := assertlogging.NewLogger(t)
+	logger.
+		ExpectError("failed to restore working directory").
+		WithError(assertlogging.EqualError("mock error"))
+
 	connMock := connectionMocks.NewConnection(t)
+	connMock.
+		On("Pwd").
+		Return(homeDirPath, nil).
+		Once()
 	connMock.
 		On("Cd", remoteDirPath).
+		Return(nil).
+		Once()
+	connMock.
+		On(
+			"Upload",
+			ctx,
+			&connection.UploadOptions{
+				Path:       fileName,
+				FileReader: buffer,
+			}).
+		Return(nil).
+		Once()
+	connMock.
+		On("Size", fileName).
+		Return(sizeInBytes, nil).
+		Once()
+	connMock.
+		On("Cd", homeDirPath).
 		Return(errors.New("mock error")).
 		Once()
 
@@ -157,8 +307,9 @@ func Test_UploadFile_Execute_CdError(t *testing.T) {
 	}
 
 	useCase := &ftp.UploadFile{}
-	err := useCase.Execute(ctx, useCaseRepos, useCaseInput)
-	require.EqualError(t, err, "an internal error occurred: failed to change directory")
+	output, err := useCase.Execute(ctx, useCaseRepos, useCaseInput)
+	assert.Nil(t, output)
+	require.EqualError(t, err, "an internal error occurred: failed to restore working directory")
 	assert.IsType(t, ftperrors.InternalErrorType, err)
 	assert.NoError(t, errors.Unwrap(err))
 }
@@ -174,10 +325,7 @@ func Test_UploadFile_Execute_UploadError(t *testing.T) {
 		WithError(assertlogging.EqualError("mock error"))
 
 	connMock := connectionMocks.NewConnection(t)
-	connMock.
-		On("Cd", remoteDirPath).
-		Return(nil).
-		Once()
+	setMocksForCd(connMock, nil)
 	connMock.
 		On(
 			"Upload",
@@ -200,7 +348,8 @@ func Test_UploadFile_Execute_UploadError(t *testing.T) {
 	}
 
 	useCase := &ftp.UploadFile{}
-	err := useCase.Execute(ctx, useCaseRepos, useCaseInput)
+	output, err := useCase.Execute(ctx, useCaseRepos, useCaseInput)
+	assert.Nil(t, output)
 	require.EqualError(t, err, "an internal error occurred: failed to upload file")
 	assert.IsType(t, ftperrors.InternalErrorType, err)
 	assert.NoError(t, errors.Unwrap(err))
@@ -217,10 +366,7 @@ func Test_UploadFile_Execute_SizeError(t *testing.T) {
 		WithError(assertlogging.EqualError("mock error"))
 
 	connMock := connectionMocks.NewConnection(t)
-	connMock.
-		On("Cd", remoteDirPath).
-		Return(nil).
-		Once()
+	setMocksForCd(connMock, nil)
 	connMock.
 		On(
 			"Upload",
@@ -247,7 +393,8 @@ func Test_UploadFile_Execute_SizeError(t *testing.T) {
 	}
 
 	useCase := &ftp.UploadFile{}
-	err := useCase.Execute(ctx, useCaseRepos, useCaseInput)
+	output, err := useCase.Execute(ctx, useCaseRepos, useCaseInput)
+	assert.Nil(t, output)
 	require.EqualError(t, err, "an internal error occurred: failed to check file size")
 	assert.IsType(t, ftperrors.InternalErrorType, err)
 	assert.NoError(t, errors.Unwrap(err))
@@ -269,10 +416,7 @@ func Test_UploadFile_Execute_SizeMismatchError(t *testing.T) {
 		)
 
 	connMock := connectionMocks.NewConnection(t)
-	connMock.
-		On("Cd", remoteDirPath).
-		Return(nil).
-		Once()
+	setMocksForCd(connMock, nil)
 	connMock.
 		On(
 			"Upload",
@@ -299,8 +443,483 @@ func Test_UploadFile_Execute_SizeMismatchError(t *testing.T) {
 	}
 
 	useCase := &ftp.UploadFile{}
-	err := useCase.Execute(ctx, useCaseRepos, useCaseInput)
+	output, err := useCase.Execute(ctx, useCaseRepos, useCaseInput)
+	assert.Nil(t, output)
 	require.EqualError(t, err, fmt.Sprintf("an internal error occurred: %s", msg))
 	assert.IsType(t, ftperrors.InternalErrorType, err)
 	assert.NoError(t, errors.Unwrap(err))
 }
+
+//nolint:funlen // test case can get a bit large
+func Test_UploadFile_Execute_IfExists_Success(t *testing.T) {
+	existingDate := time.Date(2022, time.January, 12, 16, 23, 0, 0, time.UTC)
+	existing := &entities.Entry{
+		Type:                 entities.EntryTypeFile,
+		Name:                 fileName,
+		SizeInBytes:          sizeInBytes,
+		LastModificationDate: existingDate,
+	}
+	renamedPath := fmt.Sprintf("%s/foobarbaz-2.txt", remoteDirPath)
+	// recently modified remote files are listed without a year, unless the server supports MDTM
+	listed := newRecentEntry(entities.EntryTypeFile, fileName, sizeInBytes, time.Hour)
+	modified := &entities.Entry{
+		Type:                 entities.EntryTypeFile,
+		Name:                 fileName,
+		SizeInBytes:          sizeInBytes,
+		LastModificationDate: time.Now().UTC().Add(-time.Hour),
+	}
+
+	testCases := []struct {
+		name             string
+		ifExists         entities.OverwritePolicy
+		existing         *entities.Entry
+		modTime          time.Time
+		sizeInBytes      uint64
+		expectedFileName string
+		expectedOutput   *ftp.UploadFileOutput
+	}{
+		{
+			name:             "missing file",
+			ifExists:         entities.OverwritePolicySkip,
+			sizeInBytes:      sizeInBytes,
+			expectedFileName: fileName,
+			expectedOutput:   &ftp.UploadFileOutput{RemotePath: remotePathWithDir},
+		},
+		{
+			name:        "skip",
+			ifExists:    entities.OverwritePolicySkip,
+			existing:    existing,
+			sizeInBytes: sizeInBytes,
+			expectedOutput: &ftp.UploadFileOutput{
+				RemotePath: remotePathWithDir,
+				Skipped:    &entities.SkippedTransfer{Path: remotePathWithDir, Reason: "already exists"},
+			},
+		},
+		{
+			name:        "newer with older source",
+			ifExists:    entities.OverwritePolicyNewer,
+			existing:    existing,
+			modTime:     existingDate,
+			sizeInBytes: sizeInBytes,
+			expectedOutput: &ftp.UploadFileOutput{
+				RemotePath: remotePathWithDir,
+				Skipped:    &entities.SkippedTransfer{Path: remotePathWithDir, Reason: "is not older than the source"},
+			},
+		},
+		{
+			name:             "newer with newer source",
+			ifExists:         entities.OverwritePolicyNewer,
+			existing:         existing,
+			modTime:          existingDate.Add(time.Second),
+			sizeInBytes:      sizeInBytes,
+			expectedFileName: fileName,
+			expectedOutput:   &ftp.UploadFileOutput{RemotePath: remotePathWithDir},
+		},
+		{
+			name:        "newer with older source than listed file",
+			ifExists:    entities.OverwritePolicyNewer,
+			existing:    listed,
+			modTime:     time.Now().Add(-2 * time.Hour),
+			sizeInBytes: sizeInBytes,
+			expectedOutput: &ftp.UploadFileOutput{
+				RemotePath: remotePathWithDir,
+				Skipped:    &entities.SkippedTransfer{Path: remotePathWithDir, Reason: "is not older than the source"},
+			},
+		},
+		{
+			name:             "newer with newer source than listed file",
+			ifExists:         entities.OverwritePolicyNewer,
+			existing:         listed,
+			modTime:          time.Now(),
+			sizeInBytes:      sizeInBytes,
+			expectedFileName: fileName,
+			expectedOutput:   &ftp.UploadFileOutput{RemotePath: remotePathWithDir},
+		},
+		{
+			name:        "newer with older source than modified file",
+			ifExists:    entities.OverwritePolicyNewer,
+			existing:    modified,
+			modTime:     time.Now().Add(-2 * time.Hour),
+			sizeInBytes: sizeInBytes,
+			expectedOutput: &ftp.UploadFileOutput{
+				RemotePath: remotePathWithDir,
+				Skipped:    &entities.SkippedTransfer{Path: remotePathWithDir, Reason: "is not older than the source"},
+			},
+		},
+		{
+			name:             "newer with newer source than modified file",
+			ifExists:         entities.OverwritePolicyNewer,
+			existing:         modified,
+			modTime:          time.Now(),
+			sizeInBytes:      sizeInBytes,
+			expectedFileName: fileName,
+			expectedOutput:   &ftp.UploadFileOutput{RemotePath: remotePathWithDir},
+		},
+		{
+			name:        "size differs with same size",
+			ifExists:    entities.OverwritePolicySizeDiffers,
+			existing:    existing,
+			sizeInBytes: sizeInBytes,
+			expectedOutput: &ftp.UploadFileOutput{
+				RemotePath: remotePathWithDir,
+				Skipped:    &entities.SkippedTransfer{Path: remotePathWithDir, Reason: "has the same size"},
+			},
+		},
+		{
+			name:             "size differs with different size",
+			ifExists:         entities.OverwritePolicySizeDiffers,
+			existing:         existing,
+			sizeInBytes:      sizeInBytes + 1,
+			expectedFileName: fileName,
+			expectedOutput:   &ftp.UploadFileOutput{RemotePath: remotePathWithDir},
+		},
+		{
+			name:             "rename",
+			ifExists:         entities.OverwritePolicyRename,
+			existing:         existing,
+			sizeInBytes:      sizeInBytes,
+			expectedFileName: "foobarbaz-2.txt",
+			expectedOutput:   &ftp.UploadFileOutput{RemotePath: renamedPath},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			buffer := bytes.NewBufferString("this is content of awesome file")
+
+			logger := assertlogging.NewLogger(t)
+
+			connMock := connectionMocks.NewConnection(t)
+			if tc.existing == nil {
+				connMock.
+					On("Stat", ctx, remotePathWithDir).
+					Return(nil, ftperrors.NewNotFoundError("mock error", nil)).
+					Once()
+			} else {
+				connMock.
+					On("Stat", ctx, remotePathWithDir).
+					Return(tc.existing, nil).
+					Once()
+			}
+			if tc.ifExists == entities.OverwritePolicyRename {
+				logger.
+					ExpectInfo("remote file already exists, uploading under a new name").
+					WithField("remote-path", assertlogging.Equal(remotePathWithDir)).
+					WithField("new-remote-path", assertlogging.Equal(renamedPath))
+				connMock.
+					On("Stat", ctx, fmt.Sprintf("%s/foobarbaz-1.txt", remoteDirPath)).
+					Return(tc.existing, nil).
+					Once()
+				connMock.
+					On("Stat", ctx, renamedPath).
+					Return(nil, ftperrors.NewNotFoundError("mock error", nil)).
+					Once()
+			}
+			if tc.expectedFileName != "" {
+				setMocksForCd(connMock, nil)
+				connMock.
+					On(
+						"Upload",
+						ctx,
+						&connection.UploadOptions{
+							Path:       tc.expectedFileName,
+							FileReader: buffer,
+						}).
+					Return(nil).
+					Once()
+				connMock.
+					On("Size", tc.expectedFileName).
+					Return(tc.sizeInBytes, nil).
+					Once()
+			}
+
+			useCaseRepos := &ftp.UploadFileRepos{
+				Logger:     logger,
+				Connection: connMock,
+			}
+			useCaseInput := &ftp.UploadFileInput{
+				FileReader:  buffer,
+				RemotePath:  remotePathWithDir,
+				SizeInBytes: tc.sizeInBytes,
+				ModTime:     tc.modTime,
+				IfExists:    tc.ifExists,
+			}
+
+			useCase := &ftp.UploadFile{}
+			output, err := useCase.Execute(ctx, useCaseRepos, useCaseInput)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedOutput, output)
+		})
+	}
+}
+
+func Test_UploadFile_Execute_IfExists_Errors(t *testing.T) {
+	testCases := []struct {
+		name           string
+		ifExists       entities.OverwritePolicy
+		statErr        error
+		expectedErrMsg string
+		expectedErr    error
+	}{
+		{
+			name:           "fail",
+			ifExists:       entities.OverwritePolicyFail,
+			expectedErrMsg: fmt.Sprintf("a conflict error occurred: %s already exists", remotePathWithDir),
+			expectedErr:    ftperrors.ConflictErrorType,
+		},
+		{
+			name:           "stat error",
+			ifExists:       entities.OverwritePolicySkip,
+			statErr:        errors.New("mock error"),
+			expectedErrMsg: "an internal error occurred: failed to check if entry exists",
+			expectedErr:    ftperrors.InternalErrorType,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			logger := assertlogging.NewLogger(t)
+
+			connMock := connectionMocks.NewConnection(t)
+			if tc.statErr != nil {
+				logger.
+					ExpectError("failed to check if entry exists").
+					WithError(assertlogging.EqualError("mock error")).
+					WithField("remote-path", assertlogging.Equal(remotePathWithDir))
+				connMock.
+					On("Stat", ctx, remotePathWithDir).
+					Return(nil, tc.statErr).
+					Once()
+			} else {
+				connMock.
+					On("Stat", ctx, remotePathWithDir).
+					Return(newEntry(t, entities.EntryTypeFile, fileName, sizeInBytes, "2022-01-12 16:23"), nil).
+					Once()
+			}
+
+			useCaseRepos := &ftp.UploadFileRepos{
+				Logger:     logger,
+				Connection: connMock,
+			}
+			useCaseInput := &ftp.UploadFileInput{
+				FileReader:  bytes.NewBufferString("this is content of awesome file"),
+				RemotePath:  remotePathWithDir,
+				SizeInBytes: sizeInBytes,
+				IfExists:    tc.ifExists,
+			}
+
+			useCase := &ftp.UploadFile{}
+			output, err := useCase.Execute(ctx, useCaseRepos, useCaseInput)
+			assert.Nil(t, output)
+			require.EqualError(t, err, tc.expectedErrMsg)
+			assert.IsType(t, tc.expectedErr, err)
+		})
+	}
+}
+
+// setMocksForCd function expects the change into the remote directory, after which the working directory
+// is restored unless the change fails.
+func setMocksForCd(connMock *connectionMocks.Connection, cdErr error) {
+	connMock.
+		On("Pwd").
+		Return(homeDirPath, nil).
+		Once()
+	connMock.
+		On("Cd", remoteDirPath).
+		Return(cdErr).
+		Once()
+	if cdErr == nil {
+		connMock.
+			On("Cd", homeDirPath).
+			Return(nil).
+			Once()
+	}
+}
+
+// setMocksForAtomicUpload function expects the upload of the content under the temporary name, which
+// reads the content, so that its checksum is computed.
+func setMocksForAtomicUpload(ctx context.Context, connMock *connectionMocks.Connection, uploadErr error) {
+	setMocksForCd(connMock, nil)
+	connMock.
+		On("Upload", ctx, mock.MatchedBy(func(options *connection.UploadOptions) bool {
+			return options.Path == tempFileName
+		})).
+		Run(func(args mock.Arguments) {
+			_, _ = io.Copy(io.Discard, args.Get(1).(*connection.UploadOptions).FileReader)
+		}).
+		Return(uploadErr).
+		Once()
+}
+
+func Test_UploadFile_Execute_Atomic_Success(t *testing.T) {
+	checksum := sha256.Sum256(fileContent)
+
+	testCases := []struct {
+		name     string
+		checksum string
+	}{
+		{
+			name:     "checksum verified",
+			checksum: hex.EncodeToString(checksum[:]),
+		},
+		{
+			name: "checksum not supported",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			logger := assertlogging.NewLogger(t)
+
+			connMock := connectionMocks.NewConnection(t)
+			setMocksForAtomicUpload(ctx, connMock, nil)
+			connMock.
+				On("Size", tempFileName).
+				Return(uint64(len(fileContent)), nil).
+				Once()
+			connMock.
+				On("Checksum", tempFileName, entities.HashAlgorithmSHA256).
+				Return(tc.checksum, nil).
+				Once()
+			connMock.
+				On("Move", tempFileName, fileName).
+				Return(nil).
+				Once()
+
+			useCaseRepos := &ftp.UploadFileRepos{
+				Logger:     logger,
+				Connection: connMock,
+			}
+			useCaseInput := &ftp.UploadFileInput{
+				FileReader:  bytes.NewBuffer(fileContent),
+				RemotePath:  remotePathWithDir,
+				SizeInBytes: uint64(len(fileContent)),
+				Atomic:      &entities.AtomicUploadOptions{TempPrefix: ".", TempSuffix: ".partial"},
+			}
+
+			useCase := &ftp.UploadFile{}
+			output, err := useCase.Execute(ctx, useCaseRepos, useCaseInput)
+			assert.NoError(t, err)
+			assert.Equal(t, &ftp.UploadFileOutput{RemotePath: remotePathWithDir}, output)
+		})
+	}
+}
+
+//nolint:funlen // test case can get a bit large
+func Test_UploadFile_Execute_Atomic_Errors(t *testing.T) {
+	checksum := sha256.Sum256(fileContent)
+
+	testCases := []struct {
+		name           string
+		uploadErr      error
+		checksum       string
+		moveErr        error
+		removeErr      error
+		setLogs        func(logger *assertlogging.Logger)
+		expectedErrMsg string
+	}{
+		{
+			name:      "upload error",
+			uploadErr: errors.New("mock error"),
+			setLogs: func(logger *assertlogging.Logger) {
+				logger.
+					ExpectError("failed to upload file").
+					WithError(assertlogging.EqualError("mock error"))
+			},
+			expectedErrMsg: "an internal error occurred: failed to upload file",
+		},
+		{
+			name:     "checksum mismatch",
+			checksum: "deadbeef",
+			setLogs: func(logger *assertlogging.Logger) {
+				logger.
+					ExpectError("uploaded file checksum does not match the actual").
+					WithFields(
+						assertlogging.NewField("actual-checksum", assertlogging.Equal(hex.EncodeToString(checksum[:]))),
+						assertlogging.NewField("uploaded-checksum", assertlogging.Equal("deadbeef")),
+					)
+			},
+			expectedErrMsg: "an internal error occurred: uploaded file checksum does not match the actual",
+		},
+		{
+			name:    "move error",
+			moveErr: errors.New("mock error"),
+			setLogs: func(logger *assertlogging.Logger) {
+				logger.
+					ExpectError("failed to move uploaded file into place").
+					WithError(assertlogging.EqualError("mock error"))
+			},
+			expectedErrMsg: "an internal error occurred: failed to move uploaded file into place",
+		},
+		{
+			name:      "remove error",
+			moveErr:   errors.New("mock error"),
+			removeErr: errors.New("mock remove error"),
+			setLogs: func(logger *assertlogging.Logger) {
+				logger.
+					ExpectError("failed to move uploaded file into place").
+					WithError(assertlogging.EqualError("mock error"))
+				logger.
+					ExpectWarn("failed to remove partially uploaded file").
+					WithError(assertlogging.EqualError("mock remove error")).
+					WithField("remote-path", assertlogging.Equal(tempFileName))
+			},
+			expectedErrMsg: "an internal error occurred: failed to move uploaded file into place",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			logger := assertlogging.NewLogger(t)
+			tc.setLogs(logger)
+
+			connMock := connectionMocks.NewConnection(t)
+			setMocksForAtomicUpload(ctx, connMock, tc.uploadErr)
+			if tc.uploadErr == nil {
+				connMock.
+					On("Size", tempFileName).
+					Return(uint64(len(fileContent)), nil).
+					Once()
+				connMock.
+					On("Checksum", tempFileName, entities.HashAlgorithmSHA256).
+					Return(tc.checksum, nil).
+					Once()
+			}
+			if tc.moveErr != nil {
+				connMock.
+					On("Move", tempFileName, fileName).
+					Return(tc.moveErr).
+					Once()
+			}
+			connMock.
+				On("RemoveFile", tempFileName).
+				Return(tc.removeErr).
+				Once()
+
+			useCaseRepos := &ftp.UploadFileRepos{
+				Logger:     logger,
+				Connection: connMock,
+			}
+			useCaseInput := &ftp.UploadFileInput{
+				FileReader:  bytes.NewBuffer(fileContent),
+				RemotePath:  remotePathWithDir,
+				SizeInBytes: uint64(len(fileContent)),
+				Atomic:      &entities.AtomicUploadOptions{TempPrefix: ".", TempSuffix: ".partial"},
+			}
+
+			useCase := &ftp.UploadFile{}
+			output, err := useCase.Execute(ctx, useCaseRepos, useCaseInput)
+			assert.Nil(t, output)
+			require.EqualError(t, err, tc.expectedErrMsg)
+			assert.IsType(t, ftperrors.InternalErrorType, err)
+		})
+	}
+}
@@ -0,0 +1,113 @@
+package ftp
+
+import (
+	"context"
+
+	"github.com/alexZaicev/go-ftp-client/internal/domain/connection"
+	"github.com/alexZaicev/go-ftp-client/internal/domain/entities"
+	ftperrors "github.com/alexZaicev/go-ftp-client/internal/domain/errors"
+	"github.com/alexZaicev/go-ftp-client/internal/drivers/logging"
+)
+
+type WalkUseCase interface {
+	Execute(ctx context.Context, repos *WalkRepos, input *WalkInput) error
+}
+
+type WalkInput struct {
+	// Path is the remote directory the walk starts at. Glob meta characters escaped with a backslash are
+	// listed as is.
+	Path string
+	// Literal takes Path as is, without unescaping backslashes.
+	Literal  bool
+	ShowAll  bool
+	SortType entities.SortType
+	// MaxDepth limits how many levels of directories are listed, where 1 only lists the directory at Path.
+	// Directories are walked to the bottom if it is not set.
+	MaxDepth int
+	// Visit is called with every listed directory before its subdirectories are walked, so that listings
+	// can be written as they arrive. The walk stops at the first error returned.
+	Visit func(dir *WalkedDir) error
+}
+
+// WalkedDir is a remote directory listed by the walk.
+type WalkedDir struct {
+	// Path is the remote path of the directory, joined to the path the walk started at.
+	Path string
+	// Depth is 0 for the directory the walk started at, 1 for its subdirectories and so on.
+	Depth int
+	// Entries of the directory, without "." and "..", sorted by the sort type of the walk.
+	Entries []*entities.Entry
+}
+
+type WalkRepos struct {
+	Logger     logging.Logger
+	Connection connection.Connection
+}
+
+type Walk struct {
+}
+
+func (u *Walk) Execute(ctx context.Context, repos *WalkRepos, input *WalkInput) error {
+	walker := &remoteWalker{
+		logger:   repos.Logger,
+		conn:     repos.Connection,
+		showAll:  input.ShowAll,
+		sortType: input.SortType,
+		maxDepth: input.MaxDepth,
+		visit:    input.Visit,
+	}
+	return walker.walk(ctx, entities.RemotePath(remoteLiteralPath(input.Path, input.Literal)), 0)
+}
+
+// remoteWalker walks remote directories depth first over Connection.List, listing every directory once.
+// Symbolic links are never followed, so the walk cannot loop.
+type remoteWalker struct {
+	logger   logging.Logger
+	conn     connection.Connection
+	showAll  bool
+	sortType entities.SortType
+	// maxDepth is the number of directory levels listed, there is no limit if it is not set
+	maxDepth int
+	visit    func(dir *WalkedDir) error
+}
+
+func (w *remoteWalker) walk(ctx context.Context, dirPath entities.RemotePath, depth int) error {
+	result, err := w.conn.List(ctx, &connection.ListOptions{
+		Path:    dirPath.String(),
+		ShowAll: w.showAll,
+	})
+	if err != nil {
+		w.logger.
+			WithError(err).
+			WithField("remote-path", dirPath.String()).
+			Error("failed to list directory")
+		return ftperrors.NewInternalError("failed to list directory", nil)
+	}
+
+	logSkippedLines(w.logger, dirPath.String(), result.SkippedLines)
+
+	entries := make([]*entities.Entry, 0, len(result.Entries))
+	for _, entry := range result.Entries {
+		if !isRootDir(entry.Name) {
+			entries = append(entries, entry)
+		}
+	}
+	sortEntries(entries, w.sortType)
+
+	if err = w.visit(&WalkedDir{Path: dirPath.String(), Depth: depth, Entries: entries}); err != nil {
+		return err
+	}
+
+	if w.maxDepth > 0 && depth+1 >= w.maxDepth {
+		return nil
+	}
+	for _, entry := range entries {
+		if entry.Type != entities.EntryTypeDir {
+			continue
+		}
+		if err = w.walk(ctx, dirPath.Join(entry.Name), depth+1); err != nil {
+			return err
+		}
+	}
+	return nil
+}
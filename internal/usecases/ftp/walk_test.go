@@ -0,0 +1,212 @@
+package ftp_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/alexZaicev/go-ftp-client/internal/domain/connection"
+	"github.com/alexZaicev/go-ftp-client/internal/domain/entities"
+	ftperrors "github.com/alexZaicev/go-ftp-client/internal/domain/errors"
+	"github.com/alexZaicev/go-ftp-client/internal/drivers/logging/assertlogging"
+	"github.com/alexZaicev/go-ftp-client/internal/usecases/ftp"
+	connectionMocks "github.com/alexZaicev/go-ftp-client/mocks/domain/connection"
+)
+
+func Test_Walk_Execute_Success(t *testing.T) {
+	testCases := []struct {
+		name         string
+		maxDepth     int
+		expectedDirs []*ftp.WalkedDir
+	}{
+		{
+			name:     "walk without depth limit",
+			maxDepth: 0,
+			expectedDirs: []*ftp.WalkedDir{
+				{
+					Path:  dirPath,
+					Depth: 0,
+					Entries: []*entities.Entry{
+						newEntry(t, entities.EntryTypeDir, "dir-1", 4096, "2022-01-12 16:23"),
+						newEntry(t, entities.EntryTypeFile, "file-1", 100, "2022-01-12 16:23"),
+						newEntry(t, entities.EntryTypeLink, "link-1", 6, "2022-01-12 16:23"),
+					},
+				},
+				{
+					Path:  dirPath + "/dir-1",
+					Depth: 1,
+					Entries: []*entities.Entry{
+						newEntry(t, entities.EntryTypeDir, "dir-2", 4096, "2022-01-12 16:23"),
+					},
+				},
+				{
+					Path:  dirPath + "/dir-1/dir-2",
+					Depth: 2,
+					Entries: []*entities.Entry{
+						newEntry(t, entities.EntryTypeFile, "file-2", 200, "2022-01-12 16:23"),
+					},
+				},
+			},
+		},
+		{
+			name:     "walk with depth limit",
+			maxDepth: 2,
+			expectedDirs: []*ftp.WalkedDir{
+				{
+					Path:  dirPath,
+					Depth: 0,
+					Entries: []*entities.Entry{
+						newEntry(t, entities.EntryTypeDir, "dir-1", 4096, "2022-01-12 16:23"),
+						newEntry(t, entities.EntryTypeFile, "file-1", 100, "2022-01-12 16:23"),
+						newEntry(t, entities.EntryTypeLink, "link-1", 6, "2022-01-12 16:23"),
+					},
+				},
+				{
+					Path:  dirPath + "/dir-1",
+					Depth: 1,
+					Entries: []*entities.Entry{
+						newEntry(t, entities.EntryTypeDir, "dir-2", 4096, "2022-01-12 16:23"),
+					},
+				},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			logger := assertlogging.NewLogger(t)
+
+			connMock := connectionMocks.NewConnection(t)
+			connMock.
+				On("List", ctx, &connection.ListOptions{Path: dirPath}).
+				Return(&connection.ListResult{
+					Entries: []*entities.Entry{
+						rootDir1,
+						rootDir2,
+						newEntry(t, entities.EntryTypeLink, "link-1", 6, "2022-01-12 16:23"),
+						newEntry(t, entities.EntryTypeFile, "file-1", 100, "2022-01-12 16:23"),
+						newEntry(t, entities.EntryTypeDir, "dir-1", 4096, "2022-01-12 16:23"),
+					},
+				}, nil).
+				Once()
+			connMock.
+				On("List", ctx, &connection.ListOptions{Path: dirPath + "/dir-1"}).
+				Return(&connection.ListResult{
+					Entries: []*entities.Entry{
+						newEntry(t, entities.EntryTypeDir, "dir-2", 4096, "2022-01-12 16:23"),
+					},
+				}, nil).
+				Once()
+			if tc.maxDepth == 0 {
+				connMock.
+					On("List", ctx, &connection.ListOptions{Path: dirPath + "/dir-1/dir-2"}).
+					Return(&connection.ListResult{
+						Entries: []*entities.Entry{
+							newEntry(t, entities.EntryTypeFile, "file-2", 200, "2022-01-12 16:23"),
+						},
+					}, nil).
+					Once()
+			}
+
+			var walkedDirs []*ftp.WalkedDir
+
+			useCaseRepos := &ftp.WalkRepos{
+				Logger:     logger,
+				Connection: connMock,
+			}
+			useCaseInput := &ftp.WalkInput{
+				Path:     dirPath,
+				SortType: entities.SortTypeName,
+				MaxDepth: tc.maxDepth,
+				Visit: func(dir *ftp.WalkedDir) error {
+					walkedDirs = append(walkedDirs, dir)
+					return nil
+				},
+			}
+
+			useCase := &ftp.Walk{}
+			err := useCase.Execute(ctx, useCaseRepos, useCaseInput)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedDirs, walkedDirs)
+		})
+	}
+}
+
+func Test_Walk_Execute_ListError(t *testing.T) {
+	ctx := context.Background()
+
+	logger := assertlogging.NewLogger(t)
+	logger.
+		ExpectError("failed to list directory").
+		WithError(assertlogging.EqualError("mock error")).
+		WithField("remote-path", assertlogging.Equal(dirPath+"/dir-1"))
+
+	connMock := connectionMocks.NewConnection(t)
+	connMock.
+		On("List", ctx, &connection.ListOptions{Path: dirPath}).
+		Return(&connection.ListResult{
+			Entries: []*entities.Entry{
+				newEntry(t, entities.EntryTypeDir, "dir-1", 4096, "2022-01-12 16:23"),
+			},
+		}, nil).
+		Once()
+	connMock.
+		On("List", ctx, &connection.ListOptions{Path: dirPath + "/dir-1"}).
+		Return(nil, errors.New("mock error")).
+		Once()
+
+	useCaseRepos := &ftp.WalkRepos{
+		Logger:     logger,
+		Connection: connMock,
+	}
+	useCaseInput := &ftp.WalkInput{
+		Path:     dirPath,
+		SortType: entities.SortTypeName,
+		Visit: func(dir *ftp.WalkedDir) error {
+			return nil
+		},
+	}
+
+	useCase := &ftp.Walk{}
+	err := useCase.Execute(ctx, useCaseRepos, useCaseInput)
+	require.EqualError(t, err, "an internal error occurred: failed to list directory")
+	assert.IsType(t, ftperrors.InternalErrorType, err)
+	assert.NoError(t, errors.Unwrap(err))
+}
+
+func Test_Walk_Execute_VisitError(t *testing.T) {
+	ctx := context.Background()
+
+	logger := assertlogging.NewLogger(t)
+
+	connMock := connectionMocks.NewConnection(t)
+	connMock.
+		On("List", ctx, &connection.ListOptions{Path: dirPath}).
+		Return(&connection.ListResult{
+			Entries: []*entities.Entry{
+				newEntry(t, entities.EntryTypeDir, "dir-1", 4096, "2022-01-12 16:23"),
+			},
+		}, nil).
+		Once()
+
+	useCaseRepos := &ftp.WalkRepos{
+		Logger:     logger,
+		Connection: connMock,
+	}
+	useCaseInput := &ftp.WalkInput{
+		Path:     dirPath,
+		SortType: entities.SortTypeName,
+		Visit: func(dir *ftp.WalkedDir) error {
+			return errors.New("mock error")
+		},
+	}
+
+	useCase := &ftp.Walk{}
+	err := useCase.Execute(ctx, useCaseRepos, useCaseInput)
+	assert.EqualError(t, err, "mock error")
+}
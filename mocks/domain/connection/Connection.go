@@ -31,13 +31,34 @@ func (_m *Connection) Cd(path string) error {
 	return r0
 }
 
-// Download provides a mock function with given fields: ctx, path
-func (_m *Connection) Download(ctx context.Context, path string) ([]byte, error) {
-	ret := _m.Called(ctx, path)
+// Checksum provides a mock function with given fields: path, algorithm
+func (_m *Connection) Checksum(path string, algorithm entities.HashAlgorithm) (string, error) {
+	ret := _m.Called(path, algorithm)
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func(string, entities.HashAlgorithm) string); ok {
+		r0 = rf(path, algorithm)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, entities.HashAlgorithm) error); ok {
+		r1 = rf(path, algorithm)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Download provides a mock function with given fields: ctx, options
+func (_m *Connection) Download(ctx context.Context, options *connection.DownloadOptions) ([]byte, error) {
+	ret := _m.Called(ctx, options)
 
 	var r0 []byte
-	if rf, ok := ret.Get(0).(func(context.Context, string) []byte); ok {
-		r0 = rf(ctx, path)
+	if rf, ok := ret.Get(0).(func(context.Context, *connection.DownloadOptions) []byte); ok {
+		r0 = rf(ctx, options)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]byte)
@@ -45,8 +66,8 @@ func (_m *Connection) Download(ctx context.Context, path string) ([]byte, error)
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = rf(ctx, path)
+	if rf, ok := ret.Get(1).(func(context.Context, *connection.DownloadOptions) error); ok {
+		r1 = rf(ctx, options)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -90,15 +111,15 @@ func (_m *Connection) IsDir(ctx context.Context, path string) (bool, error) {
 }
 
 // List provides a mock function with given fields: ctx, options
-func (_m *Connection) List(ctx context.Context, options *connection.ListOptions) ([]*entities.Entry, error) {
+func (_m *Connection) List(ctx context.Context, options *connection.ListOptions) (*connection.ListResult, error) {
 	ret := _m.Called(ctx, options)
 
-	var r0 []*entities.Entry
-	if rf, ok := ret.Get(0).(func(context.Context, *connection.ListOptions) []*entities.Entry); ok {
+	var r0 *connection.ListResult
+	if rf, ok := ret.Get(0).(func(context.Context, *connection.ListOptions) *connection.ListResult); ok {
 		r0 = rf(ctx, options)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]*entities.Entry)
+			r0 = ret.Get(0).(*connection.ListResult)
 		}
 	}
 
@@ -154,6 +175,27 @@ func (_m *Connection) Move(oldPath string, newPath string) error {
 	return r0
 }
 
+// Pwd provides a mock function with given fields:
+func (_m *Connection) Pwd() (string, error) {
+	ret := _m.Called()
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func() string); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Ready provides a mock function with given fields:
 func (_m *Connection) Ready() error {
 	ret := _m.Called()
@@ -217,6 +259,29 @@ func (_m *Connection) Size(path string) (uint64, error) {
 	return r0, r1
 }
 
+// Stat provides a mock function with given fields: ctx, path
+func (_m *Connection) Stat(ctx context.Context, path string) (*entities.Entry, error) {
+	ret := _m.Called(ctx, path)
+
+	var r0 *entities.Entry
+	if rf, ok := ret.Get(0).(func(context.Context, string) *entities.Entry); ok {
+		r0 = rf(ctx, path)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entities.Entry)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, path)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Status provides a mock function with given fields:
 func (_m *Connection) Status() (*entities.Status, error) {
 	ret := _m.Called()
@@ -2,7 +2,10 @@
 
 package mocks
 
-import mock "github.com/stretchr/testify/mock"
+import (
+	entities "github.com/alexZaicev/go-ftp-client/internal/domain/entities"
+	mock "github.com/stretchr/testify/mock"
+)
 
 // FileStore is an autogenerated mock type for the FileStore type
 type FileStore struct {
@@ -23,6 +26,34 @@ func (_m *FileStore) CreateDir(path string) error {
 	return r0
 }
 
+// CreateSymlink provides a mock function with given fields: path, target
+func (_m *FileStore) CreateSymlink(path string, target string) error {
+	ret := _m.Called(path, target)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string) error); ok {
+		r0 = rf(path, target)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Remove provides a mock function with given fields: path
+func (_m *FileStore) Remove(path string) error {
+	ret := _m.Called(path)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string) error); ok {
+		r0 = rf(path)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // SaveFile provides a mock function with given fields: path, data
 func (_m *FileStore) SaveFile(path string, data []byte) error {
 	ret := _m.Called(path, data)
@@ -37,6 +68,29 @@ func (_m *FileStore) SaveFile(path string, data []byte) error {
 	return r0
 }
 
+// Stat provides a mock function with given fields: path
+func (_m *FileStore) Stat(path string) (*entities.Entry, error) {
+	ret := _m.Called(path)
+
+	var r0 *entities.Entry
+	if rf, ok := ret.Get(0).(func(string) *entities.Entry); ok {
+		r0 = rf(path)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entities.Entry)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(path)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 type mockConstructorTestingTNewFileStore interface {
 	mock.TestingT
 	Cleanup(func())
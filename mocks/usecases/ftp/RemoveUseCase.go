@@ -15,17 +15,26 @@ type RemoveUseCase struct {
 }
 
 // Execute provides a mock function with given fields: _a0, _a1, _a2
-func (_m *RemoveUseCase) Execute(_a0 context.Context, _a1 *ftp.RemoveRepos, _a2 *ftp.RemoveInput) error {
+func (_m *RemoveUseCase) Execute(_a0 context.Context, _a1 *ftp.RemoveRepos, _a2 *ftp.RemoveInput) (*ftp.RemoveOutput, error) {
 	ret := _m.Called(_a0, _a1, _a2)
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, *ftp.RemoveRepos, *ftp.RemoveInput) error); ok {
+	var r0 *ftp.RemoveOutput
+	if rf, ok := ret.Get(0).(func(context.Context, *ftp.RemoveRepos, *ftp.RemoveInput) *ftp.RemoveOutput); ok {
 		r0 = rf(_a0, _a1, _a2)
 	} else {
-		r0 = ret.Error(0)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*ftp.RemoveOutput)
+		}
 	}
 
-	return r0
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *ftp.RemoveRepos, *ftp.RemoveInput) error); ok {
+		r1 = rf(_a0, _a1, _a2)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
 type mockConstructorTestingTNewRemoveUseCase interface {
@@ -15,17 +15,26 @@ type UploadFileUseCase struct {
 }
 
 // Execute provides a mock function with given fields: _a0, _a1, _a2
-func (_m *UploadFileUseCase) Execute(_a0 context.Context, _a1 *ftp.UploadFileRepos, _a2 *ftp.UploadFileInput) error {
+func (_m *UploadFileUseCase) Execute(_a0 context.Context, _a1 *ftp.UploadFileRepos, _a2 *ftp.UploadFileInput) (*ftp.UploadFileOutput, error) {
 	ret := _m.Called(_a0, _a1, _a2)
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, *ftp.UploadFileRepos, *ftp.UploadFileInput) error); ok {
+	var r0 *ftp.UploadFileOutput
+	if rf, ok := ret.Get(0).(func(context.Context, *ftp.UploadFileRepos, *ftp.UploadFileInput) *ftp.UploadFileOutput); ok {
 		r0 = rf(_a0, _a1, _a2)
 	} else {
-		r0 = ret.Error(0)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*ftp.UploadFileOutput)
+		}
 	}
 
-	return r0
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *ftp.UploadFileRepos, *ftp.UploadFileInput) error); ok {
+		r1 = rf(_a0, _a1, _a2)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
 type mockConstructorTestingTNewUploadFileUseCase interface {